@@ -0,0 +1,497 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/analytics.proto
+
+package chartdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type AssignmentStatus int32
+
+const (
+	AssignmentStatus_ASSIGNMENT_STATUS_UNSPECIFIED AssignmentStatus = 0
+	AssignmentStatus_ASSIGNMENT_STATUS_NOT_STARTED AssignmentStatus = 1
+	AssignmentStatus_ASSIGNMENT_STATUS_STARTED     AssignmentStatus = 2
+	AssignmentStatus_ASSIGNMENT_STATUS_SUBMITTED   AssignmentStatus = 3
+)
+
+// Enum value maps for AssignmentStatus.
+var (
+	AssignmentStatus_name = map[int32]string{
+		0: "ASSIGNMENT_STATUS_UNSPECIFIED",
+		1: "ASSIGNMENT_STATUS_NOT_STARTED",
+		2: "ASSIGNMENT_STATUS_STARTED",
+		3: "ASSIGNMENT_STATUS_SUBMITTED",
+	}
+	AssignmentStatus_value = map[string]int32{
+		"ASSIGNMENT_STATUS_UNSPECIFIED": 0,
+		"ASSIGNMENT_STATUS_NOT_STARTED": 1,
+		"ASSIGNMENT_STATUS_STARTED":     2,
+		"ASSIGNMENT_STATUS_SUBMITTED":   3,
+	}
+)
+
+func (x AssignmentStatus) Enum() *AssignmentStatus {
+	p := new(AssignmentStatus)
+	*p = x
+	return p
+}
+
+func (x AssignmentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AssignmentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chartdb_v1_analytics_proto_enumTypes[0].Descriptor()
+}
+
+func (AssignmentStatus) Type() protoreflect.EnumType {
+	return &file_chartdb_v1_analytics_proto_enumTypes[0]
+}
+
+func (x AssignmentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AssignmentStatus.Descriptor instead.
+func (AssignmentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chartdb_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+type DailyEdits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Day           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Edits         int64                  `protobuf:"varint,2,opt,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyEdits) Reset() {
+	*x = DailyEdits{}
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyEdits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyEdits) ProtoMessage() {}
+
+func (x *DailyEdits) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyEdits.ProtoReflect.Descriptor instead.
+func (*DailyEdits) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_analytics_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DailyEdits) GetDay() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Day
+	}
+	return nil
+}
+
+func (x *DailyEdits) GetEdits() int64 {
+	if x != nil {
+		return x.Edits
+	}
+	return 0
+}
+
+type AssignmentProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignment    *Assignment            `protobuf:"bytes,1,opt,name=assignment,proto3" json:"assignment,omitempty"`
+	NotStarted    int64                  `protobuf:"varint,2,opt,name=not_started,json=notStarted,proto3" json:"not_started,omitempty"`
+	Started       int64                  `protobuf:"varint,3,opt,name=started,proto3" json:"started,omitempty"`
+	Submitted     int64                  `protobuf:"varint,4,opt,name=submitted,proto3" json:"submitted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignmentProgress) Reset() {
+	*x = AssignmentProgress{}
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignmentProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignmentProgress) ProtoMessage() {}
+
+func (x *AssignmentProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignmentProgress.ProtoReflect.Descriptor instead.
+func (*AssignmentProgress) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_analytics_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssignmentProgress) GetAssignment() *Assignment {
+	if x != nil {
+		return x.Assignment
+	}
+	return nil
+}
+
+func (x *AssignmentProgress) GetNotStarted() int64 {
+	if x != nil {
+		return x.NotStarted
+	}
+	return 0
+}
+
+func (x *AssignmentProgress) GetStarted() int64 {
+	if x != nil {
+		return x.Started
+	}
+	return 0
+}
+
+func (x *AssignmentProgress) GetSubmitted() int64 {
+	if x != nil {
+		return x.Submitted
+	}
+	return 0
+}
+
+type StudentAnalytics struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserLogin string                 `protobuf:"bytes,2,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	// Empty for students without a group
+	GroupCode       string `protobuf:"bytes,3,opt,name=group_code,json=groupCode,proto3" json:"group_code,omitempty"`
+	DiagramsCreated int64  `protobuf:"varint,4,opt,name=diagrams_created,json=diagramsCreated,proto3" json:"diagrams_created,omitempty"`
+	Edits           int64  `protobuf:"varint,5,opt,name=edits,proto3" json:"edits,omitempty"`
+	// Empty if the student has no activity
+	LastActivityAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`
+	// Empty if no content was saved during the period
+	AverageLintFindings *float64 `protobuf:"fixed64,7,opt,name=average_lint_findings,json=averageLintFindings,proto3,oneof" json:"average_lint_findings,omitempty"`
+	// Statuses follow the order of assignments in CourseAnalytics
+	Statuses []AssignmentStatus `protobuf:"varint,8,rep,packed,name=statuses,proto3,enum=chartdb.v1.AssignmentStatus" json:"statuses,omitempty"`
+	// Number of assignments with a deadline in the next three days which are not started yet
+	NotStartedDueSoon int64 `protobuf:"varint,9,opt,name=not_started_due_soon,json=notStartedDueSoon,proto3" json:"not_started_due_soon,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *StudentAnalytics) Reset() {
+	*x = StudentAnalytics{}
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StudentAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StudentAnalytics) ProtoMessage() {}
+
+func (x *StudentAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StudentAnalytics.ProtoReflect.Descriptor instead.
+func (*StudentAnalytics) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StudentAnalytics) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StudentAnalytics) GetUserLogin() string {
+	if x != nil {
+		return x.UserLogin
+	}
+	return ""
+}
+
+func (x *StudentAnalytics) GetGroupCode() string {
+	if x != nil {
+		return x.GroupCode
+	}
+	return ""
+}
+
+func (x *StudentAnalytics) GetDiagramsCreated() int64 {
+	if x != nil {
+		return x.DiagramsCreated
+	}
+	return 0
+}
+
+func (x *StudentAnalytics) GetEdits() int64 {
+	if x != nil {
+		return x.Edits
+	}
+	return 0
+}
+
+func (x *StudentAnalytics) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+func (x *StudentAnalytics) GetAverageLintFindings() float64 {
+	if x != nil && x.AverageLintFindings != nil {
+		return *x.AverageLintFindings
+	}
+	return 0
+}
+
+func (x *StudentAnalytics) GetStatuses() []AssignmentStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *StudentAnalytics) GetNotStartedDueSoon() int64 {
+	if x != nil {
+		return x.NotStartedDueSoon
+	}
+	return 0
+}
+
+// Activity of course students during the period, counters cover the period and statuses the whole course
+type CourseAnalytics struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CourseId    string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Since       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Assignments []*AssignmentProgress  `protobuf:"bytes,3,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	Students    []*StudentAnalytics    `protobuf:"bytes,4,rep,name=students,proto3" json:"students,omitempty"`
+	// Edits of the listed students by day, days without edits are omitted
+	DailyEdits    []*DailyEdits `protobuf:"bytes,5,rep,name=daily_edits,json=dailyEdits,proto3" json:"daily_edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseAnalytics) Reset() {
+	*x = CourseAnalytics{}
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseAnalytics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseAnalytics) ProtoMessage() {}
+
+func (x *CourseAnalytics) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseAnalytics.ProtoReflect.Descriptor instead.
+func (*CourseAnalytics) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CourseAnalytics) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CourseAnalytics) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *CourseAnalytics) GetAssignments() []*AssignmentProgress {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+func (x *CourseAnalytics) GetStudents() []*StudentAnalytics {
+	if x != nil {
+		return x.Students
+	}
+	return nil
+}
+
+func (x *CourseAnalytics) GetDailyEdits() []*DailyEdits {
+	if x != nil {
+		return x.DailyEdits
+	}
+	return nil
+}
+
+var File_chartdb_v1_analytics_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_analytics_proto_rawDesc = "" +
+	"\n" +
+	"\x1achartdb/v1/analytics.proto\x12\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bchartdb/v1/assignment.proto\"P\n" +
+	"\n" +
+	"DailyEdits\x12,\n" +
+	"\x03day\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x03day\x12\x14\n" +
+	"\x05edits\x18\x02 \x01(\x03R\x05edits\"\xa5\x01\n" +
+	"\x12AssignmentProgress\x126\n" +
+	"\n" +
+	"assignment\x18\x01 \x01(\v2\x16.chartdb.v1.AssignmentR\n" +
+	"assignment\x12\x1f\n" +
+	"\vnot_started\x18\x02 \x01(\x03R\n" +
+	"notStarted\x12\x18\n" +
+	"\astarted\x18\x03 \x01(\x03R\astarted\x12\x1c\n" +
+	"\tsubmitted\x18\x04 \x01(\x03R\tsubmitted\"\xb4\x03\n" +
+	"\x10StudentAnalytics\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x02 \x01(\tR\tuserLogin\x12\x1d\n" +
+	"\n" +
+	"group_code\x18\x03 \x01(\tR\tgroupCode\x12)\n" +
+	"\x10diagrams_created\x18\x04 \x01(\x03R\x0fdiagramsCreated\x12\x14\n" +
+	"\x05edits\x18\x05 \x01(\x03R\x05edits\x12D\n" +
+	"\x10last_activity_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\x127\n" +
+	"\x15average_lint_findings\x18\a \x01(\x01H\x00R\x13averageLintFindings\x88\x01\x01\x128\n" +
+	"\bstatuses\x18\b \x03(\x0e2\x1c.chartdb.v1.AssignmentStatusR\bstatuses\x12/\n" +
+	"\x14not_started_due_soon\x18\t \x01(\x03R\x11notStartedDueSoonB\x18\n" +
+	"\x16_average_lint_findingsJ\x04\b\n" +
+	"\x10d\"\x95\x02\n" +
+	"\x0fCourseAnalytics\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12@\n" +
+	"\vassignments\x18\x03 \x03(\v2\x1e.chartdb.v1.AssignmentProgressR\vassignments\x128\n" +
+	"\bstudents\x18\x04 \x03(\v2\x1c.chartdb.v1.StudentAnalyticsR\bstudents\x127\n" +
+	"\vdaily_edits\x18\x05 \x03(\v2\x16.chartdb.v1.DailyEditsR\n" +
+	"dailyEdits*\x98\x01\n" +
+	"\x10AssignmentStatus\x12!\n" +
+	"\x1dASSIGNMENT_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dASSIGNMENT_STATUS_NOT_STARTED\x10\x01\x12\x1d\n" +
+	"\x19ASSIGNMENT_STATUS_STARTED\x10\x02\x12\x1f\n" +
+	"\x1bASSIGNMENT_STATUS_SUBMITTED\x10\x03B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_analytics_proto_rawDescOnce sync.Once
+	file_chartdb_v1_analytics_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_analytics_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_analytics_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_analytics_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_analytics_proto_rawDesc), len(file_chartdb_v1_analytics_proto_rawDesc)))
+	})
+	return file_chartdb_v1_analytics_proto_rawDescData
+}
+
+var file_chartdb_v1_analytics_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_chartdb_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_chartdb_v1_analytics_proto_goTypes = []any{
+	(AssignmentStatus)(0),         // 0: chartdb.v1.AssignmentStatus
+	(*DailyEdits)(nil),            // 1: chartdb.v1.DailyEdits
+	(*AssignmentProgress)(nil),    // 2: chartdb.v1.AssignmentProgress
+	(*StudentAnalytics)(nil),      // 3: chartdb.v1.StudentAnalytics
+	(*CourseAnalytics)(nil),       // 4: chartdb.v1.CourseAnalytics
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+	(*Assignment)(nil),            // 6: chartdb.v1.Assignment
+}
+var file_chartdb_v1_analytics_proto_depIdxs = []int32{
+	5, // 0: chartdb.v1.DailyEdits.day:type_name -> google.protobuf.Timestamp
+	6, // 1: chartdb.v1.AssignmentProgress.assignment:type_name -> chartdb.v1.Assignment
+	5, // 2: chartdb.v1.StudentAnalytics.last_activity_at:type_name -> google.protobuf.Timestamp
+	0, // 3: chartdb.v1.StudentAnalytics.statuses:type_name -> chartdb.v1.AssignmentStatus
+	5, // 4: chartdb.v1.CourseAnalytics.since:type_name -> google.protobuf.Timestamp
+	2, // 5: chartdb.v1.CourseAnalytics.assignments:type_name -> chartdb.v1.AssignmentProgress
+	3, // 6: chartdb.v1.CourseAnalytics.students:type_name -> chartdb.v1.StudentAnalytics
+	1, // 7: chartdb.v1.CourseAnalytics.daily_edits:type_name -> chartdb.v1.DailyEdits
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_analytics_proto_init() }
+func file_chartdb_v1_analytics_proto_init() {
+	if File_chartdb_v1_analytics_proto != nil {
+		return
+	}
+	file_chartdb_v1_assignment_proto_init()
+	file_chartdb_v1_analytics_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_analytics_proto_rawDesc), len(file_chartdb_v1_analytics_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chartdb_v1_analytics_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_analytics_proto_depIdxs,
+		EnumInfos:         file_chartdb_v1_analytics_proto_enumTypes,
+		MessageInfos:      file_chartdb_v1_analytics_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_analytics_proto = out.File
+	file_chartdb_v1_analytics_proto_goTypes = nil
+	file_chartdb_v1_analytics_proto_depIdxs = nil
+}
@@ -0,0 +1,899 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/assignment.proto
+
+package chartdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GradebookFormat int32
+
+const (
+	GradebookFormat_GRADEBOOK_FORMAT_UNSPECIFIED GradebookFormat = 0
+	GradebookFormat_GRADEBOOK_FORMAT_CSV         GradebookFormat = 1
+	GradebookFormat_GRADEBOOK_FORMAT_XLSX        GradebookFormat = 2
+)
+
+// Enum value maps for GradebookFormat.
+var (
+	GradebookFormat_name = map[int32]string{
+		0: "GRADEBOOK_FORMAT_UNSPECIFIED",
+		1: "GRADEBOOK_FORMAT_CSV",
+		2: "GRADEBOOK_FORMAT_XLSX",
+	}
+	GradebookFormat_value = map[string]int32{
+		"GRADEBOOK_FORMAT_UNSPECIFIED": 0,
+		"GRADEBOOK_FORMAT_CSV":         1,
+		"GRADEBOOK_FORMAT_XLSX":        2,
+	}
+)
+
+func (x GradebookFormat) Enum() *GradebookFormat {
+	p := new(GradebookFormat)
+	*p = x
+	return p
+}
+
+func (x GradebookFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GradebookFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_chartdb_v1_assignment_proto_enumTypes[0].Descriptor()
+}
+
+func (GradebookFormat) Type() protoreflect.EnumType {
+	return &file_chartdb_v1_assignment_proto_enumTypes[0]
+}
+
+func (x GradebookFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GradebookFormat.Descriptor instead.
+func (GradebookFormat) EnumDescriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{0}
+}
+
+type Assignment struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CourseId    string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Title       string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Students start working on a copy of this diagram
+	StarterDiagramId string `protobuf:"bytes,5,opt,name=starter_diagram_id,json=starterDiagramId,proto3" json:"starter_diagram_id,omitempty"`
+	// Submissions are not accepted after the deadline, empty means no deadline
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Submissions are graded automatically against this diagram, visible only to teachers
+	ReferenceDiagramId string `protobuf:"bytes,7,opt,name=reference_diagram_id,json=referenceDiagramId,proto3" json:"reference_diagram_id,omitempty"`
+	// Visible only to teachers, empty means the default rubric
+	Rubric *GradingRubric `protobuf:"bytes,8,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	// Students see grades and feedback once they are released, empty if not released
+	GradesReleasedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=grades_released_at,json=gradesReleasedAt,proto3" json:"grades_released_at,omitempty"`
+	// Set for exams, students start the exam within the window and can edit the exam diagram only until it ends
+	ExamStartsAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=exam_starts_at,json=examStartsAt,proto3" json:"exam_starts_at,omitempty"`
+	ExamEndsAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=exam_ends_at,json=examEndsAt,proto3" json:"exam_ends_at,omitempty"`
+	// Set if submissions are reviewed by other students, reviewers are assigned when the deadline passes
+	PeerReview *PeerReviewSettings `protobuf:"bytes,12,opt,name=peer_review,json=peerReview,proto3" json:"peer_review,omitempty"`
+	// Reviews are accepted until this time, then authors see them
+	PeerReviewEndsAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=peer_review_ends_at,json=peerReviewEndsAt,proto3" json:"peer_review_ends_at,omitempty"`
+	PeerReviewsAssignedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=peer_reviews_assigned_at,json=peerReviewsAssignedAt,proto3" json:"peer_reviews_assigned_at,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Assignment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Assignment) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *Assignment) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Assignment) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Assignment) GetStarterDiagramId() string {
+	if x != nil {
+		return x.StarterDiagramId
+	}
+	return ""
+}
+
+func (x *Assignment) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *Assignment) GetReferenceDiagramId() string {
+	if x != nil {
+		return x.ReferenceDiagramId
+	}
+	return ""
+}
+
+func (x *Assignment) GetRubric() *GradingRubric {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *Assignment) GetGradesReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GradesReleasedAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetExamStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamStartsAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetExamEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamEndsAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetPeerReview() *PeerReviewSettings {
+	if x != nil {
+		return x.PeerReview
+	}
+	return nil
+}
+
+func (x *Assignment) GetPeerReviewEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeerReviewEndsAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetPeerReviewsAssignedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeerReviewsAssignedAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Assignment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Submission struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	UserId       string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserLogin    string                 `protobuf:"bytes,4,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	// Diagram the snapshot was taken from, later changes of the diagram don't affect the submission
+	DiagramId   string                 `protobuf:"bytes,5,opt,name=diagram_id,json=diagramId,proto3" json:"diagram_id,omitempty"`
+	Name        string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
+	TablesCount int64                  `protobuf:"varint,7,opt,name=tables_count,json=tablesCount,proto3" json:"tables_count,omitempty"`
+	SubmittedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	// Visible only to teachers
+	AutoGrading  *GradingResult         `protobuf:"bytes,9,opt,name=auto_grading,json=autoGrading,proto3" json:"auto_grading,omitempty"`
+	AutoGradedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=auto_graded_at,json=autoGradedAt,proto3" json:"auto_graded_at,omitempty"`
+	// Grade from 0 to 100, visible to the student once grades are released
+	Grade         *float64               `protobuf:"fixed64,11,opt,name=grade,proto3,oneof" json:"grade,omitempty"`
+	Feedback      string                 `protobuf:"bytes,12,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	GradedBy      string                 `protobuf:"bytes,13,opt,name=graded_by,json=gradedBy,proto3" json:"graded_by,omitempty"`
+	GradedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=graded_at,json=gradedAt,proto3" json:"graded_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Submission) Reset() {
+	*x = Submission{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Submission) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Submission) ProtoMessage() {}
+
+func (x *Submission) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Submission.ProtoReflect.Descriptor instead.
+func (*Submission) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Submission) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Submission) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *Submission) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Submission) GetUserLogin() string {
+	if x != nil {
+		return x.UserLogin
+	}
+	return ""
+}
+
+func (x *Submission) GetDiagramId() string {
+	if x != nil {
+		return x.DiagramId
+	}
+	return ""
+}
+
+func (x *Submission) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Submission) GetTablesCount() int64 {
+	if x != nil {
+		return x.TablesCount
+	}
+	return 0
+}
+
+func (x *Submission) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetAutoGrading() *GradingResult {
+	if x != nil {
+		return x.AutoGrading
+	}
+	return nil
+}
+
+func (x *Submission) GetAutoGradedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AutoGradedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetGrade() float64 {
+	if x != nil && x.Grade != nil {
+		return *x.Grade
+	}
+	return 0
+}
+
+func (x *Submission) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+func (x *Submission) GetGradedBy() string {
+	if x != nil {
+		return x.GradedBy
+	}
+	return ""
+}
+
+func (x *Submission) GetGradedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GradedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Submission) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SubmissionWithContent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submission    *Submission            `protobuf:"bytes,1,opt,name=submission,proto3" json:"submission,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmissionWithContent) Reset() {
+	*x = SubmissionWithContent{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmissionWithContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmissionWithContent) ProtoMessage() {}
+
+func (x *SubmissionWithContent) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmissionWithContent.ProtoReflect.Descriptor instead.
+func (*SubmissionWithContent) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubmissionWithContent) GetSubmission() *Submission {
+	if x != nil {
+		return x.Submission
+	}
+	return nil
+}
+
+func (x *SubmissionWithContent) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Weights of the grading criteria are relative to each other
+type GradingRubric struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	TablesWeight        float64                `protobuf:"fixed64,1,opt,name=tables_weight,json=tablesWeight,proto3" json:"tables_weight,omitempty"`
+	ColumnsWeight       float64                `protobuf:"fixed64,2,opt,name=columns_weight,json=columnsWeight,proto3" json:"columns_weight,omitempty"`
+	KeysWeight          float64                `protobuf:"fixed64,3,opt,name=keys_weight,json=keysWeight,proto3" json:"keys_weight,omitempty"`
+	RelationshipsWeight float64                `protobuf:"fixed64,4,opt,name=relationships_weight,json=relationshipsWeight,proto3" json:"relationships_weight,omitempty"`
+	CardinalitiesWeight float64                `protobuf:"fixed64,5,opt,name=cardinalities_weight,json=cardinalitiesWeight,proto3" json:"cardinalities_weight,omitempty"`
+	// Score in percents required to pass
+	PassScore float64 `protobuf:"fixed64,6,opt,name=pass_score,json=passScore,proto3" json:"pass_score,omitempty"`
+	// Scores closer than the margin to the pass score are marked as borderline
+	BorderlineMargin float64 `protobuf:"fixed64,7,opt,name=borderline_margin,json=borderlineMargin,proto3" json:"borderline_margin,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *GradingRubric) Reset() {
+	*x = GradingRubric{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradingRubric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradingRubric) ProtoMessage() {}
+
+func (x *GradingRubric) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradingRubric.ProtoReflect.Descriptor instead.
+func (*GradingRubric) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GradingRubric) GetTablesWeight() float64 {
+	if x != nil {
+		return x.TablesWeight
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetColumnsWeight() float64 {
+	if x != nil {
+		return x.ColumnsWeight
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetKeysWeight() float64 {
+	if x != nil {
+		return x.KeysWeight
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetRelationshipsWeight() float64 {
+	if x != nil {
+		return x.RelationshipsWeight
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetCardinalitiesWeight() float64 {
+	if x != nil {
+		return x.CardinalitiesWeight
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetPassScore() float64 {
+	if x != nil {
+		return x.PassScore
+	}
+	return 0
+}
+
+func (x *GradingRubric) GetBorderlineMargin() float64 {
+	if x != nil {
+		return x.BorderlineMargin
+	}
+	return 0
+}
+
+type GradingCriterion struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Weight float64                `protobuf:"fixed64,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Share of matched reference items from 0 to 1
+	Score    float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Matched  int64   `protobuf:"varint,4,opt,name=matched,proto3" json:"matched,omitempty"`
+	Expected int64   `protobuf:"varint,5,opt,name=expected,proto3" json:"expected,omitempty"`
+	// Reference items absent in the submission
+	Missing []string `protobuf:"bytes,6,rep,name=missing,proto3" json:"missing,omitempty"`
+	// Submission items absent in the reference
+	Extra         []string `protobuf:"bytes,7,rep,name=extra,proto3" json:"extra,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradingCriterion) Reset() {
+	*x = GradingCriterion{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradingCriterion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradingCriterion) ProtoMessage() {}
+
+func (x *GradingCriterion) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradingCriterion.ProtoReflect.Descriptor instead.
+func (*GradingCriterion) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GradingCriterion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GradingCriterion) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *GradingCriterion) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GradingCriterion) GetMatched() int64 {
+	if x != nil {
+		return x.Matched
+	}
+	return 0
+}
+
+func (x *GradingCriterion) GetExpected() int64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+func (x *GradingCriterion) GetMissing() []string {
+	if x != nil {
+		return x.Missing
+	}
+	return nil
+}
+
+func (x *GradingCriterion) GetExtra() []string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type GradingResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Score in percents
+	Score  float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Passed bool    `protobuf:"varint,2,opt,name=passed,proto3" json:"passed,omitempty"`
+	// Borderline results need manual review
+	Borderline bool                `protobuf:"varint,3,opt,name=borderline,proto3" json:"borderline,omitempty"`
+	Criteria   []*GradingCriterion `protobuf:"bytes,4,rep,name=criteria,proto3" json:"criteria,omitempty"`
+	// Set if the submission couldn't be graded
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradingResult) Reset() {
+	*x = GradingResult{}
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradingResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradingResult) ProtoMessage() {}
+
+func (x *GradingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradingResult.ProtoReflect.Descriptor instead.
+func (*GradingResult) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GradingResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *GradingResult) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *GradingResult) GetBorderline() bool {
+	if x != nil {
+		return x.Borderline
+	}
+	return false
+}
+
+func (x *GradingResult) GetCriteria() []*GradingCriterion {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *GradingResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_chartdb_v1_assignment_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_assignment_proto_rawDesc = "" +
+	"\n" +
+	"\x1bchartdb/v1/assignment.proto\x12\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cchartdb/v1/peer_review.proto\"\xe3\x06\n" +
+	"\n" +
+	"Assignment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12,\n" +
+	"\x12starter_diagram_id\x18\x05 \x01(\tR\x10starterDiagramId\x126\n" +
+	"\bdeadline\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x120\n" +
+	"\x14reference_diagram_id\x18\a \x01(\tR\x12referenceDiagramId\x121\n" +
+	"\x06rubric\x18\b \x01(\v2\x19.chartdb.v1.GradingRubricR\x06rubric\x12H\n" +
+	"\x12grades_released_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x10gradesReleasedAt\x12@\n" +
+	"\x0eexam_starts_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fexamStartsAt\x12<\n" +
+	"\fexam_ends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"examEndsAt\x12?\n" +
+	"\vpeer_review\x18\f \x01(\v2\x1e.chartdb.v1.PeerReviewSettingsR\n" +
+	"peerReview\x12I\n" +
+	"\x13peer_review_ends_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x10peerReviewEndsAt\x12S\n" +
+	"\x18peer_reviews_assigned_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x15peerReviewsAssignedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x0f\x10d\"\xa1\x05\n" +
+	"\n" +
+	"Submission\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x04 \x01(\tR\tuserLogin\x12\x1d\n" +
+	"\n" +
+	"diagram_id\x18\x05 \x01(\tR\tdiagramId\x12\x12\n" +
+	"\x04name\x18\x06 \x01(\tR\x04name\x12!\n" +
+	"\ftables_count\x18\a \x01(\x03R\vtablesCount\x12=\n" +
+	"\fsubmitted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x12<\n" +
+	"\fauto_grading\x18\t \x01(\v2\x19.chartdb.v1.GradingResultR\vautoGrading\x12@\n" +
+	"\x0eauto_graded_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\fautoGradedAt\x12\x19\n" +
+	"\x05grade\x18\v \x01(\x01H\x00R\x05grade\x88\x01\x01\x12\x1a\n" +
+	"\bfeedback\x18\f \x01(\tR\bfeedback\x12\x1b\n" +
+	"\tgraded_by\x18\r \x01(\tR\bgradedBy\x127\n" +
+	"\tgraded_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\bgradedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\b\n" +
+	"\x06_gradeJ\x04\b\x0f\x10d\"i\n" +
+	"\x15SubmissionWithContent\x126\n" +
+	"\n" +
+	"submission\x18\x01 \x01(\v2\x16.chartdb.v1.SubmissionR\n" +
+	"submission\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xae\x02\n" +
+	"\rGradingRubric\x12#\n" +
+	"\rtables_weight\x18\x01 \x01(\x01R\ftablesWeight\x12%\n" +
+	"\x0ecolumns_weight\x18\x02 \x01(\x01R\rcolumnsWeight\x12\x1f\n" +
+	"\vkeys_weight\x18\x03 \x01(\x01R\n" +
+	"keysWeight\x121\n" +
+	"\x14relationships_weight\x18\x04 \x01(\x01R\x13relationshipsWeight\x121\n" +
+	"\x14cardinalities_weight\x18\x05 \x01(\x01R\x13cardinalitiesWeight\x12\x1d\n" +
+	"\n" +
+	"pass_score\x18\x06 \x01(\x01R\tpassScore\x12+\n" +
+	"\x11borderline_margin\x18\a \x01(\x01R\x10borderlineMargin\"\xba\x01\n" +
+	"\x10GradingCriterion\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06weight\x18\x02 \x01(\x01R\x06weight\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x18\n" +
+	"\amatched\x18\x04 \x01(\x03R\amatched\x12\x1a\n" +
+	"\bexpected\x18\x05 \x01(\x03R\bexpected\x12\x18\n" +
+	"\amissing\x18\x06 \x03(\tR\amissing\x12\x14\n" +
+	"\x05extra\x18\a \x03(\tR\x05extra\"\xad\x01\n" +
+	"\rGradingResult\x12\x14\n" +
+	"\x05score\x18\x01 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06passed\x18\x02 \x01(\bR\x06passed\x12\x1e\n" +
+	"\n" +
+	"borderline\x18\x03 \x01(\bR\n" +
+	"borderline\x128\n" +
+	"\bcriteria\x18\x04 \x03(\v2\x1c.chartdb.v1.GradingCriterionR\bcriteria\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error*h\n" +
+	"\x0fGradebookFormat\x12 \n" +
+	"\x1cGRADEBOOK_FORMAT_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14GRADEBOOK_FORMAT_CSV\x10\x01\x12\x19\n" +
+	"\x15GRADEBOOK_FORMAT_XLSX\x10\x02B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_assignment_proto_rawDescOnce sync.Once
+	file_chartdb_v1_assignment_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_assignment_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_assignment_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_assignment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_assignment_proto_rawDesc), len(file_chartdb_v1_assignment_proto_rawDesc)))
+	})
+	return file_chartdb_v1_assignment_proto_rawDescData
+}
+
+var file_chartdb_v1_assignment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_chartdb_v1_assignment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_chartdb_v1_assignment_proto_goTypes = []any{
+	(GradebookFormat)(0),          // 0: chartdb.v1.GradebookFormat
+	(*Assignment)(nil),            // 1: chartdb.v1.Assignment
+	(*Submission)(nil),            // 2: chartdb.v1.Submission
+	(*SubmissionWithContent)(nil), // 3: chartdb.v1.SubmissionWithContent
+	(*GradingRubric)(nil),         // 4: chartdb.v1.GradingRubric
+	(*GradingCriterion)(nil),      // 5: chartdb.v1.GradingCriterion
+	(*GradingResult)(nil),         // 6: chartdb.v1.GradingResult
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*PeerReviewSettings)(nil),    // 8: chartdb.v1.PeerReviewSettings
+}
+var file_chartdb_v1_assignment_proto_depIdxs = []int32{
+	7,  // 0: chartdb.v1.Assignment.deadline:type_name -> google.protobuf.Timestamp
+	4,  // 1: chartdb.v1.Assignment.rubric:type_name -> chartdb.v1.GradingRubric
+	7,  // 2: chartdb.v1.Assignment.grades_released_at:type_name -> google.protobuf.Timestamp
+	7,  // 3: chartdb.v1.Assignment.exam_starts_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: chartdb.v1.Assignment.exam_ends_at:type_name -> google.protobuf.Timestamp
+	8,  // 5: chartdb.v1.Assignment.peer_review:type_name -> chartdb.v1.PeerReviewSettings
+	7,  // 6: chartdb.v1.Assignment.peer_review_ends_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: chartdb.v1.Assignment.peer_reviews_assigned_at:type_name -> google.protobuf.Timestamp
+	7,  // 8: chartdb.v1.Assignment.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 9: chartdb.v1.Assignment.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 10: chartdb.v1.Submission.submitted_at:type_name -> google.protobuf.Timestamp
+	6,  // 11: chartdb.v1.Submission.auto_grading:type_name -> chartdb.v1.GradingResult
+	7,  // 12: chartdb.v1.Submission.auto_graded_at:type_name -> google.protobuf.Timestamp
+	7,  // 13: chartdb.v1.Submission.graded_at:type_name -> google.protobuf.Timestamp
+	7,  // 14: chartdb.v1.Submission.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 15: chartdb.v1.Submission.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 16: chartdb.v1.SubmissionWithContent.submission:type_name -> chartdb.v1.Submission
+	5,  // 17: chartdb.v1.GradingResult.criteria:type_name -> chartdb.v1.GradingCriterion
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_assignment_proto_init() }
+func file_chartdb_v1_assignment_proto_init() {
+	if File_chartdb_v1_assignment_proto != nil {
+		return
+	}
+	file_chartdb_v1_peer_review_proto_init()
+	file_chartdb_v1_assignment_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_assignment_proto_rawDesc), len(file_chartdb_v1_assignment_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chartdb_v1_assignment_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_assignment_proto_depIdxs,
+		EnumInfos:         file_chartdb_v1_assignment_proto_enumTypes,
+		MessageInfos:      file_chartdb_v1_assignment_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_assignment_proto = out.File
+	file_chartdb_v1_assignment_proto_goTypes = nil
+	file_chartdb_v1_assignment_proto_depIdxs = nil
+}
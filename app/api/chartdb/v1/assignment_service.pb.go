@@ -0,0 +1,1750 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/assignment_service.proto
+
+package chartdb
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssignmentRequest) Reset() {
+	*x = GetAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssignmentRequest) ProtoMessage() {}
+
+func (x *GetAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListAssignmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsRequest) Reset() {
+	*x = ListAssignmentsRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsRequest) ProtoMessage() {}
+
+func (x *ListAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListAssignmentsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+type ListAssignmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assignments   []*Assignment          `protobuf:"bytes,1,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssignmentsResponse) Reset() {
+	*x = ListAssignmentsResponse{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssignmentsResponse) ProtoMessage() {}
+
+func (x *ListAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListAssignmentsResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type CreateAssignmentRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CourseId    string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Optional
+	StarterDiagramId string `protobuf:"bytes,4,opt,name=starter_diagram_id,json=starterDiagramId,proto3" json:"starter_diagram_id,omitempty"`
+	// Optional
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Optional
+	ReferenceDiagramId string `protobuf:"bytes,6,opt,name=reference_diagram_id,json=referenceDiagramId,proto3" json:"reference_diagram_id,omitempty"`
+	// Optional, the default rubric is used if empty
+	Rubric *GradingRubric `protobuf:"bytes,7,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	// Optional, both bounds make the assignment an exam, the starter diagram is required for exams
+	ExamStartsAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=exam_starts_at,json=examStartsAt,proto3" json:"exam_starts_at,omitempty"`
+	ExamEndsAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=exam_ends_at,json=examEndsAt,proto3" json:"exam_ends_at,omitempty"`
+	// Optional, peer review requires the deadline and the end of the review period after it
+	PeerReview       *PeerReviewSettings    `protobuf:"bytes,10,opt,name=peer_review,json=peerReview,proto3" json:"peer_review,omitempty"`
+	PeerReviewEndsAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=peer_review_ends_at,json=peerReviewEndsAt,proto3" json:"peer_review_ends_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateAssignmentRequest) Reset() {
+	*x = CreateAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssignmentRequest) ProtoMessage() {}
+
+func (x *CreateAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateAssignmentRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CreateAssignmentRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAssignmentRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateAssignmentRequest) GetStarterDiagramId() string {
+	if x != nil {
+		return x.StarterDiagramId
+	}
+	return ""
+}
+
+func (x *CreateAssignmentRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *CreateAssignmentRequest) GetReferenceDiagramId() string {
+	if x != nil {
+		return x.ReferenceDiagramId
+	}
+	return ""
+}
+
+func (x *CreateAssignmentRequest) GetRubric() *GradingRubric {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *CreateAssignmentRequest) GetExamStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamStartsAt
+	}
+	return nil
+}
+
+func (x *CreateAssignmentRequest) GetExamEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamEndsAt
+	}
+	return nil
+}
+
+func (x *CreateAssignmentRequest) GetPeerReview() *PeerReviewSettings {
+	if x != nil {
+		return x.PeerReview
+	}
+	return nil
+}
+
+func (x *CreateAssignmentRequest) GetPeerReviewEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeerReviewEndsAt
+	}
+	return nil
+}
+
+type UpdateAssignmentRequest struct {
+	state         protoimpl.MessageState                `protogen:"open.v1"`
+	Id            string                                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields        *UpdateAssignmentRequest_UpdateFields `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask                `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssignmentRequest) Reset() {
+	*x = UpdateAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssignmentRequest) ProtoMessage() {}
+
+func (x *UpdateAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest) GetFields() *UpdateAssignmentRequest_UpdateFields {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssignmentRequest) Reset() {
+	*x = DeleteAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssignmentRequest) ProtoMessage() {}
+
+func (x *DeleteAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StartAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartAssignmentRequest) Reset() {
+	*x = StartAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartAssignmentRequest) ProtoMessage() {}
+
+func (x *StartAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*StartAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StartAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SubmitAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DiagramId     string                 `protobuf:"bytes,2,opt,name=diagram_id,json=diagramId,proto3" json:"diagram_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitAssignmentRequest) Reset() {
+	*x = SubmitAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitAssignmentRequest) ProtoMessage() {}
+
+func (x *SubmitAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*SubmitAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubmitAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitAssignmentRequest) GetDiagramId() string {
+	if x != nil {
+		return x.DiagramId
+	}
+	return ""
+}
+
+type ListSubmissionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssignmentId  string                 `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubmissionsRequest) Reset() {
+	*x = ListSubmissionsRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubmissionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubmissionsRequest) ProtoMessage() {}
+
+func (x *ListSubmissionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubmissionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSubmissionsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListSubmissionsRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+type ListSubmissionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Submissions   []*Submission          `protobuf:"bytes,1,rep,name=submissions,proto3" json:"submissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubmissionsResponse) Reset() {
+	*x = ListSubmissionsResponse{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubmissionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubmissionsResponse) ProtoMessage() {}
+
+func (x *ListSubmissionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubmissionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSubmissionsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListSubmissionsResponse) GetSubmissions() []*Submission {
+	if x != nil {
+		return x.Submissions
+	}
+	return nil
+}
+
+type GetSubmissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSubmissionRequest) Reset() {
+	*x = GetSubmissionRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSubmissionRequest) ProtoMessage() {}
+
+func (x *GetSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*GetSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetSubmissionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GradeAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GradeAssignmentRequest) Reset() {
+	*x = GradeAssignmentRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GradeAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GradeAssignmentRequest) ProtoMessage() {}
+
+func (x *GradeAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GradeAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*GradeAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GradeAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CheckPlagiarismRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckPlagiarismRequest) Reset() {
+	*x = CheckPlagiarismRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckPlagiarismRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckPlagiarismRequest) ProtoMessage() {}
+
+func (x *CheckPlagiarismRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckPlagiarismRequest.ProtoReflect.Descriptor instead.
+func (*CheckPlagiarismRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CheckPlagiarismRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPlagiarismReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPlagiarismReportRequest) Reset() {
+	*x = GetPlagiarismReportRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPlagiarismReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPlagiarismReportRequest) ProtoMessage() {}
+
+func (x *GetPlagiarismReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPlagiarismReportRequest.ProtoReflect.Descriptor instead.
+func (*GetPlagiarismReportRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetPlagiarismReportRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SetSubmissionGradeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Empty value removes the grade
+	Grade         *float64 `protobuf:"fixed64,2,opt,name=grade,proto3,oneof" json:"grade,omitempty"`
+	Feedback      string   `protobuf:"bytes,3,opt,name=feedback,proto3" json:"feedback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetSubmissionGradeRequest) Reset() {
+	*x = SetSubmissionGradeRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetSubmissionGradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSubmissionGradeRequest) ProtoMessage() {}
+
+func (x *SetSubmissionGradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSubmissionGradeRequest.ProtoReflect.Descriptor instead.
+func (*SetSubmissionGradeRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SetSubmissionGradeRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetSubmissionGradeRequest) GetGrade() float64 {
+	if x != nil && x.Grade != nil {
+		return *x.Grade
+	}
+	return 0
+}
+
+func (x *SetSubmissionGradeRequest) GetFeedback() string {
+	if x != nil {
+		return x.Feedback
+	}
+	return ""
+}
+
+type ReleaseGradesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// False hides grades from students again
+	Released      bool `protobuf:"varint,2,opt,name=released,proto3" json:"released,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseGradesRequest) Reset() {
+	*x = ReleaseGradesRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseGradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseGradesRequest) ProtoMessage() {}
+
+func (x *ReleaseGradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseGradesRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseGradesRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReleaseGradesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseGradesRequest) GetReleased() bool {
+	if x != nil {
+		return x.Released
+	}
+	return false
+}
+
+type ExportGradebookRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Optional, all course students are exported if empty
+	GroupId string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// CSV is used if unspecified
+	Format        GradebookFormat `protobuf:"varint,3,opt,name=format,proto3,enum=chartdb.v1.GradebookFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGradebookRequest) Reset() {
+	*x = ExportGradebookRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGradebookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGradebookRequest) ProtoMessage() {}
+
+func (x *ExportGradebookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGradebookRequest.ProtoReflect.Descriptor instead.
+func (*ExportGradebookRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ExportGradebookRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ExportGradebookRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ExportGradebookRequest) GetFormat() GradebookFormat {
+	if x != nil {
+		return x.Format
+	}
+	return GradebookFormat_GRADEBOOK_FORMAT_UNSPECIFIED
+}
+
+type GetCourseAnalyticsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Optional, all course students are included if empty
+	GroupId string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// Activity is aggregated for the last days, 14 if unspecified
+	Days          int64 `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseAnalyticsRequest) Reset() {
+	*x = GetCourseAnalyticsRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetCourseAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetCourseAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetCourseAnalyticsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *GetCourseAnalyticsRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GetCourseAnalyticsRequest) GetDays() int64 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type GetStudentAnalyticsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UserId   string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Activity is aggregated for the last days, 14 if unspecified
+	Days          int64 `protobuf:"varint,3,opt,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStudentAnalyticsRequest) Reset() {
+	*x = GetStudentAnalyticsRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStudentAnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStudentAnalyticsRequest) ProtoMessage() {}
+
+func (x *GetStudentAnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStudentAnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*GetStudentAnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetStudentAnalyticsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *GetStudentAnalyticsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetStudentAnalyticsRequest) GetDays() int64 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+type ListPeerReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssignmentId  string                 `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPeerReviewsRequest) Reset() {
+	*x = ListPeerReviewsRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPeerReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerReviewsRequest) ProtoMessage() {}
+
+func (x *ListPeerReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListPeerReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPeerReviewsRequest) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+type ListPeerReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*PeerReview          `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPeerReviewsResponse) Reset() {
+	*x = ListPeerReviewsResponse{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPeerReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerReviewsResponse) ProtoMessage() {}
+
+func (x *ListPeerReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListPeerReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListPeerReviewsResponse) GetReviews() []*PeerReview {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type GetPeerReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerReviewRequest) Reset() {
+	*x = GetPeerReviewRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerReviewRequest) ProtoMessage() {}
+
+func (x *GetPeerReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerReviewRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerReviewRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetPeerReviewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type SubmitPeerReviewRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Scores from 1 to 5 in the order of assignment criteria
+	Scores        []int64 `protobuf:"varint,2,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	Comment       string  `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitPeerReviewRequest) Reset() {
+	*x = SubmitPeerReviewRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitPeerReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitPeerReviewRequest) ProtoMessage() {}
+
+func (x *SubmitPeerReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitPeerReviewRequest.ProtoReflect.Descriptor instead.
+func (*SubmitPeerReviewRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SubmitPeerReviewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubmitPeerReviewRequest) GetScores() []int64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *SubmitPeerReviewRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type ModeratePeerReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Hidden        bool                   `protobuf:"varint,2,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeratePeerReviewRequest) Reset() {
+	*x = ModeratePeerReviewRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeratePeerReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeratePeerReviewRequest) ProtoMessage() {}
+
+func (x *ModeratePeerReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeratePeerReviewRequest.ProtoReflect.Descriptor instead.
+func (*ModeratePeerReviewRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ModeratePeerReviewRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ModeratePeerReviewRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type GetPeerReviewSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SubmissionId  string                 `protobuf:"bytes,1,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPeerReviewSummaryRequest) Reset() {
+	*x = GetPeerReviewSummaryRequest{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPeerReviewSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPeerReviewSummaryRequest) ProtoMessage() {}
+
+func (x *GetPeerReviewSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPeerReviewSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetPeerReviewSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetPeerReviewSummaryRequest) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+type UpdateAssignmentRequest_UpdateFields struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// Empty value removes the starter diagram
+	StarterDiagramId string `protobuf:"bytes,3,opt,name=starter_diagram_id,json=starterDiagramId,proto3" json:"starter_diagram_id,omitempty"`
+	// Empty value removes the deadline
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Empty value disables automatic grading
+	ReferenceDiagramId string `protobuf:"bytes,5,opt,name=reference_diagram_id,json=referenceDiagramId,proto3" json:"reference_diagram_id,omitempty"`
+	// Empty value resets to the default rubric
+	Rubric *GradingRubric `protobuf:"bytes,6,opt,name=rubric,proto3" json:"rubric,omitempty"`
+	// Empty values of both bounds turn the exam into a regular assignment,
+	// moving the end also moves it for students taking the exam
+	ExamStartsAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=exam_starts_at,json=examStartsAt,proto3" json:"exam_starts_at,omitempty"`
+	ExamEndsAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=exam_ends_at,json=examEndsAt,proto3" json:"exam_ends_at,omitempty"`
+	// Empty values of both fields disable peer review, the settings can't be changed after reviewers are assigned
+	PeerReview       *PeerReviewSettings    `protobuf:"bytes,9,opt,name=peer_review,json=peerReview,proto3" json:"peer_review,omitempty"`
+	PeerReviewEndsAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=peer_review_ends_at,json=peerReviewEndsAt,proto3" json:"peer_review_ends_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) Reset() {
+	*x = UpdateAssignmentRequest_UpdateFields{}
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssignmentRequest_UpdateFields) ProtoMessage() {}
+
+func (x *UpdateAssignmentRequest_UpdateFields) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_assignment_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssignmentRequest_UpdateFields.ProtoReflect.Descriptor instead.
+func (*UpdateAssignmentRequest_UpdateFields) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_assignment_service_proto_rawDescGZIP(), []int{4, 0}
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetStarterDiagramId() string {
+	if x != nil {
+		return x.StarterDiagramId
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetReferenceDiagramId() string {
+	if x != nil {
+		return x.ReferenceDiagramId
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetRubric() *GradingRubric {
+	if x != nil {
+		return x.Rubric
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetExamStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamStartsAt
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetExamEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExamEndsAt
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetPeerReview() *PeerReviewSettings {
+	if x != nil {
+		return x.PeerReview
+	}
+	return nil
+}
+
+func (x *UpdateAssignmentRequest_UpdateFields) GetPeerReviewEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeerReviewEndsAt
+	}
+	return nil
+}
+
+var File_chartdb_v1_assignment_service_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_assignment_service_proto_rawDesc = "" +
+	"\n" +
+	"#chartdb/v1/assignment_service.proto\x12\n" +
+	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1achartdb/v1/analytics.proto\x1a\x1bchartdb/v1/assignment.proto\x1a\x18chartdb/v1/diagram.proto\x1a\x1cchartdb/v1/peer_review.proto\x1a\x1bchartdb/v1/plagiarism.proto\".\n" +
+	"\x14GetAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"=\n" +
+	"\x16ListAssignmentsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\"S\n" +
+	"\x17ListAssignmentsResponse\x128\n" +
+	"\vassignments\x18\x01 \x03(\v2\x16.chartdb.v1.AssignmentR\vassignments\"\xd5\x04\n" +
+	"\x17CreateAssignmentRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1c\n" +
+	"\x05title\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12,\n" +
+	"\x12starter_diagram_id\x18\x04 \x01(\tR\x10starterDiagramId\x126\n" +
+	"\bdeadline\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x120\n" +
+	"\x14reference_diagram_id\x18\x06 \x01(\tR\x12referenceDiagramId\x121\n" +
+	"\x06rubric\x18\a \x01(\v2\x19.chartdb.v1.GradingRubricR\x06rubric\x12@\n" +
+	"\x0eexam_starts_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fexamStartsAt\x12<\n" +
+	"\fexam_ends_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"examEndsAt\x12?\n" +
+	"\vpeer_review\x18\n" +
+	" \x01(\v2\x1e.chartdb.v1.PeerReviewSettingsR\n" +
+	"peerReview\x12I\n" +
+	"\x13peer_review_ends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x10peerReviewEndsAt\"\xd8\x05\n" +
+	"\x17UpdateAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12H\n" +
+	"\x06fields\x18\x02 \x01(\v20.chartdb.v1.UpdateAssignmentRequest.UpdateFieldsR\x06fields\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1a\x9d\x04\n" +
+	"\fUpdateFields\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12,\n" +
+	"\x12starter_diagram_id\x18\x03 \x01(\tR\x10starterDiagramId\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x120\n" +
+	"\x14reference_diagram_id\x18\x05 \x01(\tR\x12referenceDiagramId\x121\n" +
+	"\x06rubric\x18\x06 \x01(\v2\x19.chartdb.v1.GradingRubricR\x06rubric\x12@\n" +
+	"\x0eexam_starts_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fexamStartsAt\x12<\n" +
+	"\fexam_ends_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"examEndsAt\x12?\n" +
+	"\vpeer_review\x18\t \x01(\v2\x1e.chartdb.v1.PeerReviewSettingsR\n" +
+	"peerReview\x12I\n" +
+	"\x13peer_review_ends_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x10peerReviewEndsAt\"1\n" +
+	"\x17DeleteAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"0\n" +
+	"\x16StartAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"X\n" +
+	"\x17SubmitAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12%\n" +
+	"\n" +
+	"diagram_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\tdiagramId\"E\n" +
+	"\x16ListSubmissionsRequest\x12+\n" +
+	"\rassignment_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\fassignmentId\"S\n" +
+	"\x17ListSubmissionsResponse\x128\n" +
+	"\vsubmissions\x18\x01 \x03(\v2\x16.chartdb.v1.SubmissionR\vsubmissions\".\n" +
+	"\x14GetSubmissionRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"0\n" +
+	"\x16GradeAssignmentRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"0\n" +
+	"\x16CheckPlagiarismRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"4\n" +
+	"\x1aGetPlagiarismReportRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"\x8d\x01\n" +
+	"\x19SetSubmissionGradeRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x122\n" +
+	"\x05grade\x18\x02 \x01(\x01B\x17\xbaH\x14\x12\x12\x19\x00\x00\x00\x00\x00\x00Y@)\x00\x00\x00\x00\x00\x00\x00\x00H\x00R\x05grade\x88\x01\x01\x12\x1a\n" +
+	"\bfeedback\x18\x03 \x01(\tR\bfeedbackB\b\n" +
+	"\x06_grade\"J\n" +
+	"\x14ReleaseGradesRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x1a\n" +
+	"\breleased\x18\x02 \x01(\bR\breleased\"\x8d\x01\n" +
+	"\x16ExportGradebookRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x123\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x1b.chartdb.v1.GradebookFormatR\x06format\"{\n" +
+	"\x19GetCourseAnalyticsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1e\n" +
+	"\x04days\x18\x03 \x01(\x03B\n" +
+	"\xbaH\a\"\x05\x18\xed\x02(\x00R\x04days\"\x82\x01\n" +
+	"\x1aGetStudentAnalyticsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1f\n" +
+	"\auser_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06userId\x12\x1e\n" +
+	"\x04days\x18\x03 \x01(\x03B\n" +
+	"\xbaH\a\"\x05\x18\xed\x02(\x00R\x04days\"E\n" +
+	"\x16ListPeerReviewsRequest\x12+\n" +
+	"\rassignment_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\fassignmentId\"K\n" +
+	"\x17ListPeerReviewsResponse\x120\n" +
+	"\areviews\x18\x01 \x03(\v2\x16.chartdb.v1.PeerReviewR\areviews\".\n" +
+	"\x14GetPeerReviewRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"m\n" +
+	"\x17SubmitPeerReviewRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12 \n" +
+	"\x06scores\x18\x02 \x03(\x03B\b\xbaH\x05\x92\x01\x02\b\x01R\x06scores\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"K\n" +
+	"\x19ModeratePeerReviewRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x16\n" +
+	"\x06hidden\x18\x02 \x01(\bR\x06hidden\"J\n" +
+	"\x1bGetPeerReviewSummaryRequest\x12+\n" +
+	"\rsubmission_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\fsubmissionId2\xf4\x16\n" +
+	"\x11AssignmentService\x12e\n" +
+	"\x03Get\x12 .chartdb.v1.GetAssignmentRequest\x1a\x16.chartdb.v1.Assignment\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/chartdb/v1/assignments/{id}\x12\x84\x01\n" +
+	"\x04List\x12\".chartdb.v1.ListAssignmentsRequest\x1a#.chartdb.v1.ListAssignmentsResponse\"3\x82\xd3\xe4\x93\x02-\x12+/chartdb/v1/courses/{course_id}/assignments\x12}\n" +
+	"\x06Create\x12#.chartdb.v1.CreateAssignmentRequest\x1a\x16.chartdb.v1.Assignment\"6\x82\xd3\xe4\x93\x020:\x01*\"+/chartdb/v1/courses/{course_id}/assignments\x12s\n" +
+	"\x06Update\x12#.chartdb.v1.UpdateAssignmentRequest\x1a\x16.chartdb.v1.Assignment\",\x82\xd3\xe4\x93\x02&:\x06fields2\x1c/chartdb/v1/assignments/{id}\x12k\n" +
+	"\x06Delete\x12#.chartdb.v1.DeleteAssignmentRequest\x1a\x16.google.protobuf.Empty\"$\x82\xd3\xe4\x93\x02\x1e*\x1c/chartdb/v1/assignments/{id}\x12w\n" +
+	"\x05Start\x12\".chartdb.v1.StartAssignmentRequest\x1a\x1b.chartdb.v1.DiagramMetadata\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/chartdb/v1/assignments/{id}:start\x12u\n" +
+	"\x06Submit\x12#.chartdb.v1.SubmitAssignmentRequest\x1a\x16.chartdb.v1.Submission\".\x82\xd3\xe4\x93\x02(:\x01*\"#/chartdb/v1/assignments/{id}:submit\x12\x97\x01\n" +
+	"\x0fListSubmissions\x12\".chartdb.v1.ListSubmissionsRequest\x1a#.chartdb.v1.ListSubmissionsResponse\";\x82\xd3\xe4\x93\x025\x123/chartdb/v1/assignments/{assignment_id}/submissions\x12z\n" +
+	"\rGetSubmission\x12 .chartdb.v1.GetSubmissionRequest\x1a!.chartdb.v1.SubmissionWithContent\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/chartdb/v1/submissions/{id}\x12\x7f\n" +
+	"\x05Grade\x12\".chartdb.v1.GradeAssignmentRequest\x1a#.chartdb.v1.ListSubmissionsResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/chartdb/v1/assignments/{id}:grade\x12\x8b\x01\n" +
+	"\x0fCheckPlagiarism\x12\".chartdb.v1.CheckPlagiarismRequest\x1a\x1b.chartdb.v1.PlagiarismCheck\"7\x82\xd3\xe4\x93\x021:\x01*\",/chartdb/v1/assignments/{id}:checkPlagiarism\x12\x8c\x01\n" +
+	"\x13GetPlagiarismReport\x12&.chartdb.v1.GetPlagiarismReportRequest\x1a\x1c.chartdb.v1.PlagiarismReport\"/\x82\xd3\xe4\x93\x02)\x12'/chartdb/v1/assignments/{id}/plagiarism\x12{\n" +
+	"\bSetGrade\x12%.chartdb.v1.SetSubmissionGradeRequest\x1a\x16.chartdb.v1.Submission\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/chartdb/v1/submissions/{id}:setGrade\x12\x80\x01\n" +
+	"\rReleaseGrades\x12 .chartdb.v1.ReleaseGradesRequest\x1a\x16.chartdb.v1.Assignment\"5\x82\xd3\xe4\x93\x02/:\x01*\"*/chartdb/v1/assignments/{id}:releaseGrades\x12~\n" +
+	"\x0fExportGradebook\x12\".chartdb.v1.ExportGradebookRequest\x1a\x14.google.api.HttpBody\"1\x82\xd3\xe4\x93\x02+\x12)/chartdb/v1/courses/{course_id}/gradebook\x12\x8b\x01\n" +
+	"\x12GetCourseAnalytics\x12%.chartdb.v1.GetCourseAnalyticsRequest\x1a\x1b.chartdb.v1.CourseAnalytics\"1\x82\xd3\xe4\x93\x02+\x12)/chartdb/v1/courses/{course_id}/analytics\x12\xa0\x01\n" +
+	"\x13GetStudentAnalytics\x12&.chartdb.v1.GetStudentAnalyticsRequest\x1a\x1b.chartdb.v1.CourseAnalytics\"D\x82\xd3\xe4\x93\x02>\x12</chartdb/v1/courses/{course_id}/students/{user_id}/analytics\x12\x97\x01\n" +
+	"\x0fListPeerReviews\x12\".chartdb.v1.ListPeerReviewsRequest\x1a#.chartdb.v1.ListPeerReviewsResponse\";\x82\xd3\xe4\x93\x025\x123/chartdb/v1/assignments/{assignment_id}/peerReviews\x12z\n" +
+	"\rGetPeerReview\x12 .chartdb.v1.GetPeerReviewRequest\x1a!.chartdb.v1.PeerReviewWithContent\"$\x82\xd3\xe4\x93\x02\x1e\x12\x1c/chartdb/v1/peerReviews/{id}\x12\x7f\n" +
+	"\x10SubmitPeerReview\x12#.chartdb.v1.SubmitPeerReviewRequest\x1a\x16.chartdb.v1.PeerReview\".\x82\xd3\xe4\x93\x02(:\x01*\"#/chartdb/v1/peerReviews/{id}:submit\x12\x85\x01\n" +
+	"\x12ModeratePeerReview\x12%.chartdb.v1.ModeratePeerReviewRequest\x1a\x16.chartdb.v1.PeerReview\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/chartdb/v1/peerReviews/{id}:moderate\x12\x9b\x01\n" +
+	"\x14GetPeerReviewSummary\x12'.chartdb.v1.GetPeerReviewSummaryRequest\x1a\x1d.chartdb.v1.PeerReviewSummary\";\x82\xd3\xe4\x93\x025\x123/chartdb/v1/submissions/{submission_id}/peerReviewsB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_assignment_service_proto_rawDescOnce sync.Once
+	file_chartdb_v1_assignment_service_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_assignment_service_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_assignment_service_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_assignment_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_assignment_service_proto_rawDesc), len(file_chartdb_v1_assignment_service_proto_rawDesc)))
+	})
+	return file_chartdb_v1_assignment_service_proto_rawDescData
+}
+
+var file_chartdb_v1_assignment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_chartdb_v1_assignment_service_proto_goTypes = []any{
+	(*GetAssignmentRequest)(nil),                 // 0: chartdb.v1.GetAssignmentRequest
+	(*ListAssignmentsRequest)(nil),               // 1: chartdb.v1.ListAssignmentsRequest
+	(*ListAssignmentsResponse)(nil),              // 2: chartdb.v1.ListAssignmentsResponse
+	(*CreateAssignmentRequest)(nil),              // 3: chartdb.v1.CreateAssignmentRequest
+	(*UpdateAssignmentRequest)(nil),              // 4: chartdb.v1.UpdateAssignmentRequest
+	(*DeleteAssignmentRequest)(nil),              // 5: chartdb.v1.DeleteAssignmentRequest
+	(*StartAssignmentRequest)(nil),               // 6: chartdb.v1.StartAssignmentRequest
+	(*SubmitAssignmentRequest)(nil),              // 7: chartdb.v1.SubmitAssignmentRequest
+	(*ListSubmissionsRequest)(nil),               // 8: chartdb.v1.ListSubmissionsRequest
+	(*ListSubmissionsResponse)(nil),              // 9: chartdb.v1.ListSubmissionsResponse
+	(*GetSubmissionRequest)(nil),                 // 10: chartdb.v1.GetSubmissionRequest
+	(*GradeAssignmentRequest)(nil),               // 11: chartdb.v1.GradeAssignmentRequest
+	(*CheckPlagiarismRequest)(nil),               // 12: chartdb.v1.CheckPlagiarismRequest
+	(*GetPlagiarismReportRequest)(nil),           // 13: chartdb.v1.GetPlagiarismReportRequest
+	(*SetSubmissionGradeRequest)(nil),            // 14: chartdb.v1.SetSubmissionGradeRequest
+	(*ReleaseGradesRequest)(nil),                 // 15: chartdb.v1.ReleaseGradesRequest
+	(*ExportGradebookRequest)(nil),               // 16: chartdb.v1.ExportGradebookRequest
+	(*GetCourseAnalyticsRequest)(nil),            // 17: chartdb.v1.GetCourseAnalyticsRequest
+	(*GetStudentAnalyticsRequest)(nil),           // 18: chartdb.v1.GetStudentAnalyticsRequest
+	(*ListPeerReviewsRequest)(nil),               // 19: chartdb.v1.ListPeerReviewsRequest
+	(*ListPeerReviewsResponse)(nil),              // 20: chartdb.v1.ListPeerReviewsResponse
+	(*GetPeerReviewRequest)(nil),                 // 21: chartdb.v1.GetPeerReviewRequest
+	(*SubmitPeerReviewRequest)(nil),              // 22: chartdb.v1.SubmitPeerReviewRequest
+	(*ModeratePeerReviewRequest)(nil),            // 23: chartdb.v1.ModeratePeerReviewRequest
+	(*GetPeerReviewSummaryRequest)(nil),          // 24: chartdb.v1.GetPeerReviewSummaryRequest
+	(*UpdateAssignmentRequest_UpdateFields)(nil), // 25: chartdb.v1.UpdateAssignmentRequest.UpdateFields
+	(*Assignment)(nil),                           // 26: chartdb.v1.Assignment
+	(*timestamppb.Timestamp)(nil),                // 27: google.protobuf.Timestamp
+	(*GradingRubric)(nil),                        // 28: chartdb.v1.GradingRubric
+	(*PeerReviewSettings)(nil),                   // 29: chartdb.v1.PeerReviewSettings
+	(*fieldmaskpb.FieldMask)(nil),                // 30: google.protobuf.FieldMask
+	(*Submission)(nil),                           // 31: chartdb.v1.Submission
+	(GradebookFormat)(0),                         // 32: chartdb.v1.GradebookFormat
+	(*PeerReview)(nil),                           // 33: chartdb.v1.PeerReview
+	(*emptypb.Empty)(nil),                        // 34: google.protobuf.Empty
+	(*DiagramMetadata)(nil),                      // 35: chartdb.v1.DiagramMetadata
+	(*SubmissionWithContent)(nil),                // 36: chartdb.v1.SubmissionWithContent
+	(*PlagiarismCheck)(nil),                      // 37: chartdb.v1.PlagiarismCheck
+	(*PlagiarismReport)(nil),                     // 38: chartdb.v1.PlagiarismReport
+	(*httpbody.HttpBody)(nil),                    // 39: google.api.HttpBody
+	(*CourseAnalytics)(nil),                      // 40: chartdb.v1.CourseAnalytics
+	(*PeerReviewWithContent)(nil),                // 41: chartdb.v1.PeerReviewWithContent
+	(*PeerReviewSummary)(nil),                    // 42: chartdb.v1.PeerReviewSummary
+}
+var file_chartdb_v1_assignment_service_proto_depIdxs = []int32{
+	26, // 0: chartdb.v1.ListAssignmentsResponse.assignments:type_name -> chartdb.v1.Assignment
+	27, // 1: chartdb.v1.CreateAssignmentRequest.deadline:type_name -> google.protobuf.Timestamp
+	28, // 2: chartdb.v1.CreateAssignmentRequest.rubric:type_name -> chartdb.v1.GradingRubric
+	27, // 3: chartdb.v1.CreateAssignmentRequest.exam_starts_at:type_name -> google.protobuf.Timestamp
+	27, // 4: chartdb.v1.CreateAssignmentRequest.exam_ends_at:type_name -> google.protobuf.Timestamp
+	29, // 5: chartdb.v1.CreateAssignmentRequest.peer_review:type_name -> chartdb.v1.PeerReviewSettings
+	27, // 6: chartdb.v1.CreateAssignmentRequest.peer_review_ends_at:type_name -> google.protobuf.Timestamp
+	25, // 7: chartdb.v1.UpdateAssignmentRequest.fields:type_name -> chartdb.v1.UpdateAssignmentRequest.UpdateFields
+	30, // 8: chartdb.v1.UpdateAssignmentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	31, // 9: chartdb.v1.ListSubmissionsResponse.submissions:type_name -> chartdb.v1.Submission
+	32, // 10: chartdb.v1.ExportGradebookRequest.format:type_name -> chartdb.v1.GradebookFormat
+	33, // 11: chartdb.v1.ListPeerReviewsResponse.reviews:type_name -> chartdb.v1.PeerReview
+	27, // 12: chartdb.v1.UpdateAssignmentRequest.UpdateFields.deadline:type_name -> google.protobuf.Timestamp
+	28, // 13: chartdb.v1.UpdateAssignmentRequest.UpdateFields.rubric:type_name -> chartdb.v1.GradingRubric
+	27, // 14: chartdb.v1.UpdateAssignmentRequest.UpdateFields.exam_starts_at:type_name -> google.protobuf.Timestamp
+	27, // 15: chartdb.v1.UpdateAssignmentRequest.UpdateFields.exam_ends_at:type_name -> google.protobuf.Timestamp
+	29, // 16: chartdb.v1.UpdateAssignmentRequest.UpdateFields.peer_review:type_name -> chartdb.v1.PeerReviewSettings
+	27, // 17: chartdb.v1.UpdateAssignmentRequest.UpdateFields.peer_review_ends_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: chartdb.v1.AssignmentService.Get:input_type -> chartdb.v1.GetAssignmentRequest
+	1,  // 19: chartdb.v1.AssignmentService.List:input_type -> chartdb.v1.ListAssignmentsRequest
+	3,  // 20: chartdb.v1.AssignmentService.Create:input_type -> chartdb.v1.CreateAssignmentRequest
+	4,  // 21: chartdb.v1.AssignmentService.Update:input_type -> chartdb.v1.UpdateAssignmentRequest
+	5,  // 22: chartdb.v1.AssignmentService.Delete:input_type -> chartdb.v1.DeleteAssignmentRequest
+	6,  // 23: chartdb.v1.AssignmentService.Start:input_type -> chartdb.v1.StartAssignmentRequest
+	7,  // 24: chartdb.v1.AssignmentService.Submit:input_type -> chartdb.v1.SubmitAssignmentRequest
+	8,  // 25: chartdb.v1.AssignmentService.ListSubmissions:input_type -> chartdb.v1.ListSubmissionsRequest
+	10, // 26: chartdb.v1.AssignmentService.GetSubmission:input_type -> chartdb.v1.GetSubmissionRequest
+	11, // 27: chartdb.v1.AssignmentService.Grade:input_type -> chartdb.v1.GradeAssignmentRequest
+	12, // 28: chartdb.v1.AssignmentService.CheckPlagiarism:input_type -> chartdb.v1.CheckPlagiarismRequest
+	13, // 29: chartdb.v1.AssignmentService.GetPlagiarismReport:input_type -> chartdb.v1.GetPlagiarismReportRequest
+	14, // 30: chartdb.v1.AssignmentService.SetGrade:input_type -> chartdb.v1.SetSubmissionGradeRequest
+	15, // 31: chartdb.v1.AssignmentService.ReleaseGrades:input_type -> chartdb.v1.ReleaseGradesRequest
+	16, // 32: chartdb.v1.AssignmentService.ExportGradebook:input_type -> chartdb.v1.ExportGradebookRequest
+	17, // 33: chartdb.v1.AssignmentService.GetCourseAnalytics:input_type -> chartdb.v1.GetCourseAnalyticsRequest
+	18, // 34: chartdb.v1.AssignmentService.GetStudentAnalytics:input_type -> chartdb.v1.GetStudentAnalyticsRequest
+	19, // 35: chartdb.v1.AssignmentService.ListPeerReviews:input_type -> chartdb.v1.ListPeerReviewsRequest
+	21, // 36: chartdb.v1.AssignmentService.GetPeerReview:input_type -> chartdb.v1.GetPeerReviewRequest
+	22, // 37: chartdb.v1.AssignmentService.SubmitPeerReview:input_type -> chartdb.v1.SubmitPeerReviewRequest
+	23, // 38: chartdb.v1.AssignmentService.ModeratePeerReview:input_type -> chartdb.v1.ModeratePeerReviewRequest
+	24, // 39: chartdb.v1.AssignmentService.GetPeerReviewSummary:input_type -> chartdb.v1.GetPeerReviewSummaryRequest
+	26, // 40: chartdb.v1.AssignmentService.Get:output_type -> chartdb.v1.Assignment
+	2,  // 41: chartdb.v1.AssignmentService.List:output_type -> chartdb.v1.ListAssignmentsResponse
+	26, // 42: chartdb.v1.AssignmentService.Create:output_type -> chartdb.v1.Assignment
+	26, // 43: chartdb.v1.AssignmentService.Update:output_type -> chartdb.v1.Assignment
+	34, // 44: chartdb.v1.AssignmentService.Delete:output_type -> google.protobuf.Empty
+	35, // 45: chartdb.v1.AssignmentService.Start:output_type -> chartdb.v1.DiagramMetadata
+	31, // 46: chartdb.v1.AssignmentService.Submit:output_type -> chartdb.v1.Submission
+	9,  // 47: chartdb.v1.AssignmentService.ListSubmissions:output_type -> chartdb.v1.ListSubmissionsResponse
+	36, // 48: chartdb.v1.AssignmentService.GetSubmission:output_type -> chartdb.v1.SubmissionWithContent
+	9,  // 49: chartdb.v1.AssignmentService.Grade:output_type -> chartdb.v1.ListSubmissionsResponse
+	37, // 50: chartdb.v1.AssignmentService.CheckPlagiarism:output_type -> chartdb.v1.PlagiarismCheck
+	38, // 51: chartdb.v1.AssignmentService.GetPlagiarismReport:output_type -> chartdb.v1.PlagiarismReport
+	31, // 52: chartdb.v1.AssignmentService.SetGrade:output_type -> chartdb.v1.Submission
+	26, // 53: chartdb.v1.AssignmentService.ReleaseGrades:output_type -> chartdb.v1.Assignment
+	39, // 54: chartdb.v1.AssignmentService.ExportGradebook:output_type -> google.api.HttpBody
+	40, // 55: chartdb.v1.AssignmentService.GetCourseAnalytics:output_type -> chartdb.v1.CourseAnalytics
+	40, // 56: chartdb.v1.AssignmentService.GetStudentAnalytics:output_type -> chartdb.v1.CourseAnalytics
+	20, // 57: chartdb.v1.AssignmentService.ListPeerReviews:output_type -> chartdb.v1.ListPeerReviewsResponse
+	41, // 58: chartdb.v1.AssignmentService.GetPeerReview:output_type -> chartdb.v1.PeerReviewWithContent
+	33, // 59: chartdb.v1.AssignmentService.SubmitPeerReview:output_type -> chartdb.v1.PeerReview
+	33, // 60: chartdb.v1.AssignmentService.ModeratePeerReview:output_type -> chartdb.v1.PeerReview
+	42, // 61: chartdb.v1.AssignmentService.GetPeerReviewSummary:output_type -> chartdb.v1.PeerReviewSummary
+	40, // [40:62] is the sub-list for method output_type
+	18, // [18:40] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_assignment_service_proto_init() }
+func file_chartdb_v1_assignment_service_proto_init() {
+	if File_chartdb_v1_assignment_service_proto != nil {
+		return
+	}
+	file_chartdb_v1_analytics_proto_init()
+	file_chartdb_v1_assignment_proto_init()
+	file_chartdb_v1_diagram_proto_init()
+	file_chartdb_v1_peer_review_proto_init()
+	file_chartdb_v1_plagiarism_proto_init()
+	file_chartdb_v1_assignment_service_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_assignment_service_proto_rawDesc), len(file_chartdb_v1_assignment_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_chartdb_v1_assignment_service_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_assignment_service_proto_depIdxs,
+		MessageInfos:      file_chartdb_v1_assignment_service_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_assignment_service_proto = out.File
+	file_chartdb_v1_assignment_service_proto_goTypes = nil
+	file_chartdb_v1_assignment_service_proto_depIdxs = nil
+}
@@ -0,0 +1,951 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: chartdb/v1/assignment_service.proto
+
+package chartdb
+
+import (
+	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AssignmentService_Get_FullMethodName                  = "/chartdb.v1.AssignmentService/Get"
+	AssignmentService_List_FullMethodName                 = "/chartdb.v1.AssignmentService/List"
+	AssignmentService_Create_FullMethodName               = "/chartdb.v1.AssignmentService/Create"
+	AssignmentService_Update_FullMethodName               = "/chartdb.v1.AssignmentService/Update"
+	AssignmentService_Delete_FullMethodName               = "/chartdb.v1.AssignmentService/Delete"
+	AssignmentService_Start_FullMethodName                = "/chartdb.v1.AssignmentService/Start"
+	AssignmentService_Submit_FullMethodName               = "/chartdb.v1.AssignmentService/Submit"
+	AssignmentService_ListSubmissions_FullMethodName      = "/chartdb.v1.AssignmentService/ListSubmissions"
+	AssignmentService_GetSubmission_FullMethodName        = "/chartdb.v1.AssignmentService/GetSubmission"
+	AssignmentService_Grade_FullMethodName                = "/chartdb.v1.AssignmentService/Grade"
+	AssignmentService_CheckPlagiarism_FullMethodName      = "/chartdb.v1.AssignmentService/CheckPlagiarism"
+	AssignmentService_GetPlagiarismReport_FullMethodName  = "/chartdb.v1.AssignmentService/GetPlagiarismReport"
+	AssignmentService_SetGrade_FullMethodName             = "/chartdb.v1.AssignmentService/SetGrade"
+	AssignmentService_ReleaseGrades_FullMethodName        = "/chartdb.v1.AssignmentService/ReleaseGrades"
+	AssignmentService_ExportGradebook_FullMethodName      = "/chartdb.v1.AssignmentService/ExportGradebook"
+	AssignmentService_GetCourseAnalytics_FullMethodName   = "/chartdb.v1.AssignmentService/GetCourseAnalytics"
+	AssignmentService_GetStudentAnalytics_FullMethodName  = "/chartdb.v1.AssignmentService/GetStudentAnalytics"
+	AssignmentService_ListPeerReviews_FullMethodName      = "/chartdb.v1.AssignmentService/ListPeerReviews"
+	AssignmentService_GetPeerReview_FullMethodName        = "/chartdb.v1.AssignmentService/GetPeerReview"
+	AssignmentService_SubmitPeerReview_FullMethodName     = "/chartdb.v1.AssignmentService/SubmitPeerReview"
+	AssignmentService_ModeratePeerReview_FullMethodName   = "/chartdb.v1.AssignmentService/ModeratePeerReview"
+	AssignmentService_GetPeerReviewSummary_FullMethodName = "/chartdb.v1.AssignmentService/GetPeerReviewSummary"
+)
+
+// AssignmentServiceClient is the client API for AssignmentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AssignmentServiceClient interface {
+	Get(ctx context.Context, in *GetAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error)
+	List(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error)
+	Create(ctx context.Context, in *CreateAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error)
+	Update(ctx context.Context, in *UpdateAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error)
+	Delete(ctx context.Context, in *DeleteAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Creates a diagram owned by the student from the starter diagram. For exams it opens the exam session,
+	// only the exam diagram is available to the student until the exam ends and then it is submitted automatically.
+	// Repeated calls during the exam return the same diagram.
+	Start(ctx context.Context, in *StartAssignmentRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
+	// Snapshots current diagram content, re-submission replaces the previous one until the deadline
+	Submit(ctx context.Context, in *SubmitAssignmentRequest, opts ...grpc.CallOption) (*Submission, error)
+	// Returns all submissions to teachers and only own submission to students
+	ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error)
+	GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*SubmissionWithContent, error)
+	// Grades all submissions against the reference diagram and stores the results
+	Grade(ctx context.Context, in *GradeAssignmentRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error)
+	// Schedules plagiarism detection across submissions, checks also run automatically after new submissions
+	CheckPlagiarism(ctx context.Context, in *CheckPlagiarismRequest, opts ...grpc.CallOption) (*PlagiarismCheck, error)
+	GetPlagiarismReport(ctx context.Context, in *GetPlagiarismReportRequest, opts ...grpc.CallOption) (*PlagiarismReport, error)
+	// Sets the grade and feedback of the submission
+	SetGrade(ctx context.Context, in *SetSubmissionGradeRequest, opts ...grpc.CallOption) (*Submission, error)
+	// Makes grades and feedback of the assignment visible to students
+	ReleaseGrades(ctx context.Context, in *ReleaseGradesRequest, opts ...grpc.CallOption) (*Assignment, error)
+	// Exports grades of all course assignments in a format accepted by LMS, a row per student
+	ExportGradebook(ctx context.Context, in *ExportGradebookRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
+	// Aggregates diagram activity and assignment progress of course students, available to teachers
+	GetCourseAnalytics(ctx context.Context, in *GetCourseAnalyticsRequest, opts ...grpc.CallOption) (*CourseAnalytics, error)
+	GetStudentAnalytics(ctx context.Context, in *GetStudentAnalyticsRequest, opts ...grpc.CallOption) (*CourseAnalytics, error)
+	// Lists all reviews of the assignment to teachers and reviews assigned to the caller to students
+	ListPeerReviews(ctx context.Context, in *ListPeerReviewsRequest, opts ...grpc.CallOption) (*ListPeerReviewsResponse, error)
+	// Returns the review with the reviewed snapshot, reviewers have access only while the review period is open
+	GetPeerReview(ctx context.Context, in *GetPeerReviewRequest, opts ...grpc.CallOption) (*PeerReviewWithContent, error)
+	SubmitPeerReview(ctx context.Context, in *SubmitPeerReviewRequest, opts ...grpc.CallOption) (*PeerReview, error)
+	// Hides the review from the author or shows it again, available to teachers
+	ModeratePeerReview(ctx context.Context, in *ModeratePeerReviewRequest, opts ...grpc.CallOption) (*PeerReview, error)
+	// Aggregated reviews of the submission, authors see them after the review period ends
+	GetPeerReviewSummary(ctx context.Context, in *GetPeerReviewSummaryRequest, opts ...grpc.CallOption) (*PeerReviewSummary, error)
+}
+
+type assignmentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAssignmentServiceClient(cc grpc.ClientConnInterface) AssignmentServiceClient {
+	return &assignmentServiceClient{cc}
+}
+
+func (c *assignmentServiceClient) Get(ctx context.Context, in *GetAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, AssignmentService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) List(ctx context.Context, in *ListAssignmentsRequest, opts ...grpc.CallOption) (*ListAssignmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssignmentsResponse)
+	err := c.cc.Invoke(ctx, AssignmentService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Create(ctx context.Context, in *CreateAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, AssignmentService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Update(ctx context.Context, in *UpdateAssignmentRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, AssignmentService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Delete(ctx context.Context, in *DeleteAssignmentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AssignmentService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Start(ctx context.Context, in *StartAssignmentRequest, opts ...grpc.CallOption) (*DiagramMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramMetadata)
+	err := c.cc.Invoke(ctx, AssignmentService_Start_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Submit(ctx context.Context, in *SubmitAssignmentRequest, opts ...grpc.CallOption) (*Submission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Submission)
+	err := c.cc.Invoke(ctx, AssignmentService_Submit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) ListSubmissions(ctx context.Context, in *ListSubmissionsRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubmissionsResponse)
+	err := c.cc.Invoke(ctx, AssignmentService_ListSubmissions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetSubmission(ctx context.Context, in *GetSubmissionRequest, opts ...grpc.CallOption) (*SubmissionWithContent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmissionWithContent)
+	err := c.cc.Invoke(ctx, AssignmentService_GetSubmission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) Grade(ctx context.Context, in *GradeAssignmentRequest, opts ...grpc.CallOption) (*ListSubmissionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubmissionsResponse)
+	err := c.cc.Invoke(ctx, AssignmentService_Grade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) CheckPlagiarism(ctx context.Context, in *CheckPlagiarismRequest, opts ...grpc.CallOption) (*PlagiarismCheck, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlagiarismCheck)
+	err := c.cc.Invoke(ctx, AssignmentService_CheckPlagiarism_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetPlagiarismReport(ctx context.Context, in *GetPlagiarismReportRequest, opts ...grpc.CallOption) (*PlagiarismReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlagiarismReport)
+	err := c.cc.Invoke(ctx, AssignmentService_GetPlagiarismReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) SetGrade(ctx context.Context, in *SetSubmissionGradeRequest, opts ...grpc.CallOption) (*Submission, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Submission)
+	err := c.cc.Invoke(ctx, AssignmentService_SetGrade_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) ReleaseGrades(ctx context.Context, in *ReleaseGradesRequest, opts ...grpc.CallOption) (*Assignment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Assignment)
+	err := c.cc.Invoke(ctx, AssignmentService_ReleaseGrades_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) ExportGradebook(ctx context.Context, in *ExportGradebookRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, AssignmentService_ExportGradebook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetCourseAnalytics(ctx context.Context, in *GetCourseAnalyticsRequest, opts ...grpc.CallOption) (*CourseAnalytics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourseAnalytics)
+	err := c.cc.Invoke(ctx, AssignmentService_GetCourseAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetStudentAnalytics(ctx context.Context, in *GetStudentAnalyticsRequest, opts ...grpc.CallOption) (*CourseAnalytics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourseAnalytics)
+	err := c.cc.Invoke(ctx, AssignmentService_GetStudentAnalytics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) ListPeerReviews(ctx context.Context, in *ListPeerReviewsRequest, opts ...grpc.CallOption) (*ListPeerReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPeerReviewsResponse)
+	err := c.cc.Invoke(ctx, AssignmentService_ListPeerReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetPeerReview(ctx context.Context, in *GetPeerReviewRequest, opts ...grpc.CallOption) (*PeerReviewWithContent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerReviewWithContent)
+	err := c.cc.Invoke(ctx, AssignmentService_GetPeerReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) SubmitPeerReview(ctx context.Context, in *SubmitPeerReviewRequest, opts ...grpc.CallOption) (*PeerReview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerReview)
+	err := c.cc.Invoke(ctx, AssignmentService_SubmitPeerReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) ModeratePeerReview(ctx context.Context, in *ModeratePeerReviewRequest, opts ...grpc.CallOption) (*PeerReview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerReview)
+	err := c.cc.Invoke(ctx, AssignmentService_ModeratePeerReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assignmentServiceClient) GetPeerReviewSummary(ctx context.Context, in *GetPeerReviewSummaryRequest, opts ...grpc.CallOption) (*PeerReviewSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PeerReviewSummary)
+	err := c.cc.Invoke(ctx, AssignmentService_GetPeerReviewSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssignmentServiceServer is the server API for AssignmentService service.
+// All implementations must embed UnimplementedAssignmentServiceServer
+// for forward compatibility.
+type AssignmentServiceServer interface {
+	Get(context.Context, *GetAssignmentRequest) (*Assignment, error)
+	List(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error)
+	Create(context.Context, *CreateAssignmentRequest) (*Assignment, error)
+	Update(context.Context, *UpdateAssignmentRequest) (*Assignment, error)
+	Delete(context.Context, *DeleteAssignmentRequest) (*emptypb.Empty, error)
+	// Creates a diagram owned by the student from the starter diagram. For exams it opens the exam session,
+	// only the exam diagram is available to the student until the exam ends and then it is submitted automatically.
+	// Repeated calls during the exam return the same diagram.
+	Start(context.Context, *StartAssignmentRequest) (*DiagramMetadata, error)
+	// Snapshots current diagram content, re-submission replaces the previous one until the deadline
+	Submit(context.Context, *SubmitAssignmentRequest) (*Submission, error)
+	// Returns all submissions to teachers and only own submission to students
+	ListSubmissions(context.Context, *ListSubmissionsRequest) (*ListSubmissionsResponse, error)
+	GetSubmission(context.Context, *GetSubmissionRequest) (*SubmissionWithContent, error)
+	// Grades all submissions against the reference diagram and stores the results
+	Grade(context.Context, *GradeAssignmentRequest) (*ListSubmissionsResponse, error)
+	// Schedules plagiarism detection across submissions, checks also run automatically after new submissions
+	CheckPlagiarism(context.Context, *CheckPlagiarismRequest) (*PlagiarismCheck, error)
+	GetPlagiarismReport(context.Context, *GetPlagiarismReportRequest) (*PlagiarismReport, error)
+	// Sets the grade and feedback of the submission
+	SetGrade(context.Context, *SetSubmissionGradeRequest) (*Submission, error)
+	// Makes grades and feedback of the assignment visible to students
+	ReleaseGrades(context.Context, *ReleaseGradesRequest) (*Assignment, error)
+	// Exports grades of all course assignments in a format accepted by LMS, a row per student
+	ExportGradebook(context.Context, *ExportGradebookRequest) (*httpbody.HttpBody, error)
+	// Aggregates diagram activity and assignment progress of course students, available to teachers
+	GetCourseAnalytics(context.Context, *GetCourseAnalyticsRequest) (*CourseAnalytics, error)
+	GetStudentAnalytics(context.Context, *GetStudentAnalyticsRequest) (*CourseAnalytics, error)
+	// Lists all reviews of the assignment to teachers and reviews assigned to the caller to students
+	ListPeerReviews(context.Context, *ListPeerReviewsRequest) (*ListPeerReviewsResponse, error)
+	// Returns the review with the reviewed snapshot, reviewers have access only while the review period is open
+	GetPeerReview(context.Context, *GetPeerReviewRequest) (*PeerReviewWithContent, error)
+	SubmitPeerReview(context.Context, *SubmitPeerReviewRequest) (*PeerReview, error)
+	// Hides the review from the author or shows it again, available to teachers
+	ModeratePeerReview(context.Context, *ModeratePeerReviewRequest) (*PeerReview, error)
+	// Aggregated reviews of the submission, authors see them after the review period ends
+	GetPeerReviewSummary(context.Context, *GetPeerReviewSummaryRequest) (*PeerReviewSummary, error)
+	mustEmbedUnimplementedAssignmentServiceServer()
+}
+
+// UnimplementedAssignmentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAssignmentServiceServer struct{}
+
+func (UnimplementedAssignmentServiceServer) Get(context.Context, *GetAssignmentRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedAssignmentServiceServer) List(context.Context, *ListAssignmentsRequest) (*ListAssignmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Create(context.Context, *CreateAssignmentRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Update(context.Context, *UpdateAssignmentRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Delete(context.Context, *DeleteAssignmentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Start(context.Context, *StartAssignmentRequest) (*DiagramMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Submit(context.Context, *SubmitAssignmentRequest) (*Submission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Submit not implemented")
+}
+func (UnimplementedAssignmentServiceServer) ListSubmissions(context.Context, *ListSubmissionsRequest) (*ListSubmissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubmissions not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetSubmission(context.Context, *GetSubmissionRequest) (*SubmissionWithContent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubmission not implemented")
+}
+func (UnimplementedAssignmentServiceServer) Grade(context.Context, *GradeAssignmentRequest) (*ListSubmissionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Grade not implemented")
+}
+func (UnimplementedAssignmentServiceServer) CheckPlagiarism(context.Context, *CheckPlagiarismRequest) (*PlagiarismCheck, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckPlagiarism not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetPlagiarismReport(context.Context, *GetPlagiarismReportRequest) (*PlagiarismReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlagiarismReport not implemented")
+}
+func (UnimplementedAssignmentServiceServer) SetGrade(context.Context, *SetSubmissionGradeRequest) (*Submission, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGrade not implemented")
+}
+func (UnimplementedAssignmentServiceServer) ReleaseGrades(context.Context, *ReleaseGradesRequest) (*Assignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseGrades not implemented")
+}
+func (UnimplementedAssignmentServiceServer) ExportGradebook(context.Context, *ExportGradebookRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGradebook not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetCourseAnalytics(context.Context, *GetCourseAnalyticsRequest) (*CourseAnalytics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourseAnalytics not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetStudentAnalytics(context.Context, *GetStudentAnalyticsRequest) (*CourseAnalytics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStudentAnalytics not implemented")
+}
+func (UnimplementedAssignmentServiceServer) ListPeerReviews(context.Context, *ListPeerReviewsRequest) (*ListPeerReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPeerReviews not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetPeerReview(context.Context, *GetPeerReviewRequest) (*PeerReviewWithContent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerReview not implemented")
+}
+func (UnimplementedAssignmentServiceServer) SubmitPeerReview(context.Context, *SubmitPeerReviewRequest) (*PeerReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitPeerReview not implemented")
+}
+func (UnimplementedAssignmentServiceServer) ModeratePeerReview(context.Context, *ModeratePeerReviewRequest) (*PeerReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModeratePeerReview not implemented")
+}
+func (UnimplementedAssignmentServiceServer) GetPeerReviewSummary(context.Context, *GetPeerReviewSummaryRequest) (*PeerReviewSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerReviewSummary not implemented")
+}
+func (UnimplementedAssignmentServiceServer) mustEmbedUnimplementedAssignmentServiceServer() {}
+func (UnimplementedAssignmentServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeAssignmentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AssignmentServiceServer will
+// result in compilation errors.
+type UnsafeAssignmentServiceServer interface {
+	mustEmbedUnimplementedAssignmentServiceServer()
+}
+
+func RegisterAssignmentServiceServer(s grpc.ServiceRegistrar, srv AssignmentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAssignmentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AssignmentService_ServiceDesc, srv)
+}
+
+func _AssignmentService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Get(ctx, req.(*GetAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssignmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).List(ctx, req.(*ListAssignmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Create(ctx, req.(*CreateAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Update(ctx, req.(*UpdateAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Delete(ctx, req.(*DeleteAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Start(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Start_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Start(ctx, req.(*StartAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Submit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Submit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Submit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Submit(ctx, req.(*SubmitAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_ListSubmissions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubmissionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).ListSubmissions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_ListSubmissions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).ListSubmissions(ctx, req.(*ListSubmissionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetSubmission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSubmissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetSubmission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetSubmission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetSubmission(ctx, req.(*GetSubmissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_Grade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GradeAssignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).Grade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_Grade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).Grade(ctx, req.(*GradeAssignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_CheckPlagiarism_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckPlagiarismRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).CheckPlagiarism(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_CheckPlagiarism_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).CheckPlagiarism(ctx, req.(*CheckPlagiarismRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetPlagiarismReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlagiarismReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetPlagiarismReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetPlagiarismReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetPlagiarismReport(ctx, req.(*GetPlagiarismReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_SetGrade_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSubmissionGradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).SetGrade(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_SetGrade_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).SetGrade(ctx, req.(*SetSubmissionGradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_ReleaseGrades_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseGradesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).ReleaseGrades(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_ReleaseGrades_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).ReleaseGrades(ctx, req.(*ReleaseGradesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_ExportGradebook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGradebookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).ExportGradebook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_ExportGradebook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).ExportGradebook(ctx, req.(*ExportGradebookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetCourseAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetCourseAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetCourseAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetCourseAnalytics(ctx, req.(*GetCourseAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetStudentAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStudentAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetStudentAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetStudentAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetStudentAnalytics(ctx, req.(*GetStudentAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_ListPeerReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPeerReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).ListPeerReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_ListPeerReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).ListPeerReviews(ctx, req.(*ListPeerReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetPeerReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetPeerReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetPeerReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetPeerReview(ctx, req.(*GetPeerReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_SubmitPeerReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitPeerReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).SubmitPeerReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_SubmitPeerReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).SubmitPeerReview(ctx, req.(*SubmitPeerReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_ModeratePeerReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModeratePeerReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).ModeratePeerReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_ModeratePeerReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).ModeratePeerReview(ctx, req.(*ModeratePeerReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssignmentService_GetPeerReviewSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerReviewSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssignmentServiceServer).GetPeerReviewSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssignmentService_GetPeerReviewSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssignmentServiceServer).GetPeerReviewSummary(ctx, req.(*GetPeerReviewSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssignmentService_ServiceDesc is the grpc.ServiceDesc for AssignmentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssignmentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chartdb.v1.AssignmentService",
+	HandlerType: (*AssignmentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _AssignmentService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _AssignmentService_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _AssignmentService_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _AssignmentService_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _AssignmentService_Delete_Handler,
+		},
+		{
+			MethodName: "Start",
+			Handler:    _AssignmentService_Start_Handler,
+		},
+		{
+			MethodName: "Submit",
+			Handler:    _AssignmentService_Submit_Handler,
+		},
+		{
+			MethodName: "ListSubmissions",
+			Handler:    _AssignmentService_ListSubmissions_Handler,
+		},
+		{
+			MethodName: "GetSubmission",
+			Handler:    _AssignmentService_GetSubmission_Handler,
+		},
+		{
+			MethodName: "Grade",
+			Handler:    _AssignmentService_Grade_Handler,
+		},
+		{
+			MethodName: "CheckPlagiarism",
+			Handler:    _AssignmentService_CheckPlagiarism_Handler,
+		},
+		{
+			MethodName: "GetPlagiarismReport",
+			Handler:    _AssignmentService_GetPlagiarismReport_Handler,
+		},
+		{
+			MethodName: "SetGrade",
+			Handler:    _AssignmentService_SetGrade_Handler,
+		},
+		{
+			MethodName: "ReleaseGrades",
+			Handler:    _AssignmentService_ReleaseGrades_Handler,
+		},
+		{
+			MethodName: "ExportGradebook",
+			Handler:    _AssignmentService_ExportGradebook_Handler,
+		},
+		{
+			MethodName: "GetCourseAnalytics",
+			Handler:    _AssignmentService_GetCourseAnalytics_Handler,
+		},
+		{
+			MethodName: "GetStudentAnalytics",
+			Handler:    _AssignmentService_GetStudentAnalytics_Handler,
+		},
+		{
+			MethodName: "ListPeerReviews",
+			Handler:    _AssignmentService_ListPeerReviews_Handler,
+		},
+		{
+			MethodName: "GetPeerReview",
+			Handler:    _AssignmentService_GetPeerReview_Handler,
+		},
+		{
+			MethodName: "SubmitPeerReview",
+			Handler:    _AssignmentService_SubmitPeerReview_Handler,
+		},
+		{
+			MethodName: "ModeratePeerReview",
+			Handler:    _AssignmentService_ModeratePeerReview_Handler,
+		},
+		{
+			MethodName: "GetPeerReviewSummary",
+			Handler:    _AssignmentService_GetPeerReviewSummary_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chartdb/v1/assignment_service.proto",
+}
@@ -0,0 +1,630 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/course.proto
+
+package chartdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RosterImportStatus int32
+
+const (
+	RosterImportStatus_ROSTER_IMPORT_STATUS_UNSPECIFIED RosterImportStatus = 0
+	// New pending account is created and invited by email
+	RosterImportStatus_ROSTER_IMPORT_STATUS_INVITED         RosterImportStatus = 1
+	RosterImportStatus_ROSTER_IMPORT_STATUS_ENROLLED        RosterImportStatus = 2
+	RosterImportStatus_ROSTER_IMPORT_STATUS_INVALID_EMAIL   RosterImportStatus = 3
+	RosterImportStatus_ROSTER_IMPORT_STATUS_GROUP_NOT_FOUND RosterImportStatus = 4
+	RosterImportStatus_ROSTER_IMPORT_STATUS_NOT_STUDENT     RosterImportStatus = 5
+	RosterImportStatus_ROSTER_IMPORT_STATUS_DUPLICATE_EMAIL RosterImportStatus = 6
+)
+
+// Enum value maps for RosterImportStatus.
+var (
+	RosterImportStatus_name = map[int32]string{
+		0: "ROSTER_IMPORT_STATUS_UNSPECIFIED",
+		1: "ROSTER_IMPORT_STATUS_INVITED",
+		2: "ROSTER_IMPORT_STATUS_ENROLLED",
+		3: "ROSTER_IMPORT_STATUS_INVALID_EMAIL",
+		4: "ROSTER_IMPORT_STATUS_GROUP_NOT_FOUND",
+		5: "ROSTER_IMPORT_STATUS_NOT_STUDENT",
+		6: "ROSTER_IMPORT_STATUS_DUPLICATE_EMAIL",
+	}
+	RosterImportStatus_value = map[string]int32{
+		"ROSTER_IMPORT_STATUS_UNSPECIFIED":     0,
+		"ROSTER_IMPORT_STATUS_INVITED":         1,
+		"ROSTER_IMPORT_STATUS_ENROLLED":        2,
+		"ROSTER_IMPORT_STATUS_INVALID_EMAIL":   3,
+		"ROSTER_IMPORT_STATUS_GROUP_NOT_FOUND": 4,
+		"ROSTER_IMPORT_STATUS_NOT_STUDENT":     5,
+		"ROSTER_IMPORT_STATUS_DUPLICATE_EMAIL": 6,
+	}
+)
+
+func (x RosterImportStatus) Enum() *RosterImportStatus {
+	p := new(RosterImportStatus)
+	*p = x
+	return p
+}
+
+func (x RosterImportStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RosterImportStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chartdb_v1_course_proto_enumTypes[0].Descriptor()
+}
+
+func (RosterImportStatus) Type() protoreflect.EnumType {
+	return &file_chartdb_v1_course_proto_enumTypes[0]
+}
+
+func (x RosterImportStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RosterImportStatus.Descriptor instead.
+func (RosterImportStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{0}
+}
+
+type Course struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Visible only to course teachers
+	JoinCode      string                 `protobuf:"bytes,4,opt,name=join_code,json=joinCode,proto3" json:"join_code,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Course) Reset() {
+	*x = Course{}
+	mi := &file_chartdb_v1_course_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Course) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Course) ProtoMessage() {}
+
+func (x *Course) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Course.ProtoReflect.Descriptor instead.
+func (*Course) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Course) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Course) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Course) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Course) GetJoinCode() string {
+	if x != nil {
+		return x.JoinCode
+	}
+	return ""
+}
+
+func (x *Course) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Course) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Course) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CourseGroup struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CourseId string                 `protobuf:"bytes,2,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Academic group code, e.g. IKBO-01-21
+	Code string `protobuf:"bytes,3,opt,name=code,proto3" json:"code,omitempty"`
+	// Visible only to course teachers
+	JoinCode      string                 `protobuf:"bytes,4,opt,name=join_code,json=joinCode,proto3" json:"join_code,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseGroup) Reset() {
+	*x = CourseGroup{}
+	mi := &file_chartdb_v1_course_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseGroup) ProtoMessage() {}
+
+func (x *CourseGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseGroup.ProtoReflect.Descriptor instead.
+func (*CourseGroup) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CourseGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CourseGroup) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CourseGroup) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CourseGroup) GetJoinCode() string {
+	if x != nil {
+		return x.JoinCode
+	}
+	return ""
+}
+
+func (x *CourseGroup) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CourseGroup) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CourseTeacher struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserLogin     string                 `protobuf:"bytes,3,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseTeacher) Reset() {
+	*x = CourseTeacher{}
+	mi := &file_chartdb_v1_course_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseTeacher) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseTeacher) ProtoMessage() {}
+
+func (x *CourseTeacher) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseTeacher.ProtoReflect.Descriptor instead.
+func (*CourseTeacher) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CourseTeacher) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CourseTeacher) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CourseTeacher) GetUserLogin() string {
+	if x != nil {
+		return x.UserLogin
+	}
+	return ""
+}
+
+func (x *CourseTeacher) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CourseEnrollment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserLogin     string                 `protobuf:"bytes,3,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	GroupId       string                 `protobuf:"bytes,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CourseEnrollment) Reset() {
+	*x = CourseEnrollment{}
+	mi := &file_chartdb_v1_course_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CourseEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CourseEnrollment) ProtoMessage() {}
+
+func (x *CourseEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CourseEnrollment.ProtoReflect.Descriptor instead.
+func (*CourseEnrollment) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CourseEnrollment) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CourseEnrollment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CourseEnrollment) GetUserLogin() string {
+	if x != nil {
+		return x.UserLogin
+	}
+	return ""
+}
+
+func (x *CourseEnrollment) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *CourseEnrollment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CourseEnrollment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type RosterImportRow struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Line      int64                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email     string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	GroupCode string                 `protobuf:"bytes,3,opt,name=group_code,json=groupCode,proto3" json:"group_code,omitempty"`
+	Status    RosterImportStatus     `protobuf:"varint,4,opt,name=status,proto3,enum=chartdb.v1.RosterImportStatus" json:"status,omitempty"`
+	// Set for enrolled and invited rows
+	Enrollment *CourseEnrollment `protobuf:"bytes,5,opt,name=enrollment,proto3" json:"enrollment,omitempty"`
+	// Set if the invitation email was not sent, importing the row again resends it
+	EmailError    string `protobuf:"bytes,6,opt,name=email_error,json=emailError,proto3" json:"email_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RosterImportRow) Reset() {
+	*x = RosterImportRow{}
+	mi := &file_chartdb_v1_course_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterImportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterImportRow) ProtoMessage() {}
+
+func (x *RosterImportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterImportRow.ProtoReflect.Descriptor instead.
+func (*RosterImportRow) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RosterImportRow) GetLine() int64 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *RosterImportRow) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RosterImportRow) GetGroupCode() string {
+	if x != nil {
+		return x.GroupCode
+	}
+	return ""
+}
+
+func (x *RosterImportRow) GetStatus() RosterImportStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RosterImportStatus_ROSTER_IMPORT_STATUS_UNSPECIFIED
+}
+
+func (x *RosterImportRow) GetEnrollment() *CourseEnrollment {
+	if x != nil {
+		return x.Enrollment
+	}
+	return nil
+}
+
+func (x *RosterImportRow) GetEmailError() string {
+	if x != nil {
+		return x.EmailError
+	}
+	return ""
+}
+
+var File_chartdb_v1_course_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_course_proto_rawDesc = "" +
+	"\n" +
+	"\x17chartdb/v1/course.proto\x12\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x86\x02\n" +
+	"\x06Course\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1b\n" +
+	"\tjoin_code\x18\x04 \x01(\tR\bjoinCode\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x06\x10d\"\xe7\x01\n" +
+	"\vCourseGroup\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tcourse_id\x18\x02 \x01(\tR\bcourseId\x12\x12\n" +
+	"\x04code\x18\x03 \x01(\tR\x04code\x12\x1b\n" +
+	"\tjoin_code\x18\x04 \x01(\tR\bjoinCode\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x05\x10d\"\x9f\x01\n" +
+	"\rCourseTeacher\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x03 \x01(\tR\tuserLogin\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf8\x01\n" +
+	"\x10CourseEnrollment\x12\x1b\n" +
+	"\tcourse_id\x18\x01 \x01(\tR\bcourseId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x03 \x01(\tR\tuserLogin\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\tR\agroupId\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf1\x01\n" +
+	"\x0fRosterImportRow\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x03R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x1d\n" +
+	"\n" +
+	"group_code\x18\x03 \x01(\tR\tgroupCode\x126\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1e.chartdb.v1.RosterImportStatusR\x06status\x12<\n" +
+	"\n" +
+	"enrollment\x18\x05 \x01(\v2\x1c.chartdb.v1.CourseEnrollmentR\n" +
+	"enrollment\x12\x1f\n" +
+	"\vemail_error\x18\x06 \x01(\tR\n" +
+	"emailError*\xa1\x02\n" +
+	"\x12RosterImportStatus\x12$\n" +
+	" ROSTER_IMPORT_STATUS_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cROSTER_IMPORT_STATUS_INVITED\x10\x01\x12!\n" +
+	"\x1dROSTER_IMPORT_STATUS_ENROLLED\x10\x02\x12&\n" +
+	"\"ROSTER_IMPORT_STATUS_INVALID_EMAIL\x10\x03\x12(\n" +
+	"$ROSTER_IMPORT_STATUS_GROUP_NOT_FOUND\x10\x04\x12$\n" +
+	" ROSTER_IMPORT_STATUS_NOT_STUDENT\x10\x05\x12(\n" +
+	"$ROSTER_IMPORT_STATUS_DUPLICATE_EMAIL\x10\x06B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_course_proto_rawDescOnce sync.Once
+	file_chartdb_v1_course_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_course_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_course_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_course_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_course_proto_rawDesc), len(file_chartdb_v1_course_proto_rawDesc)))
+	})
+	return file_chartdb_v1_course_proto_rawDescData
+}
+
+var file_chartdb_v1_course_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_chartdb_v1_course_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_chartdb_v1_course_proto_goTypes = []any{
+	(RosterImportStatus)(0),       // 0: chartdb.v1.RosterImportStatus
+	(*Course)(nil),                // 1: chartdb.v1.Course
+	(*CourseGroup)(nil),           // 2: chartdb.v1.CourseGroup
+	(*CourseTeacher)(nil),         // 3: chartdb.v1.CourseTeacher
+	(*CourseEnrollment)(nil),      // 4: chartdb.v1.CourseEnrollment
+	(*RosterImportRow)(nil),       // 5: chartdb.v1.RosterImportRow
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_chartdb_v1_course_proto_depIdxs = []int32{
+	6, // 0: chartdb.v1.Course.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: chartdb.v1.Course.updated_at:type_name -> google.protobuf.Timestamp
+	6, // 2: chartdb.v1.CourseGroup.created_at:type_name -> google.protobuf.Timestamp
+	6, // 3: chartdb.v1.CourseGroup.updated_at:type_name -> google.protobuf.Timestamp
+	6, // 4: chartdb.v1.CourseTeacher.created_at:type_name -> google.protobuf.Timestamp
+	6, // 5: chartdb.v1.CourseEnrollment.created_at:type_name -> google.protobuf.Timestamp
+	6, // 6: chartdb.v1.CourseEnrollment.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 7: chartdb.v1.RosterImportRow.status:type_name -> chartdb.v1.RosterImportStatus
+	4, // 8: chartdb.v1.RosterImportRow.enrollment:type_name -> chartdb.v1.CourseEnrollment
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_course_proto_init() }
+func file_chartdb_v1_course_proto_init() {
+	if File_chartdb_v1_course_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_course_proto_rawDesc), len(file_chartdb_v1_course_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chartdb_v1_course_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_course_proto_depIdxs,
+		EnumInfos:         file_chartdb_v1_course_proto_enumTypes,
+		MessageInfos:      file_chartdb_v1_course_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_course_proto = out.File
+	file_chartdb_v1_course_proto_goTypes = nil
+	file_chartdb_v1_course_proto_depIdxs = nil
+}
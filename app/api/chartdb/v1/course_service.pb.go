@@ -0,0 +1,1163 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/course_service.proto
+
+package chartdb
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCourseRequest) Reset() {
+	*x = GetCourseRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCourseRequest) ProtoMessage() {}
+
+func (x *GetCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCourseRequest.ProtoReflect.Descriptor instead.
+func (*GetCourseRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetCourseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListCoursesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoursesRequest) Reset() {
+	*x = ListCoursesRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoursesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoursesRequest) ProtoMessage() {}
+
+func (x *ListCoursesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoursesRequest.ProtoReflect.Descriptor instead.
+func (*ListCoursesRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{1}
+}
+
+type ListCoursesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Courses       []*Course              `protobuf:"bytes,1,rep,name=courses,proto3" json:"courses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoursesResponse) Reset() {
+	*x = ListCoursesResponse{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoursesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoursesResponse) ProtoMessage() {}
+
+func (x *ListCoursesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoursesResponse.ProtoReflect.Descriptor instead.
+func (*ListCoursesResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListCoursesResponse) GetCourses() []*Course {
+	if x != nil {
+		return x.Courses
+	}
+	return nil
+}
+
+type CreateCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseRequest) Reset() {
+	*x = CreateCourseRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseRequest) ProtoMessage() {}
+
+func (x *CreateCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourseRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateCourseRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCourseRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DeleteCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCourseRequest) Reset() {
+	*x = DeleteCourseRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCourseRequest) ProtoMessage() {}
+
+func (x *DeleteCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCourseRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCourseRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteCourseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateCourseGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCourseGroupRequest) Reset() {
+	*x = CreateCourseGroupRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCourseGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCourseGroupRequest) ProtoMessage() {}
+
+func (x *CreateCourseGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCourseGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateCourseGroupRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateCourseGroupRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *CreateCourseGroupRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListCourseGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseGroupsRequest) Reset() {
+	*x = ListCourseGroupsRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseGroupsRequest) ProtoMessage() {}
+
+func (x *ListCourseGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListCourseGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListCourseGroupsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+type ListCourseGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*CourseGroup         `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseGroupsResponse) Reset() {
+	*x = ListCourseGroupsResponse{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseGroupsResponse) ProtoMessage() {}
+
+func (x *ListCourseGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListCourseGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListCourseGroupsResponse) GetGroups() []*CourseGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type DeleteCourseGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	GroupId       string                 `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCourseGroupRequest) Reset() {
+	*x = DeleteCourseGroupRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCourseGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCourseGroupRequest) ProtoMessage() {}
+
+func (x *DeleteCourseGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCourseGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCourseGroupRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteCourseGroupRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *DeleteCourseGroupRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type AddCourseTeacherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	Login         string                 `protobuf:"bytes,2,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCourseTeacherRequest) Reset() {
+	*x = AddCourseTeacherRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCourseTeacherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCourseTeacherRequest) ProtoMessage() {}
+
+func (x *AddCourseTeacherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCourseTeacherRequest.ProtoReflect.Descriptor instead.
+func (*AddCourseTeacherRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AddCourseTeacherRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *AddCourseTeacherRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type RemoveCourseTeacherRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCourseTeacherRequest) Reset() {
+	*x = RemoveCourseTeacherRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCourseTeacherRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCourseTeacherRequest) ProtoMessage() {}
+
+func (x *RemoveCourseTeacherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCourseTeacherRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCourseTeacherRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RemoveCourseTeacherRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *RemoveCourseTeacherRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListCourseTeachersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseTeachersRequest) Reset() {
+	*x = ListCourseTeachersRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseTeachersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseTeachersRequest) ProtoMessage() {}
+
+func (x *ListCourseTeachersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseTeachersRequest.ProtoReflect.Descriptor instead.
+func (*ListCourseTeachersRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListCourseTeachersRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+type ListCourseTeachersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Teachers      []*CourseTeacher       `protobuf:"bytes,1,rep,name=teachers,proto3" json:"teachers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseTeachersResponse) Reset() {
+	*x = ListCourseTeachersResponse{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseTeachersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseTeachersResponse) ProtoMessage() {}
+
+func (x *ListCourseTeachersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseTeachersResponse.ProtoReflect.Descriptor instead.
+func (*ListCourseTeachersResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCourseTeachersResponse) GetTeachers() []*CourseTeacher {
+	if x != nil {
+		return x.Teachers
+	}
+	return nil
+}
+
+type EnrollStudentsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Optional
+	GroupId       string   `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Logins        []string `protobuf:"bytes,3,rep,name=logins,proto3" json:"logins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollStudentsRequest) Reset() {
+	*x = EnrollStudentsRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollStudentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollStudentsRequest) ProtoMessage() {}
+
+func (x *EnrollStudentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollStudentsRequest.ProtoReflect.Descriptor instead.
+func (*EnrollStudentsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *EnrollStudentsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *EnrollStudentsRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *EnrollStudentsRequest) GetLogins() []string {
+	if x != nil {
+		return x.Logins
+	}
+	return nil
+}
+
+type ImportRosterRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// CSV with columns email and group_code, the group code is optional and the header row can be omitted
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRosterRequest) Reset() {
+	*x = ImportRosterRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRosterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRosterRequest) ProtoMessage() {}
+
+func (x *ImportRosterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRosterRequest.ProtoReflect.Descriptor instead.
+func (*ImportRosterRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportRosterRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ImportRosterRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type ImportRosterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          []*RosterImportRow     `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRosterResponse) Reset() {
+	*x = ImportRosterResponse{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRosterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRosterResponse) ProtoMessage() {}
+
+func (x *ImportRosterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRosterResponse.ProtoReflect.Descriptor instead.
+func (*ImportRosterResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportRosterResponse) GetRows() []*RosterImportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type UnenrollStudentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CourseId      string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnenrollStudentRequest) Reset() {
+	*x = UnenrollStudentRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnenrollStudentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnenrollStudentRequest) ProtoMessage() {}
+
+func (x *UnenrollStudentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnenrollStudentRequest.ProtoReflect.Descriptor instead.
+func (*UnenrollStudentRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UnenrollStudentRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *UnenrollStudentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListCourseEnrollmentsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	CourseId string                 `protobuf:"bytes,1,opt,name=course_id,json=courseId,proto3" json:"course_id,omitempty"`
+	// Optional
+	GroupId       string `protobuf:"bytes,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseEnrollmentsRequest) Reset() {
+	*x = ListCourseEnrollmentsRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseEnrollmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseEnrollmentsRequest) ProtoMessage() {}
+
+func (x *ListCourseEnrollmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseEnrollmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCourseEnrollmentsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListCourseEnrollmentsRequest) GetCourseId() string {
+	if x != nil {
+		return x.CourseId
+	}
+	return ""
+}
+
+func (x *ListCourseEnrollmentsRequest) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+type ListCourseEnrollmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enrollments   []*CourseEnrollment    `protobuf:"bytes,1,rep,name=enrollments,proto3" json:"enrollments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCourseEnrollmentsResponse) Reset() {
+	*x = ListCourseEnrollmentsResponse{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCourseEnrollmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCourseEnrollmentsResponse) ProtoMessage() {}
+
+func (x *ListCourseEnrollmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCourseEnrollmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCourseEnrollmentsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListCourseEnrollmentsResponse) GetEnrollments() []*CourseEnrollment {
+	if x != nil {
+		return x.Enrollments
+	}
+	return nil
+}
+
+type JoinCourseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JoinCode      string                 `protobuf:"bytes,1,opt,name=join_code,json=joinCode,proto3" json:"join_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinCourseRequest) Reset() {
+	*x = JoinCourseRequest{}
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinCourseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinCourseRequest) ProtoMessage() {}
+
+func (x *JoinCourseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_course_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinCourseRequest.ProtoReflect.Descriptor instead.
+func (*JoinCourseRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_course_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *JoinCourseRequest) GetJoinCode() string {
+	if x != nil {
+		return x.JoinCode
+	}
+	return ""
+}
+
+var File_chartdb_v1_course_service_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_course_service_proto_rawDesc = "" +
+	"\n" +
+	"\x1fchartdb/v1/course_service.proto\x12\n" +
+	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x17chartdb/v1/course.proto\"*\n" +
+	"\x10GetCourseRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"\x14\n" +
+	"\x12ListCoursesRequest\"C\n" +
+	"\x13ListCoursesResponse\x12,\n" +
+	"\acourses\x18\x01 \x03(\v2\x12.chartdb.v1.CourseR\acourses\"S\n" +
+	"\x13CreateCourseRequest\x12\x1a\n" +
+	"\x04name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"-\n" +
+	"\x13DeleteCourseRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"[\n" +
+	"\x18CreateCourseGroupRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1a\n" +
+	"\x04code\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\">\n" +
+	"\x17ListCourseGroupsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\"K\n" +
+	"\x18ListCourseGroupsResponse\x12/\n" +
+	"\x06groups\x18\x01 \x03(\v2\x17.chartdb.v1.CourseGroupR\x06groups\"b\n" +
+	"\x18DeleteCourseGroupRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12!\n" +
+	"\bgroup_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\agroupId\"\\\n" +
+	"\x17AddCourseTeacherRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1c\n" +
+	"\x05login\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05login\"b\n" +
+	"\x1aRemoveCourseTeacherRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1f\n" +
+	"\auser_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06userId\"@\n" +
+	"\x19ListCourseTeachersRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\"S\n" +
+	"\x1aListCourseTeachersResponse\x125\n" +
+	"\bteachers\x18\x01 \x03(\v2\x19.chartdb.v1.CourseTeacherR\bteachers\"y\n" +
+	"\x15EnrollStudentsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12 \n" +
+	"\x06logins\x18\x03 \x03(\tB\b\xbaH\x05\x92\x01\x02\b\x01R\x06logins\"\\\n" +
+	"\x13ImportRosterRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12 \n" +
+	"\acontent\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\acontent\"G\n" +
+	"\x14ImportRosterResponse\x12/\n" +
+	"\x04rows\x18\x01 \x03(\v2\x1b.chartdb.v1.RosterImportRowR\x04rows\"^\n" +
+	"\x16UnenrollStudentRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x1f\n" +
+	"\auser_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x06userId\"^\n" +
+	"\x1cListCourseEnrollmentsRequest\x12#\n" +
+	"\tcourse_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bcourseId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\tR\agroupId\"_\n" +
+	"\x1dListCourseEnrollmentsResponse\x12>\n" +
+	"\venrollments\x18\x01 \x03(\v2\x1c.chartdb.v1.CourseEnrollmentR\venrollments\"8\n" +
+	"\x11JoinCourseRequest\x12#\n" +
+	"\tjoin_code\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bjoinCode2\x80\x0f\n" +
+	"\rCourseService\x12Y\n" +
+	"\x03Get\x12\x1c.chartdb.v1.GetCourseRequest\x1a\x12.chartdb.v1.Course\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/chartdb/v1/courses/{id}\x12d\n" +
+	"\x04List\x12\x1e.chartdb.v1.ListCoursesRequest\x1a\x1f.chartdb.v1.ListCoursesResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/chartdb/v1/courses\x12]\n" +
+	"\x06Create\x12\x1f.chartdb.v1.CreateCourseRequest\x1a\x12.chartdb.v1.Course\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*\"\x13/chartdb/v1/courses\x12c\n" +
+	"\x06Delete\x12\x1f.chartdb.v1.DeleteCourseRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a*\x18/chartdb/v1/courses/{id}\x12\x7f\n" +
+	"\vCreateGroup\x12$.chartdb.v1.CreateCourseGroupRequest\x1a\x17.chartdb.v1.CourseGroup\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/chartdb/v1/courses/{course_id}/groups\x12\x87\x01\n" +
+	"\n" +
+	"ListGroups\x12#.chartdb.v1.ListCourseGroupsRequest\x1a$.chartdb.v1.ListCourseGroupsResponse\".\x82\xd3\xe4\x93\x02(\x12&/chartdb/v1/courses/{course_id}/groups\x12\x86\x01\n" +
+	"\vDeleteGroup\x12$.chartdb.v1.DeleteCourseGroupRequest\x1a\x16.google.protobuf.Empty\"9\x82\xd3\xe4\x93\x023*1/chartdb/v1/courses/{course_id}/groups/{group_id}\x12\x81\x01\n" +
+	"\n" +
+	"AddTeacher\x12#.chartdb.v1.AddCourseTeacherRequest\x1a\x19.chartdb.v1.CourseTeacher\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/chartdb/v1/courses/{course_id}/teachers\x12\x8b\x01\n" +
+	"\rRemoveTeacher\x12&.chartdb.v1.RemoveCourseTeacherRequest\x1a\x16.google.protobuf.Empty\":\x82\xd3\xe4\x93\x024*2/chartdb/v1/courses/{course_id}/teachers/{user_id}\x12\x8f\x01\n" +
+	"\fListTeachers\x12%.chartdb.v1.ListCourseTeachersRequest\x1a&.chartdb.v1.ListCourseTeachersResponse\"0\x82\xd3\xe4\x93\x02*\x12(/chartdb/v1/courses/{course_id}/teachers\x12\x8e\x01\n" +
+	"\x06Enroll\x12!.chartdb.v1.EnrollStudentsRequest\x1a).chartdb.v1.ListCourseEnrollmentsResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/chartdb/v1/courses/{course_id}/enrollments\x12\x90\x01\n" +
+	"\fImportRoster\x12\x1f.chartdb.v1.ImportRosterRequest\x1a .chartdb.v1.ImportRosterResponse\"=\x82\xd3\xe4\x93\x027:\x01*\"2/chartdb/v1/courses/{course_id}/enrollments:import\x12\x85\x01\n" +
+	"\bUnenroll\x12\".chartdb.v1.UnenrollStudentRequest\x1a\x16.google.protobuf.Empty\"=\x82\xd3\xe4\x93\x027*5/chartdb/v1/courses/{course_id}/enrollments/{user_id}\x12\x9b\x01\n" +
+	"\x0fListEnrollments\x12(.chartdb.v1.ListCourseEnrollmentsRequest\x1a).chartdb.v1.ListCourseEnrollmentsResponse\"3\x82\xd3\xe4\x93\x02-\x12+/chartdb/v1/courses/{course_id}/enrollments\x12h\n" +
+	"\x04Join\x12\x1d.chartdb.v1.JoinCourseRequest\x1a\x1c.chartdb.v1.CourseEnrollment\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/chartdb/v1/courses:joinB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_course_service_proto_rawDescOnce sync.Once
+	file_chartdb_v1_course_service_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_course_service_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_course_service_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_course_service_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_course_service_proto_rawDesc), len(file_chartdb_v1_course_service_proto_rawDesc)))
+	})
+	return file_chartdb_v1_course_service_proto_rawDescData
+}
+
+var file_chartdb_v1_course_service_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_chartdb_v1_course_service_proto_goTypes = []any{
+	(*GetCourseRequest)(nil),              // 0: chartdb.v1.GetCourseRequest
+	(*ListCoursesRequest)(nil),            // 1: chartdb.v1.ListCoursesRequest
+	(*ListCoursesResponse)(nil),           // 2: chartdb.v1.ListCoursesResponse
+	(*CreateCourseRequest)(nil),           // 3: chartdb.v1.CreateCourseRequest
+	(*DeleteCourseRequest)(nil),           // 4: chartdb.v1.DeleteCourseRequest
+	(*CreateCourseGroupRequest)(nil),      // 5: chartdb.v1.CreateCourseGroupRequest
+	(*ListCourseGroupsRequest)(nil),       // 6: chartdb.v1.ListCourseGroupsRequest
+	(*ListCourseGroupsResponse)(nil),      // 7: chartdb.v1.ListCourseGroupsResponse
+	(*DeleteCourseGroupRequest)(nil),      // 8: chartdb.v1.DeleteCourseGroupRequest
+	(*AddCourseTeacherRequest)(nil),       // 9: chartdb.v1.AddCourseTeacherRequest
+	(*RemoveCourseTeacherRequest)(nil),    // 10: chartdb.v1.RemoveCourseTeacherRequest
+	(*ListCourseTeachersRequest)(nil),     // 11: chartdb.v1.ListCourseTeachersRequest
+	(*ListCourseTeachersResponse)(nil),    // 12: chartdb.v1.ListCourseTeachersResponse
+	(*EnrollStudentsRequest)(nil),         // 13: chartdb.v1.EnrollStudentsRequest
+	(*ImportRosterRequest)(nil),           // 14: chartdb.v1.ImportRosterRequest
+	(*ImportRosterResponse)(nil),          // 15: chartdb.v1.ImportRosterResponse
+	(*UnenrollStudentRequest)(nil),        // 16: chartdb.v1.UnenrollStudentRequest
+	(*ListCourseEnrollmentsRequest)(nil),  // 17: chartdb.v1.ListCourseEnrollmentsRequest
+	(*ListCourseEnrollmentsResponse)(nil), // 18: chartdb.v1.ListCourseEnrollmentsResponse
+	(*JoinCourseRequest)(nil),             // 19: chartdb.v1.JoinCourseRequest
+	(*Course)(nil),                        // 20: chartdb.v1.Course
+	(*CourseGroup)(nil),                   // 21: chartdb.v1.CourseGroup
+	(*CourseTeacher)(nil),                 // 22: chartdb.v1.CourseTeacher
+	(*RosterImportRow)(nil),               // 23: chartdb.v1.RosterImportRow
+	(*CourseEnrollment)(nil),              // 24: chartdb.v1.CourseEnrollment
+	(*emptypb.Empty)(nil),                 // 25: google.protobuf.Empty
+}
+var file_chartdb_v1_course_service_proto_depIdxs = []int32{
+	20, // 0: chartdb.v1.ListCoursesResponse.courses:type_name -> chartdb.v1.Course
+	21, // 1: chartdb.v1.ListCourseGroupsResponse.groups:type_name -> chartdb.v1.CourseGroup
+	22, // 2: chartdb.v1.ListCourseTeachersResponse.teachers:type_name -> chartdb.v1.CourseTeacher
+	23, // 3: chartdb.v1.ImportRosterResponse.rows:type_name -> chartdb.v1.RosterImportRow
+	24, // 4: chartdb.v1.ListCourseEnrollmentsResponse.enrollments:type_name -> chartdb.v1.CourseEnrollment
+	0,  // 5: chartdb.v1.CourseService.Get:input_type -> chartdb.v1.GetCourseRequest
+	1,  // 6: chartdb.v1.CourseService.List:input_type -> chartdb.v1.ListCoursesRequest
+	3,  // 7: chartdb.v1.CourseService.Create:input_type -> chartdb.v1.CreateCourseRequest
+	4,  // 8: chartdb.v1.CourseService.Delete:input_type -> chartdb.v1.DeleteCourseRequest
+	5,  // 9: chartdb.v1.CourseService.CreateGroup:input_type -> chartdb.v1.CreateCourseGroupRequest
+	6,  // 10: chartdb.v1.CourseService.ListGroups:input_type -> chartdb.v1.ListCourseGroupsRequest
+	8,  // 11: chartdb.v1.CourseService.DeleteGroup:input_type -> chartdb.v1.DeleteCourseGroupRequest
+	9,  // 12: chartdb.v1.CourseService.AddTeacher:input_type -> chartdb.v1.AddCourseTeacherRequest
+	10, // 13: chartdb.v1.CourseService.RemoveTeacher:input_type -> chartdb.v1.RemoveCourseTeacherRequest
+	11, // 14: chartdb.v1.CourseService.ListTeachers:input_type -> chartdb.v1.ListCourseTeachersRequest
+	13, // 15: chartdb.v1.CourseService.Enroll:input_type -> chartdb.v1.EnrollStudentsRequest
+	14, // 16: chartdb.v1.CourseService.ImportRoster:input_type -> chartdb.v1.ImportRosterRequest
+	16, // 17: chartdb.v1.CourseService.Unenroll:input_type -> chartdb.v1.UnenrollStudentRequest
+	17, // 18: chartdb.v1.CourseService.ListEnrollments:input_type -> chartdb.v1.ListCourseEnrollmentsRequest
+	19, // 19: chartdb.v1.CourseService.Join:input_type -> chartdb.v1.JoinCourseRequest
+	20, // 20: chartdb.v1.CourseService.Get:output_type -> chartdb.v1.Course
+	2,  // 21: chartdb.v1.CourseService.List:output_type -> chartdb.v1.ListCoursesResponse
+	20, // 22: chartdb.v1.CourseService.Create:output_type -> chartdb.v1.Course
+	25, // 23: chartdb.v1.CourseService.Delete:output_type -> google.protobuf.Empty
+	21, // 24: chartdb.v1.CourseService.CreateGroup:output_type -> chartdb.v1.CourseGroup
+	7,  // 25: chartdb.v1.CourseService.ListGroups:output_type -> chartdb.v1.ListCourseGroupsResponse
+	25, // 26: chartdb.v1.CourseService.DeleteGroup:output_type -> google.protobuf.Empty
+	22, // 27: chartdb.v1.CourseService.AddTeacher:output_type -> chartdb.v1.CourseTeacher
+	25, // 28: chartdb.v1.CourseService.RemoveTeacher:output_type -> google.protobuf.Empty
+	12, // 29: chartdb.v1.CourseService.ListTeachers:output_type -> chartdb.v1.ListCourseTeachersResponse
+	18, // 30: chartdb.v1.CourseService.Enroll:output_type -> chartdb.v1.ListCourseEnrollmentsResponse
+	15, // 31: chartdb.v1.CourseService.ImportRoster:output_type -> chartdb.v1.ImportRosterResponse
+	25, // 32: chartdb.v1.CourseService.Unenroll:output_type -> google.protobuf.Empty
+	18, // 33: chartdb.v1.CourseService.ListEnrollments:output_type -> chartdb.v1.ListCourseEnrollmentsResponse
+	24, // 34: chartdb.v1.CourseService.Join:output_type -> chartdb.v1.CourseEnrollment
+	20, // [20:35] is the sub-list for method output_type
+	5,  // [5:20] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_course_service_proto_init() }
+func file_chartdb_v1_course_service_proto_init() {
+	if File_chartdb_v1_course_service_proto != nil {
+		return
+	}
+	file_chartdb_v1_course_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_course_service_proto_rawDesc), len(file_chartdb_v1_course_service_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_chartdb_v1_course_service_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_course_service_proto_depIdxs,
+		MessageInfos:      file_chartdb_v1_course_service_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_course_service_proto = out.File
+	file_chartdb_v1_course_service_proto_goTypes = nil
+	file_chartdb_v1_course_service_proto_depIdxs = nil
+}
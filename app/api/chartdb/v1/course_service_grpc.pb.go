@@ -0,0 +1,664 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: chartdb/v1/course_service.proto
+
+package chartdb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	CourseService_Get_FullMethodName             = "/chartdb.v1.CourseService/Get"
+	CourseService_List_FullMethodName            = "/chartdb.v1.CourseService/List"
+	CourseService_Create_FullMethodName          = "/chartdb.v1.CourseService/Create"
+	CourseService_Delete_FullMethodName          = "/chartdb.v1.CourseService/Delete"
+	CourseService_CreateGroup_FullMethodName     = "/chartdb.v1.CourseService/CreateGroup"
+	CourseService_ListGroups_FullMethodName      = "/chartdb.v1.CourseService/ListGroups"
+	CourseService_DeleteGroup_FullMethodName     = "/chartdb.v1.CourseService/DeleteGroup"
+	CourseService_AddTeacher_FullMethodName      = "/chartdb.v1.CourseService/AddTeacher"
+	CourseService_RemoveTeacher_FullMethodName   = "/chartdb.v1.CourseService/RemoveTeacher"
+	CourseService_ListTeachers_FullMethodName    = "/chartdb.v1.CourseService/ListTeachers"
+	CourseService_Enroll_FullMethodName          = "/chartdb.v1.CourseService/Enroll"
+	CourseService_ImportRoster_FullMethodName    = "/chartdb.v1.CourseService/ImportRoster"
+	CourseService_Unenroll_FullMethodName        = "/chartdb.v1.CourseService/Unenroll"
+	CourseService_ListEnrollments_FullMethodName = "/chartdb.v1.CourseService/ListEnrollments"
+	CourseService_Join_FullMethodName            = "/chartdb.v1.CourseService/Join"
+)
+
+// CourseServiceClient is the client API for CourseService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CourseServiceClient interface {
+	Get(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error)
+	// Returns all courses for admins, taught courses for teachers and attended courses for students
+	List(ctx context.Context, in *ListCoursesRequest, opts ...grpc.CallOption) (*ListCoursesResponse, error)
+	Create(ctx context.Context, in *CreateCourseRequest, opts ...grpc.CallOption) (*Course, error)
+	Delete(ctx context.Context, in *DeleteCourseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateGroup(ctx context.Context, in *CreateCourseGroupRequest, opts ...grpc.CallOption) (*CourseGroup, error)
+	ListGroups(ctx context.Context, in *ListCourseGroupsRequest, opts ...grpc.CallOption) (*ListCourseGroupsResponse, error)
+	// Students of the group stay enrolled in the course without a group
+	DeleteGroup(ctx context.Context, in *DeleteCourseGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddTeacher(ctx context.Context, in *AddCourseTeacherRequest, opts ...grpc.CallOption) (*CourseTeacher, error)
+	RemoveTeacher(ctx context.Context, in *RemoveCourseTeacherRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListTeachers(ctx context.Context, in *ListCourseTeachersRequest, opts ...grpc.CallOption) (*ListCourseTeachersResponse, error)
+	// Enrolls students by their logins, already enrolled students are moved to the given group
+	Enroll(ctx context.Context, in *EnrollStudentsRequest, opts ...grpc.CallOption) (*ListCourseEnrollmentsResponse, error)
+	// Enrolls students from a CSV roster, pending accounts are created for unknown emails and invited by email
+	ImportRoster(ctx context.Context, in *ImportRosterRequest, opts ...grpc.CallOption) (*ImportRosterResponse, error)
+	Unenroll(ctx context.Context, in *UnenrollStudentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListEnrollments(ctx context.Context, in *ListCourseEnrollmentsRequest, opts ...grpc.CallOption) (*ListCourseEnrollmentsResponse, error)
+	// Accepts join code of a course or of one of its groups
+	Join(ctx context.Context, in *JoinCourseRequest, opts ...grpc.CallOption) (*CourseEnrollment, error)
+}
+
+type courseServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCourseServiceClient(cc grpc.ClientConnInterface) CourseServiceClient {
+	return &courseServiceClient{cc}
+}
+
+func (c *courseServiceClient) Get(ctx context.Context, in *GetCourseRequest, opts ...grpc.CallOption) (*Course, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Course)
+	err := c.cc.Invoke(ctx, CourseService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) List(ctx context.Context, in *ListCoursesRequest, opts ...grpc.CallOption) (*ListCoursesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCoursesResponse)
+	err := c.cc.Invoke(ctx, CourseService_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) Create(ctx context.Context, in *CreateCourseRequest, opts ...grpc.CallOption) (*Course, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Course)
+	err := c.cc.Invoke(ctx, CourseService_Create_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) Delete(ctx context.Context, in *DeleteCourseRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CourseService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) CreateGroup(ctx context.Context, in *CreateCourseGroupRequest, opts ...grpc.CallOption) (*CourseGroup, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourseGroup)
+	err := c.cc.Invoke(ctx, CourseService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) ListGroups(ctx context.Context, in *ListCourseGroupsRequest, opts ...grpc.CallOption) (*ListCourseGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCourseGroupsResponse)
+	err := c.cc.Invoke(ctx, CourseService_ListGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) DeleteGroup(ctx context.Context, in *DeleteCourseGroupRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CourseService_DeleteGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) AddTeacher(ctx context.Context, in *AddCourseTeacherRequest, opts ...grpc.CallOption) (*CourseTeacher, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourseTeacher)
+	err := c.cc.Invoke(ctx, CourseService_AddTeacher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) RemoveTeacher(ctx context.Context, in *RemoveCourseTeacherRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CourseService_RemoveTeacher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) ListTeachers(ctx context.Context, in *ListCourseTeachersRequest, opts ...grpc.CallOption) (*ListCourseTeachersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCourseTeachersResponse)
+	err := c.cc.Invoke(ctx, CourseService_ListTeachers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) Enroll(ctx context.Context, in *EnrollStudentsRequest, opts ...grpc.CallOption) (*ListCourseEnrollmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCourseEnrollmentsResponse)
+	err := c.cc.Invoke(ctx, CourseService_Enroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) ImportRoster(ctx context.Context, in *ImportRosterRequest, opts ...grpc.CallOption) (*ImportRosterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportRosterResponse)
+	err := c.cc.Invoke(ctx, CourseService_ImportRoster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) Unenroll(ctx context.Context, in *UnenrollStudentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CourseService_Unenroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) ListEnrollments(ctx context.Context, in *ListCourseEnrollmentsRequest, opts ...grpc.CallOption) (*ListCourseEnrollmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCourseEnrollmentsResponse)
+	err := c.cc.Invoke(ctx, CourseService_ListEnrollments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) Join(ctx context.Context, in *JoinCourseRequest, opts ...grpc.CallOption) (*CourseEnrollment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CourseEnrollment)
+	err := c.cc.Invoke(ctx, CourseService_Join_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CourseServiceServer is the server API for CourseService service.
+// All implementations must embed UnimplementedCourseServiceServer
+// for forward compatibility.
+type CourseServiceServer interface {
+	Get(context.Context, *GetCourseRequest) (*Course, error)
+	// Returns all courses for admins, taught courses for teachers and attended courses for students
+	List(context.Context, *ListCoursesRequest) (*ListCoursesResponse, error)
+	Create(context.Context, *CreateCourseRequest) (*Course, error)
+	Delete(context.Context, *DeleteCourseRequest) (*emptypb.Empty, error)
+	CreateGroup(context.Context, *CreateCourseGroupRequest) (*CourseGroup, error)
+	ListGroups(context.Context, *ListCourseGroupsRequest) (*ListCourseGroupsResponse, error)
+	// Students of the group stay enrolled in the course without a group
+	DeleteGroup(context.Context, *DeleteCourseGroupRequest) (*emptypb.Empty, error)
+	AddTeacher(context.Context, *AddCourseTeacherRequest) (*CourseTeacher, error)
+	RemoveTeacher(context.Context, *RemoveCourseTeacherRequest) (*emptypb.Empty, error)
+	ListTeachers(context.Context, *ListCourseTeachersRequest) (*ListCourseTeachersResponse, error)
+	// Enrolls students by their logins, already enrolled students are moved to the given group
+	Enroll(context.Context, *EnrollStudentsRequest) (*ListCourseEnrollmentsResponse, error)
+	// Enrolls students from a CSV roster, pending accounts are created for unknown emails and invited by email
+	ImportRoster(context.Context, *ImportRosterRequest) (*ImportRosterResponse, error)
+	Unenroll(context.Context, *UnenrollStudentRequest) (*emptypb.Empty, error)
+	ListEnrollments(context.Context, *ListCourseEnrollmentsRequest) (*ListCourseEnrollmentsResponse, error)
+	// Accepts join code of a course or of one of its groups
+	Join(context.Context, *JoinCourseRequest) (*CourseEnrollment, error)
+	mustEmbedUnimplementedCourseServiceServer()
+}
+
+// UnimplementedCourseServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCourseServiceServer struct{}
+
+func (UnimplementedCourseServiceServer) Get(context.Context, *GetCourseRequest) (*Course, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedCourseServiceServer) List(context.Context, *ListCoursesRequest) (*ListCoursesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedCourseServiceServer) Create(context.Context, *CreateCourseRequest) (*Course, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedCourseServiceServer) Delete(context.Context, *DeleteCourseRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCourseServiceServer) CreateGroup(context.Context, *CreateCourseGroupRequest) (*CourseGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedCourseServiceServer) ListGroups(context.Context, *ListCourseGroupsRequest) (*ListCourseGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedCourseServiceServer) DeleteGroup(context.Context, *DeleteCourseGroupRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGroup not implemented")
+}
+func (UnimplementedCourseServiceServer) AddTeacher(context.Context, *AddCourseTeacherRequest) (*CourseTeacher, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTeacher not implemented")
+}
+func (UnimplementedCourseServiceServer) RemoveTeacher(context.Context, *RemoveCourseTeacherRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTeacher not implemented")
+}
+func (UnimplementedCourseServiceServer) ListTeachers(context.Context, *ListCourseTeachersRequest) (*ListCourseTeachersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTeachers not implemented")
+}
+func (UnimplementedCourseServiceServer) Enroll(context.Context, *EnrollStudentsRequest) (*ListCourseEnrollmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enroll not implemented")
+}
+func (UnimplementedCourseServiceServer) ImportRoster(context.Context, *ImportRosterRequest) (*ImportRosterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportRoster not implemented")
+}
+func (UnimplementedCourseServiceServer) Unenroll(context.Context, *UnenrollStudentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unenroll not implemented")
+}
+func (UnimplementedCourseServiceServer) ListEnrollments(context.Context, *ListCourseEnrollmentsRequest) (*ListCourseEnrollmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListEnrollments not implemented")
+}
+func (UnimplementedCourseServiceServer) Join(context.Context, *JoinCourseRequest) (*CourseEnrollment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Join not implemented")
+}
+func (UnimplementedCourseServiceServer) mustEmbedUnimplementedCourseServiceServer() {}
+func (UnimplementedCourseServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeCourseServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CourseServiceServer will
+// result in compilation errors.
+type UnsafeCourseServiceServer interface {
+	mustEmbedUnimplementedCourseServiceServer()
+}
+
+func RegisterCourseServiceServer(s grpc.ServiceRegistrar, srv CourseServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCourseServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CourseService_ServiceDesc, srv)
+}
+
+func _CourseService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Get(ctx, req.(*GetCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCoursesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).List(ctx, req.(*ListCoursesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Create(ctx, req.(*CreateCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Delete(ctx, req.(*DeleteCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCourseGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).CreateGroup(ctx, req.(*CreateCourseGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCourseGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).ListGroups(ctx, req.(*ListCourseGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_DeleteGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCourseGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).DeleteGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_DeleteGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).DeleteGroup(ctx, req.(*DeleteCourseGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_AddTeacher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCourseTeacherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).AddTeacher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_AddTeacher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).AddTeacher(ctx, req.(*AddCourseTeacherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_RemoveTeacher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCourseTeacherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).RemoveTeacher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_RemoveTeacher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).RemoveTeacher(ctx, req.(*RemoveCourseTeacherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_ListTeachers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCourseTeachersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).ListTeachers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_ListTeachers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).ListTeachers(ctx, req.(*ListCourseTeachersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollStudentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Enroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Enroll(ctx, req.(*EnrollStudentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_ImportRoster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRosterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).ImportRoster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_ImportRoster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).ImportRoster(ctx, req.(*ImportRosterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_Unenroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnenrollStudentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Unenroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Unenroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Unenroll(ctx, req.(*UnenrollStudentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_ListEnrollments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCourseEnrollmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).ListEnrollments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_ListEnrollments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).ListEnrollments(ctx, req.(*ListCourseEnrollmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_Join_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinCourseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).Join(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CourseService_Join_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).Join(ctx, req.(*JoinCourseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CourseService_ServiceDesc is the grpc.ServiceDesc for CourseService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CourseService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chartdb.v1.CourseService",
+	HandlerType: (*CourseServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _CourseService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _CourseService_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _CourseService_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CourseService_Delete_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _CourseService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _CourseService_ListGroups_Handler,
+		},
+		{
+			MethodName: "DeleteGroup",
+			Handler:    _CourseService_DeleteGroup_Handler,
+		},
+		{
+			MethodName: "AddTeacher",
+			Handler:    _CourseService_AddTeacher_Handler,
+		},
+		{
+			MethodName: "RemoveTeacher",
+			Handler:    _CourseService_RemoveTeacher_Handler,
+		},
+		{
+			MethodName: "ListTeachers",
+			Handler:    _CourseService_ListTeachers_Handler,
+		},
+		{
+			MethodName: "Enroll",
+			Handler:    _CourseService_Enroll_Handler,
+		},
+		{
+			MethodName: "ImportRoster",
+			Handler:    _CourseService_ImportRoster_Handler,
+		},
+		{
+			MethodName: "Unenroll",
+			Handler:    _CourseService_Unenroll_Handler,
+		},
+		{
+			MethodName: "ListEnrollments",
+			Handler:    _CourseService_ListEnrollments_Handler,
+		},
+		{
+			MethodName: "Join",
+			Handler:    _CourseService_Join_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "chartdb/v1/course_service.proto",
+}
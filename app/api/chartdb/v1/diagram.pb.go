@@ -30,10 +30,14 @@ type DiagramMetadata struct {
 	ClientDiagramId string                 `protobuf:"bytes,4,opt,name=client_diagram_id,json=clientDiagramId,proto3" json:"client_diagram_id,omitempty"`
 	Name            string                 `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
 	TablesCount     int64                  `protobuf:"varint,6,opt,name=tables_count,json=tablesCount,proto3" json:"tables_count,omitempty"`
-	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	Tags            []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Set once the diagram is published for the first time and never changes
+	PublicSlug    string                 `protobuf:"bytes,8,opt,name=public_slug,json=publicSlug,proto3" json:"public_slug,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DiagramMetadata) Reset() {
@@ -108,6 +112,27 @@ func (x *DiagramMetadata) GetTablesCount() int64 {
 	return 0
 }
 
+func (x *DiagramMetadata) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *DiagramMetadata) GetPublicSlug() string {
+	if x != nil {
+		return x.PublicSlug
+	}
+	return ""
+}
+
+func (x *DiagramMetadata) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
 func (x *DiagramMetadata) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
 		return x.CreatedAt
@@ -122,6 +147,84 @@ func (x *DiagramMetadata) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+type DiagramEditLock struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DiagramId string                 `protobuf:"bytes,1,opt,name=diagram_id,json=diagramId,proto3" json:"diagram_id,omitempty"`
+	// Holder of the lock
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Editor of the user holding the lock
+	HolderId      string                 `protobuf:"bytes,3,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiagramEditLock) Reset() {
+	*x = DiagramEditLock{}
+	mi := &file_chartdb_v1_diagram_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiagramEditLock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagramEditLock) ProtoMessage() {}
+
+func (x *DiagramEditLock) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagramEditLock.ProtoReflect.Descriptor instead.
+func (*DiagramEditLock) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DiagramEditLock) GetDiagramId() string {
+	if x != nil {
+		return x.DiagramId
+	}
+	return ""
+}
+
+func (x *DiagramEditLock) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DiagramEditLock) GetHolderId() string {
+	if x != nil {
+		return x.HolderId
+	}
+	return ""
+}
+
+func (x *DiagramEditLock) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *DiagramEditLock) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 type Diagram struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Metadata      *DiagramMetadata       `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
@@ -132,7 +235,7 @@ type Diagram struct {
 
 func (x *Diagram) Reset() {
 	*x = Diagram{}
-	mi := &file_chartdb_v1_diagram_proto_msgTypes[1]
+	mi := &file_chartdb_v1_diagram_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -144,7 +247,7 @@ func (x *Diagram) String() string {
 func (*Diagram) ProtoMessage() {}
 
 func (x *Diagram) ProtoReflect() protoreflect.Message {
-	mi := &file_chartdb_v1_diagram_proto_msgTypes[1]
+	mi := &file_chartdb_v1_diagram_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -157,7 +260,7 @@ func (x *Diagram) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Diagram.ProtoReflect.Descriptor instead.
 func (*Diagram) Descriptor() ([]byte, []int) {
-	return file_chartdb_v1_diagram_proto_rawDescGZIP(), []int{1}
+	return file_chartdb_v1_diagram_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Diagram) GetMetadata() *DiagramMetadata {
@@ -179,18 +282,32 @@ var File_chartdb_v1_diagram_proto protoreflect.FileDescriptor
 const file_chartdb_v1_diagram_proto_rawDesc = "" +
 	"\n" +
 	"\x18chartdb/v1/diagram.proto\x12\n" +
-	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xad\x02\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa1\x03\n" +
 	"\x0fDiagramMetadata\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04code\x18\x03 \x01(\tR\x04code\x12*\n" +
 	"\x11client_diagram_id\x18\x04 \x01(\tR\x0fclientDiagramId\x12\x12\n" +
 	"\x04name\x18\x05 \x01(\tR\x04name\x12!\n" +
-	"\ftables_count\x18\x06 \x01(\x03R\vtablesCount\x129\n" +
+	"\ftables_count\x18\x06 \x01(\x03R\vtablesCount\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12\x1f\n" +
+	"\vpublic_slug\x18\b \x01(\tR\n" +
+	"publicSlug\x12=\n" +
+	"\fpublished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\n" +
+	"\x10d\"\xdc\x01\n" +
+	"\x0fDiagramEditLock\x12\x1d\n" +
+	"\n" +
+	"diagram_id\x18\x01 \x01(\tR\tdiagramId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tholder_id\x18\x03 \x01(\tR\bholderId\x129\n" +
 	"\n" +
 	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\a\x10d\"\\\n" +
+	"expires_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\\\n" +
 	"\aDiagram\x127\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1b.chartdb.v1.DiagramMetadataR\bmetadata\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontentB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
@@ -207,21 +324,25 @@ func file_chartdb_v1_diagram_proto_rawDescGZIP() []byte {
 	return file_chartdb_v1_diagram_proto_rawDescData
 }
 
-var file_chartdb_v1_diagram_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_chartdb_v1_diagram_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_chartdb_v1_diagram_proto_goTypes = []any{
 	(*DiagramMetadata)(nil),       // 0: chartdb.v1.DiagramMetadata
-	(*Diagram)(nil),               // 1: chartdb.v1.Diagram
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*DiagramEditLock)(nil),       // 1: chartdb.v1.DiagramEditLock
+	(*Diagram)(nil),               // 2: chartdb.v1.Diagram
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
 }
 var file_chartdb_v1_diagram_proto_depIdxs = []int32{
-	2, // 0: chartdb.v1.DiagramMetadata.created_at:type_name -> google.protobuf.Timestamp
-	2, // 1: chartdb.v1.DiagramMetadata.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 2: chartdb.v1.Diagram.metadata:type_name -> chartdb.v1.DiagramMetadata
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 0: chartdb.v1.DiagramMetadata.published_at:type_name -> google.protobuf.Timestamp
+	3, // 1: chartdb.v1.DiagramMetadata.created_at:type_name -> google.protobuf.Timestamp
+	3, // 2: chartdb.v1.DiagramMetadata.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 3: chartdb.v1.DiagramEditLock.created_at:type_name -> google.protobuf.Timestamp
+	3, // 4: chartdb.v1.DiagramEditLock.expires_at:type_name -> google.protobuf.Timestamp
+	0, // 5: chartdb.v1.Diagram.metadata:type_name -> chartdb.v1.DiagramMetadata
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_chartdb_v1_diagram_proto_init() }
@@ -235,7 +356,7 @@ func file_chartdb_v1_diagram_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_diagram_proto_rawDesc), len(file_chartdb_v1_diagram_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
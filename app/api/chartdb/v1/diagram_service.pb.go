@@ -9,6 +9,7 @@ package chartdb
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
@@ -71,8 +72,11 @@ func (x *GetDiagramRequest) GetIdentifier() string {
 }
 
 type ListDiagramsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Filter        string                 `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Supported terms: tag, user_id
+	// Only own diagrams are listed unless user_id is set, teachers can list diagrams of their students
+	// Example: tag="needs rework" AND user_id=abcdefghijklmnopqrst
+	Filter        string `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -227,12 +231,14 @@ func (x *CreateDiagramRequest) GetTablesCount() int64 {
 }
 
 type UpdateDiagramRequest struct {
-	state         protoimpl.MessageState             `protogen:"open.v1"`
-	Id            string                             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Fields        *UpdateDiagramRequest_UpdateFields `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask             `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState             `protogen:"open.v1"`
+	Id         string                             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Fields     *UpdateDiagramRequest_UpdateFields `protobuf:"bytes,2,opt,name=fields,proto3" json:"fields,omitempty"`
+	UpdateMask *fieldmaskpb.FieldMask             `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Required if the diagram is locked, holder_id of the edit lock
+	EditLockHolderId string `protobuf:"bytes,4,opt,name=edit_lock_holder_id,json=editLockHolderId,proto3" json:"edit_lock_holder_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateDiagramRequest) Reset() {
@@ -286,6 +292,13 @@ func (x *UpdateDiagramRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
 	return nil
 }
 
+func (x *UpdateDiagramRequest) GetEditLockHolderId() string {
+	if x != nil {
+		return x.EditLockHolderId
+	}
+	return ""
+}
+
 type DeleteDiagramRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -330,6 +343,402 @@ func (x *DeleteDiagramRequest) GetId() string {
 	return ""
 }
 
+type SetDiagramTagsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Replaces all diagram tags, empty list removes them
+	Tags          []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetDiagramTagsRequest) Reset() {
+	*x = SetDiagramTagsRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetDiagramTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetDiagramTagsRequest) ProtoMessage() {}
+
+func (x *SetDiagramTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetDiagramTagsRequest.ProtoReflect.Descriptor instead.
+func (*SetDiagramTagsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SetDiagramTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetDiagramTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveDiagramTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags          []string               `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDiagramTagsRequest) Reset() {
+	*x = RemoveDiagramTagsRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDiagramTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDiagramTagsRequest) ProtoMessage() {}
+
+func (x *RemoveDiagramTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDiagramTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDiagramTagsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveDiagramTagsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveDiagramTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type PublishDiagramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishDiagramRequest) Reset() {
+	*x = PublishDiagramRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishDiagramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishDiagramRequest) ProtoMessage() {}
+
+func (x *PublishDiagramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishDiagramRequest.ProtoReflect.Descriptor instead.
+func (*PublishDiagramRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PublishDiagramRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UnpublishDiagramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpublishDiagramRequest) Reset() {
+	*x = UnpublishDiagramRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpublishDiagramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpublishDiagramRequest) ProtoMessage() {}
+
+func (x *UnpublishDiagramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpublishDiagramRequest.ProtoReflect.Descriptor instead.
+func (*UnpublishDiagramRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UnpublishDiagramRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetPublicDiagramRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPublicDiagramRequest) Reset() {
+	*x = GetPublicDiagramRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPublicDiagramRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPublicDiagramRequest) ProtoMessage() {}
+
+func (x *GetPublicDiagramRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPublicDiagramRequest.ProtoReflect.Descriptor instead.
+func (*GetPublicDiagramRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetPublicDiagramRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+type AcquireEditLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Client generated ID of the editor, e.g. per browser tab
+	HolderId      string `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcquireEditLockRequest) Reset() {
+	*x = AcquireEditLockRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcquireEditLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcquireEditLockRequest) ProtoMessage() {}
+
+func (x *AcquireEditLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcquireEditLockRequest.ProtoReflect.Descriptor instead.
+func (*AcquireEditLockRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AcquireEditLockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AcquireEditLockRequest) GetHolderId() string {
+	if x != nil {
+		return x.HolderId
+	}
+	return ""
+}
+
+type RenewEditLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Client generated ID of the editor, e.g. per browser tab
+	HolderId      string `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenewEditLockRequest) Reset() {
+	*x = RenewEditLockRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenewEditLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewEditLockRequest) ProtoMessage() {}
+
+func (x *RenewEditLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewEditLockRequest.ProtoReflect.Descriptor instead.
+func (*RenewEditLockRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RenewEditLockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RenewEditLockRequest) GetHolderId() string {
+	if x != nil {
+		return x.HolderId
+	}
+	return ""
+}
+
+type ReleaseEditLockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Client generated ID of the editor, e.g. per browser tab
+	HolderId      string `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseEditLockRequest) Reset() {
+	*x = ReleaseEditLockRequest{}
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseEditLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseEditLockRequest) ProtoMessage() {}
+
+func (x *ReleaseEditLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseEditLockRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseEditLockRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_diagram_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ReleaseEditLockRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReleaseEditLockRequest) GetHolderId() string {
+	if x != nil {
+		return x.HolderId
+	}
+	return ""
+}
+
 type UpdateDiagramRequest_UpdateFields struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
@@ -341,7 +750,7 @@ type UpdateDiagramRequest_UpdateFields struct {
 
 func (x *UpdateDiagramRequest_UpdateFields) Reset() {
 	*x = UpdateDiagramRequest_UpdateFields{}
-	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[6]
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -353,7 +762,7 @@ func (x *UpdateDiagramRequest_UpdateFields) String() string {
 func (*UpdateDiagramRequest_UpdateFields) ProtoMessage() {}
 
 func (x *UpdateDiagramRequest_UpdateFields) ProtoReflect() protoreflect.Message {
-	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[6]
+	mi := &file_chartdb_v1_diagram_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -395,7 +804,7 @@ var File_chartdb_v1_diagram_service_proto protoreflect.FileDescriptor
 const file_chartdb_v1_diagram_service_proto_rawDesc = "" +
 	"\n" +
 	" chartdb/v1/diagram_service.proto\x12\n" +
-	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x18chartdb/v1/diagram.proto\";\n" +
+	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x18chartdb/v1/diagram.proto\";\n" +
 	"\x11GetDiagramRequest\x12&\n" +
 	"\n" +
 	"identifier\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
@@ -409,24 +818,56 @@ const file_chartdb_v1_diagram_service_proto_rawDesc = "" +
 	"\xbaH\a\xc8\x01\x01r\x02\x10\x04R\x0fclientDiagramId\x12 \n" +
 	"\acontent\x18\x03 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\acontent\x12\x1a\n" +
 	"\x04name\x18\x04 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12)\n" +
-	"\ftables_count\x18\x05 \x01(\x03B\x06\xbaH\x03\xc8\x01\x01R\vtablesCount\"\x93\x02\n" +
+	"\ftables_count\x18\x05 \x01(\x03B\x06\xbaH\x03\xc8\x01\x01R\vtablesCount\"\xc2\x02\n" +
 	"\x14UpdateDiagramRequest\x12\x16\n" +
 	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12E\n" +
 	"\x06fields\x18\x02 \x01(\v2-.chartdb.v1.UpdateDiagramRequest.UpdateFieldsR\x06fields\x12;\n" +
 	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
-	"updateMask\x1a_\n" +
+	"updateMask\x12-\n" +
+	"\x13edit_lock_holder_id\x18\x04 \x01(\tR\x10editLockHolderId\x1a_\n" +
 	"\fUpdateFields\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
 	"\ftables_count\x18\x03 \x01(\x03R\vtablesCount\".\n" +
 	"\x14DeleteDiagramRequest\x12\x16\n" +
-	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id2\xa4\x04\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"C\n" +
+	"\x15SetDiagramTagsRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\"P\n" +
+	"\x18RemoveDiagramTagsRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x1c\n" +
+	"\x04tags\x18\x02 \x03(\tB\b\xbaH\x05\x92\x01\x02\b\x01R\x04tags\"/\n" +
+	"\x15PublishDiagramRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"1\n" +
+	"\x17UnpublishDiagramRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"5\n" +
+	"\x17GetPublicDiagramRequest\x12\x1a\n" +
+	"\x04slug\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04slug\"U\n" +
+	"\x16AcquireEditLockRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12#\n" +
+	"\tholder_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bholderId\"S\n" +
+	"\x14RenewEditLockRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12#\n" +
+	"\tholder_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bholderId\"U\n" +
+	"\x16ReleaseEditLockRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12#\n" +
+	"\tholder_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bholderId2\x8a\r\n" +
 	"\x0eDiagramService\x12d\n" +
 	"\x03Get\x12\x1d.chartdb.v1.GetDiagramRequest\x1a\x13.chartdb.v1.Diagram\")\x82\xd3\xe4\x93\x02#\x12!/chartdb/v1/diagrams/{identifier}\x12g\n" +
 	"\x04List\x12\x1f.chartdb.v1.ListDiagramsRequest\x1a .chartdb.v1.ListDiagramsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/chartdb/v1/diagrams\x12h\n" +
 	"\x06Create\x12 .chartdb.v1.CreateDiagramRequest\x1a\x1b.chartdb.v1.DiagramMetadata\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/chartdb/v1/diagrams\x12r\n" +
 	"\x06Update\x12 .chartdb.v1.UpdateDiagramRequest\x1a\x1b.chartdb.v1.DiagramMetadata\")\x82\xd3\xe4\x93\x02#:\x06fields2\x19/chartdb/v1/diagrams/{id}\x12e\n" +
-	"\x06Delete\x12 .chartdb.v1.DeleteDiagramRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/chartdb/v1/diagrams/{id}B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+	"\x06Delete\x12 .chartdb.v1.DeleteDiagramRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/chartdb/v1/diagrams/{id}\x12w\n" +
+	"\aSetTags\x12!.chartdb.v1.SetDiagramTagsRequest\x1a\x1b.chartdb.v1.DiagramMetadata\",\x82\xd3\xe4\x93\x02&:\x01*\"!/chartdb/v1/diagrams/{id}:setTags\x12\x80\x01\n" +
+	"\n" +
+	"RemoveTags\x12$.chartdb.v1.RemoveDiagramTagsRequest\x1a\x1b.chartdb.v1.DiagramMetadata\"/\x82\xd3\xe4\x93\x02):\x01*\"$/chartdb/v1/diagrams/{id}:removeTags\x12w\n" +
+	"\aPublish\x12!.chartdb.v1.PublishDiagramRequest\x1a\x1b.chartdb.v1.DiagramMetadata\",\x82\xd3\xe4\x93\x02&:\x01*\"!/chartdb/v1/diagrams/{id}:publish\x12}\n" +
+	"\tUnpublish\x12#.chartdb.v1.UnpublishDiagramRequest\x1a\x1b.chartdb.v1.DiagramMetadata\".\x82\xd3\xe4\x93\x02(:\x01*\"#/chartdb/v1/diagrams/{id}:unpublish\x12\x88\x01\n" +
+	"\x0fAcquireEditLock\x12\".chartdb.v1.AcquireEditLockRequest\x1a\x1b.chartdb.v1.DiagramEditLock\"4\x82\xd3\xe4\x93\x02.:\x01*\")/chartdb/v1/diagrams/{id}:acquireEditLock\x12\x82\x01\n" +
+	"\rRenewEditLock\x12 .chartdb.v1.RenewEditLockRequest\x1a\x1b.chartdb.v1.DiagramEditLock\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/chartdb/v1/diagrams/{id}:renewEditLock\x12\x83\x01\n" +
+	"\x0fReleaseEditLock\x12\".chartdb.v1.ReleaseEditLockRequest\x1a\x16.google.protobuf.Empty\"4\x82\xd3\xe4\x93\x02.:\x01*\")/chartdb/v1/diagrams/{id}:releaseEditLock\x12f\n" +
+	"\tGetPublic\x12#.chartdb.v1.GetPublicDiagramRequest\x1a\x13.chartdb.v1.Diagram\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/public/diagrams/{slug}\x12r\n" +
+	"\x0eGetPublicEmbed\x12#.chartdb.v1.GetPublicDiagramRequest\x1a\x14.google.api.HttpBody\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/public/diagrams/{slug}/embedB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
 
 var (
 	file_chartdb_v1_diagram_service_proto_rawDescOnce sync.Once
@@ -440,7 +881,7 @@ func file_chartdb_v1_diagram_service_proto_rawDescGZIP() []byte {
 	return file_chartdb_v1_diagram_service_proto_rawDescData
 }
 
-var file_chartdb_v1_diagram_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_chartdb_v1_diagram_service_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_chartdb_v1_diagram_service_proto_goTypes = []any{
 	(*GetDiagramRequest)(nil),                 // 0: chartdb.v1.GetDiagramRequest
 	(*ListDiagramsRequest)(nil),               // 1: chartdb.v1.ListDiagramsRequest
@@ -448,28 +889,56 @@ var file_chartdb_v1_diagram_service_proto_goTypes = []any{
 	(*CreateDiagramRequest)(nil),              // 3: chartdb.v1.CreateDiagramRequest
 	(*UpdateDiagramRequest)(nil),              // 4: chartdb.v1.UpdateDiagramRequest
 	(*DeleteDiagramRequest)(nil),              // 5: chartdb.v1.DeleteDiagramRequest
-	(*UpdateDiagramRequest_UpdateFields)(nil), // 6: chartdb.v1.UpdateDiagramRequest.UpdateFields
-	(*DiagramMetadata)(nil),                   // 7: chartdb.v1.DiagramMetadata
-	(*fieldmaskpb.FieldMask)(nil),             // 8: google.protobuf.FieldMask
-	(*Diagram)(nil),                           // 9: chartdb.v1.Diagram
-	(*emptypb.Empty)(nil),                     // 10: google.protobuf.Empty
+	(*SetDiagramTagsRequest)(nil),             // 6: chartdb.v1.SetDiagramTagsRequest
+	(*RemoveDiagramTagsRequest)(nil),          // 7: chartdb.v1.RemoveDiagramTagsRequest
+	(*PublishDiagramRequest)(nil),             // 8: chartdb.v1.PublishDiagramRequest
+	(*UnpublishDiagramRequest)(nil),           // 9: chartdb.v1.UnpublishDiagramRequest
+	(*GetPublicDiagramRequest)(nil),           // 10: chartdb.v1.GetPublicDiagramRequest
+	(*AcquireEditLockRequest)(nil),            // 11: chartdb.v1.AcquireEditLockRequest
+	(*RenewEditLockRequest)(nil),              // 12: chartdb.v1.RenewEditLockRequest
+	(*ReleaseEditLockRequest)(nil),            // 13: chartdb.v1.ReleaseEditLockRequest
+	(*UpdateDiagramRequest_UpdateFields)(nil), // 14: chartdb.v1.UpdateDiagramRequest.UpdateFields
+	(*DiagramMetadata)(nil),                   // 15: chartdb.v1.DiagramMetadata
+	(*fieldmaskpb.FieldMask)(nil),             // 16: google.protobuf.FieldMask
+	(*Diagram)(nil),                           // 17: chartdb.v1.Diagram
+	(*emptypb.Empty)(nil),                     // 18: google.protobuf.Empty
+	(*DiagramEditLock)(nil),                   // 19: chartdb.v1.DiagramEditLock
+	(*httpbody.HttpBody)(nil),                 // 20: google.api.HttpBody
 }
 var file_chartdb_v1_diagram_service_proto_depIdxs = []int32{
-	7,  // 0: chartdb.v1.ListDiagramsResponse.diagrams:type_name -> chartdb.v1.DiagramMetadata
-	6,  // 1: chartdb.v1.UpdateDiagramRequest.fields:type_name -> chartdb.v1.UpdateDiagramRequest.UpdateFields
-	8,  // 2: chartdb.v1.UpdateDiagramRequest.update_mask:type_name -> google.protobuf.FieldMask
+	15, // 0: chartdb.v1.ListDiagramsResponse.diagrams:type_name -> chartdb.v1.DiagramMetadata
+	14, // 1: chartdb.v1.UpdateDiagramRequest.fields:type_name -> chartdb.v1.UpdateDiagramRequest.UpdateFields
+	16, // 2: chartdb.v1.UpdateDiagramRequest.update_mask:type_name -> google.protobuf.FieldMask
 	0,  // 3: chartdb.v1.DiagramService.Get:input_type -> chartdb.v1.GetDiagramRequest
 	1,  // 4: chartdb.v1.DiagramService.List:input_type -> chartdb.v1.ListDiagramsRequest
 	3,  // 5: chartdb.v1.DiagramService.Create:input_type -> chartdb.v1.CreateDiagramRequest
 	4,  // 6: chartdb.v1.DiagramService.Update:input_type -> chartdb.v1.UpdateDiagramRequest
 	5,  // 7: chartdb.v1.DiagramService.Delete:input_type -> chartdb.v1.DeleteDiagramRequest
-	9,  // 8: chartdb.v1.DiagramService.Get:output_type -> chartdb.v1.Diagram
-	2,  // 9: chartdb.v1.DiagramService.List:output_type -> chartdb.v1.ListDiagramsResponse
-	7,  // 10: chartdb.v1.DiagramService.Create:output_type -> chartdb.v1.DiagramMetadata
-	7,  // 11: chartdb.v1.DiagramService.Update:output_type -> chartdb.v1.DiagramMetadata
-	10, // 12: chartdb.v1.DiagramService.Delete:output_type -> google.protobuf.Empty
-	8,  // [8:13] is the sub-list for method output_type
-	3,  // [3:8] is the sub-list for method input_type
+	6,  // 8: chartdb.v1.DiagramService.SetTags:input_type -> chartdb.v1.SetDiagramTagsRequest
+	7,  // 9: chartdb.v1.DiagramService.RemoveTags:input_type -> chartdb.v1.RemoveDiagramTagsRequest
+	8,  // 10: chartdb.v1.DiagramService.Publish:input_type -> chartdb.v1.PublishDiagramRequest
+	9,  // 11: chartdb.v1.DiagramService.Unpublish:input_type -> chartdb.v1.UnpublishDiagramRequest
+	11, // 12: chartdb.v1.DiagramService.AcquireEditLock:input_type -> chartdb.v1.AcquireEditLockRequest
+	12, // 13: chartdb.v1.DiagramService.RenewEditLock:input_type -> chartdb.v1.RenewEditLockRequest
+	13, // 14: chartdb.v1.DiagramService.ReleaseEditLock:input_type -> chartdb.v1.ReleaseEditLockRequest
+	10, // 15: chartdb.v1.DiagramService.GetPublic:input_type -> chartdb.v1.GetPublicDiagramRequest
+	10, // 16: chartdb.v1.DiagramService.GetPublicEmbed:input_type -> chartdb.v1.GetPublicDiagramRequest
+	17, // 17: chartdb.v1.DiagramService.Get:output_type -> chartdb.v1.Diagram
+	2,  // 18: chartdb.v1.DiagramService.List:output_type -> chartdb.v1.ListDiagramsResponse
+	15, // 19: chartdb.v1.DiagramService.Create:output_type -> chartdb.v1.DiagramMetadata
+	15, // 20: chartdb.v1.DiagramService.Update:output_type -> chartdb.v1.DiagramMetadata
+	18, // 21: chartdb.v1.DiagramService.Delete:output_type -> google.protobuf.Empty
+	15, // 22: chartdb.v1.DiagramService.SetTags:output_type -> chartdb.v1.DiagramMetadata
+	15, // 23: chartdb.v1.DiagramService.RemoveTags:output_type -> chartdb.v1.DiagramMetadata
+	15, // 24: chartdb.v1.DiagramService.Publish:output_type -> chartdb.v1.DiagramMetadata
+	15, // 25: chartdb.v1.DiagramService.Unpublish:output_type -> chartdb.v1.DiagramMetadata
+	19, // 26: chartdb.v1.DiagramService.AcquireEditLock:output_type -> chartdb.v1.DiagramEditLock
+	19, // 27: chartdb.v1.DiagramService.RenewEditLock:output_type -> chartdb.v1.DiagramEditLock
+	18, // 28: chartdb.v1.DiagramService.ReleaseEditLock:output_type -> google.protobuf.Empty
+	17, // 29: chartdb.v1.DiagramService.GetPublic:output_type -> chartdb.v1.Diagram
+	20, // 30: chartdb.v1.DiagramService.GetPublicEmbed:output_type -> google.api.HttpBody
+	17, // [17:31] is the sub-list for method output_type
+	3,  // [3:17] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -487,7 +956,7 @@ func file_chartdb_v1_diagram_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_diagram_service_proto_rawDesc), len(file_chartdb_v1_diagram_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -8,6 +8,7 @@ package chartdb
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -20,11 +21,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DiagramService_Get_FullMethodName    = "/chartdb.v1.DiagramService/Get"
-	DiagramService_List_FullMethodName   = "/chartdb.v1.DiagramService/List"
-	DiagramService_Create_FullMethodName = "/chartdb.v1.DiagramService/Create"
-	DiagramService_Update_FullMethodName = "/chartdb.v1.DiagramService/Update"
-	DiagramService_Delete_FullMethodName = "/chartdb.v1.DiagramService/Delete"
+	DiagramService_Get_FullMethodName             = "/chartdb.v1.DiagramService/Get"
+	DiagramService_List_FullMethodName            = "/chartdb.v1.DiagramService/List"
+	DiagramService_Create_FullMethodName          = "/chartdb.v1.DiagramService/Create"
+	DiagramService_Update_FullMethodName          = "/chartdb.v1.DiagramService/Update"
+	DiagramService_Delete_FullMethodName          = "/chartdb.v1.DiagramService/Delete"
+	DiagramService_SetTags_FullMethodName         = "/chartdb.v1.DiagramService/SetTags"
+	DiagramService_RemoveTags_FullMethodName      = "/chartdb.v1.DiagramService/RemoveTags"
+	DiagramService_Publish_FullMethodName         = "/chartdb.v1.DiagramService/Publish"
+	DiagramService_Unpublish_FullMethodName       = "/chartdb.v1.DiagramService/Unpublish"
+	DiagramService_AcquireEditLock_FullMethodName = "/chartdb.v1.DiagramService/AcquireEditLock"
+	DiagramService_RenewEditLock_FullMethodName   = "/chartdb.v1.DiagramService/RenewEditLock"
+	DiagramService_ReleaseEditLock_FullMethodName = "/chartdb.v1.DiagramService/ReleaseEditLock"
+	DiagramService_GetPublic_FullMethodName       = "/chartdb.v1.DiagramService/GetPublic"
+	DiagramService_GetPublicEmbed_FullMethodName  = "/chartdb.v1.DiagramService/GetPublicEmbed"
 )
 
 // DiagramServiceClient is the client API for DiagramService service.
@@ -36,6 +46,17 @@ type DiagramServiceClient interface {
 	Create(ctx context.Context, in *CreateDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
 	Update(ctx context.Context, in *UpdateDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
 	Delete(ctx context.Context, in *DeleteDiagramRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetTags(ctx context.Context, in *SetDiagramTagsRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
+	RemoveTags(ctx context.Context, in *RemoveDiagramTagsRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
+	Publish(ctx context.Context, in *PublishDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
+	Unpublish(ctx context.Context, in *UnpublishDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error)
+	AcquireEditLock(ctx context.Context, in *AcquireEditLockRequest, opts ...grpc.CallOption) (*DiagramEditLock, error)
+	RenewEditLock(ctx context.Context, in *RenewEditLockRequest, opts ...grpc.CallOption) (*DiagramEditLock, error)
+	ReleaseEditLock(ctx context.Context, in *ReleaseEditLockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Doesn't require authentication
+	GetPublic(ctx context.Context, in *GetPublicDiagramRequest, opts ...grpc.CallOption) (*Diagram, error)
+	// Returns self-contained HTML page with rendered diagram, doesn't require authentication
+	GetPublicEmbed(ctx context.Context, in *GetPublicDiagramRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
 }
 
 type diagramServiceClient struct {
@@ -96,6 +117,96 @@ func (c *diagramServiceClient) Delete(ctx context.Context, in *DeleteDiagramRequ
 	return out, nil
 }
 
+func (c *diagramServiceClient) SetTags(ctx context.Context, in *SetDiagramTagsRequest, opts ...grpc.CallOption) (*DiagramMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramMetadata)
+	err := c.cc.Invoke(ctx, DiagramService_SetTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) RemoveTags(ctx context.Context, in *RemoveDiagramTagsRequest, opts ...grpc.CallOption) (*DiagramMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramMetadata)
+	err := c.cc.Invoke(ctx, DiagramService_RemoveTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) Publish(ctx context.Context, in *PublishDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramMetadata)
+	err := c.cc.Invoke(ctx, DiagramService_Publish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) Unpublish(ctx context.Context, in *UnpublishDiagramRequest, opts ...grpc.CallOption) (*DiagramMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramMetadata)
+	err := c.cc.Invoke(ctx, DiagramService_Unpublish_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) AcquireEditLock(ctx context.Context, in *AcquireEditLockRequest, opts ...grpc.CallOption) (*DiagramEditLock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramEditLock)
+	err := c.cc.Invoke(ctx, DiagramService_AcquireEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) RenewEditLock(ctx context.Context, in *RenewEditLockRequest, opts ...grpc.CallOption) (*DiagramEditLock, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiagramEditLock)
+	err := c.cc.Invoke(ctx, DiagramService_RenewEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) ReleaseEditLock(ctx context.Context, in *ReleaseEditLockRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DiagramService_ReleaseEditLock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) GetPublic(ctx context.Context, in *GetPublicDiagramRequest, opts ...grpc.CallOption) (*Diagram, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Diagram)
+	err := c.cc.Invoke(ctx, DiagramService_GetPublic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *diagramServiceClient) GetPublicEmbed(ctx context.Context, in *GetPublicDiagramRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(httpbody.HttpBody)
+	err := c.cc.Invoke(ctx, DiagramService_GetPublicEmbed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DiagramServiceServer is the server API for DiagramService service.
 // All implementations must embed UnimplementedDiagramServiceServer
 // for forward compatibility.
@@ -105,6 +216,17 @@ type DiagramServiceServer interface {
 	Create(context.Context, *CreateDiagramRequest) (*DiagramMetadata, error)
 	Update(context.Context, *UpdateDiagramRequest) (*DiagramMetadata, error)
 	Delete(context.Context, *DeleteDiagramRequest) (*emptypb.Empty, error)
+	SetTags(context.Context, *SetDiagramTagsRequest) (*DiagramMetadata, error)
+	RemoveTags(context.Context, *RemoveDiagramTagsRequest) (*DiagramMetadata, error)
+	Publish(context.Context, *PublishDiagramRequest) (*DiagramMetadata, error)
+	Unpublish(context.Context, *UnpublishDiagramRequest) (*DiagramMetadata, error)
+	AcquireEditLock(context.Context, *AcquireEditLockRequest) (*DiagramEditLock, error)
+	RenewEditLock(context.Context, *RenewEditLockRequest) (*DiagramEditLock, error)
+	ReleaseEditLock(context.Context, *ReleaseEditLockRequest) (*emptypb.Empty, error)
+	// Doesn't require authentication
+	GetPublic(context.Context, *GetPublicDiagramRequest) (*Diagram, error)
+	// Returns self-contained HTML page with rendered diagram, doesn't require authentication
+	GetPublicEmbed(context.Context, *GetPublicDiagramRequest) (*httpbody.HttpBody, error)
 	mustEmbedUnimplementedDiagramServiceServer()
 }
 
@@ -130,6 +252,33 @@ func (UnimplementedDiagramServiceServer) Update(context.Context, *UpdateDiagramR
 func (UnimplementedDiagramServiceServer) Delete(context.Context, *DeleteDiagramRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedDiagramServiceServer) SetTags(context.Context, *SetDiagramTagsRequest) (*DiagramMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTags not implemented")
+}
+func (UnimplementedDiagramServiceServer) RemoveTags(context.Context, *RemoveDiagramTagsRequest) (*DiagramMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTags not implemented")
+}
+func (UnimplementedDiagramServiceServer) Publish(context.Context, *PublishDiagramRequest) (*DiagramMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedDiagramServiceServer) Unpublish(context.Context, *UnpublishDiagramRequest) (*DiagramMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unpublish not implemented")
+}
+func (UnimplementedDiagramServiceServer) AcquireEditLock(context.Context, *AcquireEditLockRequest) (*DiagramEditLock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireEditLock not implemented")
+}
+func (UnimplementedDiagramServiceServer) RenewEditLock(context.Context, *RenewEditLockRequest) (*DiagramEditLock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewEditLock not implemented")
+}
+func (UnimplementedDiagramServiceServer) ReleaseEditLock(context.Context, *ReleaseEditLockRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseEditLock not implemented")
+}
+func (UnimplementedDiagramServiceServer) GetPublic(context.Context, *GetPublicDiagramRequest) (*Diagram, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublic not implemented")
+}
+func (UnimplementedDiagramServiceServer) GetPublicEmbed(context.Context, *GetPublicDiagramRequest) (*httpbody.HttpBody, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicEmbed not implemented")
+}
 func (UnimplementedDiagramServiceServer) mustEmbedUnimplementedDiagramServiceServer() {}
 func (UnimplementedDiagramServiceServer) testEmbeddedByValue()                        {}
 
@@ -241,6 +390,168 @@ func _DiagramService_Delete_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DiagramService_SetTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetDiagramTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).SetTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_SetTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).SetTags(ctx, req.(*SetDiagramTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_RemoveTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDiagramTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).RemoveTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_RemoveTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).RemoveTags(ctx, req.(*RemoveDiagramTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_Publish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishDiagramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).Publish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_Publish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).Publish(ctx, req.(*PublishDiagramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_Unpublish_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpublishDiagramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).Unpublish(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_Unpublish_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).Unpublish(ctx, req.(*UnpublishDiagramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_AcquireEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireEditLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).AcquireEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_AcquireEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).AcquireEditLock(ctx, req.(*AcquireEditLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_RenewEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewEditLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).RenewEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_RenewEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).RenewEditLock(ctx, req.(*RenewEditLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_ReleaseEditLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseEditLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).ReleaseEditLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_ReleaseEditLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).ReleaseEditLock(ctx, req.(*ReleaseEditLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_GetPublic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicDiagramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).GetPublic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_GetPublic_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).GetPublic(ctx, req.(*GetPublicDiagramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DiagramService_GetPublicEmbed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPublicDiagramRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DiagramServiceServer).GetPublicEmbed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DiagramService_GetPublicEmbed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DiagramServiceServer).GetPublicEmbed(ctx, req.(*GetPublicDiagramRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DiagramService_ServiceDesc is the grpc.ServiceDesc for DiagramService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -268,6 +579,42 @@ var DiagramService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _DiagramService_Delete_Handler,
 		},
+		{
+			MethodName: "SetTags",
+			Handler:    _DiagramService_SetTags_Handler,
+		},
+		{
+			MethodName: "RemoveTags",
+			Handler:    _DiagramService_RemoveTags_Handler,
+		},
+		{
+			MethodName: "Publish",
+			Handler:    _DiagramService_Publish_Handler,
+		},
+		{
+			MethodName: "Unpublish",
+			Handler:    _DiagramService_Unpublish_Handler,
+		},
+		{
+			MethodName: "AcquireEditLock",
+			Handler:    _DiagramService_AcquireEditLock_Handler,
+		},
+		{
+			MethodName: "RenewEditLock",
+			Handler:    _DiagramService_RenewEditLock_Handler,
+		},
+		{
+			MethodName: "ReleaseEditLock",
+			Handler:    _DiagramService_ReleaseEditLock_Handler,
+		},
+		{
+			MethodName: "GetPublic",
+			Handler:    _DiagramService_GetPublic_Handler,
+		},
+		{
+			MethodName: "GetPublicEmbed",
+			Handler:    _DiagramService_GetPublicEmbed_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chartdb/v1/diagram_service.proto",
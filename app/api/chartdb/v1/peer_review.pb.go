@@ -0,0 +1,429 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/peer_review.proto
+
+package chartdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PeerReviewSettings struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of reviews each submission gets, from 1 to 5
+	ReviewersCount int64 `protobuf:"varint,1,opt,name=reviewers_count,json=reviewersCount,proto3" json:"reviewers_count,omitempty"`
+	// Reviewers score each criterion from 1 to 5
+	Criteria      []string `protobuf:"bytes,2,rep,name=criteria,proto3" json:"criteria,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerReviewSettings) Reset() {
+	*x = PeerReviewSettings{}
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerReviewSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReviewSettings) ProtoMessage() {}
+
+func (x *PeerReviewSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReviewSettings.ProtoReflect.Descriptor instead.
+func (*PeerReviewSettings) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_peer_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PeerReviewSettings) GetReviewersCount() int64 {
+	if x != nil {
+		return x.ReviewersCount
+	}
+	return 0
+}
+
+func (x *PeerReviewSettings) GetCriteria() []string {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+// Anonymous review of a submission by another student, the author is not disclosed to the reviewer
+type PeerReview struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssignmentId string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	SubmissionId string                 `protobuf:"bytes,3,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	// Visible to the reviewer and teachers only
+	ReviewerId string `protobuf:"bytes,4,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	// Scores follow the order of assignment criteria, empty until the review is submitted
+	Scores      []int64                `protobuf:"varint,5,rep,packed,name=scores,proto3" json:"scores,omitempty"`
+	Comment     string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	SubmittedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	// Hidden reviews are not shown to the author
+	HiddenBy      string                 `protobuf:"bytes,8,opt,name=hidden_by,json=hiddenBy,proto3" json:"hidden_by,omitempty"`
+	HiddenAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=hidden_at,json=hiddenAt,proto3" json:"hidden_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerReview) Reset() {
+	*x = PeerReview{}
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReview) ProtoMessage() {}
+
+func (x *PeerReview) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReview.ProtoReflect.Descriptor instead.
+func (*PeerReview) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_peer_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PeerReview) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeerReview) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *PeerReview) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *PeerReview) GetReviewerId() string {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return ""
+}
+
+func (x *PeerReview) GetScores() []int64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *PeerReview) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *PeerReview) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+func (x *PeerReview) GetHiddenBy() string {
+	if x != nil {
+		return x.HiddenBy
+	}
+	return ""
+}
+
+func (x *PeerReview) GetHiddenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.HiddenAt
+	}
+	return nil
+}
+
+func (x *PeerReview) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PeerReview) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type PeerReviewWithContent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Review *PeerReview            `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	// Snapshot of the reviewed submission
+	Content       string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerReviewWithContent) Reset() {
+	*x = PeerReviewWithContent{}
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerReviewWithContent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReviewWithContent) ProtoMessage() {}
+
+func (x *PeerReviewWithContent) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReviewWithContent.ProtoReflect.Descriptor instead.
+func (*PeerReviewWithContent) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_peer_review_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PeerReviewWithContent) GetReview() *PeerReview {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+func (x *PeerReviewWithContent) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Aggregated reviews of the submission, hidden and not submitted reviews are skipped
+type PeerReviewSummary struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SubmissionId string                 `protobuf:"bytes,1,opt,name=submission_id,json=submissionId,proto3" json:"submission_id,omitempty"`
+	Criteria     []string               `protobuf:"bytes,2,rep,name=criteria,proto3" json:"criteria,omitempty"`
+	ReviewsCount int64                  `protobuf:"varint,3,opt,name=reviews_count,json=reviewsCount,proto3" json:"reviews_count,omitempty"`
+	// Average scores follow the order of criteria, empty if there are no reviews
+	AverageScores []float64 `protobuf:"fixed64,4,rep,packed,name=average_scores,json=averageScores,proto3" json:"average_scores,omitempty"`
+	Comments      []string  `protobuf:"bytes,5,rep,name=comments,proto3" json:"comments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerReviewSummary) Reset() {
+	*x = PeerReviewSummary{}
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerReviewSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReviewSummary) ProtoMessage() {}
+
+func (x *PeerReviewSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_peer_review_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReviewSummary.ProtoReflect.Descriptor instead.
+func (*PeerReviewSummary) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_peer_review_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PeerReviewSummary) GetSubmissionId() string {
+	if x != nil {
+		return x.SubmissionId
+	}
+	return ""
+}
+
+func (x *PeerReviewSummary) GetCriteria() []string {
+	if x != nil {
+		return x.Criteria
+	}
+	return nil
+}
+
+func (x *PeerReviewSummary) GetReviewsCount() int64 {
+	if x != nil {
+		return x.ReviewsCount
+	}
+	return 0
+}
+
+func (x *PeerReviewSummary) GetAverageScores() []float64 {
+	if x != nil {
+		return x.AverageScores
+	}
+	return nil
+}
+
+func (x *PeerReviewSummary) GetComments() []string {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+var File_chartdb_v1_peer_review_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_peer_review_proto_rawDesc = "" +
+	"\n" +
+	"\x1cchartdb/v1/peer_review.proto\x12\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"Y\n" +
+	"\x12PeerReviewSettings\x12'\n" +
+	"\x0freviewers_count\x18\x01 \x01(\x03R\x0ereviewersCount\x12\x1a\n" +
+	"\bcriteria\x18\x02 \x03(\tR\bcriteria\"\xca\x03\n" +
+	"\n" +
+	"PeerReview\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\x12#\n" +
+	"\rsubmission_id\x18\x03 \x01(\tR\fsubmissionId\x12\x1f\n" +
+	"\vreviewer_id\x18\x04 \x01(\tR\n" +
+	"reviewerId\x12\x16\n" +
+	"\x06scores\x18\x05 \x03(\x03R\x06scores\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\x12=\n" +
+	"\fsubmitted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\x12\x1b\n" +
+	"\thidden_by\x18\b \x01(\tR\bhiddenBy\x127\n" +
+	"\thidden_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bhiddenAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\n" +
+	"\x10d\"a\n" +
+	"\x15PeerReviewWithContent\x12.\n" +
+	"\x06review\x18\x01 \x01(\v2\x16.chartdb.v1.PeerReviewR\x06review\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xbc\x01\n" +
+	"\x11PeerReviewSummary\x12#\n" +
+	"\rsubmission_id\x18\x01 \x01(\tR\fsubmissionId\x12\x1a\n" +
+	"\bcriteria\x18\x02 \x03(\tR\bcriteria\x12#\n" +
+	"\rreviews_count\x18\x03 \x01(\x03R\freviewsCount\x12%\n" +
+	"\x0eaverage_scores\x18\x04 \x03(\x01R\raverageScores\x12\x1a\n" +
+	"\bcomments\x18\x05 \x03(\tR\bcommentsB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_peer_review_proto_rawDescOnce sync.Once
+	file_chartdb_v1_peer_review_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_peer_review_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_peer_review_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_peer_review_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_peer_review_proto_rawDesc), len(file_chartdb_v1_peer_review_proto_rawDesc)))
+	})
+	return file_chartdb_v1_peer_review_proto_rawDescData
+}
+
+var file_chartdb_v1_peer_review_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_chartdb_v1_peer_review_proto_goTypes = []any{
+	(*PeerReviewSettings)(nil),    // 0: chartdb.v1.PeerReviewSettings
+	(*PeerReview)(nil),            // 1: chartdb.v1.PeerReview
+	(*PeerReviewWithContent)(nil), // 2: chartdb.v1.PeerReviewWithContent
+	(*PeerReviewSummary)(nil),     // 3: chartdb.v1.PeerReviewSummary
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_chartdb_v1_peer_review_proto_depIdxs = []int32{
+	4, // 0: chartdb.v1.PeerReview.submitted_at:type_name -> google.protobuf.Timestamp
+	4, // 1: chartdb.v1.PeerReview.hidden_at:type_name -> google.protobuf.Timestamp
+	4, // 2: chartdb.v1.PeerReview.created_at:type_name -> google.protobuf.Timestamp
+	4, // 3: chartdb.v1.PeerReview.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 4: chartdb.v1.PeerReviewWithContent.review:type_name -> chartdb.v1.PeerReview
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_peer_review_proto_init() }
+func file_chartdb_v1_peer_review_proto_init() {
+	if File_chartdb_v1_peer_review_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_peer_review_proto_rawDesc), len(file_chartdb_v1_peer_review_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chartdb_v1_peer_review_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_peer_review_proto_depIdxs,
+		MessageInfos:      file_chartdb_v1_peer_review_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_peer_review_proto = out.File
+	file_chartdb_v1_peer_review_proto_goTypes = nil
+	file_chartdb_v1_peer_review_proto_depIdxs = nil
+}
@@ -0,0 +1,471 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: chartdb/v1/plagiarism.proto
+
+package chartdb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlagiarismCheckStatus int32
+
+const (
+	PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_UNSPECIFIED PlagiarismCheckStatus = 0
+	PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_PENDING     PlagiarismCheckStatus = 1
+	PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_DONE        PlagiarismCheckStatus = 2
+	PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_FAILED      PlagiarismCheckStatus = 3
+)
+
+// Enum value maps for PlagiarismCheckStatus.
+var (
+	PlagiarismCheckStatus_name = map[int32]string{
+		0: "PLAGIARISM_CHECK_STATUS_UNSPECIFIED",
+		1: "PLAGIARISM_CHECK_STATUS_PENDING",
+		2: "PLAGIARISM_CHECK_STATUS_DONE",
+		3: "PLAGIARISM_CHECK_STATUS_FAILED",
+	}
+	PlagiarismCheckStatus_value = map[string]int32{
+		"PLAGIARISM_CHECK_STATUS_UNSPECIFIED": 0,
+		"PLAGIARISM_CHECK_STATUS_PENDING":     1,
+		"PLAGIARISM_CHECK_STATUS_DONE":        2,
+		"PLAGIARISM_CHECK_STATUS_FAILED":      3,
+	}
+)
+
+func (x PlagiarismCheckStatus) Enum() *PlagiarismCheckStatus {
+	p := new(PlagiarismCheckStatus)
+	*p = x
+	return p
+}
+
+func (x PlagiarismCheckStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlagiarismCheckStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chartdb_v1_plagiarism_proto_enumTypes[0].Descriptor()
+}
+
+func (PlagiarismCheckStatus) Type() protoreflect.EnumType {
+	return &file_chartdb_v1_plagiarism_proto_enumTypes[0]
+}
+
+func (x PlagiarismCheckStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlagiarismCheckStatus.Descriptor instead.
+func (PlagiarismCheckStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chartdb_v1_plagiarism_proto_rawDescGZIP(), []int{0}
+}
+
+type PlagiarismCheck struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AssignmentId string                 `protobuf:"bytes,1,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	Status       PlagiarismCheckStatus  `protobuf:"varint,2,opt,name=status,proto3,enum=chartdb.v1.PlagiarismCheckStatus" json:"status,omitempty"`
+	// Reason of the last failure
+	Error       string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// Submissions made before this time are covered by the report
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlagiarismCheck) Reset() {
+	*x = PlagiarismCheck{}
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlagiarismCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlagiarismCheck) ProtoMessage() {}
+
+func (x *PlagiarismCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlagiarismCheck.ProtoReflect.Descriptor instead.
+func (*PlagiarismCheck) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_plagiarism_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlagiarismCheck) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *PlagiarismCheck) GetStatus() PlagiarismCheckStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_UNSPECIFIED
+}
+
+func (x *PlagiarismCheck) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PlagiarismCheck) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *PlagiarismCheck) GetCheckedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CheckedAt
+	}
+	return nil
+}
+
+func (x *PlagiarismCheck) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PlagiarismCheck) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Pair of submissions with suspiciously similar schemas, all scores are from 0 to 1
+type PlagiarismPair struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssignmentId       string                 `protobuf:"bytes,2,opt,name=assignment_id,json=assignmentId,proto3" json:"assignment_id,omitempty"`
+	FirstSubmissionId  string                 `protobuf:"bytes,3,opt,name=first_submission_id,json=firstSubmissionId,proto3" json:"first_submission_id,omitempty"`
+	FirstUserId        string                 `protobuf:"bytes,4,opt,name=first_user_id,json=firstUserId,proto3" json:"first_user_id,omitempty"`
+	FirstUserLogin     string                 `protobuf:"bytes,5,opt,name=first_user_login,json=firstUserLogin,proto3" json:"first_user_login,omitempty"`
+	SecondSubmissionId string                 `protobuf:"bytes,6,opt,name=second_submission_id,json=secondSubmissionId,proto3" json:"second_submission_id,omitempty"`
+	SecondUserId       string                 `protobuf:"bytes,7,opt,name=second_user_id,json=secondUserId,proto3" json:"second_user_id,omitempty"`
+	SecondUserLogin    string                 `protobuf:"bytes,8,opt,name=second_user_login,json=secondUserLogin,proto3" json:"second_user_login,omitempty"`
+	Score              float64                `protobuf:"fixed64,9,opt,name=score,proto3" json:"score,omitempty"`
+	// Similarity of table and column names
+	NamesScore float64 `protobuf:"fixed64,10,opt,name=names_score,json=namesScore,proto3" json:"names_score,omitempty"`
+	// Similarity of the relationship graph shape
+	StructureScore float64 `protobuf:"fixed64,11,opt,name=structure_score,json=structureScore,proto3" json:"structure_score,omitempty"`
+	// Share of tables with identical identifiers or positions, high values mean the diagram file was copied
+	LayoutScore   float64                `protobuf:"fixed64,12,opt,name=layout_score,json=layoutScore,proto3" json:"layout_score,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlagiarismPair) Reset() {
+	*x = PlagiarismPair{}
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlagiarismPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlagiarismPair) ProtoMessage() {}
+
+func (x *PlagiarismPair) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlagiarismPair.ProtoReflect.Descriptor instead.
+func (*PlagiarismPair) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_plagiarism_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlagiarismPair) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetAssignmentId() string {
+	if x != nil {
+		return x.AssignmentId
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetFirstSubmissionId() string {
+	if x != nil {
+		return x.FirstSubmissionId
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetFirstUserId() string {
+	if x != nil {
+		return x.FirstUserId
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetFirstUserLogin() string {
+	if x != nil {
+		return x.FirstUserLogin
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetSecondSubmissionId() string {
+	if x != nil {
+		return x.SecondSubmissionId
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetSecondUserId() string {
+	if x != nil {
+		return x.SecondUserId
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetSecondUserLogin() string {
+	if x != nil {
+		return x.SecondUserLogin
+	}
+	return ""
+}
+
+func (x *PlagiarismPair) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PlagiarismPair) GetNamesScore() float64 {
+	if x != nil {
+		return x.NamesScore
+	}
+	return 0
+}
+
+func (x *PlagiarismPair) GetStructureScore() float64 {
+	if x != nil {
+		return x.StructureScore
+	}
+	return 0
+}
+
+func (x *PlagiarismPair) GetLayoutScore() float64 {
+	if x != nil {
+		return x.LayoutScore
+	}
+	return 0
+}
+
+func (x *PlagiarismPair) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PlagiarismReport struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty if the check was never performed
+	Check         *PlagiarismCheck  `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	Pairs         []*PlagiarismPair `protobuf:"bytes,2,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlagiarismReport) Reset() {
+	*x = PlagiarismReport{}
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlagiarismReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlagiarismReport) ProtoMessage() {}
+
+func (x *PlagiarismReport) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_plagiarism_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlagiarismReport.ProtoReflect.Descriptor instead.
+func (*PlagiarismReport) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_plagiarism_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PlagiarismReport) GetCheck() *PlagiarismCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *PlagiarismReport) GetPairs() []*PlagiarismPair {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+var File_chartdb_v1_plagiarism_proto protoreflect.FileDescriptor
+
+const file_chartdb_v1_plagiarism_proto_rawDesc = "" +
+	"\n" +
+	"\x1bchartdb/v1/plagiarism.proto\x12\n" +
+	"chartdb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfd\x02\n" +
+	"\x0fPlagiarismCheck\x12#\n" +
+	"\rassignment_id\x18\x01 \x01(\tR\fassignmentId\x129\n" +
+	"\x06status\x18\x02 \x01(\x0e2!.chartdb.v1.PlagiarismCheckStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12=\n" +
+	"\frequested_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x129\n" +
+	"\n" +
+	"checked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x06\x10d\"\x8b\x04\n" +
+	"\x0ePlagiarismPair\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rassignment_id\x18\x02 \x01(\tR\fassignmentId\x12.\n" +
+	"\x13first_submission_id\x18\x03 \x01(\tR\x11firstSubmissionId\x12\"\n" +
+	"\rfirst_user_id\x18\x04 \x01(\tR\vfirstUserId\x12(\n" +
+	"\x10first_user_login\x18\x05 \x01(\tR\x0efirstUserLogin\x120\n" +
+	"\x14second_submission_id\x18\x06 \x01(\tR\x12secondSubmissionId\x12$\n" +
+	"\x0esecond_user_id\x18\a \x01(\tR\fsecondUserId\x12*\n" +
+	"\x11second_user_login\x18\b \x01(\tR\x0fsecondUserLogin\x12\x14\n" +
+	"\x05score\x18\t \x01(\x01R\x05score\x12\x1f\n" +
+	"\vnames_score\x18\n" +
+	" \x01(\x01R\n" +
+	"namesScore\x12'\n" +
+	"\x0fstructure_score\x18\v \x01(\x01R\x0estructureScore\x12!\n" +
+	"\flayout_score\x18\f \x01(\x01R\vlayoutScore\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtJ\x04\b\r\x10d\"w\n" +
+	"\x10PlagiarismReport\x121\n" +
+	"\x05check\x18\x01 \x01(\v2\x1b.chartdb.v1.PlagiarismCheckR\x05check\x120\n" +
+	"\x05pairs\x18\x02 \x03(\v2\x1a.chartdb.v1.PlagiarismPairR\x05pairs*\xab\x01\n" +
+	"\x15PlagiarismCheckStatus\x12'\n" +
+	"#PLAGIARISM_CHECK_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fPLAGIARISM_CHECK_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cPLAGIARISM_CHECK_STATUS_DONE\x10\x02\x12\"\n" +
+	"\x1ePLAGIARISM_CHECK_STATUS_FAILED\x10\x03B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+
+var (
+	file_chartdb_v1_plagiarism_proto_rawDescOnce sync.Once
+	file_chartdb_v1_plagiarism_proto_rawDescData []byte
+)
+
+func file_chartdb_v1_plagiarism_proto_rawDescGZIP() []byte {
+	file_chartdb_v1_plagiarism_proto_rawDescOnce.Do(func() {
+		file_chartdb_v1_plagiarism_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_chartdb_v1_plagiarism_proto_rawDesc), len(file_chartdb_v1_plagiarism_proto_rawDesc)))
+	})
+	return file_chartdb_v1_plagiarism_proto_rawDescData
+}
+
+var file_chartdb_v1_plagiarism_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_chartdb_v1_plagiarism_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_chartdb_v1_plagiarism_proto_goTypes = []any{
+	(PlagiarismCheckStatus)(0),    // 0: chartdb.v1.PlagiarismCheckStatus
+	(*PlagiarismCheck)(nil),       // 1: chartdb.v1.PlagiarismCheck
+	(*PlagiarismPair)(nil),        // 2: chartdb.v1.PlagiarismPair
+	(*PlagiarismReport)(nil),      // 3: chartdb.v1.PlagiarismReport
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_chartdb_v1_plagiarism_proto_depIdxs = []int32{
+	0, // 0: chartdb.v1.PlagiarismCheck.status:type_name -> chartdb.v1.PlagiarismCheckStatus
+	4, // 1: chartdb.v1.PlagiarismCheck.requested_at:type_name -> google.protobuf.Timestamp
+	4, // 2: chartdb.v1.PlagiarismCheck.checked_at:type_name -> google.protobuf.Timestamp
+	4, // 3: chartdb.v1.PlagiarismCheck.created_at:type_name -> google.protobuf.Timestamp
+	4, // 4: chartdb.v1.PlagiarismCheck.updated_at:type_name -> google.protobuf.Timestamp
+	4, // 5: chartdb.v1.PlagiarismPair.created_at:type_name -> google.protobuf.Timestamp
+	1, // 6: chartdb.v1.PlagiarismReport.check:type_name -> chartdb.v1.PlagiarismCheck
+	2, // 7: chartdb.v1.PlagiarismReport.pairs:type_name -> chartdb.v1.PlagiarismPair
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_chartdb_v1_plagiarism_proto_init() }
+func file_chartdb_v1_plagiarism_proto_init() {
+	if File_chartdb_v1_plagiarism_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_plagiarism_proto_rawDesc), len(file_chartdb_v1_plagiarism_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_chartdb_v1_plagiarism_proto_goTypes,
+		DependencyIndexes: file_chartdb_v1_plagiarism_proto_depIdxs,
+		EnumInfos:         file_chartdb_v1_plagiarism_proto_enumTypes,
+		MessageInfos:      file_chartdb_v1_plagiarism_proto_msgTypes,
+	}.Build()
+	File_chartdb_v1_plagiarism_proto = out.File
+	file_chartdb_v1_plagiarism_proto_goTypes = nil
+	file_chartdb_v1_plagiarism_proto_depIdxs = nil
+}
@@ -77,6 +77,58 @@ func (UserType) EnumDescriptor() ([]byte, []int) {
 	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{0}
 }
 
+type RoleRequestStatus int32
+
+const (
+	RoleRequestStatus_ROLE_REQUEST_STATUS_UNSPECIFIED RoleRequestStatus = 0
+	RoleRequestStatus_ROLE_REQUEST_STATUS_PENDING     RoleRequestStatus = 1
+	RoleRequestStatus_ROLE_REQUEST_STATUS_APPROVED    RoleRequestStatus = 2
+	RoleRequestStatus_ROLE_REQUEST_STATUS_REJECTED    RoleRequestStatus = 3
+)
+
+// Enum value maps for RoleRequestStatus.
+var (
+	RoleRequestStatus_name = map[int32]string{
+		0: "ROLE_REQUEST_STATUS_UNSPECIFIED",
+		1: "ROLE_REQUEST_STATUS_PENDING",
+		2: "ROLE_REQUEST_STATUS_APPROVED",
+		3: "ROLE_REQUEST_STATUS_REJECTED",
+	}
+	RoleRequestStatus_value = map[string]int32{
+		"ROLE_REQUEST_STATUS_UNSPECIFIED": 0,
+		"ROLE_REQUEST_STATUS_PENDING":     1,
+		"ROLE_REQUEST_STATUS_APPROVED":    2,
+		"ROLE_REQUEST_STATUS_REJECTED":    3,
+	}
+)
+
+func (x RoleRequestStatus) Enum() *RoleRequestStatus {
+	p := new(RoleRequestStatus)
+	*p = x
+	return p
+}
+
+func (x RoleRequestStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoleRequestStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_chartdb_v1_user_proto_enumTypes[1].Descriptor()
+}
+
+func (RoleRequestStatus) Type() protoreflect.EnumType {
+	return &file_chartdb_v1_user_proto_enumTypes[1]
+}
+
+func (x RoleRequestStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoleRequestStatus.Descriptor instead.
+func (RoleRequestStatus) EnumDescriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -153,6 +205,427 @@ func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+type RoleRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserLogin string                 `protobuf:"bytes,3,opt,name=user_login,json=userLogin,proto3" json:"user_login,omitempty"`
+	// Requested user type, teacher or admin
+	Type   UserType          `protobuf:"varint,4,opt,name=type,proto3,enum=chartdb.v1.UserType" json:"type,omitempty"`
+	Reason string            `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status RoleRequestStatus `protobuf:"varint,6,opt,name=status,proto3,enum=chartdb.v1.RoleRequestStatus" json:"status,omitempty"`
+	// Set once the request is approved or rejected
+	DecidedBy       string                 `protobuf:"bytes,7,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecisionComment string                 `protobuf:"bytes,8,opt,name=decision_comment,json=decisionComment,proto3" json:"decision_comment,omitempty"`
+	DecidedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,101,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RoleRequest) Reset() {
+	*x = RoleRequest{}
+	mi := &file_chartdb_v1_user_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoleRequest) ProtoMessage() {}
+
+func (x *RoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoleRequest.ProtoReflect.Descriptor instead.
+func (*RoleRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RoleRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetUserLogin() string {
+	if x != nil {
+		return x.UserLogin
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetType() UserType {
+	if x != nil {
+		return x.Type
+	}
+	return UserType_USER_TYPE_UNSPECIFIED
+}
+
+func (x *RoleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetStatus() RoleRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RoleRequestStatus_ROLE_REQUEST_STATUS_UNSPECIFIED
+}
+
+func (x *RoleRequest) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetDecisionComment() string {
+	if x != nil {
+		return x.DecisionComment
+	}
+	return ""
+}
+
+func (x *RoleRequest) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+func (x *RoleRequest) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RoleRequest) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Session struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	IpAddress string                 `protobuf:"bytes,3,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	// Session of the current access token
+	Current bool `protobuf:"varint,4,opt,name=current,proto3" json:"current,omitempty"`
+	// Last time the access token was refreshed
+	RefreshedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=refreshed_at,json=refreshedAt,proto3" json:"refreshed_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_chartdb_v1_user_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *Session) GetRefreshedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefreshedAt
+	}
+	return nil
+}
+
+func (x *Session) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type PersonalAccessToken struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// diagrams:read or diagrams:write
+	Scopes    []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Empty if the token has never been used
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,100,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PersonalAccessToken) Reset() {
+	*x = PersonalAccessToken{}
+	mi := &file_chartdb_v1_user_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PersonalAccessToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PersonalAccessToken) ProtoMessage() {}
+
+func (x *PersonalAccessToken) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PersonalAccessToken.ProtoReflect.Descriptor instead.
+func (*PersonalAccessToken) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PersonalAccessToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PersonalAccessToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PersonalAccessToken) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *PersonalAccessToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PersonalAccessToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *PersonalAccessToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type TotpEnrollment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Base32 secret for manual entry
+	Secret string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// otpauth URI to render as a QR code for authenticator apps
+	ProvisioningUri string `protobuf:"bytes,2,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TotpEnrollment) Reset() {
+	*x = TotpEnrollment{}
+	mi := &file_chartdb_v1_user_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TotpEnrollment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TotpEnrollment) ProtoMessage() {}
+
+func (x *TotpEnrollment) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TotpEnrollment.ProtoReflect.Descriptor instead.
+func (*TotpEnrollment) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TotpEnrollment) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TotpEnrollment) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type LoginChallenge struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Set if the user has to add the authenticator app before entering the code
+	Enrollment    *TotpEnrollment `protobuf:"bytes,3,opt,name=enrollment,proto3" json:"enrollment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginChallenge) Reset() {
+	*x = LoginChallenge{}
+	mi := &file_chartdb_v1_user_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginChallenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginChallenge) ProtoMessage() {}
+
+func (x *LoginChallenge) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginChallenge.ProtoReflect.Descriptor instead.
+func (*LoginChallenge) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LoginChallenge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LoginChallenge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LoginChallenge) GetEnrollment() *TotpEnrollment {
+	if x != nil {
+		return x.Enrollment
+	}
+	return nil
+}
+
 var File_chartdb_v1_user_proto protoreflect.FileDescriptor
 
 const file_chartdb_v1_user_proto_rawDesc = "" +
@@ -166,13 +639,68 @@ const file_chartdb_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x04\x10d*}\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\x04\x10d\"\xcf\x03\n" +
+	"\vRoleRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"user_login\x18\x03 \x01(\tR\tuserLogin\x12(\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x14.chartdb.v1.UserTypeR\x04type\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x125\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1d.chartdb.v1.RoleRequestStatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\a \x01(\tR\tdecidedBy\x12)\n" +
+	"\x10decision_comment\x18\b \x01(\tR\x0fdecisionComment\x129\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtJ\x04\b\n" +
+	"\x10d\"\xac\x02\n" +
+	"\aSession\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x03 \x01(\tR\tipAddress\x12\x18\n" +
+	"\acurrent\x18\x04 \x01(\bR\acurrent\x12=\n" +
+	"\frefreshed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vrefreshedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtJ\x04\b\a\x10d\"\x8b\x02\n" +
+	"\x13PersonalAccessToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12<\n" +
+	"\flast_used_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"created_at\x18d \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtJ\x04\b\x06\x10d\"S\n" +
+	"\x0eTotpEnrollment\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x02 \x01(\tR\x0fprovisioningUri\"\x97\x01\n" +
+	"\x0eLoginChallenge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12:\n" +
+	"\n" +
+	"enrollment\x18\x03 \x01(\v2\x1a.chartdb.v1.TotpEnrollmentR\n" +
+	"enrollment*}\n" +
 	"\bUserType\x12\x19\n" +
 	"\x15USER_TYPE_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fUSER_TYPE_GUEST\x10\x01\x12\x15\n" +
 	"\x11USER_TYPE_STUDENT\x10\x02\x12\x15\n" +
 	"\x11USER_TYPE_TEACHER\x10\x03\x12\x13\n" +
-	"\x0fUSER_TYPE_ADMIN\x10\x04B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+	"\x0fUSER_TYPE_ADMIN\x10\x04*\x9d\x01\n" +
+	"\x11RoleRequestStatus\x12#\n" +
+	"\x1fROLE_REQUEST_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bROLE_REQUEST_STATUS_PENDING\x10\x01\x12 \n" +
+	"\x1cROLE_REQUEST_STATUS_APPROVED\x10\x02\x12 \n" +
+	"\x1cROLE_REQUEST_STATUS_REJECTED\x10\x03B\x14Z\x12chartdb/v1;chartdbb\x06proto3"
 
 var (
 	file_chartdb_v1_user_proto_rawDescOnce sync.Once
@@ -186,22 +714,41 @@ func file_chartdb_v1_user_proto_rawDescGZIP() []byte {
 	return file_chartdb_v1_user_proto_rawDescData
 }
 
-var file_chartdb_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_chartdb_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_chartdb_v1_user_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_chartdb_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_chartdb_v1_user_proto_goTypes = []any{
 	(UserType)(0),                 // 0: chartdb.v1.UserType
-	(*User)(nil),                  // 1: chartdb.v1.User
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(RoleRequestStatus)(0),        // 1: chartdb.v1.RoleRequestStatus
+	(*User)(nil),                  // 2: chartdb.v1.User
+	(*RoleRequest)(nil),           // 3: chartdb.v1.RoleRequest
+	(*Session)(nil),               // 4: chartdb.v1.Session
+	(*PersonalAccessToken)(nil),   // 5: chartdb.v1.PersonalAccessToken
+	(*TotpEnrollment)(nil),        // 6: chartdb.v1.TotpEnrollment
+	(*LoginChallenge)(nil),        // 7: chartdb.v1.LoginChallenge
+	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
 }
 var file_chartdb_v1_user_proto_depIdxs = []int32{
-	0, // 0: chartdb.v1.User.type:type_name -> chartdb.v1.UserType
-	2, // 1: chartdb.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	2, // 2: chartdb.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: chartdb.v1.User.type:type_name -> chartdb.v1.UserType
+	8,  // 1: chartdb.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: chartdb.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 3: chartdb.v1.RoleRequest.type:type_name -> chartdb.v1.UserType
+	1,  // 4: chartdb.v1.RoleRequest.status:type_name -> chartdb.v1.RoleRequestStatus
+	8,  // 5: chartdb.v1.RoleRequest.decided_at:type_name -> google.protobuf.Timestamp
+	8,  // 6: chartdb.v1.RoleRequest.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 7: chartdb.v1.RoleRequest.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: chartdb.v1.Session.refreshed_at:type_name -> google.protobuf.Timestamp
+	8,  // 9: chartdb.v1.Session.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 10: chartdb.v1.Session.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 11: chartdb.v1.PersonalAccessToken.expires_at:type_name -> google.protobuf.Timestamp
+	8,  // 12: chartdb.v1.PersonalAccessToken.last_used_at:type_name -> google.protobuf.Timestamp
+	8,  // 13: chartdb.v1.PersonalAccessToken.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 14: chartdb.v1.LoginChallenge.expires_at:type_name -> google.protobuf.Timestamp
+	6,  // 15: chartdb.v1.LoginChallenge.enrollment:type_name -> chartdb.v1.TotpEnrollment
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_chartdb_v1_user_proto_init() }
@@ -214,8 +761,8 @@ func file_chartdb_v1_user_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_user_proto_rawDesc), len(file_chartdb_v1_user_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   1,
+			NumEnums:      2,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
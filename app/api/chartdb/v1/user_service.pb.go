@@ -11,6 +11,8 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -172,28 +174,1031 @@ func (x *LoginUserRequest) GetPassword() string {
 }
 
 type LoginUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Short-lived access token
+	Token     string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Single-use token to get the next access token
+	RefreshToken string `protobuf:"bytes,4,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	SessionId    string `protobuf:"bytes,5,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Set instead of the tokens if the login requires the second factor, it is completed by VerifyLoginChallenge
+	Challenge *LoginChallenge `protobuf:"bytes,6,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	// Set once when the login completes the TOTP enrollment
+	RecoveryCodes []string `protobuf:"bytes,7,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginUserResponse) Reset() {
+	*x = LoginUserResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginUserResponse) ProtoMessage() {}
+
+func (x *LoginUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginUserResponse.ProtoReflect.Descriptor instead.
+func (*LoginUserResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LoginUserResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *LoginUserResponse) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *LoginUserResponse) GetChallenge() *LoginChallenge {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *LoginUserResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type RefreshTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenRequest) Reset() {
+	*x = RefreshTokenRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenRequest) ProtoMessage() {}
+
+func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
+func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefreshTokenRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{5}
+}
+
+type ListSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{6}
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionRequest) Reset() {
+	*x = RevokeSessionRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionRequest) ProtoMessage() {}
+
+func (x *RevokeSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionRequest.ProtoReflect.Descriptor instead.
+func (*RevokeSessionRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RevokeSessionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RequestPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestPasswordResetRequest) Reset() {
+	*x = RequestPasswordResetRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestPasswordResetRequest) ProtoMessage() {}
+
+func (x *RequestPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*RequestPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RequestPasswordResetRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type ResetPasswordRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Reset code from the email
+	Rid           string `protobuf:"bytes,1,opt,name=rid,proto3" json:"rid,omitempty"`
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ResetPasswordRequest) GetRid() string {
+	if x != nil {
+		return x.Rid
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type ChangePasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CurrentPassword string                 `protobuf:"bytes,1,opt,name=current_password,json=currentPassword,proto3" json:"current_password,omitempty"`
+	NewPassword     string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ChangePasswordRequest) Reset() {
+	*x = ChangePasswordRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangePasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePasswordRequest) ProtoMessage() {}
+
+func (x *ChangePasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePasswordRequest.ProtoReflect.Descriptor instead.
+func (*ChangePasswordRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChangePasswordRequest) GetCurrentPassword() string {
+	if x != nil {
+		return x.CurrentPassword
+	}
+	return ""
+}
+
+func (x *ChangePasswordRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+type ChangeEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEmailRequest) Reset() {
+	*x = ChangeEmailRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEmailRequest) ProtoMessage() {}
+
+func (x *ChangeEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEmailRequest.ProtoReflect.Descriptor instead.
+func (*ChangeEmailRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChangeEmailRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+type StartOidcLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartOidcLoginRequest) Reset() {
+	*x = StartOidcLoginRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartOidcLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartOidcLoginRequest) ProtoMessage() {}
+
+func (x *StartOidcLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartOidcLoginRequest.ProtoReflect.Descriptor instead.
+func (*StartOidcLoginRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{13}
+}
+
+type StartOidcLoginResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AuthorizationUrl string                 `protobuf:"bytes,1,opt,name=authorization_url,json=authorizationUrl,proto3" json:"authorization_url,omitempty"`
+	State            string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StartOidcLoginResponse) Reset() {
+	*x = StartOidcLoginResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartOidcLoginResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartOidcLoginResponse) ProtoMessage() {}
+
+func (x *StartOidcLoginResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartOidcLoginResponse.ProtoReflect.Descriptor instead.
+func (*StartOidcLoginResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StartOidcLoginResponse) GetAuthorizationUrl() string {
+	if x != nil {
+		return x.AuthorizationUrl
+	}
+	return ""
+}
+
+func (x *StartOidcLoginResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type FinishOidcLoginRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishOidcLoginRequest) Reset() {
+	*x = FinishOidcLoginRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishOidcLoginRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishOidcLoginRequest) ProtoMessage() {}
+
+func (x *FinishOidcLoginRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishOidcLoginRequest.ProtoReflect.Descriptor instead.
+func (*FinishOidcLoginRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FinishOidcLoginRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *FinishOidcLoginRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes []string               `protobuf:"bytes,2,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	// At most a year from now
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePersonalAccessTokenRequest) Reset() {
+	*x = CreatePersonalAccessTokenRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePersonalAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePersonalAccessTokenRequest) ProtoMessage() {}
+
+func (x *CreatePersonalAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePersonalAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreatePersonalAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreatePersonalAccessTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreatePersonalAccessTokenRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreatePersonalAccessTokenRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	PersonalAccessToken *PersonalAccessToken   `protobuf:"bytes,1,opt,name=personal_access_token,json=personalAccessToken,proto3" json:"personal_access_token,omitempty"`
+	Token               string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *CreatePersonalAccessTokenResponse) Reset() {
+	*x = CreatePersonalAccessTokenResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePersonalAccessTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePersonalAccessTokenResponse) ProtoMessage() {}
+
+func (x *CreatePersonalAccessTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePersonalAccessTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreatePersonalAccessTokenResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreatePersonalAccessTokenResponse) GetPersonalAccessToken() *PersonalAccessToken {
+	if x != nil {
+		return x.PersonalAccessToken
+	}
+	return nil
+}
+
+func (x *CreatePersonalAccessTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ListPersonalAccessTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPersonalAccessTokensRequest) Reset() {
+	*x = ListPersonalAccessTokensRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalAccessTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalAccessTokensRequest) ProtoMessage() {}
+
+func (x *ListPersonalAccessTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalAccessTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListPersonalAccessTokensRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{18}
+}
+
+type ListPersonalAccessTokensResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	PersonalAccessTokens []*PersonalAccessToken `protobuf:"bytes,1,rep,name=personal_access_tokens,json=personalAccessTokens,proto3" json:"personal_access_tokens,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ListPersonalAccessTokensResponse) Reset() {
+	*x = ListPersonalAccessTokensResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPersonalAccessTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPersonalAccessTokensResponse) ProtoMessage() {}
+
+func (x *ListPersonalAccessTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPersonalAccessTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListPersonalAccessTokensResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPersonalAccessTokensResponse) GetPersonalAccessTokens() []*PersonalAccessToken {
+	if x != nil {
+		return x.PersonalAccessTokens
+	}
+	return nil
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokePersonalAccessTokenRequest) Reset() {
+	*x = RevokePersonalAccessTokenRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokePersonalAccessTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokePersonalAccessTokenRequest) ProtoMessage() {}
+
+func (x *RevokePersonalAccessTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokePersonalAccessTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokePersonalAccessTokenRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RevokePersonalAccessTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type VerifyLoginChallengeRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	// TOTP code, or a recovery code once two-factor authentication is enabled
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyLoginChallengeRequest) Reset() {
+	*x = VerifyLoginChallengeRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyLoginChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyLoginChallengeRequest) ProtoMessage() {}
+
+func (x *VerifyLoginChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyLoginChallengeRequest.ProtoReflect.Descriptor instead.
+func (*VerifyLoginChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *VerifyLoginChallengeRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *VerifyLoginChallengeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type EnrollTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTotpRequest) Reset() {
+	*x = EnrollTotpRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTotpRequest) ProtoMessage() {}
+
+func (x *EnrollTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTotpRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTotpRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{22}
+}
+
+type ConfirmTotpRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTotpRequest) Reset() {
+	*x = ConfirmTotpRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTotpRequest) ProtoMessage() {}
+
+func (x *ConfirmTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTotpRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTotpRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ConfirmTotpRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type DisableTotpRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// TOTP or recovery code
+	Code          string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoginUserResponse) Reset() {
-	*x = LoginUserResponse{}
-	mi := &file_chartdb_v1_user_service_proto_msgTypes[3]
+func (x *DisableTotpRequest) Reset() {
+	*x = DisableTotpRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoginUserResponse) String() string {
+func (x *DisableTotpRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginUserResponse) ProtoMessage() {}
+func (*DisableTotpRequest) ProtoMessage() {}
 
-func (x *LoginUserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_chartdb_v1_user_service_proto_msgTypes[3]
+func (x *DisableTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,35 +1209,118 @@ func (x *LoginUserResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginUserResponse.ProtoReflect.Descriptor instead.
-func (*LoginUserResponse) Descriptor() ([]byte, []int) {
-	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use DisableTotpRequest.ProtoReflect.Descriptor instead.
+func (*DisableTotpRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *LoginUserResponse) GetToken() string {
+func (x *DisableTotpRequest) GetCode() string {
 	if x != nil {
-		return x.Token
+		return x.Code
 	}
 	return ""
 }
 
-func (x *LoginUserResponse) GetUserId() string {
+type RegenerateRecoveryCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesRequest) Reset() {
+	*x = RegenerateRecoveryCodesRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[25]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RegenerateRecoveryCodesRequest) GetCode() string {
+	if x != nil {
+		return x.Code
 	}
 	return ""
 }
 
-type ConfirmUserRequest struct {
+type RecoveryCodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Cid           string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodesResponse) Reset() {
+	*x = RecoveryCodesResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type ConfirmUserRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Cid   string                 `protobuf:"bytes,1,opt,name=cid,proto3" json:"cid,omitempty"`
+	// Required for users invited to a course, sets their password
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ConfirmUserRequest) Reset() {
 	*x = ConfirmUserRequest{}
-	mi := &file_chartdb_v1_user_service_proto_msgTypes[4]
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -244,7 +1332,7 @@ func (x *ConfirmUserRequest) String() string {
 func (*ConfirmUserRequest) ProtoMessage() {}
 
 func (x *ConfirmUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chartdb_v1_user_service_proto_msgTypes[4]
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,7 +1345,7 @@ func (x *ConfirmUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConfirmUserRequest.ProtoReflect.Descriptor instead.
 func (*ConfirmUserRequest) Descriptor() ([]byte, []int) {
-	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{4}
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ConfirmUserRequest) GetCid() string {
@@ -267,12 +1355,272 @@ func (x *ConfirmUserRequest) GetCid() string {
 	return ""
 }
 
+func (x *ConfirmUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type UpgradeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Login         string                 `protobuf:"bytes,1,opt,name=login,proto3" json:"login,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpgradeUserRequest) Reset() {
+	*x = UpgradeUserRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpgradeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeUserRequest) ProtoMessage() {}
+
+func (x *UpgradeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeUserRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeUserRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *UpgradeUserRequest) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *UpgradeUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RequestRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          UserType               `protobuf:"varint,1,opt,name=type,proto3,enum=chartdb.v1.UserType" json:"type,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestRoleRequest) Reset() {
+	*x = RequestRoleRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestRoleRequest) ProtoMessage() {}
+
+func (x *RequestRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestRoleRequest.ProtoReflect.Descriptor instead.
+func (*RequestRoleRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RequestRoleRequest) GetType() UserType {
+	if x != nil {
+		return x.Type
+	}
+	return UserType_USER_TYPE_UNSPECIFIED
+}
+
+func (x *RequestRoleRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListRoleRequestsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional, requests of all statuses are returned if unspecified
+	Status        RoleRequestStatus `protobuf:"varint,1,opt,name=status,proto3,enum=chartdb.v1.RoleRequestStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleRequestsRequest) Reset() {
+	*x = ListRoleRequestsRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleRequestsRequest) ProtoMessage() {}
+
+func (x *ListRoleRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoleRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListRoleRequestsRequest) GetStatus() RoleRequestStatus {
+	if x != nil {
+		return x.Status
+	}
+	return RoleRequestStatus_ROLE_REQUEST_STATUS_UNSPECIFIED
+}
+
+type ListRoleRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoleRequests  []*RoleRequest         `protobuf:"bytes,1,rep,name=role_requests,json=roleRequests,proto3" json:"role_requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoleRequestsResponse) Reset() {
+	*x = ListRoleRequestsResponse{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoleRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoleRequestsResponse) ProtoMessage() {}
+
+func (x *ListRoleRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoleRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoleRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListRoleRequestsResponse) GetRoleRequests() []*RoleRequest {
+	if x != nil {
+		return x.RoleRequests
+	}
+	return nil
+}
+
+type DecideRoleRequestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approved      bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideRoleRequestRequest) Reset() {
+	*x = DecideRoleRequestRequest{}
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideRoleRequestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideRoleRequestRequest) ProtoMessage() {}
+
+func (x *DecideRoleRequestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chartdb_v1_user_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideRoleRequestRequest.ProtoReflect.Descriptor instead.
+func (*DecideRoleRequestRequest) Descriptor() ([]byte, []int) {
+	return file_chartdb_v1_user_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DecideRoleRequestRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DecideRoleRequestRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *DecideRoleRequestRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
 var File_chartdb_v1_user_service_proto protoreflect.FileDescriptor
 
 const file_chartdb_v1_user_service_proto_rawDesc = "" +
 	"\n" +
 	"\x1dchartdb/v1/user_service.proto\x12\n" +
-	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x15chartdb/v1/user.proto\" \n" +
+	"chartdb.v1\x1a\x1bbuf/validate/validate.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x15chartdb/v1/user.proto\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"M\n" +
 	"\x11CreateUserRequest\x12\x1c\n" +
@@ -280,17 +1628,112 @@ const file_chartdb_v1_user_service_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"T\n" +
 	"\x10LoginUserRequest\x12\x1c\n" +
 	"\x05login\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05login\x12\"\n" +
-	"\bpassword\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bpassword\"B\n" +
+	"\bpassword\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bpassword\"\xa2\x02\n" +
 	"\x11LoginUserResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\".\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12#\n" +
+	"\rrefresh_token\x18\x04 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x05 \x01(\tR\tsessionId\x128\n" +
+	"\tchallenge\x18\x06 \x01(\v2\x1a.chartdb.v1.LoginChallengeR\tchallenge\x12%\n" +
+	"\x0erecovery_codes\x18\a \x03(\tR\rrecoveryCodes\"B\n" +
+	"\x13RefreshTokenRequest\x12+\n" +
+	"\rrefresh_token\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\frefreshToken\"\x0f\n" +
+	"\rLogoutRequest\"\x15\n" +
+	"\x13ListSessionsRequest\"G\n" +
+	"\x14ListSessionsResponse\x12/\n" +
+	"\bsessions\x18\x01 \x03(\v2\x13.chartdb.v1.SessionR\bsessions\".\n" +
+	"\x14RevokeSessionRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\";\n" +
+	"\x1bRequestPasswordResetRequest\x12\x1c\n" +
+	"\x05login\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05login\"T\n" +
+	"\x14ResetPasswordRequest\x12\x18\n" +
+	"\x03rid\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03rid\x12\"\n" +
+	"\bpassword\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bpassword\"u\n" +
+	"\x15ChangePasswordRequest\x121\n" +
+	"\x10current_password\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x0fcurrentPassword\x12)\n" +
+	"\fnew_password\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\vnewPassword\"2\n" +
+	"\x12ChangeEmailRequest\x12\x1c\n" +
+	"\x05login\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05login\"\x17\n" +
+	"\x15StartOidcLoginRequest\"[\n" +
+	"\x16StartOidcLoginResponse\x12+\n" +
+	"\x11authorization_url\x18\x01 \x01(\tR\x10authorizationUrl\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"R\n" +
+	"\x16FinishOidcLoginRequest\x12\x1c\n" +
+	"\x05state\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05state\x12\x1a\n" +
+	"\x04code\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\"\xa3\x01\n" +
+	" CreatePersonalAccessTokenRequest\x12\x1a\n" +
+	"\x04name\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04name\x12 \n" +
+	"\x06scopes\x18\x02 \x03(\tB\b\xbaH\x05\x92\x01\x02\b\x01R\x06scopes\x12A\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampB\x06\xbaH\x03\xc8\x01\x01R\texpiresAt\"\x8e\x01\n" +
+	"!CreatePersonalAccessTokenResponse\x12S\n" +
+	"\x15personal_access_token\x18\x01 \x01(\v2\x1f.chartdb.v1.PersonalAccessTokenR\x13personalAccessToken\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"!\n" +
+	"\x1fListPersonalAccessTokensRequest\"y\n" +
+	" ListPersonalAccessTokensResponse\x12U\n" +
+	"\x16personal_access_tokens\x18\x01 \x03(\v2\x1f.chartdb.v1.PersonalAccessTokenR\x14personalAccessTokens\":\n" +
+	" RevokePersonalAccessTokenRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\"d\n" +
+	"\x1bVerifyLoginChallengeRequest\x12)\n" +
+	"\fchallenge_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\vchallengeId\x12\x1a\n" +
+	"\x04code\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\"\x13\n" +
+	"\x11EnrollTotpRequest\"0\n" +
+	"\x12ConfirmTotpRequest\x12\x1a\n" +
+	"\x04code\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\"0\n" +
+	"\x12DisableTotpRequest\x12\x1a\n" +
+	"\x04code\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\"<\n" +
+	"\x1eRegenerateRecoveryCodesRequest\x12\x1a\n" +
+	"\x04code\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x04code\">\n" +
+	"\x15RecoveryCodesResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"J\n" +
 	"\x12ConfirmUserRequest\x12\x18\n" +
-	"\x03cid\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03cid2\x88\x03\n" +
+	"\x03cid\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x03cid\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"V\n" +
+	"\x12UpgradeUserRequest\x12\x1c\n" +
+	"\x05login\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x05login\x12\"\n" +
+	"\bpassword\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\bpassword\"^\n" +
+	"\x12RequestRoleRequest\x120\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x14.chartdb.v1.UserTypeB\x06\xbaH\x03\xc8\x01\x01R\x04type\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"P\n" +
+	"\x17ListRoleRequestsRequest\x125\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1d.chartdb.v1.RoleRequestStatusR\x06status\"X\n" +
+	"\x18ListRoleRequestsResponse\x12<\n" +
+	"\rrole_requests\x18\x01 \x03(\v2\x17.chartdb.v1.RoleRequestR\froleRequests\"h\n" +
+	"\x18DecideRoleRequestRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\x02id\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment2\x87\x19\n" +
 	"\vUserService\x12S\n" +
 	"\x03Get\x12\x1a.chartdb.v1.GetUserRequest\x1a\x10.chartdb.v1.User\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/chartdb/v1/users/{id}\x12W\n" +
 	"\x06Create\x12\x1d.chartdb.v1.CreateUserRequest\x1a\x10.chartdb.v1.User\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/chartdb/v1/users\x12h\n" +
-	"\x05Login\x12\x1c.chartdb.v1.LoginUserRequest\x1a\x1d.chartdb.v1.LoginUserResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/chartdb/v1/users:login\x12a\n" +
-	"\aConfirm\x12\x1e.chartdb.v1.ConfirmUserRequest\x1a\x10.chartdb.v1.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/chartdb/v1/users:confirmB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
+	"\x05Login\x12\x1c.chartdb.v1.LoginUserRequest\x1a\x1d.chartdb.v1.LoginUserResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/chartdb/v1/users:login\x12y\n" +
+	"\fRefreshToken\x12\x1f.chartdb.v1.RefreshTokenRequest\x1a\x1d.chartdb.v1.LoginUserResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/chartdb/v1/users:refreshToken\x12`\n" +
+	"\x06Logout\x12\x19.chartdb.v1.LogoutRequest\x1a\x16.google.protobuf.Empty\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/chartdb/v1/users:logout\x12o\n" +
+	"\fListSessions\x12\x1f.chartdb.v1.ListSessionsRequest\x1a .chartdb.v1.ListSessionsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/chartdb/v1/sessions\x12l\n" +
+	"\rRevokeSession\x12 .chartdb.v1.RevokeSessionRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b*\x19/chartdb/v1/sessions/{id}\x12\x8a\x01\n" +
+	"\x14RequestPasswordReset\x12'.chartdb.v1.RequestPasswordResetRequest\x1a\x16.google.protobuf.Empty\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/chartdb/v1/users:requestPasswordReset\x12u\n" +
+	"\rResetPassword\x12 .chartdb.v1.ResetPasswordRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/chartdb/v1/users:resetPassword\x12x\n" +
+	"\x0eChangePassword\x12!.chartdb.v1.ChangePasswordRequest\x1a\x16.google.protobuf.Empty\"+\x82\xd3\xe4\x93\x02%:\x01*\" /chartdb/v1/users:changePassword\x12o\n" +
+	"\vChangeEmail\x12\x1e.chartdb.v1.ChangeEmailRequest\x1a\x16.google.protobuf.Empty\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/chartdb/v1/users:changeEmail\x12\x84\x01\n" +
+	"\x0eStartOidcLogin\x12!.chartdb.v1.StartOidcLoginRequest\x1a\".chartdb.v1.StartOidcLoginResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /chartdb/v1/users:startOidcLogin\x12\x82\x01\n" +
+	"\x0fFinishOidcLogin\x12\".chartdb.v1.FinishOidcLoginRequest\x1a\x1d.chartdb.v1.LoginUserResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/chartdb/v1/users:finishOidcLogin\x12\xa5\x01\n" +
+	"\x19CreatePersonalAccessToken\x12,.chartdb.v1.CreatePersonalAccessTokenRequest\x1a-.chartdb.v1.CreatePersonalAccessTokenResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /chartdb/v1/personalAccessTokens\x12\x9f\x01\n" +
+	"\x18ListPersonalAccessTokens\x12+.chartdb.v1.ListPersonalAccessTokensRequest\x1a,.chartdb.v1.ListPersonalAccessTokensResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /chartdb/v1/personalAccessTokens\x12\x90\x01\n" +
+	"\x19RevokePersonalAccessToken\x12,.chartdb.v1.RevokePersonalAccessTokenRequest\x1a\x16.google.protobuf.Empty\"-\x82\xd3\xe4\x93\x02'*%/chartdb/v1/personalAccessTokens/{id}\x12\x91\x01\n" +
+	"\x14VerifyLoginChallenge\x12'.chartdb.v1.VerifyLoginChallengeRequest\x1a\x1d.chartdb.v1.LoginUserResponse\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/chartdb/v1/users:verifyLoginChallenge\x12p\n" +
+	"\n" +
+	"EnrollTotp\x12\x1d.chartdb.v1.EnrollTotpRequest\x1a\x1a.chartdb.v1.TotpEnrollment\"'\x82\xd3\xe4\x93\x02!:\x01*\"\x1c/chartdb/v1/users:enrollTotp\x12z\n" +
+	"\vConfirmTotp\x12\x1e.chartdb.v1.ConfirmTotpRequest\x1a!.chartdb.v1.RecoveryCodesResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/chartdb/v1/users:confirmTotp\x12o\n" +
+	"\vDisableTotp\x12\x1e.chartdb.v1.DisableTotpRequest\x1a\x16.google.protobuf.Empty\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/chartdb/v1/users:disableTotp\x12\x9e\x01\n" +
+	"\x17RegenerateRecoveryCodes\x12*.chartdb.v1.RegenerateRecoveryCodesRequest\x1a!.chartdb.v1.RecoveryCodesResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/chartdb/v1/users:regenerateRecoveryCodes\x12a\n" +
+	"\aConfirm\x12\x1e.chartdb.v1.ConfirmUserRequest\x1a\x10.chartdb.v1.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/chartdb/v1/users:confirm\x12a\n" +
+	"\aUpgrade\x12\x1e.chartdb.v1.UpgradeUserRequest\x1a\x10.chartdb.v1.User\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/chartdb/v1/users:upgrade\x12k\n" +
+	"\vRequestRole\x12\x1e.chartdb.v1.RequestRoleRequest\x1a\x17.chartdb.v1.RoleRequest\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/chartdb/v1/roleRequests\x12\x7f\n" +
+	"\x10ListRoleRequests\x12#.chartdb.v1.ListRoleRequestsRequest\x1a$.chartdb.v1.ListRoleRequestsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/chartdb/v1/roleRequests\x12\x83\x01\n" +
+	"\x11DecideRoleRequest\x12$.chartdb.v1.DecideRoleRequestRequest\x1a\x17.chartdb.v1.RoleRequest\"/\x82\xd3\xe4\x93\x02):\x01*\"$/chartdb/v1/roleRequests/{id}:decideB\x14Z\x12chartdb/v1;chartdbb\x06proto3"
 
 var (
 	file_chartdb_v1_user_service_proto_rawDescOnce sync.Once
@@ -304,29 +1747,119 @@ func file_chartdb_v1_user_service_proto_rawDescGZIP() []byte {
 	return file_chartdb_v1_user_service_proto_rawDescData
 }
 
-var file_chartdb_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_chartdb_v1_user_service_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_chartdb_v1_user_service_proto_goTypes = []any{
-	(*GetUserRequest)(nil),     // 0: chartdb.v1.GetUserRequest
-	(*CreateUserRequest)(nil),  // 1: chartdb.v1.CreateUserRequest
-	(*LoginUserRequest)(nil),   // 2: chartdb.v1.LoginUserRequest
-	(*LoginUserResponse)(nil),  // 3: chartdb.v1.LoginUserResponse
-	(*ConfirmUserRequest)(nil), // 4: chartdb.v1.ConfirmUserRequest
-	(*User)(nil),               // 5: chartdb.v1.User
+	(*GetUserRequest)(nil),                    // 0: chartdb.v1.GetUserRequest
+	(*CreateUserRequest)(nil),                 // 1: chartdb.v1.CreateUserRequest
+	(*LoginUserRequest)(nil),                  // 2: chartdb.v1.LoginUserRequest
+	(*LoginUserResponse)(nil),                 // 3: chartdb.v1.LoginUserResponse
+	(*RefreshTokenRequest)(nil),               // 4: chartdb.v1.RefreshTokenRequest
+	(*LogoutRequest)(nil),                     // 5: chartdb.v1.LogoutRequest
+	(*ListSessionsRequest)(nil),               // 6: chartdb.v1.ListSessionsRequest
+	(*ListSessionsResponse)(nil),              // 7: chartdb.v1.ListSessionsResponse
+	(*RevokeSessionRequest)(nil),              // 8: chartdb.v1.RevokeSessionRequest
+	(*RequestPasswordResetRequest)(nil),       // 9: chartdb.v1.RequestPasswordResetRequest
+	(*ResetPasswordRequest)(nil),              // 10: chartdb.v1.ResetPasswordRequest
+	(*ChangePasswordRequest)(nil),             // 11: chartdb.v1.ChangePasswordRequest
+	(*ChangeEmailRequest)(nil),                // 12: chartdb.v1.ChangeEmailRequest
+	(*StartOidcLoginRequest)(nil),             // 13: chartdb.v1.StartOidcLoginRequest
+	(*StartOidcLoginResponse)(nil),            // 14: chartdb.v1.StartOidcLoginResponse
+	(*FinishOidcLoginRequest)(nil),            // 15: chartdb.v1.FinishOidcLoginRequest
+	(*CreatePersonalAccessTokenRequest)(nil),  // 16: chartdb.v1.CreatePersonalAccessTokenRequest
+	(*CreatePersonalAccessTokenResponse)(nil), // 17: chartdb.v1.CreatePersonalAccessTokenResponse
+	(*ListPersonalAccessTokensRequest)(nil),   // 18: chartdb.v1.ListPersonalAccessTokensRequest
+	(*ListPersonalAccessTokensResponse)(nil),  // 19: chartdb.v1.ListPersonalAccessTokensResponse
+	(*RevokePersonalAccessTokenRequest)(nil),  // 20: chartdb.v1.RevokePersonalAccessTokenRequest
+	(*VerifyLoginChallengeRequest)(nil),       // 21: chartdb.v1.VerifyLoginChallengeRequest
+	(*EnrollTotpRequest)(nil),                 // 22: chartdb.v1.EnrollTotpRequest
+	(*ConfirmTotpRequest)(nil),                // 23: chartdb.v1.ConfirmTotpRequest
+	(*DisableTotpRequest)(nil),                // 24: chartdb.v1.DisableTotpRequest
+	(*RegenerateRecoveryCodesRequest)(nil),    // 25: chartdb.v1.RegenerateRecoveryCodesRequest
+	(*RecoveryCodesResponse)(nil),             // 26: chartdb.v1.RecoveryCodesResponse
+	(*ConfirmUserRequest)(nil),                // 27: chartdb.v1.ConfirmUserRequest
+	(*UpgradeUserRequest)(nil),                // 28: chartdb.v1.UpgradeUserRequest
+	(*RequestRoleRequest)(nil),                // 29: chartdb.v1.RequestRoleRequest
+	(*ListRoleRequestsRequest)(nil),           // 30: chartdb.v1.ListRoleRequestsRequest
+	(*ListRoleRequestsResponse)(nil),          // 31: chartdb.v1.ListRoleRequestsResponse
+	(*DecideRoleRequestRequest)(nil),          // 32: chartdb.v1.DecideRoleRequestRequest
+	(*timestamppb.Timestamp)(nil),             // 33: google.protobuf.Timestamp
+	(*LoginChallenge)(nil),                    // 34: chartdb.v1.LoginChallenge
+	(*Session)(nil),                           // 35: chartdb.v1.Session
+	(*PersonalAccessToken)(nil),               // 36: chartdb.v1.PersonalAccessToken
+	(UserType)(0),                             // 37: chartdb.v1.UserType
+	(RoleRequestStatus)(0),                    // 38: chartdb.v1.RoleRequestStatus
+	(*RoleRequest)(nil),                       // 39: chartdb.v1.RoleRequest
+	(*User)(nil),                              // 40: chartdb.v1.User
+	(*emptypb.Empty)(nil),                     // 41: google.protobuf.Empty
+	(*TotpEnrollment)(nil),                    // 42: chartdb.v1.TotpEnrollment
 }
 var file_chartdb_v1_user_service_proto_depIdxs = []int32{
-	0, // 0: chartdb.v1.UserService.Get:input_type -> chartdb.v1.GetUserRequest
-	1, // 1: chartdb.v1.UserService.Create:input_type -> chartdb.v1.CreateUserRequest
-	2, // 2: chartdb.v1.UserService.Login:input_type -> chartdb.v1.LoginUserRequest
-	4, // 3: chartdb.v1.UserService.Confirm:input_type -> chartdb.v1.ConfirmUserRequest
-	5, // 4: chartdb.v1.UserService.Get:output_type -> chartdb.v1.User
-	5, // 5: chartdb.v1.UserService.Create:output_type -> chartdb.v1.User
-	3, // 6: chartdb.v1.UserService.Login:output_type -> chartdb.v1.LoginUserResponse
-	5, // 7: chartdb.v1.UserService.Confirm:output_type -> chartdb.v1.User
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	33, // 0: chartdb.v1.LoginUserResponse.expires_at:type_name -> google.protobuf.Timestamp
+	34, // 1: chartdb.v1.LoginUserResponse.challenge:type_name -> chartdb.v1.LoginChallenge
+	35, // 2: chartdb.v1.ListSessionsResponse.sessions:type_name -> chartdb.v1.Session
+	33, // 3: chartdb.v1.CreatePersonalAccessTokenRequest.expires_at:type_name -> google.protobuf.Timestamp
+	36, // 4: chartdb.v1.CreatePersonalAccessTokenResponse.personal_access_token:type_name -> chartdb.v1.PersonalAccessToken
+	36, // 5: chartdb.v1.ListPersonalAccessTokensResponse.personal_access_tokens:type_name -> chartdb.v1.PersonalAccessToken
+	37, // 6: chartdb.v1.RequestRoleRequest.type:type_name -> chartdb.v1.UserType
+	38, // 7: chartdb.v1.ListRoleRequestsRequest.status:type_name -> chartdb.v1.RoleRequestStatus
+	39, // 8: chartdb.v1.ListRoleRequestsResponse.role_requests:type_name -> chartdb.v1.RoleRequest
+	0,  // 9: chartdb.v1.UserService.Get:input_type -> chartdb.v1.GetUserRequest
+	1,  // 10: chartdb.v1.UserService.Create:input_type -> chartdb.v1.CreateUserRequest
+	2,  // 11: chartdb.v1.UserService.Login:input_type -> chartdb.v1.LoginUserRequest
+	4,  // 12: chartdb.v1.UserService.RefreshToken:input_type -> chartdb.v1.RefreshTokenRequest
+	5,  // 13: chartdb.v1.UserService.Logout:input_type -> chartdb.v1.LogoutRequest
+	6,  // 14: chartdb.v1.UserService.ListSessions:input_type -> chartdb.v1.ListSessionsRequest
+	8,  // 15: chartdb.v1.UserService.RevokeSession:input_type -> chartdb.v1.RevokeSessionRequest
+	9,  // 16: chartdb.v1.UserService.RequestPasswordReset:input_type -> chartdb.v1.RequestPasswordResetRequest
+	10, // 17: chartdb.v1.UserService.ResetPassword:input_type -> chartdb.v1.ResetPasswordRequest
+	11, // 18: chartdb.v1.UserService.ChangePassword:input_type -> chartdb.v1.ChangePasswordRequest
+	12, // 19: chartdb.v1.UserService.ChangeEmail:input_type -> chartdb.v1.ChangeEmailRequest
+	13, // 20: chartdb.v1.UserService.StartOidcLogin:input_type -> chartdb.v1.StartOidcLoginRequest
+	15, // 21: chartdb.v1.UserService.FinishOidcLogin:input_type -> chartdb.v1.FinishOidcLoginRequest
+	16, // 22: chartdb.v1.UserService.CreatePersonalAccessToken:input_type -> chartdb.v1.CreatePersonalAccessTokenRequest
+	18, // 23: chartdb.v1.UserService.ListPersonalAccessTokens:input_type -> chartdb.v1.ListPersonalAccessTokensRequest
+	20, // 24: chartdb.v1.UserService.RevokePersonalAccessToken:input_type -> chartdb.v1.RevokePersonalAccessTokenRequest
+	21, // 25: chartdb.v1.UserService.VerifyLoginChallenge:input_type -> chartdb.v1.VerifyLoginChallengeRequest
+	22, // 26: chartdb.v1.UserService.EnrollTotp:input_type -> chartdb.v1.EnrollTotpRequest
+	23, // 27: chartdb.v1.UserService.ConfirmTotp:input_type -> chartdb.v1.ConfirmTotpRequest
+	24, // 28: chartdb.v1.UserService.DisableTotp:input_type -> chartdb.v1.DisableTotpRequest
+	25, // 29: chartdb.v1.UserService.RegenerateRecoveryCodes:input_type -> chartdb.v1.RegenerateRecoveryCodesRequest
+	27, // 30: chartdb.v1.UserService.Confirm:input_type -> chartdb.v1.ConfirmUserRequest
+	28, // 31: chartdb.v1.UserService.Upgrade:input_type -> chartdb.v1.UpgradeUserRequest
+	29, // 32: chartdb.v1.UserService.RequestRole:input_type -> chartdb.v1.RequestRoleRequest
+	30, // 33: chartdb.v1.UserService.ListRoleRequests:input_type -> chartdb.v1.ListRoleRequestsRequest
+	32, // 34: chartdb.v1.UserService.DecideRoleRequest:input_type -> chartdb.v1.DecideRoleRequestRequest
+	40, // 35: chartdb.v1.UserService.Get:output_type -> chartdb.v1.User
+	40, // 36: chartdb.v1.UserService.Create:output_type -> chartdb.v1.User
+	3,  // 37: chartdb.v1.UserService.Login:output_type -> chartdb.v1.LoginUserResponse
+	3,  // 38: chartdb.v1.UserService.RefreshToken:output_type -> chartdb.v1.LoginUserResponse
+	41, // 39: chartdb.v1.UserService.Logout:output_type -> google.protobuf.Empty
+	7,  // 40: chartdb.v1.UserService.ListSessions:output_type -> chartdb.v1.ListSessionsResponse
+	41, // 41: chartdb.v1.UserService.RevokeSession:output_type -> google.protobuf.Empty
+	41, // 42: chartdb.v1.UserService.RequestPasswordReset:output_type -> google.protobuf.Empty
+	41, // 43: chartdb.v1.UserService.ResetPassword:output_type -> google.protobuf.Empty
+	41, // 44: chartdb.v1.UserService.ChangePassword:output_type -> google.protobuf.Empty
+	41, // 45: chartdb.v1.UserService.ChangeEmail:output_type -> google.protobuf.Empty
+	14, // 46: chartdb.v1.UserService.StartOidcLogin:output_type -> chartdb.v1.StartOidcLoginResponse
+	3,  // 47: chartdb.v1.UserService.FinishOidcLogin:output_type -> chartdb.v1.LoginUserResponse
+	17, // 48: chartdb.v1.UserService.CreatePersonalAccessToken:output_type -> chartdb.v1.CreatePersonalAccessTokenResponse
+	19, // 49: chartdb.v1.UserService.ListPersonalAccessTokens:output_type -> chartdb.v1.ListPersonalAccessTokensResponse
+	41, // 50: chartdb.v1.UserService.RevokePersonalAccessToken:output_type -> google.protobuf.Empty
+	3,  // 51: chartdb.v1.UserService.VerifyLoginChallenge:output_type -> chartdb.v1.LoginUserResponse
+	42, // 52: chartdb.v1.UserService.EnrollTotp:output_type -> chartdb.v1.TotpEnrollment
+	26, // 53: chartdb.v1.UserService.ConfirmTotp:output_type -> chartdb.v1.RecoveryCodesResponse
+	41, // 54: chartdb.v1.UserService.DisableTotp:output_type -> google.protobuf.Empty
+	26, // 55: chartdb.v1.UserService.RegenerateRecoveryCodes:output_type -> chartdb.v1.RecoveryCodesResponse
+	40, // 56: chartdb.v1.UserService.Confirm:output_type -> chartdb.v1.User
+	40, // 57: chartdb.v1.UserService.Upgrade:output_type -> chartdb.v1.User
+	39, // 58: chartdb.v1.UserService.RequestRole:output_type -> chartdb.v1.RoleRequest
+	31, // 59: chartdb.v1.UserService.ListRoleRequests:output_type -> chartdb.v1.ListRoleRequestsResponse
+	39, // 60: chartdb.v1.UserService.DecideRoleRequest:output_type -> chartdb.v1.RoleRequest
+	35, // [35:61] is the sub-list for method output_type
+	9,  // [9:35] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_chartdb_v1_user_service_proto_init() }
@@ -341,7 +1874,7 @@ func file_chartdb_v1_user_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_chartdb_v1_user_service_proto_rawDesc), len(file_chartdb_v1_user_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
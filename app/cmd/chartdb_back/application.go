@@ -8,8 +8,11 @@ import (
 	"time"
 
 	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/auth"
 	"github.com/IvLaptev/chartdb-back/internal/background"
 	"github.com/IvLaptev/chartdb-back/internal/handler"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+	"github.com/IvLaptev/chartdb-back/internal/service/course"
 	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
 	"github.com/IvLaptev/chartdb-back/internal/service/user"
 	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
@@ -17,7 +20,9 @@ import (
 	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
 	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
 	xhttp "github.com/IvLaptev/chartdb-back/pkg/http"
+	"github.com/IvLaptev/chartdb-back/pkg/jwt"
 	"github.com/IvLaptev/chartdb-back/pkg/middleware"
+	"github.com/IvLaptev/chartdb-back/pkg/password"
 	"github.com/IvLaptev/chartdb-back/pkg/s3client"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -62,11 +67,49 @@ func (a *application) Run(ctx context.Context) error {
 		return fmt.Errorf("new sender: %w", err)
 	}
 
-	diagramService := diagram.NewService(a.logger, dbStorage, objectStorageClient)
+	diagramService := diagram.NewService(a.logger, dbStorage, objectStorageClient, a.config.Diagrams.EditLockDuration)
 
-	userService := user.NewService(a.logger, dbStorage, emailSender, 30*time.Minute, 5*time.Minute, []byte(a.config.Auth.TokenSecret))
+	passwordHasher := password.NewHasher(a.config.Auth.Password)
 
-	httpServer, err := newChartDBServer(ctx, a.logger, a.config.HTTPServer, userService, diagramService)
+	tokenSigner, err := jwt.NewSigner(a.config.Auth.TokenConfig())
+	if err != nil {
+		return fmt.Errorf("new token signer: %w", err)
+	}
+
+	registrationPolicy, err := user.NewPolicy(a.config.Registration)
+	if err != nil {
+		return fmt.Errorf("new registration policy: %w", err)
+	}
+
+	var userOIDC *user.OIDC
+	if a.config.Auth.OIDC.Enabled {
+		userOIDC, err = user.NewOIDC(ctx, a.config.Auth.OIDC, nil)
+		if err != nil {
+			return fmt.Errorf("new oidc: %w", err)
+		}
+	}
+
+	var authenticator user.Authenticator
+	if a.config.Auth.LDAP.Enabled {
+		authenticator, err = user.NewLDAPAuthenticator(a.config.Auth.LDAP)
+		if err != nil {
+			return fmt.Errorf("new ldap authenticator: %w", err)
+		}
+	}
+
+	twoFactor, err := user.NewTwoFactor(a.config.Auth.TwoFactor)
+	if err != nil {
+		return fmt.Errorf("new two factor: %w", err)
+	}
+
+	userService := user.NewService(a.logger, dbStorage, emailSender, passwordHasher, registrationPolicy, userOIDC, authenticator,
+		twoFactor, 30*time.Minute, 5*time.Minute, tokenSigner)
+
+	courseService := course.NewService(a.logger, dbStorage, emailSender, 7*24*time.Hour, registrationPolicy)
+
+	assignmentService := assignment.NewService(a.logger, dbStorage, objectStorageClient, diagramService)
+
+	httpServer, err := newChartDBServer(ctx, a.logger, a.config.HTTPServer, userService, diagramService, courseService, assignmentService)
 	if err != nil {
 		return fmt.Errorf("new chartdb server: %w", err)
 	}
@@ -85,6 +128,8 @@ func newChartDBServer(
 	config xhttp.HTTPServerConfig,
 	userService user.Service,
 	diagramService diagram.Service,
+	courseService course.Service,
+	assignmentService assignment.Service,
 ) (*xhttp.HTTPServer, error) {
 	chartDBHandler := runtime.NewServeMux(
 		runtime.WithErrorHandler(func(ctx context.Context, sm *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, originalErr error) {
@@ -121,18 +166,70 @@ func newChartDBServer(
 		return nil, fmt.Errorf("register user service handler server: %w", err)
 	}
 
+	err = chartdbapi.RegisterCourseServiceHandlerServer(
+		ctx,
+		chartDBHandler,
+		&handler.CourseHandler{
+			Logger:        logger,
+			CourseService: courseService,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("register course service handler server: %w", err)
+	}
+
+	err = chartdbapi.RegisterAssignmentServiceHandlerServer(
+		ctx,
+		chartDBHandler,
+		&handler.AssignmentHandler{
+			Logger:            logger,
+			AssignmentService: assignmentService,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("register assignment service handler server: %w", err)
+	}
+
 	httpServer, err := xhttp.NewHTTPServer(
 		config,
 		logger,
 		[]func(http.Handler) http.Handler{
 			middleware.HTTPAuthMiddleware(logger, userService),
+			auth.HTTPScopeMiddleware(logger),
 		},
 		map[string]http.Handler{
-			"/chartdb/v1/diagrams/{id}": chartDBHandler,
-			"/chartdb/v1/diagrams":      chartDBHandler,
-			"/chartdb/v1/users":         chartDBHandler,
-			"/chartdb/v1/users:confirm": chartDBHandler,
-			"/chartdb/v1/users:login":   chartDBHandler,
+			"/chartdb/v1/diagrams/{id}":                 chartDBHandler,
+			"/chartdb/v1/diagrams":                      chartDBHandler,
+			"/chartdb/v1/users":                         chartDBHandler,
+			"/chartdb/v1/users:confirm":                 chartDBHandler,
+			"/chartdb/v1/users:login":                   chartDBHandler,
+			"/chartdb/v1/users:upgrade":                 chartDBHandler,
+			"/chartdb/v1/users:refreshToken":            chartDBHandler,
+			"/chartdb/v1/users:requestPasswordReset":    chartDBHandler,
+			"/chartdb/v1/users:resetPassword":           chartDBHandler,
+			"/chartdb/v1/users:changePassword":          chartDBHandler,
+			"/chartdb/v1/users:changeEmail":             chartDBHandler,
+			"/chartdb/v1/users:logout":                  chartDBHandler,
+			"/chartdb/v1/users:startOidcLogin":          chartDBHandler,
+			"/chartdb/v1/users:finishOidcLogin":         chartDBHandler,
+			"/chartdb/v1/users:verifyLoginChallenge":    chartDBHandler,
+			"/chartdb/v1/users:enrollTotp":              chartDBHandler,
+			"/chartdb/v1/users:confirmTotp":             chartDBHandler,
+			"/chartdb/v1/users:disableTotp":             chartDBHandler,
+			"/chartdb/v1/users:regenerateRecoveryCodes": chartDBHandler,
+			"/chartdb/v1/sessions":                      chartDBHandler,
+			"/chartdb/v1/sessions/{id}":                 chartDBHandler,
+			"/chartdb/v1/personalAccessTokens":          chartDBHandler,
+			"/chartdb/v1/personalAccessTokens/{id}":     chartDBHandler,
+			"/chartdb/v1/roleRequests":                  chartDBHandler,
+			"/chartdb/v1/roleRequests/{id}":             chartDBHandler,
+			"/chartdb/v1/courses/{id}":                  chartDBHandler,
+			"/chartdb/v1/courses":                       chartDBHandler,
+			"/chartdb/v1/courses:join":                  chartDBHandler,
+			"/chartdb/v1/assignments/{id}":              chartDBHandler,
+			"/chartdb/v1/submissions/{id}":              chartDBHandler,
+			"/chartdb/v1/peerReviews/{id}":              chartDBHandler,
+			"/public/diagrams/{slug}":                   chartDBHandler,
 			"/health": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusOK)
 			}),
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/IvLaptev/chartdb-back/internal/service/user"
+	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
+	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
+	"github.com/IvLaptev/chartdb-back/pkg/password"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+// Password is read from the environment to keep it out of the shell history
+const bootstrapAdminPasswordEnv = "BOOTSTRAP_ADMIN_PASSWORD"
+
+// BootstrapAdmin creates the first admin of the installation, other admins and teachers
+// are approved by admins through role requests
+func (a *application) BootstrapAdmin(ctx context.Context, login string, adminPassword string) error {
+	registrationPolicy, err := user.NewPolicy(a.config.Registration)
+	if err != nil {
+		return fmt.Errorf("new registration policy: %w", err)
+	}
+
+	err = registrationPolicy.ValidatePassword(adminPassword)
+	if err != nil {
+		return fmt.Errorf("%s: %w", bootstrapAdminPasswordEnv, err)
+	}
+
+	dbStorage, err := postgres.NewStorage(a.config.Storage, a.logger)
+	if err != nil {
+		return fmt.Errorf("new storage: %w", err)
+	}
+	defer dbStorage.Shutdown()
+
+	userService := user.NewService(a.logger, dbStorage, emailsender.NewMockSender(),
+		password.NewHasher(a.config.Auth.Password), registrationPolicy, nil, nil, nil, 0, 0, nil)
+
+	admin, err := userService.CreateAdmin(ctx, &user.CreateAdminParams{
+		Login:    login,
+		Password: utils.NewSecret(adminPassword),
+	})
+	if err != nil {
+		return fmt.Errorf("create admin: %w", err)
+	}
+
+	a.logger.Info("admin created", slog.String("user_id", admin.ID.String()), slog.String("login", admin.Login))
+
+	return nil
+}
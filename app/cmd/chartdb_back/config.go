@@ -3,9 +3,13 @@ package main
 import (
 	"log/slog"
 
+	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
+	"github.com/IvLaptev/chartdb-back/internal/service/user"
 	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
 	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
 	"github.com/IvLaptev/chartdb-back/pkg/http"
+	"github.com/IvLaptev/chartdb-back/pkg/jwt"
+	"github.com/IvLaptev/chartdb-back/pkg/password"
 	"github.com/IvLaptev/chartdb-back/pkg/s3client"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 )
@@ -18,6 +22,8 @@ type Config struct {
 	S3ClientConfig s3client.S3Config             `yaml:"s3_client"`
 	EmailSender    emailsender.EmailSenderConfig `yaml:"email_sender"`
 	Auth           AuthConfig                    `yaml:"auth"`
+	Registration   user.PolicyConfig             `yaml:"registration"`
+	Diagrams       diagram.Config                `yaml:"diagrams"`
 }
 
 type LoggerConfig struct {
@@ -27,5 +33,30 @@ type LoggerConfig struct {
 }
 
 type AuthConfig struct {
-	TokenSecret string `yaml:"token_secret" env:"AUTH_TOKEN_SECRET"`
+	// Used as the only HS256 key if no token keys are configured
+	TokenSecret string                `yaml:"token_secret" env:"AUTH_TOKEN_SECRET"`
+	Tokens      jwt.Config            `yaml:"tokens"`
+	Password    password.HasherConfig `yaml:"password"`
+	OIDC        user.OIDCConfig       `yaml:"oidc"`
+	LDAP        user.LDAPConfig       `yaml:"ldap"`
+	TwoFactor   user.TwoFactorConfig  `yaml:"two_factor"`
+}
+
+const defaultTokenKeyID = "default"
+
+func (c *AuthConfig) TokenConfig() jwt.Config {
+	if len(c.Tokens.Keys) > 0 {
+		return c.Tokens
+	}
+
+	return jwt.Config{
+		ActiveKeyID: defaultTokenKeyID,
+		Keys: []jwt.Key{
+			{
+				ID:        defaultTokenKeyID,
+				Algorithm: jwt.AlgorithmHS256,
+				Secret:    c.TokenSecret,
+			},
+		},
+	}
 }
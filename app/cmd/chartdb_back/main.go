@@ -17,6 +17,9 @@ import (
 func main() {
 	ctx := context.Background()
 
+	bootstrapAdmin := pflag.String("bootstrap-admin", "", "create the first admin with the login and the password from "+
+		bootstrapAdminPasswordEnv+" and exit")
+
 	cfg, err := loadConfig()
 	if err != nil {
 		log.Fatalf("load config: %v", err)
@@ -24,8 +27,15 @@ func main() {
 
 	logger := setupLogger(cfg.Logger)
 
-	logger.Info("application started")
 	app := newApplication(cfg, logger)
+	if *bootstrapAdmin != "" {
+		if err = app.BootstrapAdmin(ctx, *bootstrapAdmin, os.Getenv(bootstrapAdminPasswordEnv)); err != nil {
+			log.Fatalf("bootstrap admin: %v", err)
+		}
+		return
+	}
+
+	logger.Info("application started")
 	if err = app.Run(ctx); err != nil && !errors.Is(err, utils.ErrSignalExit) {
 		logger.Error("application stopped with error", slog.Any("error", err))
 	} else {
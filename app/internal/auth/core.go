@@ -17,6 +17,15 @@ type subjectKey struct{}
 type Subject struct {
 	UserID   model.UserID
 	UserType model.UserType
+	// Empty for guests and personal access tokens which don't have sessions
+	SessionID model.SessionID
+	// Set for personal access tokens, which are restricted to the operations of their scopes
+	PersonalAccessToken *model.PersonalAccessToken
+}
+
+// HasScope reports whether the subject may perform operations of the scope, sessions and guests have all scopes
+func (s *Subject) HasScope(scope model.TokenScope) bool {
+	return s.PersonalAccessToken == nil || s.PersonalAccessToken.HasScope(scope)
 }
 
 func SetSubject(ctx context.Context, subject *Subject) context.Context {
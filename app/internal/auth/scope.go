@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+)
+
+const diagramsPath = "/chartdb/v1/diagrams"
+
+var ErrScopeNotGranted = errors.New("operation is not granted to the access token")
+
+// routeScope returns the scope required for the request, routes without a scope
+// are available to sessions only
+func routeScope(r *http.Request) (model.TokenScope, bool) {
+	if r.URL.Path != diagramsPath && !strings.HasPrefix(r.URL.Path, diagramsPath+"/") {
+		return "", false
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return model.TokenScopeDiagramsRead, true
+	}
+	return model.TokenScopeDiagramsWrite, true
+}
+
+// HTTPScopeMiddleware restricts personal access tokens to the routes of their scopes,
+// it must follow the authentication middleware
+func HTTPScopeMiddleware(logger *slog.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			subject, err := GetSubject(ctx)
+			if err != nil || subject.PersonalAccessToken == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, ok := routeScope(r)
+			if !ok || !subject.HasScope(scope) {
+				ctxlog.Info(ctx, logger, "scope not granted", slog.String("path", r.URL.Path), slog.String("scope", scope.String()))
+				if internalErr := xerrors.HTTPErrorHandler(w, xerrors.WrapForbidden(ErrScopeNotGranted)); internalErr != nil {
+					ctxlog.Error(ctx, logger, "http error handler", slog.Any("error", internalErr))
+				}
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
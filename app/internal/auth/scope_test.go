@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteScope(t *testing.T) {
+	for _, tc := range []struct {
+		method string
+		path   string
+		scope  model.TokenScope
+		ok     bool
+	}{
+		{http.MethodGet, "/chartdb/v1/diagrams", model.TokenScopeDiagramsRead, true},
+		{http.MethodGet, "/chartdb/v1/diagrams/abc", model.TokenScopeDiagramsRead, true},
+		{http.MethodPost, "/chartdb/v1/diagrams", model.TokenScopeDiagramsWrite, true},
+		{http.MethodPost, "/chartdb/v1/diagrams/abc:publish", model.TokenScopeDiagramsWrite, true},
+		{http.MethodDelete, "/chartdb/v1/diagrams/abc", model.TokenScopeDiagramsWrite, true},
+		{http.MethodGet, "/chartdb/v1/diagramsx", "", false},
+		{http.MethodGet, "/chartdb/v1/sessions", "", false},
+		{http.MethodPost, "/chartdb/v1/personalAccessTokens", "", false},
+	} {
+		scope, ok := routeScope(httptest.NewRequest(tc.method, tc.path, nil))
+		assert.Equal(t, tc.ok, ok, "%s %s", tc.method, tc.path)
+		assert.Equal(t, tc.scope, scope, "%s %s", tc.method, tc.path)
+	}
+}
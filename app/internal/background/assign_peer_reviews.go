@@ -0,0 +1,141 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/peerreview"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const peerReviewIDLength int64 = 20
+
+// AssignPeerReviewsJob assigns reviewers to submissions of assignments with peer review once the deadline passes.
+// Assignments which review period ended before the job got to them are skipped.
+type AssignPeerReviewsJob struct {
+	period    time.Duration
+	isRunning bool
+
+	logger  *slog.Logger
+	storage storage.Storage
+}
+
+func (j *AssignPeerReviewsJob) Name() string {
+	return "assign_peer_reviews"
+}
+
+func (j *AssignPeerReviewsJob) Run(now int64) {
+	if !(now%int64(j.period.Seconds()) == 0) || j.isRunning {
+		return
+	}
+
+	j.isRunning = true
+	defer func() { j.isRunning = false }()
+
+	ctx := context.Background()
+	runID, err := utils.GenerateID(10)
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "generate run id", slog.Any("error", err))
+		return
+	}
+	ctx = ctxlog.WithFields(ctx, slog.String("run_id", runID), slog.String("job", j.Name()))
+
+	assignments, err := j.storage.Assignment().GetAllAssignments(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyPeerReviewsAssignedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+		{
+			Key:       model.TermKeyDeadline,
+			Value:     time.Unix(now, 0),
+			Operation: model.FilterOperationLess,
+		},
+		{
+			Key:       model.TermKeyPeerReviewEndsAt,
+			Value:     time.Unix(now, 0),
+			Operation: model.FilterOperationMore,
+		},
+	})
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "get assignments waiting for peer review", slog.Any("error", err))
+		return
+	}
+
+	var count int64
+	for _, assignment := range assignments {
+		assignmentCtx := ctxlog.WithFields(ctx, slog.String("assignment_id", assignment.ID.String()))
+
+		err := j.assign(assignmentCtx, assignment.ID)
+		if err != nil {
+			ctxlog.Error(assignmentCtx, j.logger, "assign peer reviews", slog.Any("error", err))
+			continue
+		}
+		count++
+	}
+	ctxlog.Info(ctx, j.logger, "assign peer reviews", slog.Int64("count", count))
+}
+
+func (j *AssignPeerReviewsJob) assign(ctx context.Context, assignmentID model.AssignmentID) error {
+	return j.storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// Teachers can't change peer review settings of the locked assignment in the meantime
+		assignment, err := j.storage.Assignment().GetAssignmentByID(ctx, assignmentID, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get assignment by id: %w", err)
+		}
+		if !assignment.HasPeerReview() || assignment.PeerReviewsAssignedAt != nil {
+			return nil
+		}
+
+		submissions, err := j.storage.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyAssignmentID,
+				Value:     assignment.ID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all submissions: %w", err)
+		}
+
+		authors := make([]model.UserID, 0, len(submissions))
+		submissionIDs := make(map[model.UserID]model.SubmissionID, len(submissions))
+		for _, submission := range submissions {
+			authors = append(authors, submission.UserID)
+			submissionIDs[submission.UserID] = submission.ID
+		}
+
+		for author, reviewers := range peerreview.Assign(authors, assignment.PeerReview.ReviewersCount) {
+			for _, reviewer := range reviewers {
+				peerReviewID, err := utils.GenerateID(peerReviewIDLength)
+				if err != nil {
+					return fmt.Errorf("generate id: %w", err)
+				}
+
+				_, err = j.storage.PeerReview().CreatePeerReview(ctx, &storage.CreatePeerReviewParams{
+					ID:           model.PeerReviewID(peerReviewID),
+					AssignmentID: assignment.ID,
+					SubmissionID: submissionIDs[author],
+					ReviewerID:   reviewer,
+				})
+				if err != nil {
+					return fmt.Errorf("create peer review: %w", err)
+				}
+			}
+		}
+
+		_, err = j.storage.Assignment().PatchAssignment(ctx, &storage.PatchAssignmentParams{
+			ID:                    assignment.ID,
+			PeerReviewsAssignedAt: utils.NewOptional(time.Now()),
+		})
+		if err != nil {
+			return fmt.Errorf("patch assignment: %w", err)
+		}
+
+		return nil
+	})
+}
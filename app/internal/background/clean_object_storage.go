@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"time"
 
 	"github.com/IvLaptev/chartdb-back/internal/model"
@@ -56,6 +57,15 @@ func (j *CleanObjectStorageJob) Run(now int64) {
 	}
 	ctxlog.Info(ctx, j.logger, "fetch diagram object storage keys", slog.Int("count", len(diagramKeys)))
 
+	submissionKeys, err := j.fetchSubmissionObjectStorageKeys(ctx)
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "fetch submission object storage keys", slog.Any("error", err))
+		return
+	}
+	ctxlog.Info(ctx, j.logger, "fetch submission object storage keys", slog.Int("count", len(submissionKeys)))
+
+	maps.Copy(diagramKeys, submissionKeys)
+
 	keysToDelete := j.findKeysToDelete(objectKeys, diagramKeys)
 	ctxlog.Info(ctx, j.logger, "find keys to delete", slog.Int("count", len(keysToDelete)))
 
@@ -125,6 +135,21 @@ func (j *CleanObjectStorageJob) fetchDiagramObjectStorageKeys(ctx context.Contex
 	return diagramKeys, nil
 }
 
+// fetchSubmissionObjectStorageKeys returns keys of submission snapshots which must outlive the submitted diagrams
+func (j *CleanObjectStorageJob) fetchSubmissionObjectStorageKeys(ctx context.Context) (map[string]struct{}, error) {
+	submissions, err := j.storage.Submission().GetAllSubmissions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get all submissions: %w", err)
+	}
+
+	submissionKeys := make(map[string]struct{}, len(submissions))
+	for _, submission := range submissions {
+		submissionKeys[submission.ObjectStorageKey] = struct{}{}
+	}
+
+	return submissionKeys, nil
+}
+
 func (j *CleanObjectStorageJob) findKeysToDelete(objectKeys map[string]struct{}, diagramKeys map[string]struct{}) []string {
 	var keysToDelete []string
 	for key := range objectKeys {
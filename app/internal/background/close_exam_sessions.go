@@ -0,0 +1,141 @@
+package background
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+// CloseExamSessionsJob submits diagrams of exam sessions which ended without a submission by the student.
+// Editing is forbidden from the end time, so the submitted state doesn't depend on the job delay.
+type CloseExamSessionsJob struct {
+	period    time.Duration
+	isRunning bool
+
+	logger   *slog.Logger
+	s3client s3client.Client
+	storage  storage.Storage
+}
+
+func (j *CloseExamSessionsJob) Name() string {
+	return "close_exam_sessions"
+}
+
+func (j *CloseExamSessionsJob) Run(now int64) {
+	if !(now%int64(j.period.Seconds()) == 0) || j.isRunning {
+		return
+	}
+
+	j.isRunning = true
+	defer func() { j.isRunning = false }()
+
+	ctx := context.Background()
+	runID, err := utils.GenerateID(10)
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "generate run id", slog.Any("error", err))
+		return
+	}
+	ctx = ctxlog.WithFields(ctx, slog.String("run_id", runID), slog.String("job", j.Name()))
+
+	examSessions, err := j.storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyClosedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+		{
+			Key:       model.TermKeyEndsAt,
+			Value:     time.Unix(now, 0),
+			Operation: model.FilterOperationLess,
+		},
+	})
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "get ended exam sessions", slog.Any("error", err))
+		return
+	}
+
+	var count int64
+	for _, examSession := range examSessions {
+		sessionCtx := ctxlog.WithFields(ctx, slog.String("exam_session_id", examSession.ID.String()))
+
+		err := j.close(sessionCtx, examSession)
+		if err != nil {
+			ctxlog.Error(sessionCtx, j.logger, "close exam session", slog.Any("error", err))
+			continue
+		}
+		count++
+	}
+	ctxlog.Info(ctx, j.logger, "close exam sessions", slog.Int64("count", count))
+}
+
+// close snapshots the session diagram into the submission of the student and closes the session
+func (j *CloseExamSessionsJob) close(ctx context.Context, examSession *model.ExamSession) error {
+	return j.storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// Submissions by students lock the assignment as well
+		_, err := j.storage.Assignment().GetAssignmentByID(ctx, examSession.AssignmentID, storage.WithLock())
+		if errors.Is(err, storage.ErrNotFound) {
+			// Nothing to submit to, the session is only closed
+			return j.closeSession(ctx, examSession)
+		}
+		if err != nil {
+			return fmt.Errorf("get assignment by id: %w", err)
+		}
+
+		current, err := j.storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyID,
+				Value:     examSession.ID.String(),
+				Operation: model.FilterOperationExact,
+			},
+			{
+				Key:       model.TermKeyClosedAt,
+				Operation: model.FilterOperationIsNil,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all exam sessions: %w", err)
+		}
+		// Submitted by the student in the meantime
+		if len(current) == 0 {
+			return nil
+		}
+
+		diagram, err := j.storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, examSession.DiagramID)
+		if err != nil {
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		_, err = assignment.SaveSubmission(ctx, j.storage, j.s3client, &assignment.SaveSubmissionParams{
+			AssignmentID: examSession.AssignmentID,
+			UserID:       examSession.UserID,
+			Diagram:      diagram,
+			ExamSession:  examSession,
+		})
+		if err != nil {
+			return fmt.Errorf("save submission: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (j *CloseExamSessionsJob) closeSession(ctx context.Context, examSession *model.ExamSession) error {
+	_, err := j.storage.ExamSession().PatchExamSession(ctx, &storage.PatchExamSessionParams{
+		ID:       examSession.ID,
+		ClosedAt: utils.NewOptional(ptr.To(time.Now())),
+	})
+	if err != nil {
+		return fmt.Errorf("patch exam session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
+	"github.com/IvLaptev/chartdb-back/internal/tests"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type CloseExamSessionsJobSuite struct {
+	suite.Suite
+
+	job      *CloseExamSessionsJob
+	storage  storage.Storage
+	logger   *slog.Logger
+	s3client *s3client.MockClient
+	// Object storage content by key
+	contents map[string]string
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(CloseExamSessionsJobSuite))
+}
+
+func (s *CloseExamSessionsJobSuite) SetupSuite() {
+	var err error
+	s.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s.storage, err = postgres.NewStorage(*tests.NewPostgresTestConfig(), s.logger)
+	assert.NoError(s.T(), err)
+}
+
+func (s *CloseExamSessionsJobSuite) SetupTest() {
+	s.storage.Erase(context.Background())
+
+	s.contents = map[string]string{}
+	s.s3client = s3client.NewMockClient(gomock.NewController(s.T()))
+	s.s3client.EXPECT().SaveContent(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, content string) error {
+		s.contents[key] = content
+		return nil
+	}).AnyTimes()
+	s.s3client.EXPECT().GetContent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (string, error) {
+		content, ok := s.contents[key]
+		if !ok {
+			return "", s3client.ErrContentNotFound
+		}
+		return content, nil
+	}).AnyTimes()
+
+	s.job = &CloseExamSessionsJob{
+		logger:   s.logger,
+		s3client: s.s3client,
+		storage:  s.storage,
+		period:   time.Minute,
+	}
+}
+
+// createExamSession returns the ended exam session of the student with the diagram content
+func (s *CloseExamSessionsJobSuite) createExamSession(ctx context.Context, content string) *model.ExamSession {
+	for _, userModel := range []*storage.CreateUserParams{
+		{ID: "teacher", Login: "teacher@mirea.ru", Type: model.UserTypeTeacher},
+		{ID: "student", Login: "student@mirea.ru", Type: model.UserTypeStudent},
+	} {
+		userModel.ConfirmedAt = ptr.To(time.Now())
+		_, err := s.storage.User().CreateUser(ctx, userModel)
+		s.Require().NoError(err)
+	}
+
+	_, err := s.storage.Course().CreateCourse(ctx, &storage.CreateCourseParams{
+		ID:        "course001",
+		Name:      "Databases",
+		JoinCode:  "course001",
+		CreatedBy: "teacher",
+	})
+	s.Require().NoError(err)
+
+	code, err := utils.GenerateID(4)
+	s.Require().NoError(err)
+
+	diagram, err := s.storage.Diagram().CreateDiagram(ctx, &storage.CreateDiagramParams{
+		ID:               "diagram001",
+		ClientDiagramID:  "diagram001",
+		Code:             code,
+		UserID:           "student",
+		ObjectStorageKey: "diagram001",
+		Name:             "Exam",
+		TablesCount:      2,
+	})
+	s.Require().NoError(err)
+	s.contents[diagram.ObjectStorageKey] = content
+
+	assignment, err := s.storage.Assignment().CreateAssignment(ctx, &storage.CreateAssignmentParams{
+		ID:               "exam000001",
+		CourseID:         "course001",
+		Title:            "Exam",
+		StarterDiagramID: &diagram.ID,
+		ExamStartsAt:     ptr.To(time.Now().Add(-time.Hour)),
+		ExamEndsAt:       ptr.To(time.Now().Add(-time.Minute)),
+		CreatedBy:        "teacher",
+	})
+	s.Require().NoError(err)
+
+	examSession, err := s.storage.ExamSession().CreateExamSession(ctx, &storage.CreateExamSessionParams{
+		ID:           "session001",
+		AssignmentID: assignment.ID,
+		UserID:       "student",
+		DiagramID:    diagram.ID,
+		EndsAt:       *assignment.ExamEndsAt,
+	})
+	s.Require().NoError(err)
+
+	return examSession
+}
+
+func (s *CloseExamSessionsJobSuite) getSubmissions(ctx context.Context, assignmentID model.AssignmentID) []*model.Submission {
+	submissions, err := s.storage.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     assignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	s.Require().NoError(err)
+
+	return submissions
+}
+
+func (s *CloseExamSessionsJobSuite) TestClose() {
+	ctx := context.Background()
+	examSession := s.createExamSession(ctx, "exam")
+
+	err := s.job.close(ctx, examSession)
+	s.Require().NoError(err)
+
+	submissions := s.getSubmissions(ctx, examSession.AssignmentID)
+	s.Require().Len(submissions, 1)
+	s.Require().Equal(model.UserID("student"), submissions[0].UserID)
+	s.Require().Equal(examSession.DiagramID, submissions[0].DiagramID)
+	s.Require().Equal(int64(2), submissions[0].TablesCount)
+	s.Require().Equal("exam", s.contents[submissions[0].ObjectStorageKey])
+
+	examSessions, err := s.storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyID,
+			Value:     examSession.ID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(examSessions, 1)
+	s.Require().NotNil(examSessions[0].ClosedAt)
+
+	// The closed session is not submitted again
+	err = s.job.close(ctx, examSession)
+	s.Require().NoError(err)
+
+	resubmissions := s.getSubmissions(ctx, examSession.AssignmentID)
+	s.Require().Len(resubmissions, 1)
+	s.Require().Equal(submissions[0].ObjectStorageKey, resubmissions[0].ObjectStorageKey)
+}
+
+func (s *CloseExamSessionsJobSuite) TestClose_SubmittedByStudent() {
+	ctx := context.Background()
+	examSession := s.createExamSession(ctx, "exam")
+
+	_, err := s.storage.ExamSession().PatchExamSession(ctx, &storage.PatchExamSessionParams{
+		ID:       examSession.ID,
+		ClosedAt: utils.NewOptional(ptr.To(time.Now())),
+	})
+	s.Require().NoError(err)
+
+	err = s.job.close(ctx, examSession)
+	s.Require().NoError(err)
+	s.Require().Empty(s.getSubmissions(ctx, examSession.AssignmentID))
+}
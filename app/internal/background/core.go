@@ -79,6 +79,38 @@ func NewWorker(
 				storage:  storage,
 				period:   1 * time.Hour,
 			},
+			&ExpireEditLocksJob{
+				logger:  logger,
+				storage: storage,
+				period:  1 * time.Minute,
+			},
+			&ExpireOIDCLoginStatesJob{
+				logger:  logger,
+				storage: storage,
+				period:  1 * time.Hour,
+			},
+			&ExpireLoginChallengesJob{
+				logger:  logger,
+				storage: storage,
+				period:  1 * time.Hour,
+			},
+			&DetectPlagiarismJob{
+				logger:   logger,
+				s3client: s3client,
+				storage:  storage,
+				period:   1 * time.Minute,
+			},
+			&CloseExamSessionsJob{
+				logger:   logger,
+				s3client: s3client,
+				storage:  storage,
+				period:   1 * time.Minute,
+			},
+			&AssignPeerReviewsJob{
+				logger:  logger,
+				storage: storage,
+				period:  1 * time.Minute,
+			},
 		},
 	}
 }
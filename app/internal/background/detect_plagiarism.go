@@ -0,0 +1,212 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/plagiarism"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const plagiarismPairIDLength int64 = 20
+
+// DetectPlagiarismJob compares submissions of each assignment pairwise and stores suspicious pairs.
+// Checks are requested by teachers or automatically when new submissions arrive.
+type DetectPlagiarismJob struct {
+	period    time.Duration
+	isRunning bool
+
+	logger   *slog.Logger
+	s3client s3client.Client
+	storage  storage.Storage
+}
+
+func (j *DetectPlagiarismJob) Name() string {
+	return "detect_plagiarism"
+}
+
+func (j *DetectPlagiarismJob) Run(now int64) {
+	if !(now%int64(j.period.Seconds()) == 0) || j.isRunning {
+		return
+	}
+
+	j.isRunning = true
+	defer func() { j.isRunning = false }()
+
+	ctx := context.Background()
+	runID, err := utils.GenerateID(10)
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "generate run id", slog.Any("error", err))
+		return
+	}
+	ctx = ctxlog.WithFields(ctx, slog.String("run_id", runID), slog.String("job", j.Name()))
+
+	count, err := j.storage.PlagiarismCheck().RequestOutdatedPlagiarismChecks(ctx, time.Unix(now, 0))
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "request outdated plagiarism checks", slog.Any("error", err))
+		return
+	}
+	ctxlog.Info(ctx, j.logger, "request outdated plagiarism checks", slog.Int64("count", count))
+
+	checks, err := j.storage.PlagiarismCheck().GetAllPlagiarismChecks(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyStatus,
+			Value:     model.PlagiarismCheckStatusPending.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "get pending plagiarism checks", slog.Any("error", err))
+		return
+	}
+
+	for _, check := range checks {
+		checkCtx := ctxlog.WithFields(ctx, slog.String("assignment_id", check.AssignmentID.String()))
+
+		pairsCount, err := j.check(checkCtx, check)
+		if err != nil {
+			ctxlog.Error(checkCtx, j.logger, "check plagiarism", slog.Any("error", err))
+
+			_, err = j.storage.PlagiarismCheck().PatchPlagiarismCheck(checkCtx, &storage.PatchPlagiarismCheckParams{
+				AssignmentID: check.AssignmentID,
+				Status:       utils.NewOptional(model.PlagiarismCheckStatusFailed),
+				Error:        utils.NewOptional(err.Error()),
+			})
+			if err != nil {
+				ctxlog.Error(checkCtx, j.logger, "mark plagiarism check failed", slog.Any("error", err))
+			}
+			continue
+		}
+		ctxlog.Info(checkCtx, j.logger, "check plagiarism", slog.Int("suspicious_pairs", pairsCount))
+	}
+}
+
+// check replaces suspicious pairs of the assignment and returns their count
+func (j *DetectPlagiarismJob) check(ctx context.Context, check *model.PlagiarismCheck) (int, error) {
+	// Submissions made during the check are covered by the next one
+	checkedAt := time.Now()
+
+	assignment, err := j.storage.Assignment().GetAssignmentByID(ctx, check.AssignmentID)
+	if err != nil {
+		return 0, fmt.Errorf("get assignment by id: %w", err)
+	}
+
+	var baseline *schema.Schema
+	if assignment.StarterDiagramID != nil {
+		starterDiagram, err := j.storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, *assignment.StarterDiagramID)
+		if err != nil {
+			return 0, fmt.Errorf("get starter diagram: %w", err)
+		}
+
+		baseline, err = j.parseContent(ctx, starterDiagram.ObjectStorageKey)
+		if err != nil {
+			return 0, fmt.Errorf("parse starter diagram: %w", err)
+		}
+	}
+
+	submissions, err := j.storage.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     check.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("get all submissions: %w", err)
+	}
+
+	schemas := make([]*schema.Schema, len(submissions))
+	for i, submission := range submissions {
+		schemas[i], err = j.parseContent(ctx, submission.ObjectStorageKey)
+		if err != nil {
+			// Malformed submission can't be compared, it shouldn't block the report for others
+			ctxlog.Warn(ctx, j.logger, "parse submission", slog.String("submission_id", submission.ID.String()), slog.Any("error", err))
+		}
+	}
+
+	var pairs []*storage.CreatePlagiarismPairParams
+	for i := range submissions {
+		for k := i + 1; k < len(submissions); k++ {
+			if schemas[i] == nil || schemas[k] == nil {
+				continue
+			}
+
+			similarity := plagiarism.Compare(schemas[i], schemas[k], baseline)
+			if !similarity.Suspicious() {
+				continue
+			}
+
+			pairID, err := utils.GenerateID(plagiarismPairIDLength)
+			if err != nil {
+				return 0, fmt.Errorf("generate id: %w", err)
+			}
+
+			pairs = append(pairs, &storage.CreatePlagiarismPairParams{
+				ID:                 model.PlagiarismPairID(pairID),
+				AssignmentID:       check.AssignmentID,
+				FirstSubmissionID:  submissions[i].ID,
+				SecondSubmissionID: submissions[k].ID,
+				Score:              similarity.Score,
+				NamesScore:         similarity.Names,
+				StructureScore:     similarity.Structure,
+				LayoutScore:        similarity.Layout,
+			})
+		}
+	}
+
+	err = j.storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		current, err := j.storage.PlagiarismCheck().GetPlagiarismCheck(ctx, check.AssignmentID, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get plagiarism check: %w", err)
+		}
+
+		err = j.storage.PlagiarismPair().DeletePlagiarismPairs(ctx, check.AssignmentID)
+		if err != nil {
+			return fmt.Errorf("delete plagiarism pairs: %w", err)
+		}
+
+		err = j.storage.PlagiarismPair().CreatePlagiarismPairs(ctx, pairs)
+		if err != nil {
+			return fmt.Errorf("create plagiarism pairs: %w", err)
+		}
+
+		// Check requested again while running stays pending
+		status := model.PlagiarismCheckStatusDone
+		if current.RequestedAt.After(check.RequestedAt) {
+			status = model.PlagiarismCheckStatusPending
+		}
+
+		_, err = j.storage.PlagiarismCheck().PatchPlagiarismCheck(ctx, &storage.PatchPlagiarismCheckParams{
+			AssignmentID: check.AssignmentID,
+			Status:       utils.NewOptional(status),
+			Error:        utils.NewOptional(""),
+			CheckedAt:    utils.NewOptional(&checkedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("patch plagiarism check: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(pairs), nil
+}
+
+func (j *DetectPlagiarismJob) parseContent(ctx context.Context, objectStorageKey string) (*schema.Schema, error) {
+	content, err := j.s3client.GetContent(ctx, objectStorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("get content: %w", err)
+	}
+
+	return schema.Parse(content)
+}
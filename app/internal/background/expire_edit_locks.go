@@ -0,0 +1,47 @@
+package background
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type ExpireEditLocksJob struct {
+	period    time.Duration
+	isRunning bool
+
+	logger  *slog.Logger
+	storage storage.Storage
+}
+
+func (j *ExpireEditLocksJob) Name() string {
+	return "expire_edit_locks"
+}
+
+func (j *ExpireEditLocksJob) Run(now int64) {
+	if !(now%int64(j.period.Seconds()) == 0) || j.isRunning {
+		return
+	}
+
+	j.isRunning = true
+	defer func() { j.isRunning = false }()
+
+	ctx := context.Background()
+	runID, err := utils.GenerateID(10)
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "generate run id", slog.Any("error", err))
+		return
+	}
+	ctx = ctxlog.WithFields(ctx, slog.String("run_id", runID), slog.String("job", j.Name()))
+
+	count, err := j.storage.DiagramEditLock().DeleteExpiredDiagramEditLocks(ctx, time.Unix(now, 0))
+	if err != nil {
+		ctxlog.Error(ctx, j.logger, "delete expired edit locks", slog.Any("error", err))
+		return
+	}
+	ctxlog.Info(ctx, j.logger, "delete expired edit locks", slog.Int64("count", count))
+}
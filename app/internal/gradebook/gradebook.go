@@ -0,0 +1,134 @@
+package gradebook
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+
+	sheetName = "Gradebook"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown gradebook format")
+)
+
+type Format string
+
+// ContentType returns MIME type of the exported file
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Write exports the gradebook as a table with a row per student, ungraded assignments are left empty
+func Write(w io.Writer, gradebook *model.Gradebook, format Format) error {
+	rows := makeRows(gradebook)
+
+	switch format {
+	case FormatCSV:
+		return writeCSV(w, rows)
+	case FormatXLSX:
+		return writeXLSX(w, rows)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// makeRows returns the header and a row per student, grades are kept as numbers
+// so they can be summed in a spreadsheet
+func makeRows(gradebook *model.Gradebook) [][]any {
+	header := []any{"login", "group"}
+	for _, assignment := range gradebook.Assignments {
+		header = append(header, assignment.Title)
+	}
+
+	rows := [][]any{header}
+	for _, row := range gradebook.Rows {
+		record := []any{row.UserLogin, row.GroupCode}
+		for _, grade := range row.Grades {
+			if grade == nil {
+				record = append(record, "")
+				continue
+			}
+			record = append(record, *grade)
+		}
+		rows = append(rows, record)
+	}
+
+	return rows
+}
+
+func writeCSV(w io.Writer, rows [][]any) error {
+	writer := csv.NewWriter(w)
+	for _, row := range rows {
+		record := make([]string, 0, len(row))
+		for _, value := range row {
+			switch value := value.(type) {
+			case float64:
+				record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+			default:
+				record = append(record, escapeFormula(fmt.Sprint(value)))
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
+
+// escapeFormula prefixes cells which a spreadsheet would evaluate as a formula, titles and logins are user input
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
+
+func writeXLSX(w io.Writer, rows [][]any) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName(file.GetSheetName(0), sheetName); err != nil {
+		return fmt.Errorf("set sheet name: %w", err)
+	}
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return fmt.Errorf("cell name: %w", err)
+		}
+		if err := file.SetSheetRow(sheetName, cell, &row); err != nil {
+			return fmt.Errorf("set sheet row: %w", err)
+		}
+	}
+
+	if _, err := file.WriteTo(w); err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+	return nil
+}
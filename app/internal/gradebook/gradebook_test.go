@@ -0,0 +1,76 @@
+package gradebook
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+func grade(value float64) *float64 {
+	return &value
+}
+
+func testGradebook() *model.Gradebook {
+	return &model.Gradebook{
+		Course: &model.Course{ID: "course", Name: "Databases"},
+		Assignments: []*model.Assignment{
+			{ID: "a1", Title: "ER model"},
+			{ID: "a2", Title: "Normalization, part 1"},
+		},
+		Rows: []*model.GradebookRow{
+			{UserID: "u1", UserLogin: "alice@example.com", GroupCode: "CS-101", Grades: []*float64{grade(90), grade(72.5)}},
+			{UserID: "u2", UserLogin: "bob@example.com", Grades: []*float64{nil, grade(60)}},
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testGradebook(), FormatCSV))
+
+	assert.Equal(t, "login,group,ER model,\"Normalization, part 1\"\n"+
+		"alice@example.com,CS-101,90,72.5\n"+
+		"bob@example.com,,,60\n", buf.String())
+}
+
+func TestWriteCSV_Formula(t *testing.T) {
+	gradebook := testGradebook()
+	gradebook.Assignments[0].Title = "=HYPERLINK(\"http://example.com\")"
+	gradebook.Assignments[1].Title = "-1+2"
+	gradebook.Rows[0].UserLogin = "@alice"
+	gradebook.Rows[1].GroupCode = "+CS"
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, gradebook, FormatCSV))
+
+	assert.Equal(t, "login,group,\"'=HYPERLINK(\"\"http://example.com\"\")\",'-1+2\n"+
+		"'@alice,CS-101,90,72.5\n"+
+		"bob@example.com,'+CS,,60\n", buf.String())
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testGradebook(), FormatXLSX))
+
+	file, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows(sheetName)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"login", "group", "ER model", "Normalization, part 1"},
+		{"alice@example.com", "CS-101", "90", "72.5"},
+		{"bob@example.com", "", "", "60"},
+	}, rows)
+}
+
+func TestWriteUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	assert.ErrorIs(t, Write(&buf, testGradebook(), Format("pdf")), ErrUnknownFormat)
+}
@@ -0,0 +1,483 @@
+package grading
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+)
+
+const (
+	// Minimal similarity of normalized names to treat them as naming variants of the same entity
+	nameSimilarityThreshold = 0.8
+	// Minimal share of common columns to match tables which names differ completely
+	columnOverlapThreshold = 0.5
+)
+
+var (
+	ErrInvalidRubric = errors.New("invalid grading rubric")
+)
+
+// DefaultRubric is used for assignments without a rubric set by the teacher
+var DefaultRubric = model.GradingRubric{
+	TablesWeight:        0.3,
+	ColumnsWeight:       0.3,
+	KeysWeight:          0.15,
+	RelationshipsWeight: 0.15,
+	CardinalitiesWeight: 0.1,
+	PassScore:           60,
+	BorderlineMargin:    10,
+}
+
+func ValidateRubric(rubric *model.GradingRubric) error {
+	weights := []float64{
+		rubric.TablesWeight,
+		rubric.ColumnsWeight,
+		rubric.KeysWeight,
+		rubric.RelationshipsWeight,
+		rubric.CardinalitiesWeight,
+	}
+
+	var total float64
+	for _, weight := range weights {
+		if weight < 0 {
+			return ErrInvalidRubric
+		}
+		total += weight
+	}
+
+	if total == 0 || rubric.PassScore < 0 || rubric.PassScore > 100 || rubric.BorderlineMargin < 0 {
+		return ErrInvalidRubric
+	}
+
+	return nil
+}
+
+// Grade compares the submitted schema with the reference one. Tables and columns are matched
+// by normalized names tolerating case, separators, plural forms and typos; tables with different
+// names are matched by their columns.
+func Grade(reference, submission *schema.Schema, rubric *model.GradingRubric) *model.GradingResult {
+	if rubric == nil {
+		rubric = &DefaultRubric
+	}
+
+	g := &grader{
+		reference:  reference,
+		submission: submission,
+		tables:     matchTables(reference.Tables, submission.Tables),
+	}
+
+	g.fields = make(map[*schema.Field]*schema.Field)
+	for refTable, subTable := range g.tables {
+		for refField, subField := range matchFields(refTable.Fields, subTable.Fields) {
+			g.fields[refField] = subField
+		}
+	}
+
+	relationships, cardinalities := g.gradeRelationships()
+
+	criteria := []*model.GradingCriterion{
+		g.gradeTables(),
+		g.gradeColumns(),
+		g.gradeKeys(),
+		relationships,
+		cardinalities,
+	}
+
+	weights := map[string]float64{
+		model.GradingCriterionTables:        rubric.TablesWeight,
+		model.GradingCriterionColumns:       rubric.ColumnsWeight,
+		model.GradingCriterionKeys:          rubric.KeysWeight,
+		model.GradingCriterionRelationships: rubric.RelationshipsWeight,
+		model.GradingCriterionCardinalities: rubric.CardinalitiesWeight,
+	}
+
+	var score, totalWeight float64
+	for _, criterion := range criteria {
+		criterion.Weight = weights[criterion.Name]
+		if criterion.Expected > 0 {
+			criterion.Score = float64(criterion.Matched) / float64(criterion.Expected)
+			score += criterion.Weight * criterion.Score
+			totalWeight += criterion.Weight
+		} else {
+			criterion.Score = 1
+		}
+	}
+
+	result := &model.GradingResult{
+		Criteria: criteria,
+	}
+	if totalWeight > 0 {
+		result.Score = math.Round(score/totalWeight*100*100) / 100
+	}
+	result.Passed = result.Score >= rubric.PassScore
+	result.Borderline = rubric.BorderlineMargin > 0 && math.Abs(result.Score-rubric.PassScore) <= rubric.BorderlineMargin
+
+	return result
+}
+
+type grader struct {
+	reference  *schema.Schema
+	submission *schema.Schema
+
+	// Matches of reference items to submitted ones
+	tables map[*schema.Table]*schema.Table
+	fields map[*schema.Field]*schema.Field
+}
+
+func (g *grader) gradeTables() *model.GradingCriterion {
+	criterion := &model.GradingCriterion{
+		Name:     model.GradingCriterionTables,
+		Expected: int64(len(g.reference.Tables)),
+	}
+
+	matched := make(map[*schema.Table]struct{})
+	for _, table := range g.reference.Tables {
+		if subTable, ok := g.tables[table]; ok {
+			criterion.Matched++
+			matched[subTable] = struct{}{}
+		} else {
+			criterion.Missing = append(criterion.Missing, table.FullName())
+		}
+	}
+
+	for _, table := range g.submission.Tables {
+		if _, ok := matched[table]; !ok {
+			criterion.Extra = append(criterion.Extra, table.FullName())
+		}
+	}
+
+	return criterion
+}
+
+func (g *grader) gradeColumns() *model.GradingCriterion {
+	criterion := &model.GradingCriterion{
+		Name: model.GradingCriterionColumns,
+	}
+
+	for _, table := range g.reference.Tables {
+		matched := make(map[*schema.Field]struct{})
+		for _, field := range table.Fields {
+			criterion.Expected++
+			if subField, ok := g.fields[field]; ok {
+				criterion.Matched++
+				matched[subField] = struct{}{}
+			} else {
+				criterion.Missing = append(criterion.Missing, table.FullName()+"."+field.Name)
+			}
+		}
+
+		if subTable, ok := g.tables[table]; ok {
+			for _, field := range subTable.Fields {
+				if _, ok := matched[field]; !ok {
+					criterion.Extra = append(criterion.Extra, subTable.FullName()+"."+field.Name)
+				}
+			}
+		}
+	}
+
+	return criterion
+}
+
+func (g *grader) gradeKeys() *model.GradingCriterion {
+	criterion := &model.GradingCriterion{
+		Name: model.GradingCriterionKeys,
+	}
+
+	for _, table := range g.reference.Tables {
+		for _, field := range table.Fields {
+			if !field.PrimaryKey {
+				continue
+			}
+
+			criterion.Expected++
+			if subField, ok := g.fields[field]; ok && subField.PrimaryKey {
+				criterion.Matched++
+			} else {
+				criterion.Missing = append(criterion.Missing, table.FullName()+"."+field.Name)
+			}
+		}
+	}
+
+	return criterion
+}
+
+// gradeRelationships matches relationships by the tables they connect in any direction,
+// cardinalities are compared only for matched relationships
+func (g *grader) gradeRelationships() (*model.GradingCriterion, *model.GradingCriterion) {
+	relationships := &model.GradingCriterion{
+		Name: model.GradingCriterionRelationships,
+	}
+	cardinalities := &model.GradingCriterion{
+		Name: model.GradingCriterionCardinalities,
+	}
+
+	used := make(map[*schema.Relationship]struct{})
+	for _, relationship := range g.reference.Relationships {
+		sourceTable := g.reference.TableByID(relationship.SourceTableID)
+		targetTable := g.reference.TableByID(relationship.TargetTableID)
+		if sourceTable == nil || targetTable == nil {
+			continue
+		}
+
+		name := sourceTable.FullName() + " -> " + targetTable.FullName()
+		relationships.Expected++
+		cardinalities.Expected++
+
+		subRelationship, swapped := g.findRelationship(relationship, sourceTable, targetTable, used)
+		if subRelationship == nil {
+			relationships.Missing = append(relationships.Missing, name)
+			cardinalities.Missing = append(cardinalities.Missing, name)
+			continue
+		}
+
+		used[subRelationship] = struct{}{}
+		relationships.Matched++
+
+		sourceCardinality, targetCardinality := subRelationship.SourceCardinality, subRelationship.TargetCardinality
+		if swapped {
+			sourceCardinality, targetCardinality = targetCardinality, sourceCardinality
+		}
+		if sourceCardinality == relationship.SourceCardinality && targetCardinality == relationship.TargetCardinality {
+			cardinalities.Matched++
+		} else {
+			cardinalities.Missing = append(cardinalities.Missing, name)
+		}
+	}
+
+	for _, relationship := range g.submission.Relationships {
+		if _, ok := used[relationship]; ok {
+			continue
+		}
+
+		sourceTable := g.submission.TableByID(relationship.SourceTableID)
+		targetTable := g.submission.TableByID(relationship.TargetTableID)
+		if sourceTable != nil && targetTable != nil {
+			relationships.Extra = append(relationships.Extra, sourceTable.FullName()+" -> "+targetTable.FullName())
+		}
+	}
+
+	return relationships, cardinalities
+}
+
+// findRelationship returns submitted relationship connecting the same tables, ones connecting the same fields are preferred
+func (g *grader) findRelationship(
+	relationship *schema.Relationship,
+	sourceTable, targetTable *schema.Table,
+	used map[*schema.Relationship]struct{},
+) (*schema.Relationship, bool) {
+	subSourceTable, ok := g.tables[sourceTable]
+	if !ok {
+		return nil, false
+	}
+	subTargetTable, ok := g.tables[targetTable]
+	if !ok {
+		return nil, false
+	}
+
+	var subSourceFieldID, subTargetFieldID string
+	if field, ok := g.fields[sourceTable.FieldByID(relationship.SourceFieldID)]; ok {
+		subSourceFieldID = field.ID
+	}
+	if field, ok := g.fields[targetTable.FieldByID(relationship.TargetFieldID)]; ok {
+		subTargetFieldID = field.ID
+	}
+
+	var (
+		found        *schema.Relationship
+		foundSwapped bool
+	)
+	for _, subRelationship := range g.submission.Relationships {
+		if _, ok := used[subRelationship]; ok {
+			continue
+		}
+
+		var sameFields, swapped bool
+		switch {
+		case subRelationship.SourceTableID == subSourceTable.ID && subRelationship.TargetTableID == subTargetTable.ID:
+			sameFields = subRelationship.SourceFieldID == subSourceFieldID && subRelationship.TargetFieldID == subTargetFieldID
+		case subRelationship.SourceTableID == subTargetTable.ID && subRelationship.TargetTableID == subSourceTable.ID:
+			sameFields = subRelationship.SourceFieldID == subTargetFieldID && subRelationship.TargetFieldID == subSourceFieldID
+			swapped = true
+		default:
+			continue
+		}
+
+		if sameFields {
+			return subRelationship, swapped
+		}
+		if found == nil {
+			found, foundSwapped = subRelationship, swapped
+		}
+	}
+
+	return found, foundSwapped
+}
+
+func matchTables(reference, submission []*schema.Table) map[*schema.Table]*schema.Table {
+	result := matchGreedy(reference, submission, func(r, s *schema.Table) float64 {
+		return nameSimilarity(r.Name, s.Name)
+	}, nameSimilarityThreshold, nil)
+
+	// Tables could be named differently, e.g. "authors" and "writers", but have the same columns
+	return matchGreedy(reference, submission, func(r, s *schema.Table) float64 {
+		return columnOverlap(r, s)
+	}, columnOverlapThreshold, result)
+}
+
+func matchFields(reference, submission []*schema.Field) map[*schema.Field]*schema.Field {
+	return matchGreedy(reference, submission, func(r, s *schema.Field) float64 {
+		return nameSimilarity(r.Name, s.Name)
+	}, nameSimilarityThreshold, nil)
+}
+
+// matchGreedy pairs not yet matched items starting from the most similar ones, each item is matched at most once
+func matchGreedy[T comparable](reference, submission []T, score func(r, s T) float64, threshold float64, matched map[T]T) map[T]T {
+	if matched == nil {
+		matched = make(map[T]T)
+	}
+
+	used := make(map[T]struct{}, len(matched))
+	for _, s := range matched {
+		used[s] = struct{}{}
+	}
+
+	type candidate struct {
+		r, s  int
+		score float64
+	}
+
+	var candidates []candidate
+	for i, r := range reference {
+		if _, ok := matched[r]; ok {
+			continue
+		}
+		for j, s := range submission {
+			if _, ok := used[s]; ok {
+				continue
+			}
+			if value := score(r, s); value >= threshold {
+				candidates = append(candidates, candidate{r: i, s: j, score: value})
+			}
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	for _, c := range candidates {
+		r, s := reference[c.r], submission[c.s]
+		if _, ok := matched[r]; ok {
+			continue
+		}
+		if _, ok := used[s]; ok {
+			continue
+		}
+		matched[r] = s
+		used[s] = struct{}{}
+	}
+
+	return matched
+}
+
+// normalizeName makes naming variants like "BookAuthors", "book_authors" and "book-author" equal
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return singular(b.String())
+}
+
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 3:
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
+
+// nameSimilarity returns similarity of normalized names from 0 to 1 based on the edit distance
+func nameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == b {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	maxLen := max(len(ra), len(rb))
+	if maxLen == 0 {
+		return 1
+	}
+
+	return 1 - float64(levenshtein(ra, rb))/float64(maxLen)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+// columnOverlap returns Jaccard index of normalized column names
+func columnOverlap(a, b *schema.Table) float64 {
+	names := make(map[string]int)
+	for _, field := range a.Fields {
+		names[normalizeName(field.Name)] |= 1
+	}
+	for _, field := range b.Fields {
+		names[normalizeName(field.Name)] |= 2
+	}
+	if len(names) == 0 {
+		return 0
+	}
+
+	common := 0
+	for _, mask := range names {
+		if mask == 3 {
+			common++
+		}
+	}
+	// Tables sharing only a surrogate key are not similar
+	if common < 2 {
+		return 0
+	}
+
+	return float64(common) / float64(len(names))
+}
@@ -0,0 +1,202 @@
+package grading
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const referenceContent = `{
+	"tables": [
+		{"id": "t1", "name": "books", "fields": [
+			{"id": "f1", "name": "id", "primaryKey": true},
+			{"id": "f2", "name": "title"},
+			{"id": "f3", "name": "author_id"}
+		]},
+		{"id": "t2", "name": "authors", "fields": [
+			{"id": "f4", "name": "id", "primaryKey": true},
+			{"id": "f5", "name": "full_name"},
+			{"id": "f6", "name": "birth_date"}
+		]}
+	],
+	"relationships": [
+		{"id": "r1", "sourceTableId": "t1", "targetTableId": "t2", "sourceFieldId": "f3", "targetFieldId": "f4",
+		 "sourceCardinality": "many", "targetCardinality": "one"}
+	]
+}`
+
+func mustParse(t *testing.T, content string) *schema.Schema {
+	s, err := schema.Parse(content)
+	require.NoError(t, err)
+	return s
+}
+
+func TestGradeSameSchema(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+
+	result := Grade(reference, mustParse(t, referenceContent), nil)
+	assert.Equal(t, float64(100), result.Score)
+	assert.True(t, result.Passed)
+	assert.False(t, result.Borderline)
+	for _, criterion := range result.Criteria {
+		assert.Equal(t, criterion.Expected, criterion.Matched, criterion.Name)
+		assert.Empty(t, criterion.Missing, criterion.Name)
+	}
+}
+
+func TestGradeNamingVariants(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+	submission := mustParse(t, `{
+		"tables": [
+			{"id": "a", "name": "Author", "fields": [
+				{"id": "a1", "name": "ID", "primaryKey": true},
+				{"id": "a2", "name": "FullName"},
+				{"id": "a3", "name": "birthdate"}
+			]},
+			{"id": "b", "name": "Book", "fields": [
+				{"id": "b1", "name": "Id", "primaryKey": true},
+				{"id": "b2", "name": "Title"},
+				{"id": "b3", "name": "AuthorID"}
+			]}
+		],
+		"relationships": [
+			{"id": "r", "sourceTableId": "a", "targetTableId": "b", "sourceFieldId": "a1", "targetFieldId": "b3",
+			 "sourceCardinality": "one", "targetCardinality": "many"}
+		]
+	}`)
+
+	result := Grade(reference, submission, nil)
+	assert.Equal(t, float64(100), result.Score)
+}
+
+func TestGradeTablesMatchedByColumns(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+	submission := mustParse(t, `{
+		"tables": [
+			{"id": "t1", "name": "books", "fields": [
+				{"id": "f1", "name": "id", "primaryKey": true},
+				{"id": "f2", "name": "title"},
+				{"id": "f3", "name": "author_id"}
+			]},
+			{"id": "t2", "name": "writers", "fields": [
+				{"id": "f4", "name": "id", "primaryKey": true},
+				{"id": "f5", "name": "full_name"},
+				{"id": "f6", "name": "birth_date"}
+			]}
+		]
+	}`)
+
+	result := Grade(reference, submission, nil)
+	criteria := criteriaByName(result)
+	assert.Equal(t, int64(2), criteria[model.GradingCriterionTables].Matched)
+	assert.Equal(t, int64(0), criteria[model.GradingCriterionRelationships].Matched)
+	assert.Equal(t, []string{"books -> authors"}, criteria[model.GradingCriterionRelationships].Missing)
+	assert.InDelta(t, 75, result.Score, 0.01)
+}
+
+func TestGradePartialSolution(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+	submission := mustParse(t, `{
+		"tables": [
+			{"id": "t1", "name": "books", "fields": [
+				{"id": "f1", "name": "id"},
+				{"id": "f2", "name": "title"},
+				{"id": "f7", "name": "isbn"}
+			]},
+			{"id": "t3", "name": "publishers", "fields": [
+				{"id": "f8", "name": "id", "primaryKey": true}
+			]}
+		],
+		"relationships": [
+			{"id": "r1", "sourceTableId": "t1", "targetTableId": "t3", "sourceFieldId": "f7", "targetFieldId": "f8",
+			 "sourceCardinality": "many", "targetCardinality": "one"}
+		]
+	}`)
+
+	rubric := &model.GradingRubric{
+		TablesWeight:  1,
+		ColumnsWeight: 1,
+		KeysWeight:    1,
+		PassScore:     50,
+	}
+
+	result := Grade(reference, submission, rubric)
+	criteria := criteriaByName(result)
+
+	tables := criteria[model.GradingCriterionTables]
+	assert.Equal(t, int64(1), tables.Matched)
+	assert.Equal(t, []string{"authors"}, tables.Missing)
+	assert.Equal(t, []string{"publishers"}, tables.Extra)
+
+	columns := criteria[model.GradingCriterionColumns]
+	assert.Equal(t, int64(2), columns.Matched)
+	assert.Equal(t, int64(6), columns.Expected)
+	assert.Equal(t, []string{"books.isbn"}, columns.Extra)
+
+	keys := criteria[model.GradingCriterionKeys]
+	assert.Equal(t, int64(0), keys.Matched)
+
+	// Relationships have no weight in the rubric
+	assert.InDelta(t, (0.5+2.0/6)/3*100, result.Score, 0.01)
+	assert.False(t, result.Passed)
+}
+
+func TestGradeCardinalities(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+	submission := mustParse(t, referenceContent)
+	submission.Relationships[0].SourceCardinality = schema.CardinalityOne
+
+	result := Grade(reference, submission, nil)
+	criteria := criteriaByName(result)
+	assert.Equal(t, int64(1), criteria[model.GradingCriterionRelationships].Matched)
+	assert.Equal(t, int64(0), criteria[model.GradingCriterionCardinalities].Matched)
+	assert.InDelta(t, 90, result.Score, 0.01)
+	assert.False(t, result.Borderline)
+}
+
+func TestBorderline(t *testing.T) {
+	reference := mustParse(t, referenceContent)
+	submission := mustParse(t, referenceContent)
+	submission.Relationships = nil
+
+	rubric := DefaultRubric
+	rubric.PassScore = 80
+
+	result := Grade(reference, submission, &rubric)
+	assert.InDelta(t, 75, result.Score, 0.01)
+	assert.False(t, result.Passed)
+	assert.True(t, result.Borderline)
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := map[string]string{
+		"BookAuthors":  "bookauthor",
+		"book_authors": "bookauthor",
+		"categories":   "category",
+		"addresses":    "address",
+		"class":        "class",
+		"id":           "id",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, normalizeName(name), name)
+	}
+}
+
+func TestValidateRubric(t *testing.T) {
+	assert.NoError(t, ValidateRubric(&DefaultRubric))
+	assert.ErrorIs(t, ValidateRubric(&model.GradingRubric{}), ErrInvalidRubric)
+	assert.ErrorIs(t, ValidateRubric(&model.GradingRubric{TablesWeight: -1, ColumnsWeight: 2}), ErrInvalidRubric)
+	assert.ErrorIs(t, ValidateRubric(&model.GradingRubric{TablesWeight: 1, PassScore: 101}), ErrInvalidRubric)
+}
+
+func criteriaByName(result *model.GradingResult) map[string]*model.GradingCriterion {
+	criteria := make(map[string]*model.GradingCriterion, len(result.Criteria))
+	for _, criterion := range result.Criteria {
+		criteria[criterion.Name] = criterion
+	}
+	return criteria
+}
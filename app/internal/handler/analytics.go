@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+)
+
+func (h *AssignmentHandler) GetCourseAnalytics(ctx context.Context, req *chartdbapi.GetCourseAnalyticsRequest) (*chartdbapi.CourseAnalytics, error) {
+	var groupID *model.CourseGroupID
+	if req.GroupId != "" {
+		id := model.CourseGroupID(req.GroupId)
+		groupID = &id
+	}
+
+	analytics, err := h.AssignmentService.GetCourseAnalytics(ctx, &assignment.GetCourseAnalyticsParams{
+		CourseID: model.CourseID(req.CourseId),
+		GroupID:  groupID,
+		Days:     req.Days,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get course analytics: %w", err)
+	}
+
+	return courseAnalyticsToPB(analytics), nil
+}
+
+func (h *AssignmentHandler) GetStudentAnalytics(ctx context.Context, req *chartdbapi.GetStudentAnalyticsRequest) (*chartdbapi.CourseAnalytics, error) {
+	analytics, err := h.AssignmentService.GetStudentAnalytics(ctx, &assignment.GetStudentAnalyticsParams{
+		CourseID: model.CourseID(req.CourseId),
+		UserID:   model.UserID(req.UserId),
+		Days:     req.Days,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get student analytics: %w", err)
+	}
+
+	return courseAnalyticsToPB(analytics), nil
+}
+
+func courseAnalyticsToPB(analytics *model.CourseAnalytics) *chartdbapi.CourseAnalytics {
+	assignments := make([]*chartdbapi.AssignmentProgress, 0, len(analytics.Assignments))
+	for _, progress := range analytics.Assignments {
+		assignments = append(assignments, &chartdbapi.AssignmentProgress{
+			Assignment: assignmentToPB(progress.Assignment),
+			NotStarted: progress.NotStarted,
+			Started:    progress.Started,
+			Submitted:  progress.Submitted,
+		})
+	}
+
+	students := make([]*chartdbapi.StudentAnalytics, 0, len(analytics.Students))
+	for _, student := range analytics.Students {
+		students = append(students, studentAnalyticsToPB(student))
+	}
+
+	dailyEdits := make([]*chartdbapi.DailyEdits, 0, len(analytics.DailyEdits))
+	for _, edits := range analytics.DailyEdits {
+		dailyEdits = append(dailyEdits, &chartdbapi.DailyEdits{
+			Day:   timestamppb.New(edits.Day),
+			Edits: edits.Edits,
+		})
+	}
+
+	return &chartdbapi.CourseAnalytics{
+		CourseId:    analytics.Course.ID.String(),
+		Since:       timestamppb.New(analytics.Since),
+		Assignments: assignments,
+		Students:    students,
+		DailyEdits:  dailyEdits,
+	}
+}
+
+func studentAnalyticsToPB(student *model.StudentAnalytics) *chartdbapi.StudentAnalytics {
+	statuses := make([]chartdbapi.AssignmentStatus, 0, len(student.Statuses))
+	for _, status := range student.Statuses {
+		var pbStatus chartdbapi.AssignmentStatus
+		switch status {
+		case model.AssignmentStatusNotStarted:
+			pbStatus = chartdbapi.AssignmentStatus_ASSIGNMENT_STATUS_NOT_STARTED
+		case model.AssignmentStatusStarted:
+			pbStatus = chartdbapi.AssignmentStatus_ASSIGNMENT_STATUS_STARTED
+		case model.AssignmentStatusSubmitted:
+			pbStatus = chartdbapi.AssignmentStatus_ASSIGNMENT_STATUS_SUBMITTED
+		}
+		statuses = append(statuses, pbStatus)
+	}
+
+	return &chartdbapi.StudentAnalytics{
+		UserId:              student.UserID.String(),
+		UserLogin:           student.UserLogin,
+		GroupCode:           student.GroupCode,
+		DiagramsCreated:     student.Stats.DiagramsCreated,
+		Edits:               student.Stats.Edits,
+		LastActivityAt:      optionalTimestamp(student.Stats.LastActivityAt),
+		AverageLintFindings: student.Stats.AverageLintFindings,
+		Statuses:            statuses,
+		NotStartedDueSoon:   student.NotStartedDueSoon,
+	}
+}
@@ -0,0 +1,394 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/gradebook"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+)
+
+type AssignmentHandler struct {
+	chartdbapi.UnimplementedAssignmentServiceServer
+
+	Logger            *slog.Logger
+	AssignmentService assignment.Service
+}
+
+func (h *AssignmentHandler) Get(ctx context.Context, req *chartdbapi.GetAssignmentRequest) (*chartdbapi.Assignment, error) {
+	assignmentModel, err := h.AssignmentService.GetAssignment(ctx, &assignment.GetAssignmentParams{
+		ID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get assignment: %w", err)
+	}
+
+	return assignmentToPB(assignmentModel), nil
+}
+
+func (h *AssignmentHandler) List(ctx context.Context, req *chartdbapi.ListAssignmentsRequest) (*chartdbapi.ListAssignmentsResponse, error) {
+	assignments, err := h.AssignmentService.ListAssignments(ctx, &assignment.ListAssignmentsParams{
+		CourseID: model.CourseID(req.CourseId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list assignments: %w", err)
+	}
+
+	result := make([]*chartdbapi.Assignment, 0, len(assignments))
+	for _, assignmentModel := range assignments {
+		result = append(result, assignmentToPB(assignmentModel))
+	}
+
+	return &chartdbapi.ListAssignmentsResponse{
+		Assignments: result,
+	}, nil
+}
+
+func (h *AssignmentHandler) Create(ctx context.Context, req *chartdbapi.CreateAssignmentRequest) (*chartdbapi.Assignment, error) {
+	assignmentModel, err := h.AssignmentService.CreateAssignment(ctx, &assignment.CreateAssignmentParams{
+		CourseID:           model.CourseID(req.CourseId),
+		Title:              req.Title,
+		Description:        req.Description,
+		StarterDiagramID:   optionalDiagramID(req.StarterDiagramId),
+		Deadline:           optionalTime(req.Deadline),
+		ReferenceDiagramID: optionalDiagramID(req.ReferenceDiagramId),
+		Rubric:             gradingRubricFromPB(req.Rubric),
+		ExamStartsAt:       optionalTime(req.ExamStartsAt),
+		ExamEndsAt:         optionalTime(req.ExamEndsAt),
+		PeerReview:         peerReviewSettingsFromPB(req.PeerReview),
+		PeerReviewEndsAt:   optionalTime(req.PeerReviewEndsAt),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create assignment: %w", err)
+	}
+
+	return assignmentToPB(assignmentModel), nil
+}
+
+func (h *AssignmentHandler) Update(ctx context.Context, req *chartdbapi.UpdateAssignmentRequest) (*chartdbapi.Assignment, error) {
+	paths, err := ExtractPaths(req.UpdateMask, req)
+	if err != nil {
+		return nil, fmt.Errorf("extract paths: %w", err)
+	}
+
+	patchAssignmentParams := &assignment.PatchAssignmentParams{
+		ID:          model.AssignmentID(req.Id),
+		Title:       ApplyFieldOptional(req.Fields.GetTitle(), "title", paths),
+		Description: ApplyFieldOptional(req.Fields.GetDescription(), "description", paths),
+		StarterDiagramID: ApplyFieldOptional(
+			optionalDiagramID(req.Fields.GetStarterDiagramId()), "starter_diagram_id", paths),
+		Deadline: ApplyFieldOptional(optionalTime(req.Fields.GetDeadline()), "deadline", paths),
+		ReferenceDiagramID: ApplyFieldOptional(
+			optionalDiagramID(req.Fields.GetReferenceDiagramId()), "reference_diagram_id", paths),
+		Rubric:       ApplyFieldOptional(gradingRubricFromPB(req.Fields.GetRubric()), "rubric", paths),
+		ExamStartsAt: ApplyFieldOptional(optionalTime(req.Fields.GetExamStartsAt()), "exam_starts_at", paths),
+		ExamEndsAt:   ApplyFieldOptional(optionalTime(req.Fields.GetExamEndsAt()), "exam_ends_at", paths),
+		PeerReview: ApplyFieldOptional(
+			peerReviewSettingsFromPB(req.Fields.GetPeerReview()), "peer_review", paths),
+		PeerReviewEndsAt: ApplyFieldOptional(
+			optionalTime(req.Fields.GetPeerReviewEndsAt()), "peer_review_ends_at", paths),
+	}
+
+	assignmentModel, err := h.AssignmentService.PatchAssignment(ctx, patchAssignmentParams)
+	if err != nil {
+		return nil, fmt.Errorf("patch assignment: %w", err)
+	}
+
+	return assignmentToPB(assignmentModel), nil
+}
+
+func (h *AssignmentHandler) Delete(ctx context.Context, req *chartdbapi.DeleteAssignmentRequest) (*emptypb.Empty, error) {
+	_, err := h.AssignmentService.DeleteAssignment(ctx, &assignment.DeleteAssignmentParams{
+		ID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete assignment: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *AssignmentHandler) Start(ctx context.Context, req *chartdbapi.StartAssignmentRequest) (*chartdbapi.DiagramMetadata, error) {
+	diagramModel, err := h.AssignmentService.StartAssignment(ctx, &assignment.StartAssignmentParams{
+		ID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start assignment: %w", err)
+	}
+
+	return diagramMetadataToPB(diagramModel), nil
+}
+
+func (h *AssignmentHandler) Submit(ctx context.Context, req *chartdbapi.SubmitAssignmentRequest) (*chartdbapi.Submission, error) {
+	submission, err := h.AssignmentService.SubmitAssignment(ctx, &assignment.SubmitAssignmentParams{
+		AssignmentID: model.AssignmentID(req.Id),
+		DiagramID:    model.DiagramID(req.DiagramId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submit assignment: %w", err)
+	}
+
+	return submissionToPB(submission), nil
+}
+
+func (h *AssignmentHandler) ListSubmissions(ctx context.Context, req *chartdbapi.ListSubmissionsRequest) (*chartdbapi.ListSubmissionsResponse, error) {
+	submissions, err := h.AssignmentService.ListSubmissions(ctx, &assignment.ListSubmissionsParams{
+		AssignmentID: model.AssignmentID(req.AssignmentId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list submissions: %w", err)
+	}
+
+	result := make([]*chartdbapi.Submission, 0, len(submissions))
+	for _, submission := range submissions {
+		result = append(result, submissionToPB(submission))
+	}
+
+	return &chartdbapi.ListSubmissionsResponse{
+		Submissions: result,
+	}, nil
+}
+
+func (h *AssignmentHandler) GetSubmission(ctx context.Context, req *chartdbapi.GetSubmissionRequest) (*chartdbapi.SubmissionWithContent, error) {
+	submission, err := h.AssignmentService.GetSubmission(ctx, &assignment.GetSubmissionParams{
+		ID: model.SubmissionID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get submission: %w", err)
+	}
+
+	content := ""
+	if submission.Content.Value != nil {
+		content = *submission.Content.Value
+	}
+
+	return &chartdbapi.SubmissionWithContent{
+		Submission: submissionToPB(submission),
+		Content:    content,
+	}, nil
+}
+
+func (h *AssignmentHandler) Grade(ctx context.Context, req *chartdbapi.GradeAssignmentRequest) (*chartdbapi.ListSubmissionsResponse, error) {
+	submissions, err := h.AssignmentService.GradeSubmissions(ctx, &assignment.GradeSubmissionsParams{
+		AssignmentID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("grade submissions: %w", err)
+	}
+
+	result := make([]*chartdbapi.Submission, 0, len(submissions))
+	for _, submission := range submissions {
+		result = append(result, submissionToPB(submission))
+	}
+
+	return &chartdbapi.ListSubmissionsResponse{
+		Submissions: result,
+	}, nil
+}
+
+func (h *AssignmentHandler) SetGrade(ctx context.Context, req *chartdbapi.SetSubmissionGradeRequest) (*chartdbapi.Submission, error) {
+	submission, err := h.AssignmentService.SetSubmissionGrade(ctx, &assignment.SetSubmissionGradeParams{
+		ID:       model.SubmissionID(req.Id),
+		Grade:    req.Grade,
+		Feedback: req.Feedback,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set submission grade: %w", err)
+	}
+
+	return submissionToPB(submission), nil
+}
+
+func (h *AssignmentHandler) ReleaseGrades(ctx context.Context, req *chartdbapi.ReleaseGradesRequest) (*chartdbapi.Assignment, error) {
+	assignmentModel, err := h.AssignmentService.ReleaseGrades(ctx, &assignment.ReleaseGradesParams{
+		AssignmentID: model.AssignmentID(req.Id),
+		Released:     req.Released,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release grades: %w", err)
+	}
+
+	return assignmentToPB(assignmentModel), nil
+}
+
+func (h *AssignmentHandler) ExportGradebook(ctx context.Context, req *chartdbapi.ExportGradebookRequest) (*httpbody.HttpBody, error) {
+	format := gradebook.FormatCSV
+	if req.Format == chartdbapi.GradebookFormat_GRADEBOOK_FORMAT_XLSX {
+		format = gradebook.FormatXLSX
+	}
+
+	var groupID *model.CourseGroupID
+	if req.GroupId != "" {
+		id := model.CourseGroupID(req.GroupId)
+		groupID = &id
+	}
+
+	gradebookModel, err := h.AssignmentService.ExportGradebook(ctx, &assignment.ExportGradebookParams{
+		CourseID: model.CourseID(req.CourseId),
+		GroupID:  groupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("export gradebook: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := gradebook.Write(&buf, gradebookModel, format); err != nil {
+		return nil, fmt.Errorf("write gradebook: %w", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: format.ContentType(),
+		Data:        buf.Bytes(),
+	}, nil
+}
+
+func optionalDiagramID(diagramID string) *model.DiagramID {
+	if diagramID == "" {
+		return nil
+	}
+
+	id := model.DiagramID(diagramID)
+	return &id
+}
+
+func optionalTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+
+	t := timestamp.AsTime()
+	return &t
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func assignmentToPB(assignmentModel *model.Assignment) *chartdbapi.Assignment {
+	var starterDiagramID string
+	if assignmentModel.StarterDiagramID != nil {
+		starterDiagramID = assignmentModel.StarterDiagramID.String()
+	}
+
+	var referenceDiagramID string
+	if assignmentModel.ReferenceDiagramID != nil {
+		referenceDiagramID = assignmentModel.ReferenceDiagramID.String()
+	}
+
+	return &chartdbapi.Assignment{
+		Id:                    assignmentModel.ID.String(),
+		CourseId:              assignmentModel.CourseID.String(),
+		Title:                 assignmentModel.Title,
+		Description:           assignmentModel.Description,
+		StarterDiagramId:      starterDiagramID,
+		Deadline:              optionalTimestamp(assignmentModel.Deadline),
+		ReferenceDiagramId:    referenceDiagramID,
+		Rubric:                gradingRubricToPB(assignmentModel.Rubric),
+		GradesReleasedAt:      optionalTimestamp(assignmentModel.GradesReleasedAt),
+		ExamStartsAt:          optionalTimestamp(assignmentModel.ExamStartsAt),
+		ExamEndsAt:            optionalTimestamp(assignmentModel.ExamEndsAt),
+		PeerReview:            peerReviewSettingsToPB(assignmentModel.PeerReview),
+		PeerReviewEndsAt:      optionalTimestamp(assignmentModel.PeerReviewEndsAt),
+		PeerReviewsAssignedAt: optionalTimestamp(assignmentModel.PeerReviewsAssignedAt),
+		CreatedAt:             timestamppb.New(assignmentModel.CreatedAt),
+		UpdatedAt:             timestamppb.New(assignmentModel.UpdatedAt),
+	}
+}
+
+func submissionToPB(submission *model.Submission) *chartdbapi.Submission {
+	var gradedBy string
+	if submission.GradedBy != nil {
+		gradedBy = submission.GradedBy.String()
+	}
+
+	return &chartdbapi.Submission{
+		Id:           submission.ID.String(),
+		AssignmentId: submission.AssignmentID.String(),
+		UserId:       submission.UserID.String(),
+		UserLogin:    submission.UserLogin,
+		DiagramId:    submission.DiagramID.String(),
+		Name:         submission.Name,
+		TablesCount:  submission.TablesCount,
+		AutoGrading:  gradingResultToPB(submission.AutoGrading),
+		AutoGradedAt: optionalTimestamp(submission.AutoGradedAt),
+		Grade:        submission.Grade,
+		Feedback:     submission.Feedback,
+		GradedBy:     gradedBy,
+		GradedAt:     optionalTimestamp(submission.GradedAt),
+		SubmittedAt:  timestamppb.New(submission.SubmittedAt),
+		CreatedAt:    timestamppb.New(submission.CreatedAt),
+		UpdatedAt:    timestamppb.New(submission.UpdatedAt),
+	}
+}
+
+func gradingRubricFromPB(rubric *chartdbapi.GradingRubric) *model.GradingRubric {
+	if rubric == nil {
+		return nil
+	}
+
+	return &model.GradingRubric{
+		TablesWeight:        rubric.TablesWeight,
+		ColumnsWeight:       rubric.ColumnsWeight,
+		KeysWeight:          rubric.KeysWeight,
+		RelationshipsWeight: rubric.RelationshipsWeight,
+		CardinalitiesWeight: rubric.CardinalitiesWeight,
+		PassScore:           rubric.PassScore,
+		BorderlineMargin:    rubric.BorderlineMargin,
+	}
+}
+
+func gradingRubricToPB(rubric *model.GradingRubric) *chartdbapi.GradingRubric {
+	if rubric == nil {
+		return nil
+	}
+
+	return &chartdbapi.GradingRubric{
+		TablesWeight:        rubric.TablesWeight,
+		ColumnsWeight:       rubric.ColumnsWeight,
+		KeysWeight:          rubric.KeysWeight,
+		RelationshipsWeight: rubric.RelationshipsWeight,
+		CardinalitiesWeight: rubric.CardinalitiesWeight,
+		PassScore:           rubric.PassScore,
+		BorderlineMargin:    rubric.BorderlineMargin,
+	}
+}
+
+func gradingResultToPB(result *model.GradingResult) *chartdbapi.GradingResult {
+	if result == nil {
+		return nil
+	}
+
+	criteria := make([]*chartdbapi.GradingCriterion, 0, len(result.Criteria))
+	for _, criterion := range result.Criteria {
+		criteria = append(criteria, &chartdbapi.GradingCriterion{
+			Name:     criterion.Name,
+			Weight:   criterion.Weight,
+			Score:    criterion.Score,
+			Matched:  criterion.Matched,
+			Expected: criterion.Expected,
+			Missing:  criterion.Missing,
+			Extra:    criterion.Extra,
+		})
+	}
+
+	return &chartdbapi.GradingResult{
+		Score:      result.Score,
+		Passed:     result.Passed,
+		Borderline: result.Borderline,
+		Criteria:   criteria,
+		Error:      result.Error,
+	}
+}
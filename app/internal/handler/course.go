@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/course"
+)
+
+type CourseHandler struct {
+	chartdbapi.UnimplementedCourseServiceServer
+
+	Logger        *slog.Logger
+	CourseService course.Service
+}
+
+func (h *CourseHandler) Get(ctx context.Context, req *chartdbapi.GetCourseRequest) (*chartdbapi.Course, error) {
+	courseModel, err := h.CourseService.GetCourse(ctx, &course.GetCourseParams{
+		ID: model.CourseID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get course: %w", err)
+	}
+
+	return courseToPB(courseModel), nil
+}
+
+func (h *CourseHandler) List(ctx context.Context, req *chartdbapi.ListCoursesRequest) (*chartdbapi.ListCoursesResponse, error) {
+	courses, err := h.CourseService.ListCourses(ctx, &course.ListCoursesParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list courses: %w", err)
+	}
+
+	result := make([]*chartdbapi.Course, 0, len(courses))
+	for _, courseModel := range courses {
+		result = append(result, courseToPB(courseModel))
+	}
+
+	return &chartdbapi.ListCoursesResponse{
+		Courses: result,
+	}, nil
+}
+
+func (h *CourseHandler) Create(ctx context.Context, req *chartdbapi.CreateCourseRequest) (*chartdbapi.Course, error) {
+	courseModel, err := h.CourseService.CreateCourse(ctx, &course.CreateCourseParams{
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create course: %w", err)
+	}
+
+	return courseToPB(courseModel), nil
+}
+
+func (h *CourseHandler) Delete(ctx context.Context, req *chartdbapi.DeleteCourseRequest) (*emptypb.Empty, error) {
+	_, err := h.CourseService.DeleteCourse(ctx, &course.DeleteCourseParams{
+		ID: model.CourseID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete course: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CourseHandler) CreateGroup(ctx context.Context, req *chartdbapi.CreateCourseGroupRequest) (*chartdbapi.CourseGroup, error) {
+	group, err := h.CourseService.CreateCourseGroup(ctx, &course.CreateCourseGroupParams{
+		CourseID: model.CourseID(req.CourseId),
+		Code:     req.Code,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create course group: %w", err)
+	}
+
+	return courseGroupToPB(group), nil
+}
+
+func (h *CourseHandler) ListGroups(ctx context.Context, req *chartdbapi.ListCourseGroupsRequest) (*chartdbapi.ListCourseGroupsResponse, error) {
+	groups, err := h.CourseService.ListCourseGroups(ctx, &course.ListCourseGroupsParams{
+		CourseID: model.CourseID(req.CourseId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list course groups: %w", err)
+	}
+
+	result := make([]*chartdbapi.CourseGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, courseGroupToPB(group))
+	}
+
+	return &chartdbapi.ListCourseGroupsResponse{
+		Groups: result,
+	}, nil
+}
+
+func (h *CourseHandler) DeleteGroup(ctx context.Context, req *chartdbapi.DeleteCourseGroupRequest) (*emptypb.Empty, error) {
+	_, err := h.CourseService.DeleteCourseGroup(ctx, &course.DeleteCourseGroupParams{
+		CourseID: model.CourseID(req.CourseId),
+		GroupID:  model.CourseGroupID(req.GroupId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("delete course group: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CourseHandler) AddTeacher(ctx context.Context, req *chartdbapi.AddCourseTeacherRequest) (*chartdbapi.CourseTeacher, error) {
+	teacher, err := h.CourseService.AddCourseTeacher(ctx, &course.AddCourseTeacherParams{
+		CourseID: model.CourseID(req.CourseId),
+		Login:    req.Login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("add course teacher: %w", err)
+	}
+
+	return courseTeacherToPB(teacher), nil
+}
+
+func (h *CourseHandler) RemoveTeacher(ctx context.Context, req *chartdbapi.RemoveCourseTeacherRequest) (*emptypb.Empty, error) {
+	_, err := h.CourseService.RemoveCourseTeacher(ctx, &course.RemoveCourseTeacherParams{
+		CourseID: model.CourseID(req.CourseId),
+		UserID:   model.UserID(req.UserId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remove course teacher: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CourseHandler) ListTeachers(ctx context.Context, req *chartdbapi.ListCourseTeachersRequest) (*chartdbapi.ListCourseTeachersResponse, error) {
+	teachers, err := h.CourseService.ListCourseTeachers(ctx, &course.ListCourseTeachersParams{
+		CourseID: model.CourseID(req.CourseId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list course teachers: %w", err)
+	}
+
+	result := make([]*chartdbapi.CourseTeacher, 0, len(teachers))
+	for _, teacher := range teachers {
+		result = append(result, courseTeacherToPB(teacher))
+	}
+
+	return &chartdbapi.ListCourseTeachersResponse{
+		Teachers: result,
+	}, nil
+}
+
+func (h *CourseHandler) Enroll(ctx context.Context, req *chartdbapi.EnrollStudentsRequest) (*chartdbapi.ListCourseEnrollmentsResponse, error) {
+	enrollments, err := h.CourseService.EnrollStudents(ctx, &course.EnrollStudentsParams{
+		CourseID: model.CourseID(req.CourseId),
+		GroupID:  optionalCourseGroupID(req.GroupId),
+		Logins:   req.Logins,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("enroll students: %w", err)
+	}
+
+	return &chartdbapi.ListCourseEnrollmentsResponse{
+		Enrollments: makeCourseEnrollmentList(enrollments),
+	}, nil
+}
+
+func (h *CourseHandler) ImportRoster(ctx context.Context, req *chartdbapi.ImportRosterRequest) (*chartdbapi.ImportRosterResponse, error) {
+	rows, err := h.CourseService.ImportRoster(ctx, &course.ImportRosterParams{
+		CourseID: model.CourseID(req.CourseId),
+		Content:  req.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("import roster: %w", err)
+	}
+
+	result := make([]*chartdbapi.RosterImportRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, rosterImportRowToPB(row))
+	}
+
+	return &chartdbapi.ImportRosterResponse{
+		Rows: result,
+	}, nil
+}
+
+func (h *CourseHandler) Unenroll(ctx context.Context, req *chartdbapi.UnenrollStudentRequest) (*emptypb.Empty, error) {
+	_, err := h.CourseService.UnenrollStudent(ctx, &course.UnenrollStudentParams{
+		CourseID: model.CourseID(req.CourseId),
+		UserID:   model.UserID(req.UserId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unenroll student: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *CourseHandler) ListEnrollments(ctx context.Context, req *chartdbapi.ListCourseEnrollmentsRequest) (*chartdbapi.ListCourseEnrollmentsResponse, error) {
+	enrollments, err := h.CourseService.ListCourseEnrollments(ctx, &course.ListCourseEnrollmentsParams{
+		CourseID: model.CourseID(req.CourseId),
+		GroupID:  optionalCourseGroupID(req.GroupId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list course enrollments: %w", err)
+	}
+
+	return &chartdbapi.ListCourseEnrollmentsResponse{
+		Enrollments: makeCourseEnrollmentList(enrollments),
+	}, nil
+}
+
+func (h *CourseHandler) Join(ctx context.Context, req *chartdbapi.JoinCourseRequest) (*chartdbapi.CourseEnrollment, error) {
+	enrollment, err := h.CourseService.JoinCourse(ctx, &course.JoinCourseParams{
+		JoinCode: req.JoinCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("join course: %w", err)
+	}
+
+	return courseEnrollmentToPB(enrollment), nil
+}
+
+func rosterImportRowToPB(row *model.RosterImportRow) *chartdbapi.RosterImportRow {
+	var status chartdbapi.RosterImportStatus
+	switch row.Status {
+	case model.RosterImportStatusInvited:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_INVITED
+	case model.RosterImportStatusEnrolled:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_ENROLLED
+	case model.RosterImportStatusInvalidEmail:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_INVALID_EMAIL
+	case model.RosterImportStatusGroupNotFound:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_GROUP_NOT_FOUND
+	case model.RosterImportStatusNotStudent:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_NOT_STUDENT
+	case model.RosterImportStatusDuplicateEmail:
+		status = chartdbapi.RosterImportStatus_ROSTER_IMPORT_STATUS_DUPLICATE_EMAIL
+	}
+
+	result := &chartdbapi.RosterImportRow{
+		Line:       int64(row.Line),
+		Email:      row.Email,
+		GroupCode:  row.GroupCode,
+		Status:     status,
+		EmailError: row.EmailError,
+	}
+	if row.Enrollment != nil {
+		result.Enrollment = courseEnrollmentToPB(row.Enrollment)
+	}
+
+	return result
+}
+
+func optionalCourseGroupID(groupID string) *model.CourseGroupID {
+	if groupID == "" {
+		return nil
+	}
+
+	id := model.CourseGroupID(groupID)
+	return &id
+}
+
+func courseToPB(courseModel *model.Course) *chartdbapi.Course {
+	return &chartdbapi.Course{
+		Id:          courseModel.ID.String(),
+		Name:        courseModel.Name,
+		Description: courseModel.Description,
+		JoinCode:    courseModel.JoinCode,
+		CreatedBy:   courseModel.CreatedBy.String(),
+		CreatedAt:   timestamppb.New(courseModel.CreatedAt),
+		UpdatedAt:   timestamppb.New(courseModel.UpdatedAt),
+	}
+}
+
+func courseGroupToPB(group *model.CourseGroup) *chartdbapi.CourseGroup {
+	return &chartdbapi.CourseGroup{
+		Id:        group.ID.String(),
+		CourseId:  group.CourseID.String(),
+		Code:      group.Code,
+		JoinCode:  group.JoinCode,
+		CreatedAt: timestamppb.New(group.CreatedAt),
+		UpdatedAt: timestamppb.New(group.UpdatedAt),
+	}
+}
+
+func courseTeacherToPB(teacher *model.CourseTeacher) *chartdbapi.CourseTeacher {
+	return &chartdbapi.CourseTeacher{
+		CourseId:  teacher.CourseID.String(),
+		UserId:    teacher.UserID.String(),
+		UserLogin: teacher.UserLogin,
+		CreatedAt: timestamppb.New(teacher.CreatedAt),
+	}
+}
+
+func courseEnrollmentToPB(enrollment *model.CourseEnrollment) *chartdbapi.CourseEnrollment {
+	var groupID string
+	if enrollment.GroupID != nil {
+		groupID = enrollment.GroupID.String()
+	}
+
+	return &chartdbapi.CourseEnrollment{
+		CourseId:  enrollment.CourseID.String(),
+		UserId:    enrollment.UserID.String(),
+		UserLogin: enrollment.UserLogin,
+		GroupId:   groupID,
+		CreatedAt: timestamppb.New(enrollment.CreatedAt),
+		UpdatedAt: timestamppb.New(enrollment.UpdatedAt),
+	}
+}
+
+func makeCourseEnrollmentList(enrollments []*model.CourseEnrollment) []*chartdbapi.CourseEnrollment {
+	result := make([]*chartdbapi.CourseEnrollment, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		result = append(result, courseEnrollmentToPB(enrollment))
+	}
+	return result
+}
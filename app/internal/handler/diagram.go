@@ -6,17 +6,23 @@ import (
 	"log/slog"
 	"strings"
 
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
 	"github.com/IvLaptev/chartdb-back/internal/auth"
 	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/render"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
 	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 )
 
-var diagramAllowedTermKeys = map[model.TermKey]struct{}{}
+var diagramAllowedTermKeys = map[model.TermKey]struct{}{
+	model.TermKeyTag:    {},
+	model.TermKeyUserID: {},
+}
 
 type DiagramHandler struct {
 	chartdbapi.UnimplementedDiagramServiceServer
@@ -85,6 +91,8 @@ func (h *DiagramHandler) Update(ctx context.Context, req *chartdbapi.UpdateDiagr
 		Content:     ApplyFieldOptional(utils.NewSecret(req.Fields.Content), "content", paths),
 		Name:        ApplyFieldOptional(req.Fields.Name, "name", paths),
 		TablesCount: ApplyFieldOptional(req.Fields.TablesCount, "tables_count", paths),
+
+		EditLockHolderID: req.EditLockHolderId,
 	}
 
 	diagramModel, err := h.DiagramService.PatchDiagram(ctx, patchDiagramParams)
@@ -106,7 +114,156 @@ func (h *DiagramHandler) Delete(ctx context.Context, req *chartdbapi.DeleteDiagr
 	return &emptypb.Empty{}, nil
 }
 
+func (h *DiagramHandler) SetTags(ctx context.Context, req *chartdbapi.SetDiagramTagsRequest) (*chartdbapi.DiagramMetadata, error) {
+	diagramModel, err := h.DiagramService.SetDiagramTags(ctx, &diagram.SetDiagramTagsParams{
+		ID:   model.DiagramID(req.Id),
+		Tags: req.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set diagram tags: %w", err)
+	}
+
+	return diagramMetadataToPB(diagramModel), nil
+}
+
+func (h *DiagramHandler) RemoveTags(ctx context.Context, req *chartdbapi.RemoveDiagramTagsRequest) (*chartdbapi.DiagramMetadata, error) {
+	diagramModel, err := h.DiagramService.RemoveDiagramTags(ctx, &diagram.RemoveDiagramTagsParams{
+		ID:   model.DiagramID(req.Id),
+		Tags: req.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remove diagram tags: %w", err)
+	}
+
+	return diagramMetadataToPB(diagramModel), nil
+}
+
+func (h *DiagramHandler) Publish(ctx context.Context, req *chartdbapi.PublishDiagramRequest) (*chartdbapi.DiagramMetadata, error) {
+	diagramModel, err := h.DiagramService.PublishDiagram(ctx, &diagram.PublishDiagramParams{
+		ID: model.DiagramID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("publish diagram: %w", err)
+	}
+
+	return diagramMetadataToPB(diagramModel), nil
+}
+
+func (h *DiagramHandler) Unpublish(ctx context.Context, req *chartdbapi.UnpublishDiagramRequest) (*chartdbapi.DiagramMetadata, error) {
+	diagramModel, err := h.DiagramService.UnpublishDiagram(ctx, &diagram.UnpublishDiagramParams{
+		ID: model.DiagramID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unpublish diagram: %w", err)
+	}
+
+	return diagramMetadataToPB(diagramModel), nil
+}
+
+func (h *DiagramHandler) GetPublic(ctx context.Context, req *chartdbapi.GetPublicDiagramRequest) (*chartdbapi.Diagram, error) {
+	diagramModel, err := h.DiagramService.GetPublicDiagram(ctx, &diagram.GetPublicDiagramParams{
+		PublicSlug: req.Slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get public diagram: %w", err)
+	}
+
+	diagramPB, err := diagramToPB(diagramModel)
+	if err != nil {
+		return nil, err
+	}
+	// Owner is not exposed to anonymous readers
+	diagramPB.Metadata.UserId = ""
+
+	return diagramPB, nil
+}
+
+func (h *DiagramHandler) GetPublicEmbed(ctx context.Context, req *chartdbapi.GetPublicDiagramRequest) (*httpbody.HttpBody, error) {
+	diagramModel, err := h.DiagramService.GetPublicDiagram(ctx, &diagram.GetPublicDiagramParams{
+		PublicSlug: req.Slug,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get public diagram: %w", err)
+	}
+
+	content := ""
+	if diagramModel.Content.Value != nil {
+		content = *diagramModel.Content.Value
+	}
+
+	diagramSchema, err := schema.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse diagram schema: %w", err)
+	}
+
+	page, err := render.EmbedPage(diagramModel.Name, diagramSchema)
+	if err != nil {
+		return nil, fmt.Errorf("render embed page: %w", err)
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: "text/html; charset=utf-8",
+		Data:        page,
+	}, nil
+}
+
+func (h *DiagramHandler) AcquireEditLock(ctx context.Context, req *chartdbapi.AcquireEditLockRequest) (*chartdbapi.DiagramEditLock, error) {
+	editLock, err := h.DiagramService.AcquireEditLock(ctx, &diagram.AcquireEditLockParams{
+		DiagramID: model.DiagramID(req.Id),
+		HolderID:  req.HolderId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("acquire edit lock: %w", err)
+	}
+
+	return diagramEditLockToPB(editLock), nil
+}
+
+func (h *DiagramHandler) RenewEditLock(ctx context.Context, req *chartdbapi.RenewEditLockRequest) (*chartdbapi.DiagramEditLock, error) {
+	editLock, err := h.DiagramService.RenewEditLock(ctx, &diagram.RenewEditLockParams{
+		DiagramID: model.DiagramID(req.Id),
+		HolderID:  req.HolderId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("renew edit lock: %w", err)
+	}
+
+	return diagramEditLockToPB(editLock), nil
+}
+
+func (h *DiagramHandler) ReleaseEditLock(ctx context.Context, req *chartdbapi.ReleaseEditLockRequest) (*emptypb.Empty, error) {
+	_, err := h.DiagramService.ReleaseEditLock(ctx, &diagram.ReleaseEditLockParams{
+		DiagramID: model.DiagramID(req.Id),
+		HolderID:  req.HolderId,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("release edit lock: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func diagramEditLockToPB(editLock *model.DiagramEditLock) *chartdbapi.DiagramEditLock {
+	return &chartdbapi.DiagramEditLock{
+		DiagramId: editLock.DiagramID.String(),
+		UserId:    editLock.UserID.String(),
+		HolderId:  editLock.HolderID,
+		CreatedAt: timestamppb.New(editLock.CreatedAt),
+		ExpiresAt: timestamppb.New(editLock.ExpiresAt),
+	}
+}
+
 func diagramMetadataToPB(diagramModel *model.Diagram) *chartdbapi.DiagramMetadata {
+	var publishedAt *timestamppb.Timestamp
+	if diagramModel.PublishedAt != nil {
+		publishedAt = timestamppb.New(*diagramModel.PublishedAt)
+	}
+
+	var publicSlug string
+	if diagramModel.PublicSlug != nil {
+		publicSlug = *diagramModel.PublicSlug
+	}
+
 	return &chartdbapi.DiagramMetadata{
 		Id:              diagramModel.ID.String(),
 		UserId:          diagramModel.UserID.String(),
@@ -114,6 +271,9 @@ func diagramMetadataToPB(diagramModel *model.Diagram) *chartdbapi.DiagramMetadat
 		Code:            diagramModel.Code,
 		Name:            diagramModel.Name,
 		TablesCount:     diagramModel.TablesCount,
+		Tags:            diagramModel.Tags,
+		PublicSlug:      publicSlug,
+		PublishedAt:     publishedAt,
 		CreatedAt:       timestamppb.New(diagramModel.CreatedAt),
 		UpdatedAt:       timestamppb.New(diagramModel.UpdatedAt),
 	}
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+)
+
+func (h *AssignmentHandler) ListPeerReviews(ctx context.Context, req *chartdbapi.ListPeerReviewsRequest) (*chartdbapi.ListPeerReviewsResponse, error) {
+	peerReviews, err := h.AssignmentService.ListPeerReviews(ctx, &assignment.ListPeerReviewsParams{
+		AssignmentID: model.AssignmentID(req.AssignmentId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list peer reviews: %w", err)
+	}
+
+	reviews := make([]*chartdbapi.PeerReview, 0, len(peerReviews))
+	for _, peerReview := range peerReviews {
+		reviews = append(reviews, peerReviewToPB(peerReview))
+	}
+
+	return &chartdbapi.ListPeerReviewsResponse{
+		Reviews: reviews,
+	}, nil
+}
+
+func (h *AssignmentHandler) GetPeerReview(ctx context.Context, req *chartdbapi.GetPeerReviewRequest) (*chartdbapi.PeerReviewWithContent, error) {
+	peerReview, err := h.AssignmentService.GetPeerReview(ctx, &assignment.GetPeerReviewParams{
+		ID: model.PeerReviewID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get peer review: %w", err)
+	}
+
+	var content string
+	if peerReview.Content.Value != nil {
+		content = *peerReview.Content.Value
+	}
+
+	return &chartdbapi.PeerReviewWithContent{
+		Review:  peerReviewToPB(peerReview),
+		Content: content,
+	}, nil
+}
+
+func (h *AssignmentHandler) SubmitPeerReview(ctx context.Context, req *chartdbapi.SubmitPeerReviewRequest) (*chartdbapi.PeerReview, error) {
+	peerReview, err := h.AssignmentService.SubmitPeerReview(ctx, &assignment.SubmitPeerReviewParams{
+		ID:      model.PeerReviewID(req.Id),
+		Scores:  req.Scores,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submit peer review: %w", err)
+	}
+
+	return peerReviewToPB(peerReview), nil
+}
+
+func (h *AssignmentHandler) ModeratePeerReview(ctx context.Context, req *chartdbapi.ModeratePeerReviewRequest) (*chartdbapi.PeerReview, error) {
+	peerReview, err := h.AssignmentService.ModeratePeerReview(ctx, &assignment.ModeratePeerReviewParams{
+		ID:     model.PeerReviewID(req.Id),
+		Hidden: req.Hidden,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("moderate peer review: %w", err)
+	}
+
+	return peerReviewToPB(peerReview), nil
+}
+
+func (h *AssignmentHandler) GetPeerReviewSummary(ctx context.Context, req *chartdbapi.GetPeerReviewSummaryRequest) (*chartdbapi.PeerReviewSummary, error) {
+	summary, err := h.AssignmentService.GetPeerReviewSummary(ctx, &assignment.GetPeerReviewSummaryParams{
+		SubmissionID: model.SubmissionID(req.SubmissionId),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get peer review summary: %w", err)
+	}
+
+	return &chartdbapi.PeerReviewSummary{
+		SubmissionId:  summary.SubmissionID.String(),
+		Criteria:      summary.Criteria,
+		ReviewsCount:  summary.ReviewsCount,
+		AverageScores: summary.AverageScores,
+		Comments:      summary.Comments,
+	}, nil
+}
+
+func peerReviewToPB(peerReview *model.PeerReview) *chartdbapi.PeerReview {
+	var hiddenBy string
+	if peerReview.HiddenBy != nil {
+		hiddenBy = peerReview.HiddenBy.String()
+	}
+
+	return &chartdbapi.PeerReview{
+		Id:           peerReview.ID.String(),
+		AssignmentId: peerReview.AssignmentID.String(),
+		SubmissionId: peerReview.SubmissionID.String(),
+		ReviewerId:   peerReview.ReviewerID.String(),
+		Scores:       peerReview.Scores,
+		Comment:      peerReview.Comment,
+		SubmittedAt:  optionalTimestamp(peerReview.SubmittedAt),
+		HiddenBy:     hiddenBy,
+		HiddenAt:     optionalTimestamp(peerReview.HiddenAt),
+		CreatedAt:    timestamppb.New(peerReview.CreatedAt),
+		UpdatedAt:    timestamppb.New(peerReview.UpdatedAt),
+	}
+}
+
+func peerReviewSettingsFromPB(settings *chartdbapi.PeerReviewSettings) *model.PeerReviewSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &model.PeerReviewSettings{
+		ReviewersCount: settings.ReviewersCount,
+		Criteria:       settings.Criteria,
+	}
+}
+
+func peerReviewSettingsToPB(settings *model.PeerReviewSettings) *chartdbapi.PeerReviewSettings {
+	if settings == nil {
+		return nil
+	}
+
+	return &chartdbapi.PeerReviewSettings{
+		ReviewersCount: settings.ReviewersCount,
+		Criteria:       settings.Criteria,
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/assignment"
+)
+
+func (h *AssignmentHandler) CheckPlagiarism(ctx context.Context, req *chartdbapi.CheckPlagiarismRequest) (*chartdbapi.PlagiarismCheck, error) {
+	check, err := h.AssignmentService.RequestPlagiarismCheck(ctx, &assignment.RequestPlagiarismCheckParams{
+		AssignmentID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request plagiarism check: %w", err)
+	}
+
+	return plagiarismCheckToPB(check), nil
+}
+
+func (h *AssignmentHandler) GetPlagiarismReport(ctx context.Context, req *chartdbapi.GetPlagiarismReportRequest) (*chartdbapi.PlagiarismReport, error) {
+	report, err := h.AssignmentService.GetPlagiarismReport(ctx, &assignment.GetPlagiarismReportParams{
+		AssignmentID: model.AssignmentID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get plagiarism report: %w", err)
+	}
+
+	pairs := make([]*chartdbapi.PlagiarismPair, 0, len(report.Pairs))
+	for _, pair := range report.Pairs {
+		pairs = append(pairs, plagiarismPairToPB(pair))
+	}
+
+	return &chartdbapi.PlagiarismReport{
+		Check: plagiarismCheckToPB(report.Check),
+		Pairs: pairs,
+	}, nil
+}
+
+func plagiarismCheckToPB(check *model.PlagiarismCheck) *chartdbapi.PlagiarismCheck {
+	if check == nil {
+		return nil
+	}
+
+	var status chartdbapi.PlagiarismCheckStatus
+	switch check.Status {
+	case model.PlagiarismCheckStatusPending:
+		status = chartdbapi.PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_PENDING
+	case model.PlagiarismCheckStatusDone:
+		status = chartdbapi.PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_DONE
+	case model.PlagiarismCheckStatusFailed:
+		status = chartdbapi.PlagiarismCheckStatus_PLAGIARISM_CHECK_STATUS_FAILED
+	}
+
+	return &chartdbapi.PlagiarismCheck{
+		AssignmentId: check.AssignmentID.String(),
+		Status:       status,
+		Error:        check.Error,
+		RequestedAt:  timestamppb.New(check.RequestedAt),
+		CheckedAt:    optionalTimestamp(check.CheckedAt),
+		CreatedAt:    timestamppb.New(check.CreatedAt),
+		UpdatedAt:    timestamppb.New(check.UpdatedAt),
+	}
+}
+
+func plagiarismPairToPB(pair *model.PlagiarismPair) *chartdbapi.PlagiarismPair {
+	return &chartdbapi.PlagiarismPair{
+		Id:                 pair.ID.String(),
+		AssignmentId:       pair.AssignmentID.String(),
+		FirstSubmissionId:  pair.FirstSubmissionID.String(),
+		FirstUserId:        pair.FirstUserID.String(),
+		FirstUserLogin:     pair.FirstUserLogin,
+		SecondSubmissionId: pair.SecondSubmissionID.String(),
+		SecondUserId:       pair.SecondUserID.String(),
+		SecondUserLogin:    pair.SecondUserLogin,
+		Score:              pair.Score,
+		NamesScore:         pair.NamesScore,
+		StructureScore:     pair.StructureScore,
+		LayoutScore:        pair.LayoutScore,
+		CreatedAt:          timestamppb.New(pair.CreatedAt),
+	}
+}
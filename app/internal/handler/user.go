@@ -6,16 +6,14 @@ import (
 	"log/slog"
 
 	chartdbapi "github.com/IvLaptev/chartdb-back/api/chartdb/v1"
+	"github.com/IvLaptev/chartdb-back/internal/auth"
 	"github.com/IvLaptev/chartdb-back/internal/model"
 	"github.com/IvLaptev/chartdb-back/internal/service/user"
-	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
-	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-const minPasswordLength = 8
-
 type UserHandler struct {
 	Logger      *slog.Logger
 	UserService user.Service
@@ -35,17 +33,11 @@ func (h *UserHandler) Get(ctx context.Context, req *chartdbapi.GetUserRequest) (
 }
 
 func (h *UserHandler) Create(ctx context.Context, req *chartdbapi.CreateUserRequest) (*chartdbapi.User, error) {
-	var passwordHash *string
-	if req.Password != "" {
-		if len(req.Password) < minPasswordLength {
-			return nil, xerrors.WrapInvalidArgument(fmt.Errorf("password must be at least %d characters long", minPasswordLength))
-		}
-		passwordHash = ptr.To(utils.SHA1(req.Password))
-	}
+	password := optionalPassword(req.Password)
 
 	userModel, err := h.UserService.CreateUser(ctx, &user.CreateUserParams{
-		Login:        req.Login,
-		PasswordHash: utils.NewSecret(passwordHash),
+		Login:    req.Login,
+		Password: utils.NewSecret(password),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
@@ -55,23 +47,264 @@ func (h *UserHandler) Create(ctx context.Context, req *chartdbapi.CreateUserRequ
 }
 
 func (h *UserHandler) Login(ctx context.Context, req *chartdbapi.LoginUserRequest) (*chartdbapi.LoginUserResponse, error) {
+	userAgent, ipAddress := clientInfo(ctx)
 	token, err := h.UserService.LoginUser(ctx, &user.LoginUserParams{
-		Login:        req.Login,
-		PasswordHash: utils.NewSecret(utils.SHA1(req.Password)),
+		Login:     req.Login,
+		Password:  utils.NewSecret(req.Password),
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("login user: %w", err)
 	}
 
-	return &chartdbapi.LoginUserResponse{
-		Token:  token.Value,
-		UserId: token.UserID.String(),
+	return userTokenToPB(token), nil
+}
+
+func (h *UserHandler) RefreshToken(ctx context.Context, req *chartdbapi.RefreshTokenRequest) (*chartdbapi.LoginUserResponse, error) {
+	token, err := h.UserService.RefreshToken(ctx, &user.RefreshTokenParams{
+		RefreshToken: utils.NewSecret(req.RefreshToken),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+
+	return userTokenToPB(token), nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, req *chartdbapi.LogoutRequest) (*emptypb.Empty, error) {
+	err := h.UserService.Logout(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("logout: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) ListSessions(ctx context.Context, req *chartdbapi.ListSessionsRequest) (*chartdbapi.ListSessionsResponse, error) {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	sessions, err := h.UserService.ListSessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+
+	result := make([]*chartdbapi.Session, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &chartdbapi.Session{
+			Id:          session.ID.String(),
+			UserAgent:   session.UserAgent,
+			IpAddress:   session.IPAddress,
+			Current:     session.ID == subject.SessionID,
+			RefreshedAt: timestamppb.New(session.RefreshedAt),
+			ExpiresAt:   timestamppb.New(session.ExpiresAt),
+			CreatedAt:   timestamppb.New(session.CreatedAt),
+		})
+	}
+
+	return &chartdbapi.ListSessionsResponse{
+		Sessions: result,
+	}, nil
+}
+
+func (h *UserHandler) RevokeSession(ctx context.Context, req *chartdbapi.RevokeSessionRequest) (*emptypb.Empty, error) {
+	err := h.UserService.RevokeSession(ctx, &user.RevokeSessionParams{
+		ID: model.SessionID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revoke session: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) CreatePersonalAccessToken(ctx context.Context, req *chartdbapi.CreatePersonalAccessTokenRequest) (*chartdbapi.CreatePersonalAccessTokenResponse, error) {
+	token, err := h.UserService.CreatePersonalAccessToken(ctx, &user.CreatePersonalAccessTokenParams{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt.AsTime(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create personal access token: %w", err)
+	}
+
+	return &chartdbapi.CreatePersonalAccessTokenResponse{
+		PersonalAccessToken: personalAccessTokenToPB(token),
+		Token:               token.Value.Value,
+	}, nil
+}
+
+func (h *UserHandler) ListPersonalAccessTokens(ctx context.Context, req *chartdbapi.ListPersonalAccessTokensRequest) (*chartdbapi.ListPersonalAccessTokensResponse, error) {
+	tokens, err := h.UserService.ListPersonalAccessTokens(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list personal access tokens: %w", err)
+	}
+
+	result := make([]*chartdbapi.PersonalAccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, personalAccessTokenToPB(token))
+	}
+
+	return &chartdbapi.ListPersonalAccessTokensResponse{
+		PersonalAccessTokens: result,
+	}, nil
+}
+
+func (h *UserHandler) RevokePersonalAccessToken(ctx context.Context, req *chartdbapi.RevokePersonalAccessTokenRequest) (*emptypb.Empty, error) {
+	err := h.UserService.RevokePersonalAccessToken(ctx, &user.RevokePersonalAccessTokenParams{
+		ID: model.PersonalAccessTokenID(req.Id),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revoke personal access token: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *chartdbapi.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	err := h.UserService.RequestPasswordReset(ctx, &user.RequestPasswordResetParams{
+		Login: req.Login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request password reset: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) ResetPassword(ctx context.Context, req *chartdbapi.ResetPasswordRequest) (*emptypb.Empty, error) {
+	password := optionalPassword(req.Password)
+
+	err := h.UserService.ResetPassword(ctx, &user.ResetPasswordParams{
+		PasswordResetID: utils.NewSecret(model.PasswordResetID(req.Rid)),
+		Password:        utils.NewSecret(password),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reset password: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) ChangePassword(ctx context.Context, req *chartdbapi.ChangePasswordRequest) (*emptypb.Empty, error) {
+	password := optionalPassword(req.NewPassword)
+
+	err := h.UserService.ChangePassword(ctx, &user.ChangePasswordParams{
+		CurrentPassword: utils.NewSecret(req.CurrentPassword),
+		NewPassword:     utils.NewSecret(password),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("change password: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) ChangeEmail(ctx context.Context, req *chartdbapi.ChangeEmailRequest) (*emptypb.Empty, error) {
+	err := h.UserService.ChangeEmail(ctx, &user.ChangeEmailParams{
+		Login: req.Login,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("change email: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) StartOidcLogin(ctx context.Context, req *chartdbapi.StartOidcLoginRequest) (*chartdbapi.StartOidcLoginResponse, error) {
+	result, err := h.UserService.StartOIDCLogin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("start oidc login: %w", err)
+	}
+
+	return &chartdbapi.StartOidcLoginResponse{
+		AuthorizationUrl: result.AuthorizationURL,
+		State:            result.State.String(),
+	}, nil
+}
+
+func (h *UserHandler) FinishOidcLogin(ctx context.Context, req *chartdbapi.FinishOidcLoginRequest) (*chartdbapi.LoginUserResponse, error) {
+	userAgent, ipAddress := clientInfo(ctx)
+	token, err := h.UserService.FinishOIDCLogin(ctx, &user.FinishOIDCLoginParams{
+		State:     model.OIDCLoginStateID(req.State),
+		Code:      utils.NewSecret(req.Code),
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("finish oidc login: %w", err)
+	}
+
+	return userTokenToPB(token), nil
+}
+
+func (h *UserHandler) VerifyLoginChallenge(ctx context.Context, req *chartdbapi.VerifyLoginChallengeRequest) (*chartdbapi.LoginUserResponse, error) {
+	token, err := h.UserService.VerifyLoginChallenge(ctx, &user.VerifyLoginChallengeParams{
+		ChallengeID: model.LoginChallengeID(req.ChallengeId),
+		Code:        utils.NewSecret(req.Code),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify login challenge: %w", err)
+	}
+
+	return userTokenToPB(token), nil
+}
+
+func (h *UserHandler) EnrollTotp(ctx context.Context, req *chartdbapi.EnrollTotpRequest) (*chartdbapi.TotpEnrollment, error) {
+	enrollment, err := h.UserService.EnrollTOTP(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("enroll totp: %w", err)
+	}
+
+	return totpEnrollmentToPB(enrollment), nil
+}
+
+func (h *UserHandler) ConfirmTotp(ctx context.Context, req *chartdbapi.ConfirmTotpRequest) (*chartdbapi.RecoveryCodesResponse, error) {
+	recoveryCodes, err := h.UserService.ConfirmTOTP(ctx, &user.ConfirmTOTPParams{
+		Code: utils.NewSecret(req.Code),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("confirm totp: %w", err)
+	}
+
+	return &chartdbapi.RecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *UserHandler) DisableTotp(ctx context.Context, req *chartdbapi.DisableTotpRequest) (*emptypb.Empty, error) {
+	err := h.UserService.DisableTOTP(ctx, &user.DisableTOTPParams{
+		Code: utils.NewSecret(req.Code),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("disable totp: %w", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *UserHandler) RegenerateRecoveryCodes(ctx context.Context, req *chartdbapi.RegenerateRecoveryCodesRequest) (*chartdbapi.RecoveryCodesResponse, error) {
+	recoveryCodes, err := h.UserService.RegenerateRecoveryCodes(ctx, &user.RegenerateRecoveryCodesParams{
+		Code: utils.NewSecret(req.Code),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("regenerate recovery codes: %w", err)
+	}
+
+	return &chartdbapi.RecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
 	}, nil
 }
 
 func (h *UserHandler) Confirm(ctx context.Context, req *chartdbapi.ConfirmUserRequest) (*chartdbapi.User, error) {
+	password := optionalPassword(req.Password)
+
 	userModel, err := h.UserService.ConfirmUser(ctx, &user.ConfirmUserParams{
 		UserConfirmationID: model.UserConfirmationID(req.Cid),
+		Password:           utils.NewSecret(password),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("confirm user: %w", err)
@@ -80,24 +313,207 @@ func (h *UserHandler) Confirm(ctx context.Context, req *chartdbapi.ConfirmUserRe
 	return userToPB(userModel), nil
 }
 
-func userToPB(user *model.User) *chartdbapi.User {
-	var userType chartdbapi.UserType
-	switch user.Type {
+func (h *UserHandler) Upgrade(ctx context.Context, req *chartdbapi.UpgradeUserRequest) (*chartdbapi.User, error) {
+	password := optionalPassword(req.Password)
+
+	userModel, err := h.UserService.UpgradeGuest(ctx, &user.UpgradeGuestParams{
+		Login:    req.Login,
+		Password: utils.NewSecret(password),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upgrade guest: %w", err)
+	}
+
+	return userToPB(userModel), nil
+}
+
+func (h *UserHandler) RequestRole(ctx context.Context, req *chartdbapi.RequestRoleRequest) (*chartdbapi.RoleRequest, error) {
+	roleRequest, err := h.UserService.RequestRole(ctx, &user.RequestRoleParams{
+		Type:   userTypeFromPB(req.Type),
+		Reason: req.Reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("request role: %w", err)
+	}
+
+	return roleRequestToPB(roleRequest), nil
+}
+
+func (h *UserHandler) ListRoleRequests(ctx context.Context, req *chartdbapi.ListRoleRequestsRequest) (*chartdbapi.ListRoleRequestsResponse, error) {
+	var status model.RoleRequestStatus
+	switch req.Status {
+	case chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_PENDING:
+		status = model.RoleRequestStatusPending
+	case chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_APPROVED:
+		status = model.RoleRequestStatusApproved
+	case chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_REJECTED:
+		status = model.RoleRequestStatusRejected
+	}
+
+	roleRequests, err := h.UserService.ListRoleRequests(ctx, &user.ListRoleRequestsParams{
+		Status: status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list role requests: %w", err)
+	}
+
+	result := make([]*chartdbapi.RoleRequest, 0, len(roleRequests))
+	for _, roleRequest := range roleRequests {
+		result = append(result, roleRequestToPB(roleRequest))
+	}
+
+	return &chartdbapi.ListRoleRequestsResponse{
+		RoleRequests: result,
+	}, nil
+}
+
+func (h *UserHandler) DecideRoleRequest(ctx context.Context, req *chartdbapi.DecideRoleRequestRequest) (*chartdbapi.RoleRequest, error) {
+	roleRequest, err := h.UserService.DecideRoleRequest(ctx, &user.DecideRoleRequestParams{
+		ID:       model.RoleRequestID(req.Id),
+		Approved: req.Approved,
+		Comment:  req.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decide role request: %w", err)
+	}
+
+	return roleRequestToPB(roleRequest), nil
+}
+
+// optionalPassword returns nil for empty passwords, the registration policy is checked by the service
+func optionalPassword(password string) *string {
+	if password == "" {
+		return nil
+	}
+
+	return &password
+}
+
+func userTypeToPB(userType model.UserType) chartdbapi.UserType {
+	switch userType {
 	case model.UserTypeAdmin:
-		userType = chartdbapi.UserType_USER_TYPE_ADMIN
+		return chartdbapi.UserType_USER_TYPE_ADMIN
 	case model.UserTypeTeacher:
-		userType = chartdbapi.UserType_USER_TYPE_TEACHER
+		return chartdbapi.UserType_USER_TYPE_TEACHER
 	case model.UserTypeStudent:
-		userType = chartdbapi.UserType_USER_TYPE_STUDENT
+		return chartdbapi.UserType_USER_TYPE_STUDENT
 	case model.UserTypeGuest:
-		userType = chartdbapi.UserType_USER_TYPE_GUEST
+		return chartdbapi.UserType_USER_TYPE_GUEST
+	default:
+		return chartdbapi.UserType_USER_TYPE_UNSPECIFIED
+	}
+}
+
+func userTypeFromPB(userType chartdbapi.UserType) model.UserType {
+	switch userType {
+	case chartdbapi.UserType_USER_TYPE_ADMIN:
+		return model.UserTypeAdmin
+	case chartdbapi.UserType_USER_TYPE_TEACHER:
+		return model.UserTypeTeacher
+	case chartdbapi.UserType_USER_TYPE_STUDENT:
+		return model.UserTypeStudent
+	case chartdbapi.UserType_USER_TYPE_GUEST:
+		return model.UserTypeGuest
+	default:
+		return model.UserTypeUnspecified
+	}
+}
+
+func userTokenToPB(token *model.UserToken) *chartdbapi.LoginUserResponse {
+	if token.Challenge != nil {
+		return &chartdbapi.LoginUserResponse{
+			UserId:    token.UserID.String(),
+			Challenge: loginChallengeToPB(token.Challenge),
+		}
+	}
+
+	return &chartdbapi.LoginUserResponse{
+		Token:         token.Value,
+		UserId:        token.UserID.String(),
+		ExpiresAt:     timestamppb.New(token.ExpiresAt),
+		RefreshToken:  token.RefreshToken.Value,
+		SessionId:     token.SessionID.String(),
+		RecoveryCodes: token.RecoveryCodes.Value,
+	}
+}
+
+func loginChallengeToPB(challenge *model.LoginChallenge) *chartdbapi.LoginChallenge {
+	result := &chartdbapi.LoginChallenge{
+		Id:        challenge.ID.String(),
+		ExpiresAt: timestamppb.New(challenge.ExpiresAt),
+	}
+	if challenge.Enrollment != nil {
+		result.Enrollment = totpEnrollmentToPB(challenge.Enrollment)
+	}
+
+	return result
+}
+
+func totpEnrollmentToPB(enrollment *model.TOTPEnrollment) *chartdbapi.TotpEnrollment {
+	return &chartdbapi.TotpEnrollment{
+		Secret:          enrollment.Secret.Value,
+		ProvisioningUri: enrollment.ProvisioningURI.Value,
 	}
+}
 
+func userToPB(user *model.User) *chartdbapi.User {
 	return &chartdbapi.User{
 		Id:        user.ID.String(),
 		Login:     user.Login,
-		Type:      userType,
+		Type:      userTypeToPB(user.Type),
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
 	}
 }
+
+func roleRequestToPB(roleRequest *model.RoleRequest) *chartdbapi.RoleRequest {
+	var status chartdbapi.RoleRequestStatus
+	switch roleRequest.Status {
+	case model.RoleRequestStatusPending:
+		status = chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_PENDING
+	case model.RoleRequestStatusApproved:
+		status = chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_APPROVED
+	case model.RoleRequestStatusRejected:
+		status = chartdbapi.RoleRequestStatus_ROLE_REQUEST_STATUS_REJECTED
+	}
+
+	result := &chartdbapi.RoleRequest{
+		Id:              roleRequest.ID.String(),
+		UserId:          roleRequest.UserID.String(),
+		UserLogin:       roleRequest.UserLogin,
+		Type:            userTypeToPB(roleRequest.Type),
+		Reason:          roleRequest.Reason,
+		Status:          status,
+		DecisionComment: roleRequest.DecisionComment,
+		CreatedAt:       timestamppb.New(roleRequest.CreatedAt),
+		UpdatedAt:       timestamppb.New(roleRequest.UpdatedAt),
+	}
+	if roleRequest.DecidedBy != nil {
+		result.DecidedBy = roleRequest.DecidedBy.String()
+	}
+	if roleRequest.DecidedAt != nil {
+		result.DecidedAt = timestamppb.New(*roleRequest.DecidedAt)
+	}
+
+	return result
+}
+
+func personalAccessTokenToPB(token *model.PersonalAccessToken) *chartdbapi.PersonalAccessToken {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	result := &chartdbapi.PersonalAccessToken{
+		Id:        token.ID.String(),
+		Name:      token.Name,
+		Scopes:    scopes,
+		ExpiresAt: timestamppb.New(token.ExpiresAt),
+		CreatedAt: timestamppb.New(token.CreatedAt),
+	}
+	if token.LastUsedAt != nil {
+		result.LastUsedAt = timestamppb.New(*token.LastUsedAt)
+	}
+
+	return result
+}
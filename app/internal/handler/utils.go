@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/IvLaptev/chartdb-back/internal/model"
 	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
@@ -158,3 +160,21 @@ func capitalize(s string) string {
 	}
 	return strings.ToTitle(s[:1]) + s[1:]
 }
+
+// clientInfo returns the user agent and the address of the client forwarded by the gateway
+func clientInfo(ctx context.Context) (string, string) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", ""
+	}
+
+	var userAgent, ipAddress string
+	if values := md.Get("grpcgateway-user-agent"); len(values) > 0 {
+		userAgent = values[0]
+	}
+	if values := md.Get("x-forwarded-for"); len(values) > 0 {
+		ipAddress = strings.TrimSpace(strings.Split(values[0], ",")[0])
+	}
+
+	return userAgent, ipAddress
+}
@@ -0,0 +1,86 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+)
+
+type Rule string
+
+const (
+	RuleNoPrimaryKey       Rule = "no_primary_key"
+	RuleEmptyTable         Rule = "empty_table"
+	RuleDuplicateTable     Rule = "duplicate_table"
+	RuleDuplicateColumn    Rule = "duplicate_column"
+	RuleIsolatedTable      Rule = "isolated_table"
+	RuleBrokenRelationship Rule = "broken_relationship"
+)
+
+// Finding is a design problem of the schema, Table and Field are empty if the problem is not bound to them
+type Finding struct {
+	Rule  Rule
+	Table string
+	Field string
+}
+
+// Lint checks the schema for common design mistakes of students. Views are not required to have keys or relationships.
+func Lint(s *schema.Schema) []*Finding {
+	var findings []*Finding
+
+	related := make(map[string]struct{})
+	for _, relationship := range s.Relationships {
+		source := s.TableByID(relationship.SourceTableID)
+		target := s.TableByID(relationship.TargetTableID)
+		if source == nil || target == nil ||
+			source.FieldByID(relationship.SourceFieldID) == nil || target.FieldByID(relationship.TargetFieldID) == nil {
+			findings = append(findings, &Finding{Rule: RuleBrokenRelationship})
+			continue
+		}
+
+		related[source.ID] = struct{}{}
+		related[target.ID] = struct{}{}
+	}
+
+	tableNames := make(map[string]struct{}, len(s.Tables))
+	for _, table := range s.Tables {
+		name := normalize(table.FullName())
+		if _, ok := tableNames[name]; ok {
+			findings = append(findings, &Finding{Rule: RuleDuplicateTable, Table: table.FullName()})
+		}
+		tableNames[name] = struct{}{}
+
+		if len(table.Fields) == 0 {
+			findings = append(findings, &Finding{Rule: RuleEmptyTable, Table: table.FullName()})
+			continue
+		}
+
+		hasPrimaryKey := false
+		fieldNames := make(map[string]struct{}, len(table.Fields))
+		for _, field := range table.Fields {
+			hasPrimaryKey = hasPrimaryKey || field.PrimaryKey
+
+			name := normalize(field.Name)
+			if _, ok := fieldNames[name]; ok {
+				findings = append(findings, &Finding{Rule: RuleDuplicateColumn, Table: table.FullName(), Field: field.Name})
+			}
+			fieldNames[name] = struct{}{}
+		}
+
+		if table.IsView {
+			continue
+		}
+		if !hasPrimaryKey {
+			findings = append(findings, &Finding{Rule: RuleNoPrimaryKey, Table: table.FullName()})
+		}
+		if _, ok := related[table.ID]; !ok && len(s.Tables) > 1 {
+			findings = append(findings, &Finding{Rule: RuleIsolatedTable, Table: table.FullName()})
+		}
+	}
+
+	return findings
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParse(t *testing.T, content string) *schema.Schema {
+	s, err := schema.Parse(content)
+	require.NoError(t, err)
+	return s
+}
+
+func TestLintCleanSchema(t *testing.T) {
+	s := mustParse(t, `{
+		"tables": [
+			{"id": "t1", "name": "books", "fields": [
+				{"id": "f1", "name": "id", "primaryKey": true},
+				{"id": "f2", "name": "author_id"}
+			]},
+			{"id": "t2", "name": "authors", "fields": [
+				{"id": "f3", "name": "id", "primaryKey": true}
+			]},
+			{"id": "t3", "name": "books_view", "isView": true, "fields": [
+				{"id": "f4", "name": "title"}
+			]}
+		],
+		"relationships": [
+			{"id": "r1", "sourceTableId": "t1", "targetTableId": "t2", "sourceFieldId": "f2", "targetFieldId": "f3"}
+		]
+	}`)
+
+	assert.Empty(t, Lint(s))
+}
+
+func TestLintSingleTable(t *testing.T) {
+	s := mustParse(t, `{"tables": [{"id": "t1", "name": "books", "fields": [{"id": "f1", "name": "id", "primaryKey": true}]}]}`)
+
+	assert.Empty(t, Lint(s))
+}
+
+func TestLintFindings(t *testing.T) {
+	s := mustParse(t, `{
+		"tables": [
+			{"id": "t1", "name": "books", "fields": [
+				{"id": "f1", "name": "id"},
+				{"id": "f2", "name": "ID"}
+			]},
+			{"id": "t2", "name": "Books", "fields": []}
+		],
+		"relationships": [
+			{"id": "r1", "sourceTableId": "t1", "targetTableId": "t3", "sourceFieldId": "f1", "targetFieldId": "f5"}
+		]
+	}`)
+
+	assert.ElementsMatch(t, []*Finding{
+		{Rule: RuleBrokenRelationship},
+		{Rule: RuleDuplicateColumn, Table: "books", Field: "ID"},
+		{Rule: RuleNoPrimaryKey, Table: "books"},
+		{Rule: RuleIsolatedTable, Table: "books"},
+		{Rule: RuleDuplicateTable, Table: "Books"},
+		{Rule: RuleEmptyTable, Table: "Books"},
+	}, Lint(s))
+}
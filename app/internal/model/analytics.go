@@ -0,0 +1,89 @@
+package model
+
+import "time"
+
+type DiagramActivityID string
+
+func (i DiagramActivityID) String() string {
+	return string(i)
+}
+
+type DiagramActivityAction string
+
+const (
+	DiagramActivityActionCreate DiagramActivityAction = "create"
+	DiagramActivityActionEdit   DiagramActivityAction = "edit"
+	DiagramActivityActionDelete DiagramActivityAction = "delete"
+)
+
+func (a DiagramActivityAction) String() string {
+	return string(a)
+}
+
+// DiagramActivity is an entry of the activity log written on changes of diagrams
+type DiagramActivity struct {
+	ID        DiagramActivityID
+	UserID    UserID
+	DiagramID DiagramID
+	// Set for diagrams created by starting the assignment
+	AssignmentID *AssignmentID
+	Action       DiagramActivityAction
+	// Number of lint findings in the saved content, nil if the content was not changed or can't be parsed
+	LintFindings *int64
+	CreatedAt    time.Time
+}
+
+// DiagramActivityStats aggregates the activity log of the user since the requested time
+type DiagramActivityStats struct {
+	UserID          UserID
+	DiagramsCreated int64
+	Edits           int64
+	// Last activity is not limited by the requested time
+	LastActivityAt      *time.Time
+	AverageLintFindings *float64
+}
+
+type DailyEdits struct {
+	Day   time.Time
+	Edits int64
+}
+
+type AssignmentStatus string
+
+const (
+	AssignmentStatusNotStarted AssignmentStatus = "not_started"
+	AssignmentStatusStarted    AssignmentStatus = "started"
+	AssignmentStatusSubmitted  AssignmentStatus = "submitted"
+)
+
+func (s AssignmentStatus) String() string {
+	return string(s)
+}
+
+// CourseAnalytics is an activity summary of course students, statuses follow the order of assignments
+type CourseAnalytics struct {
+	Course      *Course
+	Since       time.Time
+	Assignments []*AssignmentProgress
+	Students    []*StudentAnalytics
+	// Edits of the listed students by day, days without edits are omitted
+	DailyEdits []*DailyEdits
+}
+
+type AssignmentProgress struct {
+	Assignment *Assignment
+	NotStarted int64
+	Started    int64
+	Submitted  int64
+}
+
+type StudentAnalytics struct {
+	UserID    UserID
+	UserLogin string
+	// Empty for students without a group
+	GroupCode string
+	Stats     *DiagramActivityStats
+	Statuses  []AssignmentStatus
+	// Number of assignments which are due soon but not started yet
+	NotStartedDueSoon int64
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type AssignmentID string
+
+func (i AssignmentID) String() string {
+	return string(i)
+}
+
+type Assignment struct {
+	ID               AssignmentID
+	CourseID         CourseID
+	Title            string
+	Description      string
+	StarterDiagramID *DiagramID
+	Deadline         *time.Time
+	// Reference solution used for automatic grading, visible only to teachers
+	ReferenceDiagramID *DiagramID
+	Rubric             *GradingRubric
+	// Students see grades and feedback only after they are released
+	GradesReleasedAt *time.Time
+	// Exam assignments are taken in sessions within the window, see ExamSession
+	ExamStartsAt *time.Time
+	ExamEndsAt   *time.Time
+	// Nil if peer review is disabled
+	PeerReview *PeerReviewSettings
+	// Reviews are accepted until this time, then authors see them
+	PeerReviewEndsAt      *time.Time
+	PeerReviewsAssignedAt *time.Time
+	CreatedBy             UserID
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// IsOverdue reports whether submissions are not accepted anymore
+func (a *Assignment) IsOverdue(now time.Time) bool {
+	if a.Deadline != nil && now.After(*a.Deadline) {
+		return true
+	}
+	return a.IsExam() && !now.Before(*a.ExamEndsAt)
+}
+
+func (a *Assignment) IsExam() bool {
+	return a.ExamStartsAt != nil && a.ExamEndsAt != nil
+}
+
+func (a *Assignment) HasPeerReview() bool {
+	return a.PeerReview != nil
+}
+
+// IsPeerReviewOpen reports whether assigned reviewers can read submissions and submit reviews
+func (a *Assignment) IsPeerReviewOpen(now time.Time) bool {
+	return a.HasPeerReview() && a.PeerReviewsAssignedAt != nil && now.Before(*a.PeerReviewEndsAt)
+}
+
+func (a *Assignment) GradesReleased() bool {
+	return a.GradesReleasedAt != nil
+}
+
+type SubmissionID string
+
+func (i SubmissionID) String() string {
+	return string(i)
+}
+
+// Submission is an immutable snapshot of the diagram handed in for the assignment
+type Submission struct {
+	ID               SubmissionID
+	AssignmentID     AssignmentID
+	UserID           UserID
+	UserLogin        string
+	DiagramID        DiagramID
+	ObjectStorageKey string
+	Name             string
+	TablesCount      int64
+	Content          utils.Secret[*string]
+	AutoGrading      *GradingResult
+	AutoGradedAt     *time.Time
+	// Grade from 0 to 100 set by a teacher
+	Grade       *float64
+	Feedback    string
+	GradedBy    *UserID
+	GradedAt    *time.Time
+	SubmittedAt time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+type CourseID string
+
+func (i CourseID) String() string {
+	return string(i)
+}
+
+type Course struct {
+	ID          CourseID
+	Name        string
+	Description string
+	JoinCode    string
+	CreatedBy   UserID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CourseGroupID string
+
+func (i CourseGroupID) String() string {
+	return string(i)
+}
+
+// CourseGroup is an academic group of students inside the course
+type CourseGroup struct {
+	ID        CourseGroupID
+	CourseID  CourseID
+	Code      string
+	JoinCode  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CourseTeacher struct {
+	CourseID  CourseID
+	UserID    UserID
+	UserLogin string
+	CreatedAt time.Time
+}
+
+type CourseEnrollment struct {
+	CourseID  CourseID
+	UserID    UserID
+	UserLogin string
+	GroupID   *CourseGroupID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
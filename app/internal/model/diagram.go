@@ -20,6 +20,9 @@ type Diagram struct {
 	ObjectStorageKey string
 	Name             string
 	TablesCount      int64
+	Tags             []string
+	PublicSlug       *string
+	PublishedAt      *time.Time
 	Content          utils.Secret[*string]
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// DiagramEditLock is a lease which grants exclusive write access to the diagram until it expires
+type DiagramEditLock struct {
+	DiagramID DiagramID
+	UserID    UserID
+	// Client generated ID of the editor (e.g. browser tab), so several editors of the same user conflict too
+	HolderID  string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func (l *DiagramEditLock) IsActive(now time.Time) bool {
+	return l.ExpiresAt.After(now)
+}
+
+func (l *DiagramEditLock) IsHeldBy(userID UserID, holderID string) bool {
+	return l.UserID == userID && l.HolderID == holderID
+}
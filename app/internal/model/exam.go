@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+type ExamSessionID string
+
+func (i ExamSessionID) String() string {
+	return string(i)
+}
+
+// ExamSession is an attempt of a student at an exam assignment. While the session is open the student
+// can access only the session diagram, when it ends the diagram is submitted automatically.
+type ExamSession struct {
+	ID           ExamSessionID
+	AssignmentID AssignmentID
+	UserID       UserID
+	DiagramID    DiagramID
+	EndsAt       time.Time
+	// Set when the diagram is submitted by the student or by the background job
+	ClosedAt  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsOpen reports whether the session diagram can be edited
+func (s *ExamSession) IsOpen(now time.Time) bool {
+	return s.ClosedAt == nil && now.Before(s.EndsAt)
+}
@@ -11,14 +11,39 @@ type FilterTerm struct {
 const (
 	Unspecified = "UNSPECIFIED"
 
-	TermID               = "id"
-	TermCode             = "code"
-	TermUserID           = "user_id"
-	TermLogin            = "login"
-	TermPasswordHash     = "password_hash"
-	TermType             = "type"
-	TermConfirmedAt      = "confirmed_at"
-	TermObjectStorageKey = "object_storage_key"
+	TermID                    = "id"
+	TermCode                  = "code"
+	TermUserID                = "user_id"
+	TermLogin                 = "login"
+	TermPasswordHash          = "password_hash"
+	TermType                  = "type"
+	TermConfirmedAt           = "confirmed_at"
+	TermObjectStorageKey      = "object_storage_key"
+	TermTag                   = "tag"
+	TermPublicSlug            = "public_slug"
+	TermPublishedAt           = "published_at"
+	TermCourseID              = "course_id"
+	TermGroupID               = "group_id"
+	TermJoinCode              = "join_code"
+	TermTeacherID             = "teacher_id"
+	TermStudentID             = "student_id"
+	TermVisibleToTeacher      = "visible_to_teacher"
+	TermAssignmentID          = "assignment_id"
+	TermDiagramID             = "diagram_id"
+	TermStatus                = "status"
+	TermEndsAt                = "ends_at"
+	TermClosedAt              = "closed_at"
+	TermSubmissionID          = "submission_id"
+	TermReviewerID            = "reviewer_id"
+	TermAssignedReviewer      = "assigned_reviewer"
+	TermDeadline              = "deadline"
+	TermPeerReviewEndsAt      = "peer_review_ends_at"
+	TermPeerReviewsAssignedAt = "peer_reviews_assigned_at"
+	TermExpiresAt             = "expires_at"
+	TermRevokedAt             = "revoked_at"
+	TermIssuer                = "issuer"
+	TermSubject               = "subject"
+	TermUsedAt                = "used_at"
 )
 
 type TermKey int64
@@ -33,6 +58,31 @@ const (
 	TermKeyType
 	TermKeyConfirmedAt
 	TermKeyObjectStorageKey
+	TermKeyTag
+	TermKeyPublicSlug
+	TermKeyPublishedAt
+	TermKeyCourseID
+	TermKeyGroupID
+	TermKeyJoinCode
+	TermKeyTeacherID
+	TermKeyStudentID
+	TermKeyVisibleToTeacher
+	TermKeyAssignmentID
+	TermKeyDiagramID
+	TermKeyStatus
+	TermKeyEndsAt
+	TermKeyClosedAt
+	TermKeySubmissionID
+	TermKeyReviewerID
+	TermKeyAssignedReviewer
+	TermKeyDeadline
+	TermKeyPeerReviewEndsAt
+	TermKeyPeerReviewsAssignedAt
+	TermKeyExpiresAt
+	TermKeyRevokedAt
+	TermKeyIssuer
+	TermKeySubject
+	TermKeyUsedAt
 )
 
 func (k TermKey) String() string {
@@ -53,6 +103,56 @@ func (k TermKey) String() string {
 		return TermConfirmedAt
 	case TermKeyObjectStorageKey:
 		return TermObjectStorageKey
+	case TermKeyTag:
+		return TermTag
+	case TermKeyPublicSlug:
+		return TermPublicSlug
+	case TermKeyPublishedAt:
+		return TermPublishedAt
+	case TermKeyCourseID:
+		return TermCourseID
+	case TermKeyGroupID:
+		return TermGroupID
+	case TermKeyJoinCode:
+		return TermJoinCode
+	case TermKeyTeacherID:
+		return TermTeacherID
+	case TermKeyStudentID:
+		return TermStudentID
+	case TermKeyVisibleToTeacher:
+		return TermVisibleToTeacher
+	case TermKeyAssignmentID:
+		return TermAssignmentID
+	case TermKeyDiagramID:
+		return TermDiagramID
+	case TermKeyStatus:
+		return TermStatus
+	case TermKeyEndsAt:
+		return TermEndsAt
+	case TermKeyClosedAt:
+		return TermClosedAt
+	case TermKeySubmissionID:
+		return TermSubmissionID
+	case TermKeyReviewerID:
+		return TermReviewerID
+	case TermKeyAssignedReviewer:
+		return TermAssignedReviewer
+	case TermKeyDeadline:
+		return TermDeadline
+	case TermKeyPeerReviewEndsAt:
+		return TermPeerReviewEndsAt
+	case TermKeyPeerReviewsAssignedAt:
+		return TermPeerReviewsAssignedAt
+	case TermKeyExpiresAt:
+		return TermExpiresAt
+	case TermKeyRevokedAt:
+		return TermRevokedAt
+	case TermKeyIssuer:
+		return TermIssuer
+	case TermKeySubject:
+		return TermSubject
+	case TermKeyUsedAt:
+		return TermUsedAt
 	default:
 		return Unspecified
 	}
@@ -76,6 +176,56 @@ func TermKeyFromString(str string) (TermKey, error) {
 		return TermKeyConfirmedAt, nil
 	case TermObjectStorageKey:
 		return TermKeyObjectStorageKey, nil
+	case TermTag:
+		return TermKeyTag, nil
+	case TermPublicSlug:
+		return TermKeyPublicSlug, nil
+	case TermPublishedAt:
+		return TermKeyPublishedAt, nil
+	case TermCourseID:
+		return TermKeyCourseID, nil
+	case TermGroupID:
+		return TermKeyGroupID, nil
+	case TermJoinCode:
+		return TermKeyJoinCode, nil
+	case TermTeacherID:
+		return TermKeyTeacherID, nil
+	case TermStudentID:
+		return TermKeyStudentID, nil
+	case TermVisibleToTeacher:
+		return TermKeyVisibleToTeacher, nil
+	case TermAssignmentID:
+		return TermKeyAssignmentID, nil
+	case TermDiagramID:
+		return TermKeyDiagramID, nil
+	case TermStatus:
+		return TermKeyStatus, nil
+	case TermEndsAt:
+		return TermKeyEndsAt, nil
+	case TermClosedAt:
+		return TermKeyClosedAt, nil
+	case TermSubmissionID:
+		return TermKeySubmissionID, nil
+	case TermReviewerID:
+		return TermKeyReviewerID, nil
+	case TermAssignedReviewer:
+		return TermKeyAssignedReviewer, nil
+	case TermDeadline:
+		return TermKeyDeadline, nil
+	case TermPeerReviewEndsAt:
+		return TermKeyPeerReviewEndsAt, nil
+	case TermPeerReviewsAssignedAt:
+		return TermKeyPeerReviewsAssignedAt, nil
+	case TermExpiresAt:
+		return TermKeyExpiresAt, nil
+	case TermRevokedAt:
+		return TermKeyRevokedAt, nil
+	case TermIssuer:
+		return TermKeyIssuer, nil
+	case TermSubject:
+		return TermKeySubject, nil
+	case TermUsedAt:
+		return TermKeyUsedAt, nil
 	default:
 		return 0, fmt.Errorf("invalid term key: %s", str)
 	}
@@ -0,0 +1,18 @@
+package model
+
+// Gradebook is a table of grades of course students, columns follow the order of assignments
+type Gradebook struct {
+	Course      *Course
+	Group       *CourseGroup
+	Assignments []*Assignment
+	Rows        []*GradebookRow
+}
+
+type GradebookRow struct {
+	UserID    UserID
+	UserLogin string
+	// Empty for students without a group
+	GroupCode string
+	// Nil if the assignment is not submitted or not graded yet
+	Grades []*float64
+}
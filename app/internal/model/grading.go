@@ -0,0 +1,45 @@
+package model
+
+// GradingRubric defines weights of criteria used to grade a submission against the reference solution
+type GradingRubric struct {
+	TablesWeight        float64 `json:"tables_weight"`
+	ColumnsWeight       float64 `json:"columns_weight"`
+	KeysWeight          float64 `json:"keys_weight"`
+	RelationshipsWeight float64 `json:"relationships_weight"`
+	CardinalitiesWeight float64 `json:"cardinalities_weight"`
+
+	// Score in percents required to pass the assignment
+	PassScore float64 `json:"pass_score"`
+	// Scores closer than the margin to PassScore need manual review
+	BorderlineMargin float64 `json:"borderline_margin"`
+}
+
+const (
+	GradingCriterionTables        = "tables"
+	GradingCriterionColumns       = "columns"
+	GradingCriterionKeys          = "keys"
+	GradingCriterionRelationships = "relationships"
+	GradingCriterionCardinalities = "cardinalities"
+)
+
+type GradingCriterion struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	// Share of matched reference items from 0 to 1
+	Score    float64  `json:"score"`
+	Matched  int64    `json:"matched"`
+	Expected int64    `json:"expected"`
+	Missing  []string `json:"missing,omitempty"`
+	Extra    []string `json:"extra,omitempty"`
+}
+
+// GradingResult is a result of automatic grading of a submission
+type GradingResult struct {
+	// Score in percents
+	Score      float64             `json:"score"`
+	Passed     bool                `json:"passed"`
+	Borderline bool                `json:"borderline"`
+	Criteria   []*GradingCriterion `json:"criteria"`
+	// Set if the submission couldn't be graded, e.g. its content is malformed
+	Error string `json:"error,omitempty"`
+}
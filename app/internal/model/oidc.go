@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type OIDCLoginStateID string
+
+func (i OIDCLoginStateID) String() string {
+	return string(i)
+}
+
+// OIDCLoginState keeps the nonce and the PKCE verifier between the start of the login
+// and the redirect back from the identity provider, its ID is the state parameter
+type OIDCLoginState struct {
+	ID           OIDCLoginStateID
+	Nonce        string
+	CodeVerifier utils.Secret[string]
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+type UserIdentityID string
+
+func (i UserIdentityID) String() string {
+	return string(i)
+}
+
+// UserIdentity links the user to the account of the identity provider
+type UserIdentity struct {
+	ID      UserIdentityID
+	UserID  UserID
+	Issuer  string
+	Subject string
+	// Email of the provider account when the identity was linked
+	Email     string
+	CreatedAt time.Time
+}
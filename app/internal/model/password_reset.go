@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type PasswordResetID string
+
+func (i PasswordResetID) String() string {
+	return string(i)
+}
+
+// PasswordReset is sent to the user by email and sets a new password once
+type PasswordReset struct {
+	ID        PasswordResetID
+	UserID    UserID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
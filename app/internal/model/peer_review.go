@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+// PeerReviewSettings enables peer review of the assignment, reviewers are assigned when the deadline passes
+type PeerReviewSettings struct {
+	// Number of reviews each submission gets, limited by the number of other submissions
+	ReviewersCount int64    `json:"reviewers_count"`
+	Criteria       []string `json:"criteria"`
+}
+
+type PeerReviewID string
+
+func (i PeerReviewID) String() string {
+	return string(i)
+}
+
+// PeerReview is an anonymous review of a submission by another student of the course
+type PeerReview struct {
+	ID           PeerReviewID
+	AssignmentID AssignmentID
+	SubmissionID SubmissionID
+	ReviewerID   UserID
+	// Scores from 1 to 5 follow the order of assignment criteria, nil until the review is submitted
+	Scores      []int64
+	Comment     string
+	SubmittedAt *time.Time
+	// Reviews hidden by a teacher are not shown to the author
+	HiddenBy *UserID
+	HiddenAt *time.Time
+	// Submission snapshot, filled only for the reviewer
+	Content   utils.Secret[*string]
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (r *PeerReview) Submitted() bool {
+	return r.SubmittedAt != nil
+}
+
+func (r *PeerReview) Hidden() bool {
+	return r.HiddenAt != nil
+}
+
+// PeerReviewSummary aggregates submitted reviews of the submission which are not hidden
+type PeerReviewSummary struct {
+	SubmissionID SubmissionID
+	Criteria     []string
+	ReviewsCount int64
+	// Average scores follow the order of criteria, empty if there are no reviews
+	AverageScores []float64
+	Comments      []string
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type PersonalAccessTokenID string
+
+func (i PersonalAccessTokenID) String() string {
+	return string(i)
+}
+
+type TokenScope string
+
+const (
+	TokenScopeDiagramsRead  TokenScope = "diagrams:read"
+	TokenScopeDiagramsWrite TokenScope = "diagrams:write"
+)
+
+func (s TokenScope) String() string {
+	return string(s)
+}
+
+func TokenScopeFromString(s string) (TokenScope, error) {
+	switch scope := TokenScope(s); scope {
+	case TokenScopeDiagramsRead, TokenScopeDiagramsWrite:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("invalid token scope: %s", s)
+	}
+}
+
+// PersonalAccessToken authenticates scripts and CI pipelines of the user, the token
+// is shown once on creation and gives access to operations of its scopes only
+type PersonalAccessToken struct {
+	ID        PersonalAccessTokenID
+	UserID    UserID
+	Name      string
+	TokenHash utils.Secret[string]
+	Scopes    []TokenScope
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	// Updated on authentication
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+
+	// Set only on creation, the value can't be restored later
+	Value utils.Secret[string]
+}
+
+func (t *PersonalAccessToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && t.ExpiresAt.After(now)
+}
+
+func (t *PersonalAccessToken) HasScope(scope TokenScope) bool {
+	return slices.Contains(t.Scopes, scope)
+}
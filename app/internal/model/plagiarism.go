@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+type PlagiarismCheckStatus string
+
+const (
+	PlagiarismCheckStatusPending PlagiarismCheckStatus = "pending"
+	PlagiarismCheckStatusDone    PlagiarismCheckStatus = "done"
+	PlagiarismCheckStatusFailed  PlagiarismCheckStatus = "failed"
+)
+
+func (s PlagiarismCheckStatus) String() string {
+	return string(s)
+}
+
+// PlagiarismCheck is a state of plagiarism detection for an assignment, it is requested again
+// when new submissions arrive or a teacher asks for it
+type PlagiarismCheck struct {
+	AssignmentID AssignmentID
+	Status       PlagiarismCheckStatus
+	Error        string
+	RequestedAt  time.Time
+	// Submissions made before this time are covered by the last completed check
+	CheckedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PlagiarismPairID string
+
+func (i PlagiarismPairID) String() string {
+	return string(i)
+}
+
+// PlagiarismPair is a pair of submissions flagged as suspiciously similar
+type PlagiarismPair struct {
+	ID                 PlagiarismPairID
+	AssignmentID       AssignmentID
+	FirstSubmissionID  SubmissionID
+	FirstUserID        UserID
+	FirstUserLogin     string
+	SecondSubmissionID SubmissionID
+	SecondUserID       UserID
+	SecondUserLogin    string
+	Score              float64
+	NamesScore         float64
+	StructureScore     float64
+	LayoutScore        float64
+	CreatedAt          time.Time
+}
+
+type PlagiarismReport struct {
+	Check *PlagiarismCheck
+	Pairs []*PlagiarismPair
+}
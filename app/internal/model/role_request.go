@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+type RoleRequestID string
+
+func (i RoleRequestID) String() string {
+	return string(i)
+}
+
+type RoleRequestStatus string
+
+const (
+	RoleRequestStatusPending  RoleRequestStatus = "pending"
+	RoleRequestStatusApproved RoleRequestStatus = "approved"
+	RoleRequestStatusRejected RoleRequestStatus = "rejected"
+)
+
+func (s RoleRequestStatus) String() string {
+	return string(s)
+}
+
+// RoleRequest is a request of the user to become a teacher or an admin, it is decided by admins
+type RoleRequest struct {
+	ID        RoleRequestID
+	UserID    UserID
+	UserLogin string
+	Type      UserType
+	Reason    string
+	Status    RoleRequestStatus
+	// Set once the request is approved or rejected
+	DecidedBy       *UserID
+	DecisionComment string
+	DecidedAt       *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
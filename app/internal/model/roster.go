@@ -0,0 +1,35 @@
+package model
+
+type RosterImportStatus string
+
+const (
+	// New pending account is created and enrolled, the invitation is sent
+	RosterImportStatusInvited RosterImportStatus = "invited"
+	// Existing student is enrolled or moved to the group
+	RosterImportStatusEnrolled       RosterImportStatus = "enrolled"
+	RosterImportStatusInvalidEmail   RosterImportStatus = "invalid_email"
+	RosterImportStatusGroupNotFound  RosterImportStatus = "group_not_found"
+	RosterImportStatusNotStudent     RosterImportStatus = "not_student"
+	RosterImportStatusDuplicateEmail RosterImportStatus = "duplicate_email"
+)
+
+func (s RosterImportStatus) String() string {
+	return string(s)
+}
+
+// RosterImportRow is a result of importing one row of a course roster
+type RosterImportRow struct {
+	// Line number in the imported file starting from 1
+	Line      int
+	Email     string
+	GroupCode string
+	Status    RosterImportStatus
+	// Set for imported rows
+	Enrollment *CourseEnrollment
+	// Set if the invitation email failed, the account and the enrollment are kept
+	EmailError string
+}
+
+func (r *RosterImportRow) Imported() bool {
+	return r.Status == RosterImportStatusInvited || r.Status == RosterImportStatusEnrolled
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type SessionID string
+
+func (i SessionID) String() string {
+	return string(i)
+}
+
+// Session is created on login, access tokens of the session are issued by its refresh token
+// and stop working once the session is revoked
+type Session struct {
+	ID               SessionID
+	UserID           UserID
+	RefreshTokenHash utils.Secret[string]
+	UserAgent        string
+	IPAddress        string
+	CreatedAt        time.Time
+	// Last time the refresh token was used
+	RefreshedAt time.Time
+	ExpiresAt   time.Time
+	RevokedAt   *time.Time
+}
+
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(now)
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+// UserTOTP is the authenticator app of the user, logins require its codes once it is enabled
+type UserTOTP struct {
+	UserID UserID
+	Secret utils.Secret[string]
+	// Nil until the first code confirms the enrollment
+	EnabledAt *time.Time
+	// Time step of the last accepted code, codes of this and earlier steps are rejected
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+func (t *UserTOTP) Enabled() bool {
+	return t.EnabledAt != nil
+}
+
+// TOTPEnrollment is shown to the user once to add the secret to the authenticator app
+type TOTPEnrollment struct {
+	Secret utils.Secret[string]
+	// otpauth URI rendered as a QR code
+	ProvisioningURI utils.Secret[string]
+}
+
+type UserRecoveryCodeID string
+
+func (i UserRecoveryCodeID) String() string {
+	return string(i)
+}
+
+// UserRecoveryCode replaces a TOTP code once if the authenticator app is lost
+type UserRecoveryCode struct {
+	ID        UserRecoveryCodeID
+	UserID    UserID
+	CodeHash  utils.Secret[string]
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+type LoginChallengeID string
+
+func (i LoginChallengeID) String() string {
+	return string(i)
+}
+
+// LoginChallenge is the login with the checked password waiting for the second factor,
+// its ID is returned instead of tokens and the session is started once the code is verified
+type LoginChallenge struct {
+	ID     LoginChallengeID
+	UserID UserID
+	// Describe the client of the session started by the challenge
+	UserAgent string
+	IPAddress string
+	// Wrong codes entered for the challenge
+	Attempts  int64
+	CreatedAt time.Time
+	ExpiresAt time.Time
+
+	// Set if the user has to enroll the authenticator app to complete the login
+	Enrollment *TOTPEnrollment
+}
@@ -91,20 +91,60 @@ type User struct {
 	UpdatedAt    time.Time             `json:"updated_at"`
 }
 
+// Invited users are pre-created by course teachers and set their password on confirmation
+func (u *User) Invited() bool {
+	return u.ConfirmedAt == nil && u.PasswordHash.Value == nil
+}
+
 type UserConfirmationID string
 
 func (i UserConfirmationID) String() string {
 	return string(i)
 }
 
+type UserConfirmationPurpose string
+
+const (
+	UserConfirmationPurposeRegistration UserConfirmationPurpose = "registration"
+	UserConfirmationPurposeGuestUpgrade UserConfirmationPurpose = "guest_upgrade"
+	UserConfirmationPurposeEmailChange  UserConfirmationPurpose = "email_change"
+)
+
+func (p UserConfirmationPurpose) String() string {
+	return string(p)
+}
+
 type UserConfirmation struct {
-	ID        UserConfirmationID `json:"id"`
-	UserID    UserID             `json:"user_id"`
-	CreatedAt time.Time          `json:"created_at"`
-	ExpiresAt time.Time          `json:"expires_at"`
+	ID      UserConfirmationID      `json:"id"`
+	UserID  UserID                  `json:"user_id"`
+	Purpose UserConfirmationPurpose `json:"purpose"`
+	// Set for guest upgrades and email changes, the user gets the login on confirmation.
+	// Guests also get the password.
+	Login        *string               `json:"login"`
+	PasswordHash utils.Secret[*string] `json:"password_hash"`
+	CreatedAt    time.Time             `json:"created_at"`
+	ExpiresAt    time.Time             `json:"expires_at"`
+}
+
+// GuestUpgrade reports whether the confirmation turns a guest into a student
+func (c *UserConfirmation) GuestUpgrade() bool {
+	return c.Purpose == UserConfirmationPurposeGuestUpgrade
+}
+
+// EmailChange reports whether the confirmation switches the login to the new email
+func (c *UserConfirmation) EmailChange() bool {
+	return c.Purpose == UserConfirmationPurposeEmailChange
 }
 
 type UserToken struct {
-	Value  string `json:"value"`
-	UserID UserID `json:"user_id"`
+	Value     string    `json:"value"`
+	UserID    UserID    `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SessionID SessionID `json:"session_id"`
+	// Set on login and refresh, each refresh replaces the refresh token of the session
+	RefreshToken utils.Secret[string] `json:"refresh_token"`
+	// Set instead of the tokens if the login requires the second factor
+	Challenge *LoginChallenge `json:"challenge"`
+	// Set once on the login completing the TOTP enrollment
+	RecoveryCodes utils.Secret[[]string] `json:"recovery_codes"`
 }
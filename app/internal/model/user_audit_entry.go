@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+type UserAuditEntryID string
+
+func (i UserAuditEntryID) String() string {
+	return string(i)
+}
+
+type UserAuditAction string
+
+const (
+	UserAuditActionPasswordChanged      UserAuditAction = "password_changed"
+	UserAuditActionPasswordReset        UserAuditAction = "password_reset"
+	UserAuditActionEmailChangeRequested UserAuditAction = "email_change_requested"
+	UserAuditActionEmailChanged         UserAuditAction = "email_changed"
+	UserAuditActionIdentityLinked       UserAuditAction = "identity_linked"
+	UserAuditActionTOTPEnabled          UserAuditAction = "totp_enabled"
+	UserAuditActionTOTPDisabled         UserAuditAction = "totp_disabled"
+	UserAuditActionRecoveryCodesRenewed UserAuditAction = "recovery_codes_renewed"
+	UserAuditActionRecoveryCodeUsed     UserAuditAction = "recovery_code_used"
+)
+
+func (a UserAuditAction) String() string {
+	return string(a)
+}
+
+// UserAuditEntry records a change of the user credentials
+type UserAuditEntry struct {
+	ID     UserAuditEntryID
+	UserID UserID
+	Action UserAuditAction
+	// Human-readable description, e.g. the old and the new email
+	Details   string
+	CreatedAt time.Time
+}
@@ -0,0 +1,67 @@
+package peerreview
+
+import (
+	"math/rand/v2"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+// Assign distributes reviewers among authors: every author reviews count submissions of others and gets
+// count reviews. Authors are shuffled, so reviewers can't guess whose submission they got by the course list.
+// Count is limited by the number of other authors.
+func Assign(authors []model.UserID, count int64) map[model.UserID][]model.UserID {
+	result := make(map[model.UserID][]model.UserID, len(authors))
+	if len(authors) < 2 || count < 1 {
+		return result
+	}
+	count = min(count, int64(len(authors)-1))
+
+	shuffled := make([]model.UserID, len(authors))
+	copy(shuffled, authors)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	// Shifts from 1 to count never map an author to itself or pick the same reviewer twice
+	for i, author := range shuffled {
+		reviewers := make([]model.UserID, 0, count)
+		for shift := int64(1); shift <= count; shift++ {
+			reviewers = append(reviewers, shuffled[(int64(i)+shift)%int64(len(shuffled))])
+		}
+		result[author] = reviewers
+	}
+
+	return result
+}
+
+// Summarize averages scores of submitted reviews which are not hidden by a teacher, reviewers are not disclosed
+func Summarize(submissionID model.SubmissionID, criteria []string, reviews []*model.PeerReview) *model.PeerReviewSummary {
+	summary := &model.PeerReviewSummary{
+		SubmissionID: submissionID,
+		Criteria:     criteria,
+	}
+
+	sums := make([]float64, len(criteria))
+	for _, review := range reviews {
+		if !review.Submitted() || review.Hidden() || len(review.Scores) != len(criteria) {
+			continue
+		}
+
+		summary.ReviewsCount++
+		for i, score := range review.Scores {
+			sums[i] += float64(score)
+		}
+		if review.Comment != "" {
+			summary.Comments = append(summary.Comments, review.Comment)
+		}
+	}
+
+	if summary.ReviewsCount > 0 {
+		summary.AverageScores = make([]float64, len(criteria))
+		for i, sum := range sums {
+			summary.AverageScores[i] = sum / float64(summary.ReviewsCount)
+		}
+	}
+
+	return summary
+}
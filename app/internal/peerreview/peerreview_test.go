@@ -0,0 +1,73 @@
+package peerreview
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssign(t *testing.T) {
+	authors := []model.UserID{"a", "b", "c", "d", "e"}
+
+	result := Assign(authors, 3)
+	assert.Len(t, result, len(authors))
+
+	reviewsCount := make(map[model.UserID]int)
+	for author, reviewers := range result {
+		assert.Len(t, reviewers, 3)
+		assert.NotContains(t, reviewers, author)
+
+		unique := make(map[model.UserID]struct{})
+		for _, reviewer := range reviewers {
+			unique[reviewer] = struct{}{}
+			reviewsCount[reviewer]++
+		}
+		assert.Len(t, unique, 3)
+	}
+
+	for _, author := range authors {
+		assert.Equal(t, 3, reviewsCount[author])
+	}
+}
+
+func TestAssignLimitsCount(t *testing.T) {
+	result := Assign([]model.UserID{"a", "b"}, 3)
+	assert.Equal(t, map[model.UserID][]model.UserID{
+		"a": {"b"},
+		"b": {"a"},
+	}, result)
+}
+
+func TestAssignNotEnoughAuthors(t *testing.T) {
+	assert.Empty(t, Assign([]model.UserID{"a"}, 2))
+	assert.Empty(t, Assign(nil, 2))
+}
+
+func TestSummarize(t *testing.T) {
+	submittedAt := time.Now()
+	reviews := []*model.PeerReview{
+		{Scores: []int64{5, 3}, Comment: "good", SubmittedAt: &submittedAt},
+		{Scores: []int64{3, 4}, SubmittedAt: &submittedAt},
+		// Not submitted yet
+		{Scores: []int64{1, 1}},
+		// Hidden by a teacher
+		{Scores: []int64{1, 1}, Comment: "rude", SubmittedAt: &submittedAt, HiddenAt: &submittedAt},
+	}
+
+	summary := Summarize("s1", []string{"naming", "normalization"}, reviews)
+	assert.Equal(t, &model.PeerReviewSummary{
+		SubmissionID:  "s1",
+		Criteria:      []string{"naming", "normalization"},
+		ReviewsCount:  2,
+		AverageScores: []float64{4, 3.5},
+		Comments:      []string{"good"},
+	}, summary)
+}
+
+func TestSummarizeNoReviews(t *testing.T) {
+	summary := Summarize("s1", []string{"naming"}, nil)
+	assert.Zero(t, summary.ReviewsCount)
+	assert.Empty(t, summary.AverageScores)
+}
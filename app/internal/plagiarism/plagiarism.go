@@ -0,0 +1,212 @@
+package plagiarism
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+)
+
+const (
+	namesWeight     = 0.4
+	structureWeight = 0.3
+	layoutWeight    = 0.3
+
+	// Pairs with a higher total score are reported
+	suspiciousScore = 0.8
+	// Share of tables copied with the same identifiers or positions which is reported regardless of the total score,
+	// editors generate them randomly so they only match if the diagram was copied from the same file
+	suspiciousLayoutScore = 0.5
+	// Schemas with fewer own tables are too small to tell copying from the same solution
+	minTables = 2
+)
+
+// Similarity of two schemas, all scores are from 0 to 1
+type Similarity struct {
+	Score float64
+	// Similarity of table and column name sets
+	Names float64
+	// Similarity of the relationship graph shape regardless of names
+	Structure float64
+	// Share of tables with identical identifiers or positions
+	Layout float64
+}
+
+func (s *Similarity) Suspicious() bool {
+	return s.Score >= suspiciousScore || s.Layout >= suspiciousLayoutScore
+}
+
+// Compare computes structural similarity of two submitted schemas. Tables of the baseline schema,
+// e.g. the starter diagram of the assignment, are ignored since all students share them.
+func Compare(first, second, baseline *schema.Schema) *Similarity {
+	firstTables := ownTables(first, baseline)
+	secondTables := ownTables(second, baseline)
+
+	if len(firstTables) < minTables || len(secondTables) < minTables {
+		return &Similarity{}
+	}
+
+	similarity := &Similarity{
+		Names: (jaccard(tableNames(firstTables), tableNames(secondTables)) +
+			jaccard(columnNames(firstTables), columnNames(secondTables))) / 2,
+		Structure: (sequenceSimilarity(degrees(first, firstTables), degrees(second, secondTables)) +
+			sequenceSimilarity(columnCounts(firstTables), columnCounts(secondTables))) / 2,
+		Layout: layoutSimilarity(firstTables, secondTables),
+	}
+
+	similarity.Score = round(namesWeight*similarity.Names + structureWeight*similarity.Structure + layoutWeight*similarity.Layout)
+	similarity.Names = round(similarity.Names)
+	similarity.Structure = round(similarity.Structure)
+	similarity.Layout = round(similarity.Layout)
+
+	return similarity
+}
+
+func ownTables(s *schema.Schema, baseline *schema.Schema) []*schema.Table {
+	if baseline == nil {
+		return s.Tables
+	}
+
+	baselineIDs := make(map[string]struct{}, len(baseline.Tables))
+	baselineNames := make(map[string]struct{}, len(baseline.Tables))
+	for _, table := range baseline.Tables {
+		baselineIDs[table.ID] = struct{}{}
+		baselineNames[normalize(table.FullName())] = struct{}{}
+	}
+
+	var tables []*schema.Table
+	for _, table := range s.Tables {
+		if _, ok := baselineIDs[table.ID]; ok {
+			continue
+		}
+		if _, ok := baselineNames[normalize(table.FullName())]; ok {
+			continue
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func tableNames(tables []*schema.Table) map[string]struct{} {
+	names := make(map[string]struct{}, len(tables))
+	for _, table := range tables {
+		names[normalize(table.FullName())] = struct{}{}
+	}
+	return names
+}
+
+func columnNames(tables []*schema.Table) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, table := range tables {
+		for _, field := range table.Fields {
+			names[normalize(table.FullName())+"."+normalize(field.Name)] = struct{}{}
+		}
+	}
+	return names
+}
+
+func jaccard(first, second map[string]struct{}) float64 {
+	if len(first) == 0 && len(second) == 0 {
+		return 0
+	}
+
+	var common int
+	for name := range first {
+		if _, ok := second[name]; ok {
+			common++
+		}
+	}
+
+	return float64(common) / float64(len(first)+len(second)-common)
+}
+
+// degrees returns numbers of relationships of the tables
+func degrees(s *schema.Schema, tables []*schema.Table) []int {
+	index := make(map[string]int, len(tables))
+	for i, table := range tables {
+		index[table.ID] = i
+	}
+
+	result := make([]int, len(tables))
+	for _, relationship := range s.Relationships {
+		if i, ok := index[relationship.SourceTableID]; ok {
+			result[i]++
+		}
+		if i, ok := index[relationship.TargetTableID]; ok {
+			result[i]++
+		}
+	}
+	return result
+}
+
+func columnCounts(tables []*schema.Table) []int {
+	result := make([]int, 0, len(tables))
+	for _, table := range tables {
+		result = append(result, len(table.Fields))
+	}
+	return result
+}
+
+// sequenceSimilarity compares sorted sequences element-wise, the shorter one is padded with zeros
+func sequenceSimilarity(first, second []int) float64 {
+	first = slices.Clone(first)
+	second = slices.Clone(second)
+	slices.Sort(first)
+	slices.Reverse(first)
+	slices.Sort(second)
+	slices.Reverse(second)
+
+	var minSum, maxSum int
+	for i := 0; i < max(len(first), len(second)); i++ {
+		var a, b int
+		if i < len(first) {
+			a = first[i]
+		}
+		if i < len(second) {
+			b = second[i]
+		}
+		minSum += min(a, b)
+		maxSum += max(a, b)
+	}
+
+	if maxSum == 0 {
+		return 1
+	}
+	return float64(minSum) / float64(maxSum)
+}
+
+func layoutSimilarity(first, second []*schema.Table) float64 {
+	type position struct{ x, y float64 }
+
+	ids := make(map[string]struct{}, len(second))
+	positions := make(map[position]struct{}, len(second))
+	for _, table := range second {
+		ids[table.ID] = struct{}{}
+		// Tables at the origin are usually just not positioned yet
+		if table.X != 0 || table.Y != 0 {
+			positions[position{table.X, table.Y}] = struct{}{}
+		}
+	}
+
+	var copied int
+	for _, table := range first {
+		if _, ok := ids[table.ID]; ok {
+			copied++
+			continue
+		}
+		if _, ok := positions[position{table.X, table.Y}]; ok {
+			copied++
+		}
+	}
+
+	return float64(copied) / float64(min(len(first), len(second)))
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,105 @@
+package plagiarism
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const originalContent = `{
+	"tables": [
+		{"id": "t1", "name": "books", "x": 100, "y": 200, "fields": [
+			{"id": "f1", "name": "id"},
+			{"id": "f2", "name": "title"},
+			{"id": "f3", "name": "author_id"}
+		]},
+		{"id": "t2", "name": "authors", "x": 400, "y": 200, "fields": [
+			{"id": "f4", "name": "id"},
+			{"id": "f5", "name": "full_name"}
+		]},
+		{"id": "t3", "name": "publishers", "x": 700, "y": 200, "fields": [
+			{"id": "f6", "name": "id"},
+			{"id": "f7", "name": "name"}
+		]}
+	],
+	"relationships": [
+		{"id": "r1", "sourceTableId": "t1", "targetTableId": "t2"}
+	]
+}`
+
+func mustParse(t *testing.T, content string) *schema.Schema {
+	s, err := schema.Parse(content)
+	require.NoError(t, err)
+	return s
+}
+
+func TestCompareCopiedFile(t *testing.T) {
+	similarity := Compare(mustParse(t, originalContent), mustParse(t, originalContent), nil)
+
+	assert.Equal(t, float64(1), similarity.Score)
+	assert.Equal(t, float64(1), similarity.Layout)
+	assert.True(t, similarity.Suspicious())
+}
+
+func TestCompareSameNamesDifferentLayout(t *testing.T) {
+	// Correct solutions of the same task converge on names and shape, only a copied file keeps the layout
+	sameNames := mustParse(t, `{
+		"tables": [
+			{"id": "a", "name": "Books", "x": 10, "y": 20, "fields": [
+				{"id": "a1", "name": "id"}, {"id": "a2", "name": "title"}, {"id": "a3", "name": "author_id"}
+			]},
+			{"id": "b", "name": "authors", "x": 40, "y": 20, "fields": [
+				{"id": "b1", "name": "id"}, {"id": "b2", "name": "full_name"}
+			]},
+			{"id": "c", "name": "publishers", "x": 70, "y": 20, "fields": [
+				{"id": "c1", "name": "id"}, {"id": "c2", "name": "name"}
+			]}
+		],
+		"relationships": [
+			{"id": "r", "sourceTableId": "a", "targetTableId": "b"}
+		]
+	}`)
+
+	similarity := Compare(mustParse(t, originalContent), sameNames, nil)
+
+	assert.Equal(t, float64(1), similarity.Names)
+	assert.Equal(t, float64(1), similarity.Structure)
+	assert.Equal(t, float64(0), similarity.Layout)
+	assert.False(t, similarity.Suspicious())
+}
+
+func TestCompareIndependentSolutions(t *testing.T) {
+	other := mustParse(t, `{
+		"tables": [
+			{"id": "a", "name": "book", "x": 10, "y": 20, "fields": [
+				{"id": "a1", "name": "book_id"}, {"id": "a2", "name": "name"}, {"id": "a3", "name": "isbn"},
+				{"id": "a4", "name": "writer_id"}
+			]},
+			{"id": "b", "name": "writer", "x": 40, "y": 20, "fields": [
+				{"id": "b1", "name": "writer_id"}, {"id": "b2", "name": "first_name"}, {"id": "b3", "name": "last_name"}
+			]}
+		],
+		"relationships": [
+			{"id": "r", "sourceTableId": "a", "targetTableId": "b"}
+		]
+	}`)
+
+	similarity := Compare(mustParse(t, originalContent), other, nil)
+
+	assert.Equal(t, float64(0), similarity.Names)
+	assert.Equal(t, float64(0), similarity.Layout)
+	assert.Less(t, similarity.Score, suspiciousScore)
+	assert.False(t, similarity.Suspicious())
+}
+
+func TestCompareIgnoresBaseline(t *testing.T) {
+	// Both students only kept the starter diagram
+	baseline := mustParse(t, originalContent)
+
+	similarity := Compare(mustParse(t, originalContent), mustParse(t, originalContent), baseline)
+
+	assert.Equal(t, float64(0), similarity.Score)
+	assert.False(t, similarity.Suspicious())
+}
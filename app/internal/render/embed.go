@@ -0,0 +1,44 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+)
+
+var embedTemplate = template.Must(template.New("embed").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Name }}</title>
+<style>
+html, body { margin: 0; height: 100%; background: #f8fafc; }
+.diagram { width: 100%; height: 100%; }
+.diagram svg { width: 100%; height: 100%; }
+</style>
+</head>
+<body>
+<div class="diagram">{{ .SVG }}</div>
+</body>
+</html>
+`))
+
+// EmbedPage renders a self-contained HTML page with the diagram picture suitable for iframes
+func EmbedPage(name string, s *schema.Schema) ([]byte, error) {
+	var buf bytes.Buffer
+	err := embedTemplate.Execute(&buf, struct {
+		Name string
+		SVG  template.HTML
+	}{
+		Name: name,
+		SVG:  template.HTML(SVG(s)), // all schema values are escaped by SVG
+	})
+	if err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
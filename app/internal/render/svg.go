@@ -0,0 +1,153 @@
+package render
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+)
+
+const (
+	charWidth     = 7.5
+	rowHeight     = 22
+	headerHeight  = 30
+	tablePadding  = 12
+	minTableWidth = 160
+	margin        = 40
+
+	defaultTableColor = "#8eb7ff"
+)
+
+type tableBox struct {
+	table  *schema.Table
+	x, y   float64
+	width  float64
+	height float64
+}
+
+func (b *tableBox) fieldY(fieldID string) float64 {
+	for i, field := range b.table.Fields {
+		if field.ID == fieldID {
+			return b.y + headerHeight + float64(i)*rowHeight + rowHeight/2
+		}
+	}
+	return b.y + headerHeight/2
+}
+
+// SVG renders a read-only picture of the schema
+func SVG(s *schema.Schema) string {
+	boxes := make(map[string]*tableBox, len(s.Tables))
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+
+	for _, table := range s.Tables {
+		box := &tableBox{
+			table:  table,
+			x:      table.X,
+			y:      table.Y,
+			width:  tableWidth(table),
+			height: headerHeight + float64(len(table.Fields))*rowHeight,
+		}
+		boxes[table.ID] = box
+
+		minX = math.Min(minX, box.x)
+		minY = math.Min(minY, box.y)
+		maxX = math.Max(maxX, box.x+box.width)
+		maxY = math.Max(maxY, box.y+box.height)
+	}
+	if len(boxes) == 0 {
+		minX, minY, maxX, maxY = 0, 0, minTableWidth, headerHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="%.0f %.0f %.0f %.0f" font-family="sans-serif" font-size="13">`,
+		minX-margin, minY-margin, maxX-minX+2*margin, maxY-minY+2*margin,
+	)
+
+	for _, relationship := range s.Relationships {
+		source, target := boxes[relationship.SourceTableID], boxes[relationship.TargetTableID]
+		if source == nil || target == nil {
+			continue
+		}
+		writeRelationship(&b, relationship, source, target)
+	}
+
+	for _, table := range s.Tables {
+		writeTable(&b, boxes[table.ID])
+	}
+
+	b.WriteString(`</svg>`)
+
+	return b.String()
+}
+
+func tableWidth(table *schema.Table) float64 {
+	maxChars := utf8.RuneCountInString(table.FullName())
+	for _, field := range table.Fields {
+		chars := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Type.Name) + 4
+		maxChars = max(maxChars, chars)
+	}
+
+	return math.Max(minTableWidth, float64(maxChars)*charWidth+2*tablePadding)
+}
+
+func writeTable(b *strings.Builder, box *tableBox) {
+	color := box.table.Color
+	if color == "" {
+		color = defaultTableColor
+	}
+
+	fmt.Fprintf(b, `<g><rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="6" fill="#ffffff" stroke="#94a3b8"/>`,
+		box.x, box.y, box.width, box.height)
+	fmt.Fprintf(b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%d" rx="6" fill="%s"/>`,
+		box.x, box.y, box.width, headerHeight, html.EscapeString(color))
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-weight="bold">%s</text>`,
+		box.x+tablePadding, box.y+headerHeight/2+5, html.EscapeString(box.table.FullName()))
+
+	for i, field := range box.table.Fields {
+		y := box.y + headerHeight + float64(i)*rowHeight + rowHeight/2 + 5
+
+		name := field.Name
+		if field.PrimaryKey {
+			name = "🔑 " + name
+		}
+
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f">%s</text>`,
+			box.x+tablePadding, y, html.EscapeString(name))
+		fmt.Fprintf(b, `<text x="%.1f" y="%.1f" text-anchor="end" fill="#64748b">%s</text>`,
+			box.x+box.width-tablePadding, y, html.EscapeString(field.Type.Name))
+	}
+
+	b.WriteString(`</g>`)
+}
+
+func writeRelationship(b *strings.Builder, relationship *schema.Relationship, source, target *tableBox) {
+	x1, x2 := source.x+source.width, target.x
+	if target.x+target.width/2 < source.x+source.width/2 {
+		x1, x2 = source.x, target.x+target.width
+	}
+	y1, y2 := source.fieldY(relationship.SourceFieldID), target.fieldY(relationship.TargetFieldID)
+	midX := (x1 + x2) / 2
+
+	fmt.Fprintf(b, `<path d="M %.1f %.1f C %.1f %.1f, %.1f %.1f, %.1f %.1f" fill="none" stroke="#64748b" stroke-width="1.5"/>`,
+		x1, y1, midX, y1, midX, y2, x2, y2)
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-size="11" fill="#64748b">%s</text>`,
+		x1, y1-4, cardinalityLabel(relationship.SourceCardinality))
+	fmt.Fprintf(b, `<text x="%.1f" y="%.1f" font-size="11" fill="#64748b">%s</text>`,
+		x2, y2-4, cardinalityLabel(relationship.TargetCardinality))
+}
+
+func cardinalityLabel(cardinality string) string {
+	switch cardinality {
+	case schema.CardinalityOne:
+		return "1"
+	case schema.CardinalityMany:
+		return "N"
+	default:
+		return ""
+	}
+}
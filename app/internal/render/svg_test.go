@@ -0,0 +1,47 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const content = `{
+	"name": "library",
+	"tables": [
+		{"id": "t1", "name": "books", "x": 0, "y": 0, "fields": [
+			{"id": "f1", "name": "id", "type": {"id": "bigint", "name": "bigint"}, "primaryKey": true},
+			{"id": "f2", "name": "author_id", "type": {"id": "bigint", "name": "bigint"}}
+		]},
+		{"id": "t2", "name": "<script>alert(1)</script>", "x": 300, "y": 0, "fields": [
+			{"id": "f3", "name": "id", "type": {"id": "bigint", "name": "bigint"}, "primaryKey": true}
+		]}
+	],
+	"relationships": [
+		{"id": "r1", "sourceTableId": "t1", "targetTableId": "t2", "sourceFieldId": "f2", "targetFieldId": "f3",
+		 "sourceCardinality": "many", "targetCardinality": "one"}
+	]
+}`
+
+func TestSVG(t *testing.T) {
+	s, err := schema.Parse(content)
+	require.NoError(t, err)
+
+	svg := SVG(s)
+	assert.Contains(t, svg, "books")
+	assert.Contains(t, svg, "<path")
+	assert.NotContains(t, svg, "<script>")
+	assert.Contains(t, svg, "&lt;script&gt;")
+}
+
+func TestEmbedPage(t *testing.T) {
+	s, err := schema.Parse(content)
+	require.NoError(t, err)
+
+	page, err := EmbedPage("<b>library</b>", s)
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "<svg")
+	assert.Contains(t, string(page), "<title>&lt;b&gt;library&lt;/b&gt;</title>")
+}
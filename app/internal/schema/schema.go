@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyContent = errors.New("empty diagram content")
+)
+
+// Schema is a database schema stored in ChartDB diagram content
+type Schema struct {
+	Name          string          `json:"name"`
+	DatabaseType  string          `json:"databaseType"`
+	Tables        []*Table        `json:"tables"`
+	Relationships []*Relationship `json:"relationships"`
+}
+
+type Table struct {
+	ID     string   `json:"id"`
+	Name   string   `json:"name"`
+	Schema string   `json:"schema"`
+	X      float64  `json:"x"`
+	Y      float64  `json:"y"`
+	Color  string   `json:"color"`
+	IsView bool     `json:"isView"`
+	Fields []*Field `json:"fields"`
+}
+
+type Field struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Type       FieldType `json:"type"`
+	PrimaryKey bool      `json:"primaryKey"`
+	Unique     bool      `json:"unique"`
+	Nullable   bool      `json:"nullable"`
+}
+
+type FieldType struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+const (
+	CardinalityOne  = "one"
+	CardinalityMany = "many"
+)
+
+type Relationship struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	SourceTableID     string `json:"sourceTableId"`
+	TargetTableID     string `json:"targetTableId"`
+	SourceFieldID     string `json:"sourceFieldId"`
+	TargetFieldID     string `json:"targetFieldId"`
+	SourceCardinality string `json:"sourceCardinality"`
+	TargetCardinality string `json:"targetCardinality"`
+}
+
+func Parse(content string) (*Schema, error) {
+	if content == "" {
+		return nil, ErrEmptyContent
+	}
+
+	var schema Schema
+	err := json.Unmarshal([]byte(content), &schema)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &schema, nil
+}
+
+func (s *Schema) TableByID(id string) *Table {
+	for _, table := range s.Tables {
+		if table.ID == id {
+			return table
+		}
+	}
+	return nil
+}
+
+func (t *Table) FieldByID(id string) *Field {
+	for _, field := range t.Fields {
+		if field.ID == id {
+			return field
+		}
+	}
+	return nil
+}
+
+// FullName returns table name qualified with its schema if any
+func (t *Table) FullName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
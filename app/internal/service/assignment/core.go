@@ -0,0 +1,1752 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/grading"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
+	"github.com/IvLaptev/chartdb-back/internal/service/course"
+	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const (
+	assignmentIDLength     int64 = 10
+	submissionIDLength     int64 = 20
+	examSessionIDLength    int64 = 20
+	objectStorageKeyLength int64 = 20
+
+	maxPeerReviewersCount   = 5
+	maxPeerReviewCriteria   = 10
+	maxCriterionLength      = 256
+	minPeerReviewScore      = 1
+	maxPeerReviewScore      = 5
+	maxPeerReviewCommentLen = 4000
+
+	maxTitleLength = 256
+	maxGrade       = 100
+
+	defaultAnalyticsDays = 14
+	maxAnalyticsDays     = 365
+	// Students who have not started an assignment due within this period are flagged in analytics
+	dueSoonPeriod = 3 * 24 * time.Hour
+)
+
+var (
+	ErrAssignmentNotFound          = errors.New("assignment not found")
+	ErrInvalidTitle                = errors.New("invalid title")
+	ErrNoStarterDiagram            = errors.New("assignment has no starter diagram")
+	ErrDeadlinePassed              = errors.New("assignment deadline has passed")
+	ErrDiagramNotFound             = errors.New("diagram not found")
+	ErrDiagramContentNotFound      = errors.New("diagram content not found")
+	ErrSubmissionContentUnreadable = errors.New("submission content can't be read")
+	ErrSubmissionNotFound          = errors.New("submission not found")
+	ErrNoReferenceDiagram          = errors.New("assignment has no reference diagram")
+	ErrInvalidGrade                = errors.New("grade must be from 0 to 100")
+	ErrInvalidExamWindow           = errors.New("exam must start before it ends")
+	ErrExamNotStarted              = errors.New("exam has not started yet")
+	ErrExamFinished                = errors.New("exam is finished")
+	ErrNotExamDiagram              = errors.New("only the exam diagram can be submitted")
+	ErrInvalidAnalyticsPeriod      = errors.New("analytics period must be from 1 to 365 days")
+
+	ErrInvalidPeerReview       = errors.New("peer review needs 1 to 5 reviewers, 1 to 10 criteria and must end after the deadline")
+	ErrPeerReviewStarted       = errors.New("peer review settings and deadline can't be changed after reviewers are assigned")
+	ErrPeerReviewNotFound      = errors.New("peer review not found")
+	ErrPeerReviewClosed        = errors.New("peer review is closed")
+	ErrInvalidPeerReviewScores = errors.New("every criterion must be scored from 1 to 5")
+	ErrPeerReviewCommentLength = errors.New("peer review comment is too long")
+	ErrPeerReviewsNotPublished = errors.New("peer reviews are shown after the review period ends")
+
+	ErrForbidden = errors.New("forbidden")
+)
+
+type Service interface {
+	GetAssignment(ctx context.Context, params *GetAssignmentParams) (*model.Assignment, error)
+	ListAssignments(ctx context.Context, params *ListAssignmentsParams) ([]*model.Assignment, error)
+	CreateAssignment(ctx context.Context, params *CreateAssignmentParams) (*model.Assignment, error)
+	PatchAssignment(ctx context.Context, params *PatchAssignmentParams) (*model.Assignment, error)
+	DeleteAssignment(ctx context.Context, params *DeleteAssignmentParams) (*model.Assignment, error)
+
+	StartAssignment(ctx context.Context, params *StartAssignmentParams) (*model.Diagram, error)
+	SubmitAssignment(ctx context.Context, params *SubmitAssignmentParams) (*model.Submission, error)
+
+	GetSubmission(ctx context.Context, params *GetSubmissionParams) (*model.Submission, error)
+	ListSubmissions(ctx context.Context, params *ListSubmissionsParams) ([]*model.Submission, error)
+	GradeSubmissions(ctx context.Context, params *GradeSubmissionsParams) ([]*model.Submission, error)
+
+	RequestPlagiarismCheck(ctx context.Context, params *RequestPlagiarismCheckParams) (*model.PlagiarismCheck, error)
+	GetPlagiarismReport(ctx context.Context, params *GetPlagiarismReportParams) (*model.PlagiarismReport, error)
+
+	SetSubmissionGrade(ctx context.Context, params *SetSubmissionGradeParams) (*model.Submission, error)
+	ReleaseGrades(ctx context.Context, params *ReleaseGradesParams) (*model.Assignment, error)
+	ExportGradebook(ctx context.Context, params *ExportGradebookParams) (*model.Gradebook, error)
+
+	GetCourseAnalytics(ctx context.Context, params *GetCourseAnalyticsParams) (*model.CourseAnalytics, error)
+	GetStudentAnalytics(ctx context.Context, params *GetStudentAnalyticsParams) (*model.CourseAnalytics, error)
+
+	ListPeerReviews(ctx context.Context, params *ListPeerReviewsParams) ([]*model.PeerReview, error)
+	GetPeerReview(ctx context.Context, params *GetPeerReviewParams) (*model.PeerReview, error)
+	SubmitPeerReview(ctx context.Context, params *SubmitPeerReviewParams) (*model.PeerReview, error)
+	ModeratePeerReview(ctx context.Context, params *ModeratePeerReviewParams) (*model.PeerReview, error)
+	GetPeerReviewSummary(ctx context.Context, params *GetPeerReviewSummaryParams) (*model.PeerReviewSummary, error)
+}
+
+type ServiceImpl struct {
+	Logger         *slog.Logger
+	Storage        storage.Storage
+	S3Client       s3client.Client
+	DiagramService diagram.Service
+}
+
+type GetAssignmentParams struct {
+	ID model.AssignmentID
+}
+
+func (s *ServiceImpl) GetAssignment(ctx context.Context, params *GetAssignmentParams) (*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "get assignment", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if role != course.RoleTeacher {
+		hideReferenceSolution(assignment)
+	}
+
+	return assignment, nil
+}
+
+type ListAssignmentsParams struct {
+	CourseID model.CourseID
+}
+
+func (s *ServiceImpl) ListAssignments(ctx context.Context, params *ListAssignmentsParams) ([]*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "list assignments", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getCourseWithRole(ctx, subject, params.CourseID, course.RoleStudent, course.RoleTeacher)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.Storage.Assignment().GetAllAssignments(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all assignments: %w", err)
+	}
+
+	if role != course.RoleTeacher {
+		for _, assignment := range assignments {
+			hideReferenceSolution(assignment)
+		}
+	}
+
+	return assignments, nil
+}
+
+type CreateAssignmentParams struct {
+	CourseID           model.CourseID
+	Title              string
+	Description        string
+	StarterDiagramID   *model.DiagramID
+	Deadline           *time.Time
+	ReferenceDiagramID *model.DiagramID
+	Rubric             *model.GradingRubric
+	ExamStartsAt       *time.Time
+	ExamEndsAt         *time.Time
+	PeerReview         *model.PeerReviewSettings
+	PeerReviewEndsAt   *time.Time
+}
+
+func (s *ServiceImpl) CreateAssignment(ctx context.Context, params *CreateAssignmentParams) (*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "create assignment", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	title := strings.TrimSpace(params.Title)
+	if title == "" || len(title) > maxTitleLength {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidTitle)
+	}
+
+	if params.Rubric != nil {
+		if err := grading.ValidateRubric(params.Rubric); err != nil {
+			return nil, xerrors.WrapInvalidArgument(err)
+		}
+	}
+
+	err = validateExamWindow(params.ExamStartsAt, params.ExamEndsAt, params.StarterDiagramID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validatePeerReview(params.PeerReview, params.PeerReviewEndsAt, params.Deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	assignmentID, err := utils.GenerateID(assignmentIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	var assignment *model.Assignment
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		_, err := s.getCourse(ctx, subject, params.CourseID, course.RoleTeacher)
+		if err != nil {
+			return err
+		}
+
+		if params.StarterDiagramID != nil {
+			_, err = s.getReadableDiagram(ctx, *params.StarterDiagramID)
+			if err != nil {
+				return err
+			}
+		}
+
+		if params.ReferenceDiagramID != nil {
+			_, err = s.getReadableDiagram(ctx, *params.ReferenceDiagramID)
+			if err != nil {
+				return err
+			}
+		}
+
+		assignment, err = s.Storage.Assignment().CreateAssignment(ctx, &storage.CreateAssignmentParams{
+			ID:                 model.AssignmentID(assignmentID),
+			CourseID:           params.CourseID,
+			Title:              title,
+			Description:        params.Description,
+			StarterDiagramID:   params.StarterDiagramID,
+			Deadline:           params.Deadline,
+			ReferenceDiagramID: params.ReferenceDiagramID,
+			Rubric:             params.Rubric,
+			ExamStartsAt:       params.ExamStartsAt,
+			ExamEndsAt:         params.ExamEndsAt,
+			PeerReview:         params.PeerReview,
+			PeerReviewEndsAt:   params.PeerReviewEndsAt,
+			CreatedBy:          subject.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("create assignment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create assignment: %w", err)
+	}
+
+	return assignment, nil
+}
+
+type PatchAssignmentParams struct {
+	ID model.AssignmentID
+
+	Title              utils.Optional[string]
+	Description        utils.Optional[string]
+	StarterDiagramID   utils.Optional[*model.DiagramID]
+	Deadline           utils.Optional[*time.Time]
+	ReferenceDiagramID utils.Optional[*model.DiagramID]
+	Rubric             utils.Optional[*model.GradingRubric]
+	ExamStartsAt       utils.Optional[*time.Time]
+	ExamEndsAt         utils.Optional[*time.Time]
+	PeerReview         utils.Optional[*model.PeerReviewSettings]
+	PeerReviewEndsAt   utils.Optional[*time.Time]
+}
+
+// PatchAssignment changes the assignment, moving the exam end also moves the end of sessions in progress.
+// Once reviewers are assigned only the end of the peer review can be changed.
+func (s *ServiceImpl) PatchAssignment(ctx context.Context, params *PatchAssignmentParams) (*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "patch assignment", slog.Any("params", params))
+
+	if params.Title.Valid {
+		params.Title.Value = strings.TrimSpace(params.Title.Value)
+		if params.Title.Value == "" || len(params.Title.Value) > maxTitleLength {
+			return nil, xerrors.WrapInvalidArgument(ErrInvalidTitle)
+		}
+	}
+
+	if params.Rubric.Valid && params.Rubric.Value != nil {
+		if err := grading.ValidateRubric(params.Rubric.Value); err != nil {
+			return nil, xerrors.WrapInvalidArgument(err)
+		}
+	}
+
+	var assignment *model.Assignment
+	err := s.doAsAssignmentTeacher(ctx, params.ID, func(ctx context.Context, current *model.Assignment) error {
+		err := validateExamWindow(
+			params.ExamStartsAt.ValueOr(current.ExamStartsAt),
+			params.ExamEndsAt.ValueOr(current.ExamEndsAt),
+			params.StarterDiagramID.ValueOr(current.StarterDiagramID),
+		)
+		if err != nil {
+			return err
+		}
+
+		if current.PeerReviewsAssignedAt != nil && (params.PeerReview.Valid || params.Deadline.Valid) {
+			return xerrors.WrapConflict(ErrPeerReviewStarted)
+		}
+
+		err = validatePeerReview(
+			params.PeerReview.ValueOr(current.PeerReview),
+			params.PeerReviewEndsAt.ValueOr(current.PeerReviewEndsAt),
+			params.Deadline.ValueOr(current.Deadline),
+		)
+		if err != nil {
+			return err
+		}
+
+		if params.StarterDiagramID.Valid && params.StarterDiagramID.Value != nil {
+			_, err := s.getReadableDiagram(ctx, *params.StarterDiagramID.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		if params.ReferenceDiagramID.Valid && params.ReferenceDiagramID.Value != nil {
+			_, err := s.getReadableDiagram(ctx, *params.ReferenceDiagramID.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		assignment, err = s.Storage.Assignment().PatchAssignment(ctx, &storage.PatchAssignmentParams{
+			ID:                 params.ID,
+			Title:              params.Title,
+			Description:        params.Description,
+			StarterDiagramID:   params.StarterDiagramID,
+			Deadline:           params.Deadline,
+			ReferenceDiagramID: params.ReferenceDiagramID,
+			Rubric:             params.Rubric,
+			ExamStartsAt:       params.ExamStartsAt,
+			ExamEndsAt:         params.ExamEndsAt,
+			PeerReview:         params.PeerReview,
+			PeerReviewEndsAt:   params.PeerReviewEndsAt,
+		})
+		if err != nil {
+			return fmt.Errorf("patch assignment: %w", err)
+		}
+
+		if params.ExamEndsAt.Valid && assignment.IsExam() {
+			err = s.moveExamSessionsEnd(ctx, assignment)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't patch assignment: %w", err)
+	}
+
+	return assignment, nil
+}
+
+type DeleteAssignmentParams struct {
+	ID model.AssignmentID
+}
+
+func (s *ServiceImpl) DeleteAssignment(ctx context.Context, params *DeleteAssignmentParams) (*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "delete assignment", slog.Any("params", params))
+
+	var assignment *model.Assignment
+	err := s.doAsAssignmentTeacher(ctx, params.ID, func(ctx context.Context, _ *model.Assignment) error {
+		var err error
+		assignment, err = s.Storage.Assignment().DeleteAssignment(ctx, params.ID)
+		if err != nil {
+			return fmt.Errorf("delete assignment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't delete assignment: %w", err)
+	}
+
+	return assignment, nil
+}
+
+type StartAssignmentParams struct {
+	ID model.AssignmentID
+}
+
+// StartAssignment creates a diagram owned by the subject from the assignment starter diagram until the deadline,
+// the diagram started earlier is returned instead. For exams it also opens the exam session
+func (s *ServiceImpl) StartAssignment(ctx context.Context, params *StartAssignmentParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "start assignment", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if role != course.RoleStudent {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+	if assignment.StarterDiagramID == nil {
+		return nil, xerrors.WrapInvalidArgument(ErrNoStarterDiagram)
+	}
+
+	// Starter diagram belongs to the teacher, access is granted by the course enrollment
+	starterDiagram, err := s.Storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, *assignment.StarterDiagramID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrDiagramNotFound)
+		}
+		return nil, fmt.Errorf("get diagram by id: %w", err)
+	}
+
+	content, err := s.getContent(ctx, starterDiagram.ObjectStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if assignment.IsExam() {
+		diagramModel, err := s.startExam(ctx, subject, assignment, starterDiagram, content)
+		if err != nil {
+			return nil, fmt.Errorf("can't start exam: %w", err)
+		}
+
+		return diagramModel, nil
+	}
+
+	if assignment.IsOverdue(time.Now()) {
+		return nil, xerrors.WrapForbidden(ErrDeadlinePassed)
+	}
+
+	var diagramModel *model.Diagram
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// Concurrent requests of the student must not start two diagrams
+		_, err := s.Storage.Assignment().GetAssignmentByID(ctx, assignment.ID, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get assignment by id: %w", err)
+		}
+
+		diagramModel, err = s.getStartedDiagram(ctx, subject.UserID, assignment.ID)
+		if err != nil {
+			return err
+		}
+		if diagramModel != nil {
+			return nil
+		}
+
+		diagramModel, err = s.startDiagram(ctx, subject.UserID, assignment, starterDiagram, content)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't start assignment: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+type SubmitAssignmentParams struct {
+	AssignmentID model.AssignmentID
+	DiagramID    model.DiagramID
+}
+
+// SubmitAssignment snapshots the diagram content, re-submission replaces the previous snapshot until the deadline.
+// Exams accept only the session diagram and submitting closes the session.
+func (s *ServiceImpl) SubmitAssignment(ctx context.Context, params *SubmitAssignmentParams) (*model.Submission, error) {
+	ctxlog.Info(ctx, s.Logger, "submit assignment", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	var (
+		assignment *model.Assignment
+		submission *model.Submission
+	)
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		var (
+			role course.Role
+			err  error
+		)
+		assignment, role, err = s.getAssignment(ctx, subject, params.AssignmentID, storage.WithLock())
+		if err != nil {
+			return err
+		}
+		if role != course.RoleStudent {
+			return xerrors.WrapForbidden(ErrForbidden)
+		}
+		if assignment.IsOverdue(time.Now()) {
+			return xerrors.WrapForbidden(ErrDeadlinePassed)
+		}
+
+		var examSession *model.ExamSession
+		if assignment.IsExam() {
+			examSession, err = s.getExamSession(ctx, assignment.ID, subject.UserID)
+			if err != nil {
+				return err
+			}
+			if examSession == nil {
+				return xerrors.WrapForbidden(ErrExamNotStarted)
+			}
+			if !examSession.IsOpen(time.Now()) {
+				return xerrors.WrapForbidden(ErrExamFinished)
+			}
+			if examSession.DiagramID != params.DiagramID {
+				return xerrors.WrapInvalidArgument(ErrNotExamDiagram)
+			}
+		}
+
+		rowPolicy, err := storage.RowPolicyFromContext(ctx)
+		if err != nil {
+			return fmt.Errorf("row policy from context: %w", err)
+		}
+
+		diagramModel, err := s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, params.DiagramID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrDiagramNotFound)
+			}
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		submission, err = SaveSubmission(ctx, s.Storage, s.S3Client, &SaveSubmissionParams{
+			AssignmentID: params.AssignmentID,
+			UserID:       subject.UserID,
+			Diagram:      diagramModel,
+			ExamSession:  examSession,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't submit assignment: %w", err)
+	}
+
+	// Grading is best effort, the submission is accepted even if the reference solution is broken
+	if assignment.ReferenceDiagramID != nil {
+		reference, err := s.getReferenceSchema(ctx, assignment)
+		if err != nil {
+			ctxlog.Error(ctx, s.Logger, "get reference schema", slog.Any("error", err))
+		} else if _, err := s.gradeSubmission(ctx, reference, assignment.Rubric, submission, *submission.Content.Value); err != nil {
+			ctxlog.Error(ctx, s.Logger, "grade submission", slog.Any("error", err))
+		}
+	}
+
+	hideFromStudent(assignment, submission)
+
+	return submission, nil
+}
+
+type GetSubmissionParams struct {
+	ID model.SubmissionID
+}
+
+// GetSubmission returns the submission with its snapshot content to the author and course teachers,
+// authors taking an exam can't read their submissions until it ends
+func (s *ServiceImpl) GetSubmission(ctx context.Context, params *GetSubmissionParams) (*model.Submission, error) {
+	ctxlog.Info(ctx, s.Logger, "get submission", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	submission, err := s.Storage.Submission().GetSubmissionByID(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrSubmissionNotFound)
+		}
+		return nil, fmt.Errorf("get submission by id: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if role != course.RoleTeacher {
+		if submission.UserID != subject.UserID {
+			return nil, xerrors.WrapNotFound(ErrSubmissionNotFound)
+		}
+
+		// Past submissions could be used to bring in prepared content during the exam
+		examSession, err := diagram.GetOpenExamSession(ctx, s.Storage, subject.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if examSession != nil {
+			return nil, xerrors.WrapForbidden(diagram.ErrExamInProgress)
+		}
+
+		hideFromStudent(assignment, submission)
+	}
+
+	content, err := s.getContent(ctx, submission.ObjectStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	submission.Content = utils.NewSecret(&content)
+
+	return submission, nil
+}
+
+type ListSubmissionsParams struct {
+	AssignmentID model.AssignmentID
+}
+
+// ListSubmissions returns all submissions to teachers and only own submission to students
+func (s *ServiceImpl) ListSubmissions(ctx context.Context, params *ListSubmissionsParams) ([]*model.Submission, error) {
+	ctxlog.Info(ctx, s.Logger, "list submissions", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, params.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     params.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+	isTeacher := role == course.RoleTeacher
+	if !isTeacher {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	submissions, err := s.Storage.Submission().GetAllSubmissions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all submissions: %w", err)
+	}
+
+	if !isTeacher {
+		for _, submission := range submissions {
+			hideFromStudent(assignment, submission)
+		}
+	}
+
+	return submissions, nil
+}
+
+type GradeSubmissionsParams struct {
+	AssignmentID model.AssignmentID
+}
+
+// GradeSubmissions grades all submissions of the assignment against its reference diagram and stores the results,
+// a submission which content can't be read gets the result with the error
+func (s *ServiceImpl) GradeSubmissions(ctx context.Context, params *GradeSubmissionsParams) ([]*model.Submission, error) {
+	ctxlog.Info(ctx, s.Logger, "grade submissions", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, params.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if role != course.RoleTeacher {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+	if assignment.ReferenceDiagramID == nil {
+		return nil, xerrors.WrapInvalidArgument(ErrNoReferenceDiagram)
+	}
+
+	reference, err := s.getReferenceSchema(ctx, assignment)
+	if err != nil {
+		return nil, fmt.Errorf("can't grade submissions: %w", err)
+	}
+
+	submissions, err := s.Storage.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     params.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all submissions: %w", err)
+	}
+
+	result := make([]*model.Submission, 0, len(submissions))
+	for _, submission := range submissions {
+		var graded *model.Submission
+		content, err := s.getContent(ctx, submission.ObjectStorageKey)
+		if err != nil {
+			// The lost snapshot fails only its own grading
+			ctxlog.Error(ctx, s.Logger, "get submission content", slog.String("submission_id", submission.ID.String()), slog.Any("error", err))
+			graded, err = s.saveGradingResult(ctx, submission, &model.GradingResult{Error: ErrSubmissionContentUnreadable.Error()})
+		} else {
+			graded, err = s.gradeSubmission(ctx, reference, assignment.Rubric, submission, content)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't grade submission %s: %w", submission.ID, err)
+		}
+
+		result = append(result, graded)
+	}
+
+	return result, nil
+}
+
+type RequestPlagiarismCheckParams struct {
+	AssignmentID model.AssignmentID
+}
+
+// RequestPlagiarismCheck schedules plagiarism detection, it is performed by the background worker
+func (s *ServiceImpl) RequestPlagiarismCheck(ctx context.Context, params *RequestPlagiarismCheckParams) (*model.PlagiarismCheck, error) {
+	ctxlog.Info(ctx, s.Logger, "request plagiarism check", slog.Any("params", params))
+
+	var check *model.PlagiarismCheck
+	err := s.doAsAssignmentTeacher(ctx, params.AssignmentID, func(ctx context.Context, _ *model.Assignment) error {
+		_, err := s.Storage.PlagiarismCheck().GetPlagiarismCheck(ctx, params.AssignmentID, storage.WithLock())
+		if errors.Is(err, storage.ErrNotFound) {
+			check, err = s.Storage.PlagiarismCheck().CreatePlagiarismCheck(ctx, &storage.CreatePlagiarismCheckParams{
+				AssignmentID: params.AssignmentID,
+			})
+			if err != nil {
+				return fmt.Errorf("create plagiarism check: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get plagiarism check: %w", err)
+		}
+
+		check, err = s.Storage.PlagiarismCheck().PatchPlagiarismCheck(ctx, &storage.PatchPlagiarismCheckParams{
+			AssignmentID: params.AssignmentID,
+			Status:       utils.NewOptional(model.PlagiarismCheckStatusPending),
+			RequestedAt:  utils.NewOptional(time.Now()),
+		})
+		if err != nil {
+			return fmt.Errorf("patch plagiarism check: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't request plagiarism check: %w", err)
+	}
+
+	return check, nil
+}
+
+type GetPlagiarismReportParams struct {
+	AssignmentID model.AssignmentID
+}
+
+// GetPlagiarismReport returns suspicious pairs found by the last check, the check is nil if it was never requested
+func (s *ServiceImpl) GetPlagiarismReport(ctx context.Context, params *GetPlagiarismReportParams) (*model.PlagiarismReport, error) {
+	ctxlog.Info(ctx, s.Logger, "get plagiarism report", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getAssignment(ctx, subject, params.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if role != course.RoleTeacher {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	report := &model.PlagiarismReport{}
+
+	report.Check, err = s.Storage.PlagiarismCheck().GetPlagiarismCheck(ctx, params.AssignmentID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return report, nil
+		}
+		return nil, fmt.Errorf("get plagiarism check: %w", err)
+	}
+
+	report.Pairs, err = s.Storage.PlagiarismPair().GetAllPlagiarismPairs(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     params.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all plagiarism pairs: %w", err)
+	}
+
+	return report, nil
+}
+
+type SetSubmissionGradeParams struct {
+	ID model.SubmissionID
+	// Nil removes the grade
+	Grade    *float64
+	Feedback string
+}
+
+func (s *ServiceImpl) SetSubmissionGrade(ctx context.Context, params *SetSubmissionGradeParams) (*model.Submission, error) {
+	ctxlog.Info(ctx, s.Logger, "set submission grade", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	if params.Grade != nil && (*params.Grade < 0 || *params.Grade > maxGrade) {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidGrade)
+	}
+
+	var submission *model.Submission
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.Storage.Submission().GetSubmissionByID(ctx, params.ID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrSubmissionNotFound)
+			}
+			return fmt.Errorf("get submission by id: %w", err)
+		}
+
+		_, role, err := s.getAssignment(ctx, subject, current.AssignmentID)
+		if err != nil {
+			return err
+		}
+		if role != course.RoleTeacher {
+			if current.UserID == subject.UserID {
+				return xerrors.WrapForbidden(ErrForbidden)
+			}
+			return xerrors.WrapNotFound(ErrSubmissionNotFound)
+		}
+
+		var gradedBy *model.UserID
+		if params.Grade != nil {
+			gradedBy = &subject.UserID
+		}
+
+		submission, err = s.Storage.Submission().PatchSubmission(ctx, &storage.PatchSubmissionParams{
+			ID:       params.ID,
+			Grade:    utils.NewOptional(params.Grade),
+			Feedback: utils.NewOptional(params.Feedback),
+			GradedBy: utils.NewOptional(gradedBy),
+		})
+		if err != nil {
+			return fmt.Errorf("patch submission: %w", err)
+		}
+		submission.UserLogin = current.UserLogin
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't set submission grade: %w", err)
+	}
+
+	return submission, nil
+}
+
+type ReleaseGradesParams struct {
+	AssignmentID model.AssignmentID
+	// False hides grades from students again
+	Released bool
+}
+
+func (s *ServiceImpl) ReleaseGrades(ctx context.Context, params *ReleaseGradesParams) (*model.Assignment, error) {
+	ctxlog.Info(ctx, s.Logger, "release grades", slog.Any("params", params))
+
+	var assignment *model.Assignment
+	err := s.doAsAssignmentTeacher(ctx, params.AssignmentID, func(ctx context.Context, current *model.Assignment) error {
+		if current.GradesReleased() == params.Released {
+			assignment = current
+			return nil
+		}
+
+		var releasedAt *time.Time
+		if params.Released {
+			now := time.Now()
+			releasedAt = &now
+		}
+
+		var err error
+		assignment, err = s.Storage.Assignment().PatchAssignment(ctx, &storage.PatchAssignmentParams{
+			ID:               params.AssignmentID,
+			GradesReleasedAt: utils.NewOptional(releasedAt),
+		})
+		if err != nil {
+			return fmt.Errorf("patch assignment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't release grades: %w", err)
+	}
+
+	return assignment, nil
+}
+
+type ExportGradebookParams struct {
+	CourseID model.CourseID
+	// Optional, all course students are exported if nil
+	GroupID *model.CourseGroupID
+}
+
+// ExportGradebook collects grades of all course assignments for enrolled students
+func (s *ServiceImpl) ExportGradebook(ctx context.Context, params *ExportGradebookParams) (*model.Gradebook, error) {
+	ctxlog.Info(ctx, s.Logger, "export gradebook", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	courseModel, err := s.getCourse(ctx, subject, params.CourseID, course.RoleTeacher)
+	if err != nil {
+		return nil, err
+	}
+
+	gradebook := &model.Gradebook{
+		Course: courseModel,
+	}
+
+	courseFilter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+
+	groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, courseFilter)
+	if err != nil {
+		return nil, fmt.Errorf("get all course groups: %w", err)
+	}
+
+	groupCodes := make(map[model.CourseGroupID]string, len(groups))
+	for _, group := range groups {
+		groupCodes[group.ID] = group.Code
+		if params.GroupID != nil && group.ID == *params.GroupID {
+			gradebook.Group = group
+		}
+	}
+	if params.GroupID != nil && gradebook.Group == nil {
+		return nil, xerrors.WrapNotFound(course.ErrCourseGroupNotFound)
+	}
+
+	enrollmentFilter := slices.Clone(courseFilter)
+	if params.GroupID != nil {
+		enrollmentFilter = append(enrollmentFilter, &model.FilterTerm{
+			Key:       model.TermKeyGroupID,
+			Value:     params.GroupID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, enrollmentFilter)
+	if err != nil {
+		return nil, fmt.Errorf("get all course enrollments: %w", err)
+	}
+
+	gradebook.Assignments, err = s.Storage.Assignment().GetAllAssignments(ctx, courseFilter)
+	if err != nil {
+		return nil, fmt.Errorf("get all assignments: %w", err)
+	}
+	// Assignments are listed newest first, the gradebook follows the course order
+	slices.Reverse(gradebook.Assignments)
+
+	rows := make(map[model.UserID]*model.GradebookRow, len(enrollments))
+	for _, enrollment := range enrollments {
+		row := &model.GradebookRow{
+			UserID:    enrollment.UserID,
+			UserLogin: enrollment.UserLogin,
+			Grades:    make([]*float64, len(gradebook.Assignments)),
+		}
+		if enrollment.GroupID != nil {
+			row.GroupCode = groupCodes[*enrollment.GroupID]
+		}
+
+		rows[enrollment.UserID] = row
+		gradebook.Rows = append(gradebook.Rows, row)
+	}
+
+	for i, assignment := range gradebook.Assignments {
+		submissions, err := s.Storage.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyAssignmentID,
+				Value:     assignment.ID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get all submissions: %w", err)
+		}
+
+		for _, submission := range submissions {
+			// Students who left the course or are in other groups are skipped
+			if row, ok := rows[submission.UserID]; ok {
+				row.Grades[i] = submission.Grade
+			}
+		}
+	}
+
+	return gradebook, nil
+}
+
+type GetCourseAnalyticsParams struct {
+	CourseID model.CourseID
+	// Optional, all course students are included if nil
+	GroupID *model.CourseGroupID
+	// Activity is aggregated for the last days, defaultAnalyticsDays if zero
+	Days int64
+}
+
+// GetCourseAnalytics aggregates the activity log and assignment progress of enrolled students
+func (s *ServiceImpl) GetCourseAnalytics(ctx context.Context, params *GetCourseAnalyticsParams) (*model.CourseAnalytics, error) {
+	ctxlog.Info(ctx, s.Logger, "get course analytics", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	since, err := analyticsSince(params.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	courseModel, err := s.getCourse(ctx, subject, params.CourseID, course.RoleTeacher)
+	if err != nil {
+		return nil, err
+	}
+
+	groupCodes, err := s.getCourseGroupCodes(ctx, params.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollmentFilter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+	if params.GroupID != nil {
+		if _, ok := groupCodes[*params.GroupID]; !ok {
+			return nil, xerrors.WrapNotFound(course.ErrCourseGroupNotFound)
+		}
+
+		enrollmentFilter = append(enrollmentFilter, &model.FilterTerm{
+			Key:       model.TermKeyGroupID,
+			Value:     params.GroupID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, enrollmentFilter)
+	if err != nil {
+		return nil, fmt.Errorf("get all course enrollments: %w", err)
+	}
+
+	analytics, err := s.collectAnalytics(ctx, courseModel, enrollments, groupCodes, since)
+	if err != nil {
+		return nil, fmt.Errorf("can't collect analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
+type GetStudentAnalyticsParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+	// Activity is aggregated for the last days, defaultAnalyticsDays if zero
+	Days int64
+}
+
+// GetStudentAnalytics is GetCourseAnalytics limited to a single enrolled student
+func (s *ServiceImpl) GetStudentAnalytics(ctx context.Context, params *GetStudentAnalyticsParams) (*model.CourseAnalytics, error) {
+	ctxlog.Info(ctx, s.Logger, "get student analytics", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	since, err := analyticsSince(params.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	courseModel, err := s.getCourse(ctx, subject, params.CourseID, course.RoleTeacher)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     params.UserID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all course enrollments: %w", err)
+	}
+	if len(enrollments) == 0 {
+		return nil, xerrors.WrapNotFound(course.ErrStudentNotFound)
+	}
+
+	groupCodes, err := s.getCourseGroupCodes(ctx, params.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := s.collectAnalytics(ctx, courseModel, enrollments, groupCodes, since)
+	if err != nil {
+		return nil, fmt.Errorf("can't collect analytics: %w", err)
+	}
+
+	return analytics, nil
+}
+
+func analyticsSince(days int64) (time.Time, error) {
+	if days == 0 {
+		days = defaultAnalyticsDays
+	}
+	if days < 1 || days > maxAnalyticsDays {
+		return time.Time{}, xerrors.WrapInvalidArgument(ErrInvalidAnalyticsPeriod)
+	}
+
+	return time.Now().AddDate(0, 0, -int(days)), nil
+}
+
+func (s *ServiceImpl) getCourseGroupCodes(ctx context.Context, courseID model.CourseID) (map[model.CourseGroupID]string, error) {
+	groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     courseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all course groups: %w", err)
+	}
+
+	groupCodes := make(map[model.CourseGroupID]string, len(groups))
+	for _, group := range groups {
+		groupCodes[group.ID] = group.Code
+	}
+	return groupCodes, nil
+}
+
+// collectAnalytics builds analytics of the enrolled students. An assignment is started when the student
+// created a diagram for it and submitted when there is a submission.
+func (s *ServiceImpl) collectAnalytics(
+	ctx context.Context,
+	courseModel *model.Course,
+	enrollments []*model.CourseEnrollment,
+	groupCodes map[model.CourseGroupID]string,
+	since time.Time,
+) (*model.CourseAnalytics, error) {
+	assignments, err := s.Storage.Assignment().GetAllAssignments(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     courseModel.ID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all assignments: %w", err)
+	}
+	// Assignments are listed newest first, analytics follows the course order
+	slices.Reverse(assignments)
+
+	userIDs := make([]model.UserID, 0, len(enrollments))
+	for _, enrollment := range enrollments {
+		userIDs = append(userIDs, enrollment.UserID)
+	}
+
+	statsParams := &storage.GetDiagramActivityStatsParams{
+		UserIDs: userIDs,
+		Since:   since,
+	}
+
+	stats, err := s.Storage.DiagramActivity().GetDiagramActivityStats(ctx, statsParams)
+	if err != nil {
+		return nil, fmt.Errorf("get diagram activity stats: %w", err)
+	}
+
+	analytics := &model.CourseAnalytics{
+		Course: courseModel,
+		Since:  since,
+	}
+
+	analytics.DailyEdits, err = s.Storage.DiagramActivity().GetDailyEdits(ctx, statsParams)
+	if err != nil {
+		return nil, fmt.Errorf("get daily edits: %w", err)
+	}
+
+	students := make(map[model.UserID]*model.StudentAnalytics, len(enrollments))
+	for _, enrollment := range enrollments {
+		student := &model.StudentAnalytics{
+			UserID:    enrollment.UserID,
+			UserLogin: enrollment.UserLogin,
+			// Students without activity have no stats row
+			Stats:    &model.DiagramActivityStats{UserID: enrollment.UserID},
+			Statuses: make([]model.AssignmentStatus, len(assignments)),
+		}
+		if enrollment.GroupID != nil {
+			student.GroupCode = groupCodes[*enrollment.GroupID]
+		}
+		for i := range student.Statuses {
+			student.Statuses[i] = model.AssignmentStatusNotStarted
+		}
+
+		students[enrollment.UserID] = student
+		analytics.Students = append(analytics.Students, student)
+	}
+
+	for _, userStats := range stats {
+		if student, ok := students[userStats.UserID]; ok {
+			student.Stats = userStats
+		}
+	}
+
+	if len(assignments) > 0 {
+		assignmentIndexes := make(map[model.AssignmentID]int, len(assignments))
+		assignmentIDs := make([]string, 0, len(assignments))
+		for i, assignment := range assignments {
+			assignmentIndexes[assignment.ID] = i
+			assignmentIDs = append(assignmentIDs, assignment.ID.String())
+		}
+
+		assignmentFilter := []*model.FilterTerm{
+			{
+				Key:       model.TermKeyAssignmentID,
+				Value:     assignmentIDs,
+				Operation: model.FilterOperationExact,
+			},
+		}
+
+		starts, err := s.Storage.DiagramActivity().GetAllDiagramActivities(ctx, assignmentFilter)
+		if err != nil {
+			return nil, fmt.Errorf("get all diagram activities: %w", err)
+		}
+
+		for _, start := range starts {
+			if student, ok := students[start.UserID]; ok {
+				student.Statuses[assignmentIndexes[*start.AssignmentID]] = model.AssignmentStatusStarted
+			}
+		}
+
+		submissions, err := s.Storage.Submission().GetAllSubmissions(ctx, assignmentFilter)
+		if err != nil {
+			return nil, fmt.Errorf("get all submissions: %w", err)
+		}
+
+		for _, submission := range submissions {
+			if student, ok := students[submission.UserID]; ok {
+				student.Statuses[assignmentIndexes[submission.AssignmentID]] = model.AssignmentStatusSubmitted
+			}
+		}
+	}
+
+	now := time.Now()
+	for i, assignment := range assignments {
+		progress := &model.AssignmentProgress{
+			Assignment: assignment,
+		}
+
+		dueAt := assignment.Deadline
+		if dueAt == nil {
+			dueAt = assignment.ExamEndsAt
+		}
+		dueSoon := dueAt != nil && dueAt.After(now) && dueAt.Sub(now) <= dueSoonPeriod
+
+		for _, student := range analytics.Students {
+			switch student.Statuses[i] {
+			case model.AssignmentStatusNotStarted:
+				progress.NotStarted++
+				if dueSoon {
+					student.NotStartedDueSoon++
+				}
+			case model.AssignmentStatusStarted:
+				progress.Started++
+			case model.AssignmentStatusSubmitted:
+				progress.Submitted++
+			}
+		}
+
+		analytics.Assignments = append(analytics.Assignments, progress)
+	}
+
+	return analytics, nil
+}
+
+// getReferenceSchema parses the reference diagram of the assignment
+func (s *ServiceImpl) getReferenceSchema(ctx context.Context, assignment *model.Assignment) (*schema.Schema, error) {
+	// Reference diagram may belong to another teacher of the course
+	referenceDiagram, err := s.Storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, *assignment.ReferenceDiagramID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrDiagramNotFound)
+		}
+		return nil, fmt.Errorf("get diagram by id: %w", err)
+	}
+
+	content, err := s.getContent(ctx, referenceDiagram.ObjectStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := schema.Parse(content)
+	if err != nil {
+		return nil, xerrors.WrapInvalidArgument(fmt.Errorf("parse reference diagram: %w", err))
+	}
+
+	return reference, nil
+}
+
+// gradeSubmission stores the grading result of the submission content, malformed content is graded with an error
+func (s *ServiceImpl) gradeSubmission(
+	ctx context.Context,
+	reference *schema.Schema,
+	rubric *model.GradingRubric,
+	submission *model.Submission,
+	content string,
+) (*model.Submission, error) {
+	var result *model.GradingResult
+	submissionSchema, err := schema.Parse(content)
+	if err != nil {
+		result = &model.GradingResult{Error: err.Error()}
+	} else {
+		result = grading.Grade(reference, submissionSchema, rubric)
+	}
+
+	return s.saveGradingResult(ctx, submission, result)
+}
+
+// saveGradingResult stores the auto grading result of the submission
+func (s *ServiceImpl) saveGradingResult(ctx context.Context, submission *model.Submission, result *model.GradingResult) (*model.Submission, error) {
+	graded, err := s.Storage.Submission().PatchSubmission(ctx, &storage.PatchSubmissionParams{
+		ID:          submission.ID,
+		AutoGrading: utils.NewOptional(result),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch submission: %w", err)
+	}
+	graded.UserLogin = submission.UserLogin
+
+	return graded, nil
+}
+
+// startExam creates the exam diagram and opens the session, repeated calls return the diagram of the open session
+func (s *ServiceImpl) startExam(
+	ctx context.Context,
+	subject *auth.Subject,
+	assignment *model.Assignment,
+	starterDiagram *model.Diagram,
+	content string,
+) (*model.Diagram, error) {
+	now := time.Now()
+	if now.Before(*assignment.ExamStartsAt) {
+		return nil, xerrors.WrapForbidden(ErrExamNotStarted)
+	}
+	if assignment.IsOverdue(now) {
+		return nil, xerrors.WrapForbidden(ErrExamFinished)
+	}
+
+	examSessionID, err := utils.GenerateID(examSessionIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	var diagramModel *model.Diagram
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// Concurrent requests of the student must not open two sessions
+		_, err := s.Storage.Assignment().GetAssignmentByID(ctx, assignment.ID, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get assignment by id: %w", err)
+		}
+
+		examSession, err := s.getExamSession(ctx, assignment.ID, subject.UserID)
+		if err != nil {
+			return err
+		}
+
+		if examSession != nil {
+			if !examSession.IsOpen(now) {
+				return xerrors.WrapForbidden(ErrExamFinished)
+			}
+
+			diagramModel, err = s.Storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, examSession.DiagramID)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return xerrors.WrapNotFound(ErrDiagramNotFound)
+				}
+				return fmt.Errorf("get diagram by id: %w", err)
+			}
+
+			sessionContent, err := s.getContent(ctx, diagramModel.ObjectStorageKey)
+			if err != nil {
+				return err
+			}
+			diagramModel.Content = utils.NewSecret(&sessionContent)
+
+			return nil
+		}
+
+		diagramModel, err = s.startDiagram(ctx, subject.UserID, assignment, starterDiagram, content)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.Storage.ExamSession().CreateExamSession(ctx, &storage.CreateExamSessionParams{
+			ID:           model.ExamSessionID(examSessionID),
+			AssignmentID: assignment.ID,
+			UserID:       subject.UserID,
+			DiagramID:    diagramModel.ID,
+			EndsAt:       *assignment.ExamEndsAt,
+		})
+		if err != nil {
+			return fmt.Errorf("create exam session: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diagramModel, nil
+}
+
+// getExamSession returns the session of the user for the exam or nil if the user hasn't started it
+func (s *ServiceImpl) getExamSession(ctx context.Context, assignmentID model.AssignmentID, userID model.UserID) (*model.ExamSession, error) {
+	examSessions, err := s.Storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     assignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all exam sessions: %w", err)
+	}
+	if len(examSessions) == 0 {
+		return nil, nil
+	}
+
+	return examSessions[0], nil
+}
+
+// moveExamSessionsEnd applies the exam end of the assignment to sessions in progress
+func (s *ServiceImpl) moveExamSessionsEnd(ctx context.Context, assignment *model.Assignment) error {
+	examSessions, err := s.Storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     assignment.ID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyClosedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get all exam sessions: %w", err)
+	}
+
+	for _, examSession := range examSessions {
+		_, err = s.Storage.ExamSession().PatchExamSession(ctx, &storage.PatchExamSessionParams{
+			ID:     examSession.ID,
+			EndsAt: utils.NewOptional(*assignment.ExamEndsAt),
+		})
+		if err != nil {
+			return fmt.Errorf("patch exam session: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateExamWindow checks the exam settings of the assignment, exams need both bounds and a starter diagram
+func validateExamWindow(startsAt, endsAt *time.Time, starterDiagramID *model.DiagramID) error {
+	if startsAt == nil && endsAt == nil {
+		return nil
+	}
+	if startsAt == nil || endsAt == nil || !startsAt.Before(*endsAt) {
+		return xerrors.WrapInvalidArgument(ErrInvalidExamWindow)
+	}
+	if starterDiagramID == nil {
+		return xerrors.WrapInvalidArgument(ErrNoStarterDiagram)
+	}
+
+	return nil
+}
+
+// validatePeerReview checks that peer review settings and the end are set together,
+// reviewers are assigned at the deadline, so it is required
+func validatePeerReview(settings *model.PeerReviewSettings, endsAt, deadline *time.Time) error {
+	if settings == nil && endsAt == nil {
+		return nil
+	}
+	if settings == nil || endsAt == nil || deadline == nil || !deadline.Before(*endsAt) {
+		return xerrors.WrapInvalidArgument(ErrInvalidPeerReview)
+	}
+	if settings.ReviewersCount < 1 || settings.ReviewersCount > maxPeerReviewersCount {
+		return xerrors.WrapInvalidArgument(ErrInvalidPeerReview)
+	}
+	if len(settings.Criteria) == 0 || len(settings.Criteria) > maxPeerReviewCriteria {
+		return xerrors.WrapInvalidArgument(ErrInvalidPeerReview)
+	}
+	for _, criterion := range settings.Criteria {
+		if strings.TrimSpace(criterion) == "" || len(criterion) > maxCriterionLength {
+			return xerrors.WrapInvalidArgument(ErrInvalidPeerReview)
+		}
+	}
+
+	return nil
+}
+
+func hideReferenceSolution(assignment *model.Assignment) {
+	assignment.ReferenceDiagramID = nil
+	assignment.Rubric = nil
+}
+
+// hideFromStudent hides the preliminary automatic grade and the grade which is not released yet
+func hideFromStudent(assignment *model.Assignment, submission *model.Submission) {
+	submission.AutoGrading = nil
+	submission.AutoGradedAt = nil
+
+	if !assignment.GradesReleased() {
+		submission.Grade = nil
+		submission.Feedback = ""
+		submission.GradedBy = nil
+		submission.GradedAt = nil
+	}
+}
+
+// getCourse returns the course if the subject has one of allowed roles in it
+func (s *ServiceImpl) getCourse(ctx context.Context, subject *auth.Subject, id model.CourseID, allowedRoles ...course.Role) (*model.Course, error) {
+	courseModel, _, err := s.getCourseWithRole(ctx, subject, id, allowedRoles...)
+	return courseModel, err
+}
+
+func (s *ServiceImpl) getCourseWithRole(
+	ctx context.Context,
+	subject *auth.Subject,
+	id model.CourseID,
+	allowedRoles ...course.Role,
+) (*model.Course, course.Role, error) {
+	courseModel, role, err := course.GetCourseRole(ctx, s.Storage, subject, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, course.RoleNone, xerrors.WrapNotFound(course.ErrCourseNotFound)
+		}
+		return nil, course.RoleNone, fmt.Errorf("get course role: %w", err)
+	}
+
+	for _, allowedRole := range allowedRoles {
+		if role == allowedRole {
+			return courseModel, role, nil
+		}
+	}
+
+	if role == course.RoleNone {
+		return nil, course.RoleNone, xerrors.WrapNotFound(course.ErrCourseNotFound)
+	}
+	return nil, course.RoleNone, xerrors.WrapForbidden(ErrForbidden)
+}
+
+// getAssignment returns the assignment with the subject's role in its course,
+// assignments of courses the subject doesn't belong to are not found
+func (s *ServiceImpl) getAssignment(
+	ctx context.Context,
+	subject *auth.Subject,
+	id model.AssignmentID,
+	opts ...storage.RequestOption,
+) (*model.Assignment, course.Role, error) {
+	assignment, err := s.Storage.Assignment().GetAssignmentByID(ctx, id, opts...)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, course.RoleNone, xerrors.WrapNotFound(ErrAssignmentNotFound)
+		}
+		return nil, course.RoleNone, fmt.Errorf("get assignment by id: %w", err)
+	}
+
+	_, role, err := course.GetCourseRole(ctx, s.Storage, subject, assignment.CourseID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, course.RoleNone, xerrors.WrapNotFound(ErrAssignmentNotFound)
+		}
+		return nil, course.RoleNone, fmt.Errorf("get course role: %w", err)
+	}
+	if role == course.RoleNone {
+		return nil, course.RoleNone, xerrors.WrapNotFound(ErrAssignmentNotFound)
+	}
+
+	return assignment, role, nil
+}
+
+// doAsAssignmentTeacher locks the assignment managed by the subject and calls f in the same transaction
+func (s *ServiceImpl) doAsAssignmentTeacher(ctx context.Context, id model.AssignmentID, f func(ctx context.Context, assignment *model.Assignment) error) error {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+
+	return s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		assignment, role, err := s.getAssignment(ctx, subject, id, storage.WithLock())
+		if err != nil {
+			return err
+		}
+		if role != course.RoleTeacher {
+			return xerrors.WrapForbidden(ErrForbidden)
+		}
+
+		return f(ctx, assignment)
+	})
+}
+
+// getReadableDiagram returns the diagram if the subject is allowed to read it
+func (s *ServiceImpl) getReadableDiagram(ctx context.Context, id model.DiagramID) (*model.Diagram, error) {
+	rowPolicy, err := storage.ReadRowPolicyFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read row policy from context: %w", err)
+	}
+
+	diagramModel, err := s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrDiagramNotFound)
+		}
+		return nil, fmt.Errorf("get diagram by id: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+func (s *ServiceImpl) getContent(ctx context.Context, objectStorageKey string) (string, error) {
+	content, err := s.S3Client.GetContent(ctx, objectStorageKey)
+	if err != nil {
+		if errors.Is(err, s3client.ErrContentNotFound) {
+			return "", xerrors.WrapNotFound(ErrDiagramContentNotFound)
+		}
+		return "", fmt.Errorf("get content: %w", err)
+	}
+
+	return content, nil
+}
+
+// getStartedDiagram returns the latest diagram the student started for the assignment with its content,
+// nil if there is none or it was deleted
+func (s *ServiceImpl) getStartedDiagram(ctx context.Context, userID model.UserID, assignmentID model.AssignmentID) (*model.Diagram, error) {
+	starts, err := s.Storage.DiagramActivity().GetAllDiagramActivities(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     assignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all diagram activities: %w", err)
+	}
+
+	for i := len(starts) - 1; i >= 0; i-- {
+		diagramModel, err := s.Storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyBackground{}, starts[i].DiagramID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		content, err := s.getContent(ctx, diagramModel.ObjectStorageKey)
+		if err != nil {
+			return nil, err
+		}
+		diagramModel.Content = utils.NewSecret(&content)
+
+		return diagramModel, nil
+	}
+
+	return nil, nil
+}
+
+// startDiagram creates the diagram of the student from the starter diagram, the start is recorded to the activity log
+func (s *ServiceImpl) startDiagram(
+	ctx context.Context,
+	userID model.UserID,
+	assignment *model.Assignment,
+	starterDiagram *model.Diagram,
+	content string,
+) (*model.Diagram, error) {
+	return s.DiagramService.CreateDiagram(ctx, &diagram.CreateDiagramParams{
+		UserID:       userID,
+		Content:      utils.NewSecret(content),
+		Name:         assignment.Title,
+		TablesCount:  starterDiagram.TablesCount,
+		AssignmentID: &assignment.ID,
+	})
+}
+
+func NewService(logger *slog.Logger, storage storage.Storage, s3Client s3client.Client, diagramService diagram.Service) *ServiceImpl {
+	return &ServiceImpl{
+		Logger:         logger.With("name", "service/assignment"),
+		Storage:        storage,
+		S3Client:       s3Client,
+		DiagramService: diagramService,
+	}
+}
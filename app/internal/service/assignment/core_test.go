@@ -0,0 +1,412 @@
+package assignment
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
+	"github.com/IvLaptev/chartdb-back/internal/tests"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	gomock "go.uber.org/mock/gomock"
+)
+
+const starterContent = `{"tables":[]}`
+
+type AssignmentServiceSuite struct {
+	suite.Suite
+
+	AssignmentService *ServiceImpl
+	DiagramService    *diagram.ServiceImpl
+	storage           storage.Storage
+	logger            *slog.Logger
+	s3client          *s3client.MockClient
+	// Object storage content by key
+	contents map[string]string
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(AssignmentServiceSuite))
+}
+
+func (s *AssignmentServiceSuite) SetupSuite() {
+	var err error
+	s.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s.storage, err = postgres.NewStorage(*tests.NewPostgresTestConfig(), s.logger)
+	assert.NoError(s.T(), err)
+}
+
+func (s *AssignmentServiceSuite) SetupTest() {
+	s.storage.Erase(context.Background())
+
+	s.contents = map[string]string{}
+	s.s3client = s3client.NewMockClient(gomock.NewController(s.T()))
+	s.s3client.EXPECT().SaveContent(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string, content string) error {
+		s.contents[key] = content
+		return nil
+	}).AnyTimes()
+	s.s3client.EXPECT().GetContent(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, key string) (string, error) {
+		content, ok := s.contents[key]
+		if !ok {
+			return "", s3client.ErrContentNotFound
+		}
+		return content, nil
+	}).AnyTimes()
+
+	s.DiagramService = diagram.NewService(s.logger, s.storage, s.s3client, 2*time.Minute)
+	s.AssignmentService = NewService(s.logger, s.storage, s.s3client, s.DiagramService)
+}
+
+// createUser returns the context with the created user as the subject
+func (s *AssignmentServiceSuite) createUser(ctx context.Context, userID model.UserID, userType model.UserType) context.Context {
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:          userID,
+		Login:       userID.String() + "@edu.mirea.ru",
+		Type:        userType,
+		ConfirmedAt: ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: userModel.Type,
+	})
+}
+
+// createCourse creates the course of the teacher with the student enrolled
+func (s *AssignmentServiceSuite) createCourse(ctx context.Context, courseID model.CourseID, teacherID, studentID model.UserID) {
+	_, err := s.storage.Course().CreateCourse(ctx, &storage.CreateCourseParams{
+		ID:        courseID,
+		Name:      courseID.String(),
+		JoinCode:  courseID.String(),
+		CreatedBy: teacherID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.CourseTeacher().CreateCourseTeacher(ctx, &storage.CreateCourseTeacherParams{
+		CourseID: courseID,
+		UserID:   teacherID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.CourseEnrollment().CreateCourseEnrollment(ctx, &storage.CreateCourseEnrollmentParams{
+		CourseID: courseID,
+		UserID:   studentID,
+	})
+	s.Require().NoError(err)
+}
+
+// createAssignment returns the assignment of the course with the starter diagram of the teacher
+func (s *AssignmentServiceSuite) createAssignment(teacherCtx context.Context, courseID model.CourseID, deadline *time.Time) *model.Assignment {
+	subject, err := auth.GetSubject(teacherCtx)
+	s.Require().NoError(err)
+
+	starterDiagram, err := s.DiagramService.CreateDiagram(teacherCtx, &diagram.CreateDiagramParams{
+		UserID:      subject.UserID,
+		Content:     utils.NewSecret(starterContent),
+		Name:        "Starter",
+		TablesCount: 1,
+	})
+	s.Require().NoError(err)
+
+	assignment, err := s.AssignmentService.CreateAssignment(teacherCtx, &CreateAssignmentParams{
+		CourseID:         courseID,
+		Title:            "Library",
+		StarterDiagramID: &starterDiagram.ID,
+		Deadline:         deadline,
+	})
+	s.Require().NoError(err)
+
+	return assignment
+}
+
+// editDiagram saves the new content of the diagram as the editor does
+func (s *AssignmentServiceSuite) editDiagram(ctx context.Context, diagramID model.DiagramID, content string) {
+	_, err := s.DiagramService.PatchDiagram(ctx, &diagram.PatchDiagramParams{
+		ID:      diagramID,
+		Content: utils.NewOptional(utils.NewSecret(content)),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *AssignmentServiceSuite) TestStartAssignment() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserID("student"), diagramModel.UserID)
+	s.Require().Equal("Library", diagramModel.Name)
+	s.Require().Equal(int64(1), diagramModel.TablesCount)
+	s.Require().NotEmpty(diagramModel.ClientDiagramID)
+	s.Require().Equal(starterContent, s.contents[diagramModel.ObjectStorageKey])
+
+	// The copy belongs to the student, the starter diagram is not shared
+	s.Require().NotEqual(*assignment.StarterDiagramID, diagramModel.ID)
+
+	_, err = s.AssignmentService.StartAssignment(teacherCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().ErrorIs(err, ErrForbidden)
+
+	otherCtx := s.createUser(context.Background(), "other", model.UserTypeStudent)
+	_, err = s.AssignmentService.StartAssignment(otherCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().ErrorIs(err, ErrAssignmentNotFound)
+}
+
+func (s *AssignmentServiceSuite) TestStartAssignment_Repeated() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.editDiagram(studentCtx, diagramModel.ID, "first")
+
+	// The started diagram is returned with the progress of the student
+	startedDiagram, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.Require().Equal(diagramModel.ID, startedDiagram.ID)
+	s.Require().Equal("first", *startedDiagram.Content.Value)
+
+	// A deleted diagram is started again
+	_, err = s.DiagramService.DeleteDiagram(studentCtx, &diagram.DeleteDiagramParams{ID: diagramModel.ID})
+	s.Require().NoError(err)
+
+	startedDiagram, err = s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.Require().NotEqual(diagramModel.ID, startedDiagram.ID)
+	s.Require().Equal(starterContent, s.contents[startedDiagram.ObjectStorageKey])
+}
+
+func (s *AssignmentServiceSuite) TestStartAssignment_DeadlinePassed() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	_, err := s.storage.Assignment().PatchAssignment(teacherCtx, &storage.PatchAssignmentParams{
+		ID:       assignment.ID,
+		Deadline: utils.NewOptional(ptr.To(time.Now().Add(-time.Minute))),
+	})
+	s.Require().NoError(err)
+
+	_, err = s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().ErrorIs(err, ErrDeadlinePassed)
+}
+
+func (s *AssignmentServiceSuite) TestSubmitAssignment() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.editDiagram(studentCtx, diagramModel.ID, "first")
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserID("student"), submission.UserID)
+	s.Require().Equal(diagramModel.ID, submission.DiagramID)
+	s.Require().Equal("first", *submission.Content.Value)
+
+	// The submission is a snapshot, later edits don't change it
+	s.editDiagram(studentCtx, diagramModel.ID, "second")
+
+	submission, err = s.AssignmentService.GetSubmission(studentCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+	s.Require().Equal("first", *submission.Content.Value)
+
+	submission, err = s.AssignmentService.GetSubmission(teacherCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+	s.Require().Equal("first", *submission.Content.Value)
+
+	_, err = s.AssignmentService.SubmitAssignment(teacherCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().ErrorIs(err, ErrForbidden)
+}
+
+func (s *AssignmentServiceSuite) TestSubmitAssignment_Resubmit() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.editDiagram(studentCtx, diagramModel.ID, "first")
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+
+	s.editDiagram(studentCtx, diagramModel.ID, "second")
+
+	// Re-submission before the deadline replaces the snapshot of the same submission
+	resubmission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(submission.ID, resubmission.ID)
+	s.Require().Equal("second", *resubmission.Content.Value)
+	s.Require().False(resubmission.SubmittedAt.Before(submission.SubmittedAt))
+
+	submissions, err := s.AssignmentService.ListSubmissions(teacherCtx, &ListSubmissionsParams{AssignmentID: assignment.ID})
+	s.Require().NoError(err)
+	s.Require().Len(submissions, 1)
+
+	resubmission, err = s.AssignmentService.GetSubmission(teacherCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+	s.Require().Equal("second", *resubmission.Content.Value)
+}
+
+func (s *AssignmentServiceSuite) TestSubmitAssignment_ResubmitGraded() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.Assignment().PatchAssignment(teacherCtx, &storage.PatchAssignmentParams{
+		ID:                 assignment.ID,
+		ReferenceDiagramID: utils.NewOptional(assignment.StarterDiagramID),
+	})
+	s.Require().NoError(err)
+	_, err = s.AssignmentService.GradeSubmissions(teacherCtx, &GradeSubmissionsParams{AssignmentID: assignment.ID})
+	s.Require().NoError(err)
+	_, err = s.AssignmentService.SetSubmissionGrade(teacherCtx, &SetSubmissionGradeParams{
+		ID:       submission.ID,
+		Grade:    ptr.To(90.0),
+		Feedback: "Good",
+	})
+	s.Require().NoError(err)
+
+	// Without the reference the new snapshot stays ungraded
+	_, err = s.storage.Assignment().PatchAssignment(teacherCtx, &storage.PatchAssignmentParams{
+		ID:                 assignment.ID,
+		ReferenceDiagramID: utils.NewOptional[*model.DiagramID](nil),
+	})
+	s.Require().NoError(err)
+
+	// The grades of the previous snapshot are cleared by the re-submission
+	resubmission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(resubmission.AutoGrading)
+	s.Require().Nil(resubmission.AutoGradedAt)
+	s.Require().Nil(resubmission.Grade)
+	s.Require().Empty(resubmission.Feedback)
+	s.Require().Nil(resubmission.GradedBy)
+	s.Require().Nil(resubmission.GradedAt)
+}
+
+func (s *AssignmentServiceSuite) TestGradeSubmissions_LostContent() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	otherCtx := s.createUser(context.Background(), "other", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	_, err := s.storage.CourseEnrollment().CreateCourseEnrollment(teacherCtx, &storage.CreateCourseEnrollmentParams{
+		CourseID: "course001",
+		UserID:   "other",
+	})
+	s.Require().NoError(err)
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	submissions := make([]*model.Submission, 0, 2)
+	for _, ctx := range []context.Context{studentCtx, otherCtx} {
+		diagramModel, err := s.AssignmentService.StartAssignment(ctx, &StartAssignmentParams{ID: assignment.ID})
+		s.Require().NoError(err)
+
+		submission, err := s.AssignmentService.SubmitAssignment(ctx, &SubmitAssignmentParams{
+			AssignmentID: assignment.ID,
+			DiagramID:    diagramModel.ID,
+		})
+		s.Require().NoError(err)
+		submissions = append(submissions, submission)
+	}
+	delete(s.contents, submissions[0].ObjectStorageKey)
+
+	_, err = s.storage.Assignment().PatchAssignment(teacherCtx, &storage.PatchAssignmentParams{
+		ID:                 assignment.ID,
+		ReferenceDiagramID: utils.NewOptional(assignment.StarterDiagramID),
+	})
+	s.Require().NoError(err)
+
+	graded, err := s.AssignmentService.GradeSubmissions(teacherCtx, &GradeSubmissionsParams{AssignmentID: assignment.ID})
+	s.Require().NoError(err)
+	s.Require().Len(graded, 2)
+
+	results := make(map[model.SubmissionID]*model.GradingResult, len(graded))
+	for _, submission := range graded {
+		results[submission.ID] = submission.AutoGrading
+	}
+	s.Require().Equal(ErrSubmissionContentUnreadable.Error(), results[submissions[0].ID].Error)
+	s.Require().NotNil(results[submissions[1].ID])
+	s.Require().Empty(results[submissions[1].ID].Error)
+}
+
+func (s *AssignmentServiceSuite) TestSubmitAssignment_DeadlinePassed() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+	s.editDiagram(studentCtx, diagramModel.ID, "first")
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.Assignment().PatchAssignment(teacherCtx, &storage.PatchAssignmentParams{
+		ID:       assignment.ID,
+		Deadline: utils.NewOptional(ptr.To(time.Now().Add(-time.Minute))),
+	})
+	s.Require().NoError(err)
+
+	s.editDiagram(studentCtx, diagramModel.ID, "late")
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().ErrorIs(err, ErrDeadlinePassed)
+
+	// The submission made before the deadline is kept
+	submission, err = s.AssignmentService.GetSubmission(teacherCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+	s.Require().Equal("first", *submission.Content.Value)
+}
@@ -0,0 +1,139 @@
+package assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/service/diagram"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+// createExam returns the exam of the course which is in progress for the next hour
+func (s *AssignmentServiceSuite) createExam(teacherCtx context.Context, courseID model.CourseID) *model.Assignment {
+	assignment := s.createAssignment(teacherCtx, courseID, nil)
+
+	assignment, err := s.AssignmentService.PatchAssignment(teacherCtx, &PatchAssignmentParams{
+		ID:           assignment.ID,
+		Title:        utils.NewOptional("Exam"),
+		ExamStartsAt: utils.NewOptional(ptr.To(time.Now().Add(-time.Minute))),
+		ExamEndsAt:   utils.NewOptional(ptr.To(time.Now().Add(time.Hour))),
+	})
+	s.Require().NoError(err)
+	s.Require().True(assignment.IsExam())
+
+	return assignment
+}
+
+func (s *AssignmentServiceSuite) TestStartAssignment_ExamInProgress() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+	exam := s.createExam(teacherCtx, "course001")
+
+	examDiagram, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: exam.ID})
+	s.Require().NoError(err)
+	s.Require().Equal("Exam", examDiagram.Name)
+
+	// Repeated start returns the diagram of the open session
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: exam.ID})
+	s.Require().NoError(err)
+	s.Require().Equal(examDiagram.ID, diagramModel.ID)
+
+	// Other assignments could be used to bring in prepared content
+	_, err = s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().ErrorIs(err, diagram.ErrExamInProgress)
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    examDiagram.ID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+}
+
+func (s *AssignmentServiceSuite) TestSubmitAssignment_Exam() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+	exam := s.createExam(teacherCtx, "course001")
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().ErrorIs(err, ErrExamNotStarted)
+
+	examDiagram, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: exam.ID})
+	s.Require().NoError(err)
+	s.editDiagram(studentCtx, examDiagram.ID, "exam")
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().ErrorIs(err, ErrNotExamDiagram)
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    examDiagram.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("exam", *submission.Content.Value)
+
+	// Submitting closes the session
+	examSession, err := s.AssignmentService.getExamSession(teacherCtx, exam.ID, "student")
+	s.Require().NoError(err)
+	s.Require().NotNil(examSession.ClosedAt)
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    examDiagram.ID,
+	})
+	s.Require().ErrorIs(err, ErrExamFinished)
+}
+
+func (s *AssignmentServiceSuite) TestGetSubmission_ExamInProgress() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createCourse(teacherCtx, "course001", "teacher", "student")
+	assignment := s.createAssignment(teacherCtx, "course001", ptr.To(time.Now().Add(time.Hour)))
+	exam := s.createExam(teacherCtx, "course001")
+
+	diagramModel, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: assignment.ID})
+	s.Require().NoError(err)
+
+	submission, err := s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: assignment.ID,
+		DiagramID:    diagramModel.ID,
+	})
+	s.Require().NoError(err)
+
+	examDiagram, err := s.AssignmentService.StartAssignment(studentCtx, &StartAssignmentParams{ID: exam.ID})
+	s.Require().NoError(err)
+
+	_, err = s.AssignmentService.GetSubmission(studentCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().ErrorIs(err, diagram.ErrExamInProgress)
+
+	// Teachers are not restricted by the exam of the student
+	_, err = s.AssignmentService.GetSubmission(teacherCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+
+	_, err = s.AssignmentService.SubmitAssignment(studentCtx, &SubmitAssignmentParams{
+		AssignmentID: exam.ID,
+		DiagramID:    examDiagram.ID,
+	})
+	s.Require().NoError(err)
+
+	submission, err = s.AssignmentService.GetSubmission(studentCtx, &GetSubmissionParams{ID: submission.ID})
+	s.Require().NoError(err)
+	s.Require().Equal(starterContent, *submission.Content.Value)
+}
@@ -0,0 +1,293 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/peerreview"
+	"github.com/IvLaptev/chartdb-back/internal/service/course"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+type ListPeerReviewsParams struct {
+	AssignmentID model.AssignmentID
+}
+
+// ListPeerReviews returns all reviews of the assignment to teachers and reviews assigned to the subject to students
+func (s *ServiceImpl) ListPeerReviews(ctx context.Context, params *ListPeerReviewsParams) ([]*model.PeerReview, error) {
+	ctxlog.Info(ctx, s.Logger, "list peer reviews", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getAssignment(ctx, subject, params.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     params.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+	if role != course.RoleTeacher {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyReviewerID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	peerReviews, err := s.Storage.PeerReview().GetAllPeerReviews(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all peer reviews: %w", err)
+	}
+
+	return peerReviews, nil
+}
+
+type GetPeerReviewParams struct {
+	ID model.PeerReviewID
+}
+
+// GetPeerReview returns the review with the reviewed snapshot. Reviewers read the snapshot
+// only while the review period is open and don't see its author.
+func (s *ServiceImpl) GetPeerReview(ctx context.Context, params *GetPeerReviewParams) (*model.PeerReview, error) {
+	ctxlog.Info(ctx, s.Logger, "get peer review", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	peerReview, err := s.getPeerReview(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, role, err := s.getAssignment(ctx, subject, peerReview.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rowPolicy storage.RowPolicy = &storage.RowPolicyBackground{}
+	if role != course.RoleTeacher {
+		if peerReview.ReviewerID != subject.UserID {
+			return nil, xerrors.WrapNotFound(ErrPeerReviewNotFound)
+		}
+		rowPolicy = &storage.RowPolicyPeerReviewer{
+			UserID: subject.UserID,
+		}
+	}
+
+	submissions, err := s.Storage.Submission().GetAllSubmissions(ctx, append([]*model.FilterTerm{
+		{
+			Key:       model.TermKeyID,
+			Value:     peerReview.SubmissionID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}, rowPolicy.GetFilter()...))
+	if err != nil {
+		return nil, fmt.Errorf("get all submissions: %w", err)
+	}
+	if len(submissions) == 0 {
+		return nil, xerrors.WrapForbidden(ErrPeerReviewClosed)
+	}
+
+	content, err := s.getContent(ctx, submissions[0].ObjectStorageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	peerReview.Content = utils.NewSecret(&content)
+
+	return peerReview, nil
+}
+
+type SubmitPeerReviewParams struct {
+	ID      model.PeerReviewID
+	Scores  []int64
+	Comment string
+}
+
+// SubmitPeerReview saves scores and the comment of the reviewer, the review can be changed until the period ends
+func (s *ServiceImpl) SubmitPeerReview(ctx context.Context, params *SubmitPeerReviewParams) (*model.PeerReview, error) {
+	ctxlog.Info(ctx, s.Logger, "submit peer review", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	comment := strings.TrimSpace(params.Comment)
+	if len(comment) > maxPeerReviewCommentLen {
+		return nil, xerrors.WrapInvalidArgument(ErrPeerReviewCommentLength)
+	}
+
+	var peerReview *model.PeerReview
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.getPeerReview(ctx, params.ID, storage.WithLock())
+		if err != nil {
+			return err
+		}
+		if current.ReviewerID != subject.UserID {
+			return xerrors.WrapNotFound(ErrPeerReviewNotFound)
+		}
+
+		assignment, role, err := s.getAssignment(ctx, subject, current.AssignmentID)
+		if err != nil {
+			return err
+		}
+		if role != course.RoleStudent {
+			return xerrors.WrapForbidden(ErrForbidden)
+		}
+		if !assignment.IsPeerReviewOpen(time.Now()) {
+			return xerrors.WrapForbidden(ErrPeerReviewClosed)
+		}
+
+		if len(params.Scores) != len(assignment.PeerReview.Criteria) {
+			return xerrors.WrapInvalidArgument(ErrInvalidPeerReviewScores)
+		}
+		for _, score := range params.Scores {
+			if score < minPeerReviewScore || score > maxPeerReviewScore {
+				return xerrors.WrapInvalidArgument(ErrInvalidPeerReviewScores)
+			}
+		}
+
+		peerReview, err = s.Storage.PeerReview().PatchPeerReview(ctx, &storage.PatchPeerReviewParams{
+			ID:          params.ID,
+			Scores:      utils.NewOptional(params.Scores),
+			Comment:     utils.NewOptional(comment),
+			SubmittedAt: utils.NewOptional(time.Now()),
+		})
+		if err != nil {
+			return fmt.Errorf("patch peer review: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't submit peer review: %w", err)
+	}
+
+	return peerReview, nil
+}
+
+type ModeratePeerReviewParams struct {
+	ID     model.PeerReviewID
+	Hidden bool
+}
+
+// ModeratePeerReview hides the review from the author or shows it again
+func (s *ServiceImpl) ModeratePeerReview(ctx context.Context, params *ModeratePeerReviewParams) (*model.PeerReview, error) {
+	ctxlog.Info(ctx, s.Logger, "moderate peer review", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	current, err := s.getPeerReview(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hiddenBy *model.UserID
+	if params.Hidden {
+		hiddenBy = ptr.To(subject.UserID)
+	}
+
+	var peerReview *model.PeerReview
+	err = s.doAsAssignmentTeacher(ctx, current.AssignmentID, func(ctx context.Context, _ *model.Assignment) error {
+		peerReview, err = s.Storage.PeerReview().PatchPeerReview(ctx, &storage.PatchPeerReviewParams{
+			ID:       params.ID,
+			HiddenBy: utils.NewOptional(hiddenBy),
+		})
+		if err != nil {
+			return fmt.Errorf("patch peer review: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't moderate peer review: %w", err)
+	}
+
+	return peerReview, nil
+}
+
+type GetPeerReviewSummaryParams struct {
+	SubmissionID model.SubmissionID
+}
+
+// GetPeerReviewSummary aggregates reviews of the submission for teachers and,
+// after the review period ends, for the author
+func (s *ServiceImpl) GetPeerReviewSummary(ctx context.Context, params *GetPeerReviewSummaryParams) (*model.PeerReviewSummary, error) {
+	ctxlog.Info(ctx, s.Logger, "get peer review summary", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	submission, err := s.Storage.Submission().GetSubmissionByID(ctx, params.SubmissionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrSubmissionNotFound)
+		}
+		return nil, fmt.Errorf("get submission by id: %w", err)
+	}
+
+	assignment, role, err := s.getAssignment(ctx, subject, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if role != course.RoleTeacher && submission.UserID != subject.UserID {
+		return nil, xerrors.WrapNotFound(ErrSubmissionNotFound)
+	}
+	if !assignment.HasPeerReview() {
+		return nil, xerrors.WrapNotFound(ErrPeerReviewNotFound)
+	}
+	if role != course.RoleTeacher && time.Now().Before(*assignment.PeerReviewEndsAt) {
+		return nil, xerrors.WrapForbidden(ErrPeerReviewsNotPublished)
+	}
+
+	peerReviews, err := s.Storage.PeerReview().GetAllPeerReviews(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeySubmissionID,
+			Value:     params.SubmissionID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all peer reviews: %w", err)
+	}
+
+	return peerreview.Summarize(submission.ID, assignment.PeerReview.Criteria, peerReviews), nil
+}
+
+func (s *ServiceImpl) getPeerReview(ctx context.Context, id model.PeerReviewID, opts ...storage.RequestOption) (*model.PeerReview, error) {
+	peerReview, err := s.Storage.PeerReview().GetPeerReviewByID(ctx, id, opts...)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrPeerReviewNotFound)
+		}
+		return nil, fmt.Errorf("get peer review by id: %w", err)
+	}
+
+	return peerReview, nil
+}
@@ -0,0 +1,114 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/s3client"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+type SaveSubmissionParams struct {
+	AssignmentID model.AssignmentID
+	UserID       model.UserID
+	Diagram      *model.Diagram
+	// Set for exams, the session is closed by the submission
+	ExamSession *model.ExamSession
+}
+
+// SaveSubmission snapshots the diagram content into the submission of the user, the previous snapshot is replaced
+// and its grades are cleared.
+// It is called in the transaction holding the assignment lock, so concurrent submissions don't create two submissions.
+func SaveSubmission(ctx context.Context, st storage.Storage, s3Client s3client.Client, params *SaveSubmissionParams) (*model.Submission, error) {
+	submissionID, err := utils.GenerateID(submissionIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	objectStorageKey, err := utils.GenerateID(objectStorageKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id (storage key): %w", err)
+	}
+
+	content, err := s3Client.GetContent(ctx, params.Diagram.ObjectStorageKey)
+	if err != nil {
+		if errors.Is(err, s3client.ErrContentNotFound) {
+			return nil, xerrors.WrapNotFound(ErrDiagramContentNotFound)
+		}
+		return nil, fmt.Errorf("get content: %w", err)
+	}
+
+	err = s3Client.SaveContent(ctx, objectStorageKey, content)
+	if err != nil {
+		return nil, fmt.Errorf("save content: %w", err)
+	}
+
+	submissions, err := st.Submission().GetAllSubmissions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignmentID,
+			Value:     params.AssignmentID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     params.UserID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all submissions: %w", err)
+	}
+
+	var submission *model.Submission
+	if len(submissions) > 0 {
+		submission, err = st.Submission().PatchSubmission(ctx, &storage.PatchSubmissionParams{
+			ID:               submissions[0].ID,
+			DiagramID:        utils.NewOptional(params.Diagram.ID),
+			ObjectStorageKey: utils.NewOptional(objectStorageKey),
+			Name:             utils.NewOptional(params.Diagram.Name),
+			TablesCount:      utils.NewOptional(params.Diagram.TablesCount),
+			SubmittedAt:      utils.NewOptional(time.Now()),
+			// The grades of the previous snapshot don't apply to the new one
+			AutoGrading: utils.NewOptional[*model.GradingResult](nil),
+			Grade:       utils.NewOptional[*float64](nil),
+			Feedback:    utils.NewOptional(""),
+			GradedBy:    utils.NewOptional[*model.UserID](nil),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("patch submission: %w", err)
+		}
+	} else {
+		submission, err = st.Submission().CreateSubmission(ctx, &storage.CreateSubmissionParams{
+			ID:               model.SubmissionID(submissionID),
+			AssignmentID:     params.AssignmentID,
+			UserID:           params.UserID,
+			DiagramID:        params.Diagram.ID,
+			ObjectStorageKey: objectStorageKey,
+			Name:             params.Diagram.Name,
+			TablesCount:      params.Diagram.TablesCount,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create submission: %w", err)
+		}
+	}
+
+	submission.Content = utils.NewSecret(&content)
+
+	if params.ExamSession != nil {
+		_, err = st.ExamSession().PatchExamSession(ctx, &storage.PatchExamSessionParams{
+			ID:       params.ExamSession.ID,
+			ClosedAt: utils.NewOptional(ptr.To(time.Now())),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("patch exam session: %w", err)
+		}
+	}
+
+	return submission, nil
+}
@@ -0,0 +1,70 @@
+package course
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+)
+
+// Role is a role of the subject in the course
+type Role int64
+
+const (
+	RoleNone Role = iota
+	RoleStudent
+	RoleTeacher
+)
+
+// GetCourseRole returns the course with the subject's role in it, admins act as teachers of every course.
+// storage.ErrNotFound is returned if the course doesn't exist.
+func GetCourseRole(
+	ctx context.Context,
+	st storage.Storage,
+	subject *auth.Subject,
+	id model.CourseID,
+	opts ...storage.RequestOption,
+) (*model.Course, Role, error) {
+	course, err := st.Course().GetCourseByID(ctx, id, opts...)
+	if err != nil {
+		return nil, RoleNone, fmt.Errorf("get course by id: %w", err)
+	}
+
+	courseMemberFilter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     id.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+
+	switch subject.UserType {
+	case model.UserTypeAdmin:
+		return course, RoleTeacher, nil
+	case model.UserTypeTeacher:
+		teachers, err := st.CourseTeacher().GetAllCourseTeachers(ctx, courseMemberFilter)
+		if err != nil {
+			return nil, RoleNone, fmt.Errorf("get all course teachers: %w", err)
+		}
+		if len(teachers) > 0 {
+			return course, RoleTeacher, nil
+		}
+	case model.UserTypeStudent:
+		enrollments, err := st.CourseEnrollment().GetAllCourseEnrollments(ctx, courseMemberFilter)
+		if err != nil {
+			return nil, RoleNone, fmt.Errorf("get all course enrollments: %w", err)
+		}
+		if len(enrollments) > 0 {
+			return course, RoleStudent, nil
+		}
+	}
+
+	return course, RoleNone, nil
+}
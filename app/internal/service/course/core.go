@@ -0,0 +1,1118 @@
+package course
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	userservice "github.com/IvLaptev/chartdb-back/internal/service/user"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const (
+	courseIDLength      int64 = 10
+	courseGroupIDLength int64 = 10
+	joinCodeLength      int64 = 8
+	userIDLength        int64 = 20
+	invitationIDLength  int64 = 40
+
+	maxCourseNameLength  = 256
+	maxGroupCodeLength   = 64
+	maxEnrollLoginsCount = 500
+)
+
+var (
+	ErrCourseNotFound      = errors.New("course not found")
+	ErrCourseGroupNotFound = errors.New("course group not found")
+	ErrInvalidCourseName   = errors.New("invalid course name")
+	ErrInvalidGroupCode    = errors.New("invalid group code")
+	ErrGroupAlreadyExists  = errors.New("group already exists")
+
+	ErrTeacherNotFound      = errors.New("teacher not found")
+	ErrTeacherAlreadyExists = errors.New("teacher already assigned to the course")
+	ErrLastCourseTeacher    = errors.New("course must have at least one teacher")
+
+	ErrStudentNotFound  = errors.New("student not found")
+	ErrTooManyLogins    = errors.New("too many logins")
+	ErrJoinCodeNotFound = errors.New("join code not found")
+	ErrInvalidRoster    = errors.New("invalid roster")
+
+	ErrForbidden = errors.New("forbidden")
+)
+
+type Service interface {
+	GetCourse(ctx context.Context, params *GetCourseParams) (*model.Course, error)
+	ListCourses(ctx context.Context, params *ListCoursesParams) ([]*model.Course, error)
+	CreateCourse(ctx context.Context, params *CreateCourseParams) (*model.Course, error)
+	DeleteCourse(ctx context.Context, params *DeleteCourseParams) (*model.Course, error)
+
+	CreateCourseGroup(ctx context.Context, params *CreateCourseGroupParams) (*model.CourseGroup, error)
+	ListCourseGroups(ctx context.Context, params *ListCourseGroupsParams) ([]*model.CourseGroup, error)
+	DeleteCourseGroup(ctx context.Context, params *DeleteCourseGroupParams) (*model.CourseGroup, error)
+
+	AddCourseTeacher(ctx context.Context, params *AddCourseTeacherParams) (*model.CourseTeacher, error)
+	RemoveCourseTeacher(ctx context.Context, params *RemoveCourseTeacherParams) (*model.CourseTeacher, error)
+	ListCourseTeachers(ctx context.Context, params *ListCourseTeachersParams) ([]*model.CourseTeacher, error)
+
+	EnrollStudents(ctx context.Context, params *EnrollStudentsParams) ([]*model.CourseEnrollment, error)
+	ImportRoster(ctx context.Context, params *ImportRosterParams) ([]*model.RosterImportRow, error)
+	UnenrollStudent(ctx context.Context, params *UnenrollStudentParams) (*model.CourseEnrollment, error)
+	ListCourseEnrollments(ctx context.Context, params *ListCourseEnrollmentsParams) ([]*model.CourseEnrollment, error)
+
+	JoinCourse(ctx context.Context, params *JoinCourseParams) (*model.CourseEnrollment, error)
+}
+
+type ServiceImpl struct {
+	Logger      *slog.Logger
+	Storage     storage.Storage
+	EmailSender emailsender.EmailSender
+	// Lifetime of invitation links of imported students
+	InvitationTime time.Duration
+	// Emails of imported students must be allowed to register
+	RegistrationPolicy *userservice.Policy
+}
+
+type GetCourseParams struct {
+	ID model.CourseID
+}
+
+func (s *ServiceImpl) GetCourse(ctx context.Context, params *GetCourseParams) (*model.Course, error) {
+	ctxlog.Info(ctx, s.Logger, "get course", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	course, role, err := s.getCourse(ctx, subject, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if role == RoleNone {
+		return nil, xerrors.WrapNotFound(ErrCourseNotFound)
+	}
+
+	return hideJoinCodes(course, role), nil
+}
+
+type ListCoursesParams struct{}
+
+// ListCourses returns all courses for admins, taught courses for teachers and attended courses for students
+func (s *ServiceImpl) ListCourses(ctx context.Context, params *ListCoursesParams) ([]*model.Course, error) {
+	ctxlog.Info(ctx, s.Logger, "list courses", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	var filter []*model.FilterTerm
+	role := RoleTeacher
+	switch subject.UserType {
+	case model.UserTypeAdmin:
+	case model.UserTypeTeacher:
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyTeacherID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	case model.UserTypeStudent:
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyStudentID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+		role = RoleStudent
+	default:
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	courses, err := s.Storage.Course().GetAllCourses(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all courses: %w", err)
+	}
+
+	for i, course := range courses {
+		courses[i] = hideJoinCodes(course, role)
+	}
+
+	return courses, nil
+}
+
+type CreateCourseParams struct {
+	Name        string
+	Description string
+}
+
+// CreateCourse creates a course and assigns the subject as its first teacher
+func (s *ServiceImpl) CreateCourse(ctx context.Context, params *CreateCourseParams) (*model.Course, error) {
+	ctxlog.Info(ctx, s.Logger, "create course", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	allowedUserTypes := []model.UserType{
+		model.UserTypeAdmin,
+		model.UserTypeTeacher,
+	}
+
+	if !slices.Contains(allowedUserTypes, subject.UserType) {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || len(name) > maxCourseNameLength {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidCourseName)
+	}
+
+	courseID, err := utils.GenerateID(courseIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	joinCode, err := utils.GenerateID(joinCodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id (join code): %w", err)
+	}
+
+	var course *model.Course
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		course, err = s.Storage.Course().CreateCourse(ctx, &storage.CreateCourseParams{
+			ID:          model.CourseID(courseID),
+			Name:        name,
+			Description: params.Description,
+			JoinCode:    joinCode,
+			CreatedBy:   subject.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("create course: %w", err)
+		}
+
+		_, err = s.Storage.CourseTeacher().CreateCourseTeacher(ctx, &storage.CreateCourseTeacherParams{
+			CourseID: course.ID,
+			UserID:   subject.UserID,
+		})
+		if err != nil {
+			return fmt.Errorf("create course teacher: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create course: %w", err)
+	}
+
+	return course, nil
+}
+
+type DeleteCourseParams struct {
+	ID model.CourseID
+}
+
+// DeleteCourse removes the course with its groups, so their join codes stop working
+func (s *ServiceImpl) DeleteCourse(ctx context.Context, params *DeleteCourseParams) (*model.Course, error) {
+	ctxlog.Info(ctx, s.Logger, "delete course", slog.Any("params", params))
+
+	var course *model.Course
+	err := s.doAsCourseTeacher(ctx, params.ID, func(ctx context.Context, _ *model.Course) error {
+		groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyCourseID,
+				Value:     params.ID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course groups: %w", err)
+		}
+
+		for _, group := range groups {
+			_, err = s.Storage.CourseGroup().DeleteCourseGroup(ctx, group.ID)
+			if err != nil {
+				return fmt.Errorf("delete course group: %w", err)
+			}
+		}
+
+		course, err = s.Storage.Course().DeleteCourse(ctx, params.ID)
+		if err != nil {
+			return fmt.Errorf("delete course: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't delete course: %w", err)
+	}
+
+	return course, nil
+}
+
+type CreateCourseGroupParams struct {
+	CourseID model.CourseID
+	Code     string
+}
+
+func (s *ServiceImpl) CreateCourseGroup(ctx context.Context, params *CreateCourseGroupParams) (*model.CourseGroup, error) {
+	ctxlog.Info(ctx, s.Logger, "create course group", slog.Any("params", params))
+
+	code := strings.TrimSpace(params.Code)
+	if code == "" || len(code) > maxGroupCodeLength {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidGroupCode)
+	}
+
+	groupID, err := utils.GenerateID(courseGroupIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	joinCode, err := utils.GenerateID(joinCodeLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id (join code): %w", err)
+	}
+
+	var group *model.CourseGroup
+	err = s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyCourseID,
+				Value:     params.CourseID.String(),
+				Operation: model.FilterOperationExact,
+			},
+			{
+				Key:       model.TermKeyCode,
+				Value:     code,
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course groups: %w", err)
+		}
+		if len(groups) > 0 {
+			return xerrors.WrapConflict(ErrGroupAlreadyExists)
+		}
+
+		group, err = s.Storage.CourseGroup().CreateCourseGroup(ctx, &storage.CreateCourseGroupParams{
+			ID:       model.CourseGroupID(groupID),
+			CourseID: params.CourseID,
+			Code:     code,
+			JoinCode: joinCode,
+		})
+		if err != nil {
+			return fmt.Errorf("create course group: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create course group: %w", err)
+	}
+
+	return group, nil
+}
+
+type ListCourseGroupsParams struct {
+	CourseID model.CourseID
+}
+
+func (s *ServiceImpl) ListCourseGroups(ctx context.Context, params *ListCourseGroupsParams) ([]*model.CourseGroup, error) {
+	ctxlog.Info(ctx, s.Logger, "list course groups", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getCourse(ctx, subject, params.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if role == RoleNone {
+		return nil, xerrors.WrapNotFound(ErrCourseNotFound)
+	}
+
+	groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all course groups: %w", err)
+	}
+
+	if role != RoleTeacher {
+		for _, group := range groups {
+			group.JoinCode = ""
+		}
+	}
+
+	return groups, nil
+}
+
+type DeleteCourseGroupParams struct {
+	CourseID model.CourseID
+	GroupID  model.CourseGroupID
+}
+
+// DeleteCourseGroup removes the group, students of the group stay enrolled in the course without a group
+func (s *ServiceImpl) DeleteCourseGroup(ctx context.Context, params *DeleteCourseGroupParams) (*model.CourseGroup, error) {
+	ctxlog.Info(ctx, s.Logger, "delete course group", slog.Any("params", params))
+
+	var group *model.CourseGroup
+	err := s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		_, err := s.getCourseGroup(ctx, params.CourseID, params.GroupID)
+		if err != nil {
+			return err
+		}
+
+		enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyGroupID,
+				Value:     params.GroupID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course enrollments: %w", err)
+		}
+
+		for _, enrollment := range enrollments {
+			_, err = s.Storage.CourseEnrollment().PatchCourseEnrollment(ctx, &storage.PatchCourseEnrollmentParams{
+				CourseID: enrollment.CourseID,
+				UserID:   enrollment.UserID,
+				GroupID:  utils.NewOptional[*model.CourseGroupID](nil),
+			})
+			if err != nil {
+				return fmt.Errorf("patch course enrollment: %w", err)
+			}
+		}
+
+		group, err = s.Storage.CourseGroup().DeleteCourseGroup(ctx, params.GroupID)
+		if err != nil {
+			return fmt.Errorf("delete course group: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't delete course group: %w", err)
+	}
+
+	return group, nil
+}
+
+type AddCourseTeacherParams struct {
+	CourseID model.CourseID
+	Login    string
+}
+
+func (s *ServiceImpl) AddCourseTeacher(ctx context.Context, params *AddCourseTeacherParams) (*model.CourseTeacher, error) {
+	ctxlog.Info(ctx, s.Logger, "add course teacher", slog.Any("params", params))
+
+	var teacher *model.CourseTeacher
+	err := s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		user, err := s.getUserByLogin(ctx, params.Login)
+		if err != nil {
+			return err
+		}
+		if user == nil || user.Type != model.UserTypeTeacher {
+			return xerrors.WrapNotFound(ErrTeacherNotFound)
+		}
+
+		teachers, err := s.Storage.CourseTeacher().GetAllCourseTeachers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyCourseID,
+				Value:     params.CourseID.String(),
+				Operation: model.FilterOperationExact,
+			},
+			{
+				Key:       model.TermKeyUserID,
+				Value:     user.ID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course teachers: %w", err)
+		}
+		if len(teachers) > 0 {
+			return xerrors.WrapConflict(ErrTeacherAlreadyExists)
+		}
+
+		teacher, err = s.Storage.CourseTeacher().CreateCourseTeacher(ctx, &storage.CreateCourseTeacherParams{
+			CourseID: params.CourseID,
+			UserID:   user.ID,
+		})
+		if err != nil {
+			return fmt.Errorf("create course teacher: %w", err)
+		}
+		teacher.UserLogin = user.Login
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't add course teacher: %w", err)
+	}
+
+	return teacher, nil
+}
+
+type RemoveCourseTeacherParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+}
+
+func (s *ServiceImpl) RemoveCourseTeacher(ctx context.Context, params *RemoveCourseTeacherParams) (*model.CourseTeacher, error) {
+	ctxlog.Info(ctx, s.Logger, "remove course teacher", slog.Any("params", params))
+
+	var teacher *model.CourseTeacher
+	err := s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		teachers, err := s.Storage.CourseTeacher().GetAllCourseTeachers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyCourseID,
+				Value:     params.CourseID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course teachers: %w", err)
+		}
+
+		idx := slices.IndexFunc(teachers, func(t *model.CourseTeacher) bool {
+			return t.UserID == params.UserID
+		})
+		if idx < 0 {
+			return xerrors.WrapNotFound(ErrTeacherNotFound)
+		}
+		if len(teachers) == 1 {
+			return xerrors.WrapInvalidArgument(ErrLastCourseTeacher)
+		}
+
+		teacher, err = s.Storage.CourseTeacher().DeleteCourseTeacher(ctx, params.CourseID, params.UserID)
+		if err != nil {
+			return fmt.Errorf("delete course teacher: %w", err)
+		}
+		teacher.UserLogin = teachers[idx].UserLogin
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't remove course teacher: %w", err)
+	}
+
+	return teacher, nil
+}
+
+type ListCourseTeachersParams struct {
+	CourseID model.CourseID
+}
+
+func (s *ServiceImpl) ListCourseTeachers(ctx context.Context, params *ListCourseTeachersParams) ([]*model.CourseTeacher, error) {
+	ctxlog.Info(ctx, s.Logger, "list course teachers", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getCourse(ctx, subject, params.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if role == RoleNone {
+		return nil, xerrors.WrapNotFound(ErrCourseNotFound)
+	}
+
+	teachers, err := s.Storage.CourseTeacher().GetAllCourseTeachers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all course teachers: %w", err)
+	}
+
+	return teachers, nil
+}
+
+type EnrollStudentsParams struct {
+	CourseID model.CourseID
+	GroupID  *model.CourseGroupID
+	Logins   []string
+}
+
+// EnrollStudents enrolls students by their logins, already enrolled students are moved to the given group
+func (s *ServiceImpl) EnrollStudents(ctx context.Context, params *EnrollStudentsParams) ([]*model.CourseEnrollment, error) {
+	ctxlog.Info(ctx, s.Logger, "enroll students", slog.Any("params", params))
+
+	if len(params.Logins) > maxEnrollLoginsCount {
+		return nil, xerrors.WrapInvalidArgument(ErrTooManyLogins)
+	}
+
+	var enrollments []*model.CourseEnrollment
+	err := s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		if params.GroupID != nil {
+			_, err := s.getCourseGroup(ctx, params.CourseID, *params.GroupID)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, login := range params.Logins {
+			user, err := s.getUserByLogin(ctx, login)
+			if err != nil {
+				return err
+			}
+			if user == nil || user.Type != model.UserTypeStudent {
+				return xerrors.WrapNotFound(fmt.Errorf("%w: %s", ErrStudentNotFound, login))
+			}
+
+			enrollment, err := s.enrollStudent(ctx, params.CourseID, user, params.GroupID)
+			if err != nil {
+				return err
+			}
+
+			enrollments = append(enrollments, enrollment)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't enroll students: %w", err)
+	}
+
+	return enrollments, nil
+}
+
+type ImportRosterParams struct {
+	CourseID model.CourseID
+	// CSV with an email and an optional group code in each row, the header row is optional
+	Content string
+}
+
+// ImportRoster enrolls students from the roster creating pending accounts for unknown emails,
+// invalid rows are reported and don't prevent importing the others
+func (s *ServiceImpl) ImportRoster(ctx context.Context, params *ImportRosterParams) ([]*model.RosterImportRow, error) {
+	ctxlog.Info(ctx, s.Logger, "import roster", slog.String("course_id", params.CourseID.String()))
+
+	rows, err := parseRoster(params.Content)
+	if err != nil {
+		return nil, fmt.Errorf("can't import roster: %w", err)
+	}
+	if len(rows) > maxEnrollLoginsCount {
+		return nil, xerrors.WrapInvalidArgument(ErrTooManyLogins)
+	}
+
+	var courseName string
+	invitations := make(map[*model.RosterImportRow]*model.UserConfirmation)
+	err = s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, course *model.Course) error {
+		courseName = course.Name
+
+		groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyCourseID,
+				Value:     params.CourseID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all course groups: %w", err)
+		}
+
+		groupsByCode := make(map[string]*model.CourseGroup, len(groups))
+		for _, group := range groups {
+			groupsByCode[group.Code] = group
+		}
+
+		emails := make(map[string]struct{}, len(rows))
+		for _, row := range rows {
+			// Accounts are looked up and created by the normalized email
+			row.Email = strings.ToLower(strings.TrimSpace(row.Email))
+			if s.RegistrationPolicy.ValidateLogin(row.Email) != nil {
+				row.Status = model.RosterImportStatusInvalidEmail
+				continue
+			}
+
+			if _, ok := emails[row.Email]; ok {
+				row.Status = model.RosterImportStatusDuplicateEmail
+				continue
+			}
+			emails[row.Email] = struct{}{}
+
+			var groupID *model.CourseGroupID
+			if row.GroupCode != "" {
+				group, ok := groupsByCode[row.GroupCode]
+				if !ok {
+					row.Status = model.RosterImportStatusGroupNotFound
+					continue
+				}
+				groupID = &group.ID
+			}
+
+			invitation, err := s.importRosterRow(ctx, params.CourseID, groupID, row)
+			if err != nil {
+				return err
+			}
+			if invitation != nil {
+				invitations[row] = invitation
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't import roster: %w", err)
+	}
+
+	// Emails are sent after the commit, failed ones are reported and can be sent again by importing the row again
+	for _, row := range rows {
+		invitation, ok := invitations[row]
+		if !ok {
+			continue
+		}
+
+		err := s.EmailSender.SendInvitationEmail(row.Email, courseName, invitation.ID.String())
+		if err != nil {
+			ctxlog.Error(ctx, s.Logger, "send invitation email", slog.String("email", row.Email), slog.Any("error", err))
+			row.EmailError = err.Error()
+		}
+	}
+
+	return rows, nil
+}
+
+// importRosterRow enrolls the student of the row, returns the invitation to send if the account is pending
+func (s *ServiceImpl) importRosterRow(
+	ctx context.Context,
+	courseID model.CourseID,
+	groupID *model.CourseGroupID,
+	row *model.RosterImportRow,
+) (*model.UserConfirmation, error) {
+	users, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     row.Email,
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all users: %w", err)
+	}
+
+	var student *model.User
+	row.Status = model.RosterImportStatusEnrolled
+	if len(users) > 0 {
+		student = users[0]
+		if student.Type != model.UserTypeStudent {
+			row.Status = model.RosterImportStatusNotStudent
+			return nil, nil
+		}
+
+		// The self-registration wasn't confirmed, so the owner of the email is invited instead
+		if student.ConfirmedAt == nil && !student.Invited() {
+			student, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+				ID:           student.ID,
+				PasswordHash: utils.NewOptional[*string](nil),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("patch user: %w", err)
+			}
+		}
+	} else {
+		userID, err := utils.GenerateID(userIDLength)
+		if err != nil {
+			return nil, fmt.Errorf("generate id: %w", err)
+		}
+
+		student, err = s.Storage.User().CreateUser(ctx, &storage.CreateUserParams{
+			ID:    model.UserID(userID),
+			Login: row.Email,
+			Type:  model.UserTypeStudent,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create user: %w", err)
+		}
+	}
+
+	row.Enrollment, err = s.enrollStudent(ctx, courseID, student, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !student.Invited() {
+		return nil, nil
+	}
+	row.Status = model.RosterImportStatusInvited
+
+	invitationID, err := utils.GenerateID(invitationIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	invitation, err := s.Storage.UserConfirmation().CreateUserConfirmation(ctx, &storage.CreateUserConfirmationParams{
+		ID:       model.UserConfirmationID(invitationID),
+		UserID:   student.ID,
+		Duration: s.InvitationTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user confirmation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+// parseRoster reads rows of email and optional group code, a first row starting with "email" is a header
+func parseRoster(content string) ([]*model.RosterImportRow, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []*model.RosterImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.WrapInvalidArgument(fmt.Errorf("%w: %w", ErrInvalidRoster, err))
+		}
+
+		line, _ := reader.FieldPos(0)
+		email := strings.TrimSpace(record[0])
+		if line == 1 && strings.EqualFold(email, "email") {
+			continue
+		}
+
+		row := &model.RosterImportRow{
+			Line:  line,
+			Email: email,
+		}
+		if len(record) > 1 {
+			row.GroupCode = strings.TrimSpace(record[1])
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, xerrors.WrapInvalidArgument(fmt.Errorf("%w: no rows", ErrInvalidRoster))
+	}
+
+	return rows, nil
+}
+
+type UnenrollStudentParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+}
+
+func (s *ServiceImpl) UnenrollStudent(ctx context.Context, params *UnenrollStudentParams) (*model.CourseEnrollment, error) {
+	ctxlog.Info(ctx, s.Logger, "unenroll student", slog.Any("params", params))
+
+	var enrollment *model.CourseEnrollment
+	err := s.doAsCourseTeacher(ctx, params.CourseID, func(ctx context.Context, _ *model.Course) error {
+		var err error
+		enrollment, err = s.Storage.CourseEnrollment().DeleteCourseEnrollment(ctx, params.CourseID, params.UserID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrStudentNotFound)
+			}
+			return fmt.Errorf("delete course enrollment: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't unenroll student: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+type ListCourseEnrollmentsParams struct {
+	CourseID model.CourseID
+	GroupID  *model.CourseGroupID
+}
+
+func (s *ServiceImpl) ListCourseEnrollments(ctx context.Context, params *ListCourseEnrollmentsParams) ([]*model.CourseEnrollment, error) {
+	ctxlog.Info(ctx, s.Logger, "list course enrollments", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	_, role, err := s.getCourse(ctx, subject, params.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if role != RoleTeacher {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	filter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     params.CourseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	}
+	if params.GroupID != nil {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyGroupID,
+			Value:     params.GroupID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all course enrollments: %w", err)
+	}
+
+	return enrollments, nil
+}
+
+type JoinCourseParams struct {
+	JoinCode string
+}
+
+// JoinCourse enrolls the subject by a join code of a course group or of the whole course
+func (s *ServiceImpl) JoinCourse(ctx context.Context, params *JoinCourseParams) (*model.CourseEnrollment, error) {
+	ctxlog.Info(ctx, s.Logger, "join course", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	if subject.UserType != model.UserTypeStudent {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	joinCode := strings.ToLower(strings.TrimSpace(params.JoinCode))
+	joinCodeFilter := []*model.FilterTerm{
+		{
+			Key:       model.TermKeyJoinCode,
+			Value:     joinCode,
+			Operation: model.FilterOperationExact,
+		},
+	}
+
+	var enrollment *model.CourseEnrollment
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		var (
+			courseID model.CourseID
+			groupID  *model.CourseGroupID
+		)
+
+		groups, err := s.Storage.CourseGroup().GetAllCourseGroups(ctx, joinCodeFilter)
+		if err != nil {
+			return fmt.Errorf("get all course groups: %w", err)
+		}
+
+		if len(groups) > 0 {
+			courseID = groups[0].CourseID
+			groupID = &groups[0].ID
+		} else {
+			courses, err := s.Storage.Course().GetAllCourses(ctx, joinCodeFilter)
+			if err != nil {
+				return fmt.Errorf("get all courses: %w", err)
+			}
+			if len(courses) == 0 {
+				return xerrors.WrapNotFound(ErrJoinCodeNotFound)
+			}
+			courseID = courses[0].ID
+		}
+
+		// Locked to not enroll into the course being deleted
+		_, err = s.Storage.Course().GetCourseByID(ctx, courseID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrJoinCodeNotFound)
+			}
+			return fmt.Errorf("get course by id: %w", err)
+		}
+
+		user, err := s.Storage.User().GetUserByID(ctx, subject.UserID)
+		if err != nil {
+			return fmt.Errorf("get user by id: %w", err)
+		}
+
+		enrollment, err = s.enrollStudent(ctx, courseID, user, groupID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't join course: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+// getCourse returns the course with the subject's role in it
+func (s *ServiceImpl) getCourse(ctx context.Context, subject *auth.Subject, id model.CourseID, opts ...storage.RequestOption) (*model.Course, Role, error) {
+	course, role, err := GetCourseRole(ctx, s.Storage, subject, id, opts...)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, RoleNone, xerrors.WrapNotFound(ErrCourseNotFound)
+		}
+		return nil, RoleNone, fmt.Errorf("get course role: %w", err)
+	}
+
+	return course, role, nil
+}
+
+// doAsCourseTeacher locks the course managed by the subject and calls f in the same transaction
+func (s *ServiceImpl) doAsCourseTeacher(ctx context.Context, id model.CourseID, f func(ctx context.Context, course *model.Course) error) error {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+
+	return s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		course, role, err := s.getCourse(ctx, subject, id, storage.WithLock())
+		if err != nil {
+			return err
+		}
+
+		switch role {
+		case RoleTeacher:
+		case RoleStudent:
+			return xerrors.WrapForbidden(ErrForbidden)
+		default:
+			return xerrors.WrapNotFound(ErrCourseNotFound)
+		}
+
+		return f(ctx, course)
+	})
+}
+
+func (s *ServiceImpl) getCourseGroup(ctx context.Context, courseID model.CourseID, groupID model.CourseGroupID) (*model.CourseGroup, error) {
+	group, err := s.Storage.CourseGroup().GetCourseGroupByID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrCourseGroupNotFound)
+		}
+		return nil, fmt.Errorf("get course group by id: %w", err)
+	}
+	if group.CourseID != courseID {
+		return nil, xerrors.WrapNotFound(ErrCourseGroupNotFound)
+	}
+
+	return group, nil
+}
+
+// getUserByLogin returns nil if there is no confirmed user with the login
+func (s *ServiceImpl) getUserByLogin(ctx context.Context, login string) (*model.User, error) {
+	users, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     strings.TrimSpace(login),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all users: %w", err)
+	}
+
+	for _, user := range users {
+		if user.ConfirmedAt != nil {
+			return user, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// enrollStudent creates the enrollment or moves already enrolled student to the group
+func (s *ServiceImpl) enrollStudent(ctx context.Context, courseID model.CourseID, user *model.User, groupID *model.CourseGroupID) (*model.CourseEnrollment, error) {
+	enrollments, err := s.Storage.CourseEnrollment().GetAllCourseEnrollments(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyCourseID,
+			Value:     courseID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUserID,
+			Value:     user.ID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all course enrollments: %w", err)
+	}
+
+	var enrollment *model.CourseEnrollment
+	if len(enrollments) > 0 {
+		if groupID == nil {
+			return enrollments[0], nil
+		}
+
+		enrollment, err = s.Storage.CourseEnrollment().PatchCourseEnrollment(ctx, &storage.PatchCourseEnrollmentParams{
+			CourseID: courseID,
+			UserID:   user.ID,
+			GroupID:  utils.NewOptional(groupID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("patch course enrollment: %w", err)
+		}
+	} else {
+		enrollment, err = s.Storage.CourseEnrollment().CreateCourseEnrollment(ctx, &storage.CreateCourseEnrollmentParams{
+			CourseID: courseID,
+			UserID:   user.ID,
+			GroupID:  groupID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create course enrollment: %w", err)
+		}
+	}
+	enrollment.UserLogin = user.Login
+
+	return enrollment, nil
+}
+
+// hideJoinCodes removes the join code from courses which the subject doesn't manage
+func hideJoinCodes(course *model.Course, role Role) *model.Course {
+	if role == RoleTeacher {
+		return course
+	}
+
+	result := *course
+	result.JoinCode = ""
+	return &result
+}
+
+func NewService(
+	logger *slog.Logger,
+	storage storage.Storage,
+	emailSender emailsender.EmailSender,
+	invitationTime time.Duration,
+	registrationPolicy *userservice.Policy,
+) *ServiceImpl {
+	return &ServiceImpl{
+		Logger:             logger.With("name", "service/course"),
+		Storage:            storage,
+		EmailSender:        emailSender,
+		InvitationTime:     invitationTime,
+		RegistrationPolicy: registrationPolicy,
+	}
+}
@@ -0,0 +1,207 @@
+package course
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	userservice "github.com/IvLaptev/chartdb-back/internal/service/user"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
+	"github.com/IvLaptev/chartdb-back/internal/tests"
+	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	gomock "go.uber.org/mock/gomock"
+)
+
+type CourseServiceSuite struct {
+	suite.Suite
+
+	CourseService *ServiceImpl
+	storage       storage.Storage
+	logger        *slog.Logger
+	emailsender   *emailsender.MockEmailSender
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(CourseServiceSuite))
+}
+
+func (s *CourseServiceSuite) SetupSuite() {
+	var err error
+	s.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s.storage, err = postgres.NewStorage(*tests.NewPostgresTestConfig(), s.logger)
+	assert.NoError(s.T(), err)
+	s.emailsender = emailsender.NewMockEmailSender(gomock.NewController(s.T()))
+}
+
+func (s *CourseServiceSuite) SetupTest() {
+	s.storage.Erase(context.Background())
+	policy, err := userservice.NewPolicy(userservice.PolicyConfig{})
+	s.Require().NoError(err)
+	s.CourseService = NewService(s.logger, s.storage, s.emailsender, 7*24*time.Hour, policy)
+}
+
+// createUser returns the context with the created user as the subject
+func (s *CourseServiceSuite) createUser(ctx context.Context, userID model.UserID, userType model.UserType) context.Context {
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:          userID,
+		Login:       userID.String() + "@edu.mirea.ru",
+		Type:        userType,
+		ConfirmedAt: ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: userModel.Type,
+	})
+}
+
+// createCourse returns the course of the teacher with one group
+func (s *CourseServiceSuite) createCourse(teacherCtx context.Context) (*model.Course, *model.CourseGroup) {
+	course, err := s.CourseService.CreateCourse(teacherCtx, &CreateCourseParams{
+		Name: "Databases",
+	})
+	s.Require().NoError(err)
+
+	group, err := s.CourseService.CreateCourseGroup(teacherCtx, &CreateCourseGroupParams{
+		CourseID: course.ID,
+		Code:     "IKBO-01-22",
+	})
+	s.Require().NoError(err)
+
+	return course, group
+}
+
+func (s *CourseServiceSuite) TestCreateCourse() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+
+	course, err := s.CourseService.CreateCourse(teacherCtx, &CreateCourseParams{
+		Name:        " Databases ",
+		Description: "Relational modeling",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("Databases", course.Name)
+	s.Require().Equal(model.UserID("teacher"), course.CreatedBy)
+	s.Require().NotEmpty(course.JoinCode)
+
+	teachers, err := s.CourseService.ListCourseTeachers(teacherCtx, &ListCourseTeachersParams{CourseID: course.ID})
+	s.Require().NoError(err)
+	s.Require().Len(teachers, 1)
+	s.Require().Equal(model.UserID("teacher"), teachers[0].UserID)
+
+	_, err = s.CourseService.CreateCourse(teacherCtx, &CreateCourseParams{Name: " "})
+	s.Require().ErrorIs(err, ErrInvalidCourseName)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	_, err = s.CourseService.CreateCourse(studentCtx, &CreateCourseParams{Name: "Databases"})
+	s.Require().ErrorIs(err, ErrForbidden)
+}
+
+func (s *CourseServiceSuite) TestJoinCourse_CourseCode() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, _ := s.createCourse(teacherCtx)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	enrollment, err := s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{
+		JoinCode: " " + course.JoinCode + " ",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(course.ID, enrollment.CourseID)
+	s.Require().Equal(model.UserID("student"), enrollment.UserID)
+	s.Require().Nil(enrollment.GroupID)
+
+	// Students don't see join codes
+	studentCourse, err := s.CourseService.GetCourse(studentCtx, &GetCourseParams{ID: course.ID})
+	s.Require().NoError(err)
+	s.Require().Empty(studentCourse.JoinCode)
+
+	_, err = s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: "unknown"})
+	s.Require().ErrorIs(err, ErrJoinCodeNotFound)
+
+	_, err = s.CourseService.JoinCourse(teacherCtx, &JoinCourseParams{JoinCode: course.JoinCode})
+	s.Require().ErrorIs(err, ErrForbidden)
+}
+
+func (s *CourseServiceSuite) TestJoinCourse_GroupCode() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, group := s.createCourse(teacherCtx)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	_, err := s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: course.JoinCode})
+	s.Require().NoError(err)
+
+	// Already enrolled student is moved to the group
+	enrollment, err := s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: group.JoinCode})
+	s.Require().NoError(err)
+	s.Require().Equal(course.ID, enrollment.CourseID)
+	s.Require().NotNil(enrollment.GroupID)
+	s.Require().Equal(group.ID, *enrollment.GroupID)
+
+	enrollments, err := s.CourseService.ListCourseEnrollments(teacherCtx, &ListCourseEnrollmentsParams{
+		CourseID: course.ID,
+		GroupID:  &group.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(enrollments, 1)
+	s.Require().Equal(model.UserID("student"), enrollments[0].UserID)
+}
+
+func (s *CourseServiceSuite) TestJoinCourse_DeletedCourse() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, group := s.createCourse(teacherCtx)
+
+	_, err := s.CourseService.DeleteCourse(teacherCtx, &DeleteCourseParams{ID: course.ID})
+	s.Require().NoError(err)
+
+	_, err = s.storage.CourseGroup().GetCourseGroupByID(teacherCtx, group.ID)
+	s.Require().ErrorIs(err, storage.ErrNotFound)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	_, err = s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: course.JoinCode})
+	s.Require().ErrorIs(err, ErrJoinCodeNotFound)
+
+	_, err = s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: group.JoinCode})
+	s.Require().ErrorIs(err, ErrJoinCodeNotFound)
+
+	enrollments, err := s.storage.CourseEnrollment().GetAllCourseEnrollments(teacherCtx, nil)
+	s.Require().NoError(err)
+	s.Require().Empty(enrollments)
+}
+
+func (s *CourseServiceSuite) TestDeleteCourseGroup_UnassignsEnrollments() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, group := s.createCourse(teacherCtx)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	_, err := s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: group.JoinCode})
+	s.Require().NoError(err)
+
+	_, err = s.CourseService.DeleteCourseGroup(teacherCtx, &DeleteCourseGroupParams{
+		CourseID: course.ID,
+		GroupID:  group.ID,
+	})
+	s.Require().NoError(err)
+
+	enrollments, err := s.CourseService.ListCourseEnrollments(teacherCtx, &ListCourseEnrollmentsParams{
+		CourseID: course.ID,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(enrollments, 1)
+	s.Require().Equal(model.UserID("student"), enrollments[0].UserID)
+	s.Require().Nil(enrollments[0].GroupID)
+
+	groups, err := s.CourseService.ListCourseGroups(teacherCtx, &ListCourseGroupsParams{CourseID: course.ID})
+	s.Require().NoError(err)
+	s.Require().Empty(groups)
+
+	_, err = s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: group.JoinCode})
+	s.Require().ErrorIs(err, ErrJoinCodeNotFound)
+}
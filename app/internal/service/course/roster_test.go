@@ -0,0 +1,159 @@
+package course
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func TestParseRoster(t *testing.T) {
+	rows, err := parseRoster("Email,Group\n first@mirea.ru , IKBO-01-22\nsecond@mirea.ru\n")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, &model.RosterImportRow{Line: 2, Email: "first@mirea.ru", GroupCode: "IKBO-01-22"}, rows[0])
+	assert.Equal(t, &model.RosterImportRow{Line: 3, Email: "second@mirea.ru"}, rows[1])
+
+	// The header is optional
+	rows, err = parseRoster("first@mirea.ru")
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1, rows[0].Line)
+
+	_, err = parseRoster("email\n")
+	assert.ErrorIs(t, err, ErrInvalidRoster)
+
+	_, err = parseRoster("\"first@mirea.ru\n")
+	assert.ErrorIs(t, err, ErrInvalidRoster)
+}
+
+func (s *CourseServiceSuite) TestImportRoster() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, group := s.createCourse(teacherCtx)
+	s.createUser(context.Background(), "student", model.UserTypeStudent)
+
+	s.emailsender.EXPECT().SendInvitationEmail("new@mirea.ru", course.Name, gomock.Any()).Return(nil)
+	s.emailsender.EXPECT().SendInvitationEmail("failed@mirea.ru", course.Name, gomock.Any()).Return(errors.New("smtp is down"))
+
+	rows, err := s.CourseService.ImportRoster(teacherCtx, &ImportRosterParams{
+		CourseID: course.ID,
+		Content: "email,group\n" +
+			"new@mirea.ru," + group.Code + "\n" +
+			"student@edu.mirea.ru\n" +
+			"teacher@edu.mirea.ru\n" +
+			"outsider@gmail.com\n" +
+			"NEW@mirea.ru\n" +
+			"lost@mirea.ru,unknown\n" +
+			"failed@mirea.ru\n",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(rows, 7)
+
+	statuses := make([]model.RosterImportStatus, 0, len(rows))
+	for _, row := range rows {
+		statuses = append(statuses, row.Status)
+	}
+	s.Require().Equal([]model.RosterImportStatus{
+		model.RosterImportStatusInvited,
+		model.RosterImportStatusEnrolled,
+		model.RosterImportStatusNotStudent,
+		model.RosterImportStatusInvalidEmail,
+		model.RosterImportStatusDuplicateEmail,
+		model.RosterImportStatusGroupNotFound,
+		model.RosterImportStatusInvited,
+	}, statuses)
+	s.Require().Equal(2, rows[0].Line)
+	s.Require().NotNil(rows[0].Enrollment.GroupID)
+	s.Require().Equal(group.ID, *rows[0].Enrollment.GroupID)
+	s.Require().Empty(rows[0].EmailError)
+	s.Require().Equal(model.UserID("student"), rows[1].Enrollment.UserID)
+	s.Require().Nil(rows[2].Enrollment)
+
+	// The account and the enrollment are kept if the invitation wasn't sent
+	s.Require().Equal("smtp is down", rows[6].EmailError)
+	s.Require().NotNil(rows[6].Enrollment)
+
+	enrollments, err := s.CourseService.ListCourseEnrollments(teacherCtx, &ListCourseEnrollmentsParams{CourseID: course.ID})
+	s.Require().NoError(err)
+	s.Require().Len(enrollments, 3)
+
+	// Pending accounts wait for the invitation to be accepted
+	pending, err := s.storage.User().GetUserByID(teacherCtx, rows[0].Enrollment.UserID)
+	s.Require().NoError(err)
+	s.Require().Equal("new@mirea.ru", pending.Login)
+	s.Require().Equal(model.UserTypeStudent, pending.Type)
+	s.Require().True(pending.Invited())
+}
+
+func (s *CourseServiceSuite) TestImportRoster_ExistingUsers() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, _ := s.createCourse(teacherCtx)
+	s.createUser(context.Background(), "student", model.UserTypeStudent)
+
+	// Self-registered, but the email wasn't confirmed
+	_, err := s.storage.User().CreateUser(teacherCtx, &storage.CreateUserParams{
+		ID:           "pending",
+		Login:        "pending@edu.mirea.ru",
+		PasswordHash: ptr.To("hash"),
+		Type:         model.UserTypeStudent,
+	})
+	s.Require().NoError(err)
+
+	s.emailsender.EXPECT().SendInvitationEmail("pending@edu.mirea.ru", course.Name, gomock.Any()).Return(nil)
+
+	rows, err := s.CourseService.ImportRoster(teacherCtx, &ImportRosterParams{
+		CourseID: course.ID,
+		Content:  "Student@EDU.mirea.ru\nPENDING@edu.mirea.ru\n",
+	})
+	s.Require().NoError(err)
+	s.Require().Len(rows, 2)
+
+	s.Require().Equal(model.RosterImportStatusEnrolled, rows[0].Status)
+	s.Require().Equal("student@edu.mirea.ru", rows[0].Email)
+	s.Require().Equal(model.UserID("student"), rows[0].Enrollment.UserID)
+
+	// The password of the unconfirmed registration is dropped, the owner of the email sets a new one
+	s.Require().Equal(model.RosterImportStatusInvited, rows[1].Status)
+	s.Require().Equal(model.UserID("pending"), rows[1].Enrollment.UserID)
+	pending, err := s.storage.User().GetUserByID(teacherCtx, "pending")
+	s.Require().NoError(err)
+	s.Require().True(pending.Invited())
+}
+
+func (s *CourseServiceSuite) TestImportRoster_Invalid() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	course, _ := s.createCourse(teacherCtx)
+
+	_, err := s.CourseService.ImportRoster(teacherCtx, &ImportRosterParams{
+		CourseID: course.ID,
+		Content:  "email\n",
+	})
+	s.Require().ErrorIs(err, ErrInvalidRoster)
+
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	_, err = s.CourseService.JoinCourse(studentCtx, &JoinCourseParams{JoinCode: course.JoinCode})
+	s.Require().NoError(err)
+
+	// Students of the course can't import
+	_, err = s.CourseService.ImportRoster(studentCtx, &ImportRosterParams{
+		CourseID: course.ID,
+		Content:  "new@mirea.ru\n",
+	})
+	s.Require().ErrorIs(err, ErrForbidden)
+
+	users, err := s.storage.User().GetAllUsers(teacherCtx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     "new@mirea.ru",
+			Operation: model.FilterOperationExact,
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(users)
+}
@@ -6,26 +6,47 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/lint"
 	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/schema"
 	"github.com/IvLaptev/chartdb-back/internal/storage"
 	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
 	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
 	"github.com/IvLaptev/chartdb-back/pkg/s3client"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
 )
 
 const (
 	diagramIDLength        int64 = 10
+	clientDiagramIDLength  int64 = 12
 	codeLength             int64 = 4
 	objectStorageKeyLength int64 = 20
+	publicSlugLength       int64 = 16
+	activityIDLength       int64 = 20
+
+	maxTagLength = 64
+	maxTagsCount = 20
 )
 
 var (
 	ErrDiagramNotFound        = errors.New("diagram not found")
 	ErrDiagramContentNotFound = errors.New("diagram content not found")
 
+	ErrDiagramLocked   = errors.New("diagram is locked for editing by another user")
+	ErrEditLockNotHeld = errors.New("edit lock is not held")
+
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTooManyTags = errors.New("too many tags")
+
+	ErrExamInProgress = errors.New("only the exam diagram is available during the exam")
+	ErrExamFinished   = errors.New("exam is finished")
+
 	ErrForbidden = errors.New("forbidden")
 )
 
@@ -36,12 +57,29 @@ type Service interface {
 	CreateDiagram(ctx context.Context, params *CreateDiagramParams) (*model.Diagram, error)
 	PatchDiagram(ctx context.Context, params *PatchDiagramParams) (*model.Diagram, error)
 	DeleteDiagram(ctx context.Context, params *DeleteDiagramParams) (*model.Diagram, error)
+
+	SetDiagramTags(ctx context.Context, params *SetDiagramTagsParams) (*model.Diagram, error)
+	RemoveDiagramTags(ctx context.Context, params *RemoveDiagramTagsParams) (*model.Diagram, error)
+
+	PublishDiagram(ctx context.Context, params *PublishDiagramParams) (*model.Diagram, error)
+	UnpublishDiagram(ctx context.Context, params *UnpublishDiagramParams) (*model.Diagram, error)
+	GetPublicDiagram(ctx context.Context, params *GetPublicDiagramParams) (*model.Diagram, error)
+
+	AcquireEditLock(ctx context.Context, params *AcquireEditLockParams) (*model.DiagramEditLock, error)
+	RenewEditLock(ctx context.Context, params *RenewEditLockParams) (*model.DiagramEditLock, error)
+	ReleaseEditLock(ctx context.Context, params *ReleaseEditLockParams) (*model.DiagramEditLock, error)
+}
+
+type Config struct {
+	// Edit locks are renewed by editors while they are open, so the lock of a closed editor expires soon
+	EditLockDuration time.Duration `yaml:"edit_lock_duration" env-default:"2m"`
 }
 
 type ServiceImpl struct {
-	Storage  storage.Storage
-	S3Client s3client.Client
-	Logger   *slog.Logger
+	Storage          storage.Storage
+	S3Client         s3client.Client
+	Logger           *slog.Logger
+	EditLockDuration time.Duration
 }
 
 type GetDiagramParams struct {
@@ -51,18 +89,14 @@ type GetDiagramParams struct {
 func (s *ServiceImpl) GetDiagram(ctx context.Context, params *GetDiagramParams) (*model.Diagram, error) {
 	ctxlog.Info(ctx, s.Logger, "get diagram", slog.Any("params", params))
 
-	rowPolicy, err := storage.RowPolicyFromContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("row policy from context: %w", err)
-	}
 	subject, err := auth.GetSubject(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("get subject: %w", err)
 	}
 
-	adminUserTypes := []model.UserType{model.UserTypeAdmin, model.UserTypeTeacher}
-	if slices.Contains(adminUserTypes, subject.UserType) {
-		rowPolicy = &storage.RowPolicyBackground{}
+	rowPolicy, err := storage.ReadRowPolicyFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read row policy from context: %w", err)
 	}
 
 	var diagramModel *model.Diagram
@@ -95,6 +129,11 @@ func (s *ServiceImpl) GetDiagram(ctx context.Context, params *GetDiagramParams)
 
 	diagramModel = diagramList.Diagrams[0]
 
+	err = s.checkExamAccess(ctx, subject.UserID, diagramModel.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	content, err := s.S3Client.GetContent(ctx, diagramModel.ObjectStorageKey)
 	if err != nil {
 		if errors.Is(err, s3client.ErrContentNotFound) {
@@ -115,12 +154,51 @@ type ListDiagramsParams struct {
 func (s *ServiceImpl) ListDiagrams(ctx context.Context, params *ListDiagramsParams) (*model.DiagramList, error) {
 	ctxlog.Info(ctx, s.Logger, "list diagrams", slog.Any("params", params))
 
-	rowPolicy, err := storage.RowPolicyFromContext(ctx)
+	subject, err := auth.GetSubject(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("row policy from context: %w", err)
+		return nil, fmt.Errorf("get subject: %w", err)
 	}
 
-	diagramList, err := s.Storage.Diagram().GetAllDiagrams(ctx, rowPolicy, params.Filter, nil)
+	rowPolicy, err := storage.ReadRowPolicyFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read row policy from context: %w", err)
+	}
+
+	hasUserIDTerm := false
+	for _, term := range params.Filter {
+		switch term.Key {
+		case model.TermKeyTag:
+			if tag, ok := term.Value.(string); ok {
+				term.Value = normalizeTag(tag)
+			}
+		case model.TermKeyUserID:
+			hasUserIDTerm = true
+		}
+	}
+
+	// Diagrams of other users are listed only on explicit request
+	filter := params.Filter
+	if !hasUserIDTerm {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	examSession, err := GetOpenExamSession(ctx, s.Storage, subject.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if examSession != nil {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyID,
+			Value:     examSession.DiagramID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	diagramList, err := s.Storage.Diagram().GetAllDiagrams(ctx, rowPolicy, filter, nil)
 	if err != nil {
 		return nil, fmt.Errorf("get all diagrams: %w", err)
 	}
@@ -129,21 +207,42 @@ func (s *ServiceImpl) ListDiagrams(ctx context.Context, params *ListDiagramsPara
 }
 
 type CreateDiagramParams struct {
+	// Generated if empty
 	ClientDiagramID string
 	UserID          model.UserID
 	Content         utils.Secret[string]
 	Name            string
 	TablesCount     int64
+
+	// Set for diagrams started from the assignment starter diagram
+	AssignmentID *model.AssignmentID
 }
 
 func (s *ServiceImpl) CreateDiagram(ctx context.Context, params *CreateDiagramParams) (*model.Diagram, error) {
 	ctxlog.Info(ctx, s.Logger, "create diagram", slog.Any("params", params))
 
+	// Diagrams created during the exam could be used to bring in prepared content
+	examSession, err := GetOpenExamSession(ctx, s.Storage, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if examSession != nil {
+		return nil, xerrors.WrapForbidden(ErrExamInProgress)
+	}
+
 	diagramID, err := utils.GenerateID(diagramIDLength)
 	if err != nil {
 		return nil, fmt.Errorf("generate id: %w", err)
 	}
 
+	clientDiagramID := params.ClientDiagramID
+	if clientDiagramID == "" {
+		clientDiagramID, err = utils.GenerateID(clientDiagramIDLength)
+		if err != nil {
+			return nil, fmt.Errorf("generate id (client diagram id): %w", err)
+		}
+	}
+
 	code, err := utils.GenerateID(codeLength)
 	if err != nil {
 		return nil, fmt.Errorf("generate id (code): %w", err)
@@ -158,7 +257,7 @@ func (s *ServiceImpl) CreateDiagram(ctx context.Context, params *CreateDiagramPa
 	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
 		diagramModel, err = s.Storage.Diagram().CreateDiagram(ctx, &storage.CreateDiagramParams{
 			ID:               model.DiagramID(diagramID),
-			ClientDiagramID:  params.ClientDiagramID,
+			ClientDiagramID:  clientDiagramID,
 			Code:             code,
 			UserID:           params.UserID,
 			ObjectStorageKey: objStorageKey,
@@ -176,7 +275,13 @@ func (s *ServiceImpl) CreateDiagram(ctx context.Context, params *CreateDiagramPa
 
 		diagramModel.Content = utils.NewSecret(&params.Content.Value)
 
-		return nil
+		// Starter content is not linted, it is not the work of the student
+		var lintFindings *int64
+		if params.AssignmentID == nil {
+			lintFindings = countLintFindings(params.Content.Value)
+		}
+
+		return s.logActivity(ctx, params.UserID, diagramModel.ID, params.AssignmentID, model.DiagramActivityActionCreate, lintFindings)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't create diagram: %w", err)
@@ -191,6 +296,9 @@ type PatchDiagramParams struct {
 	Content     utils.Optional[utils.Secret[string]]
 	Name        utils.Optional[string]
 	TablesCount utils.Optional[int64]
+
+	// Required only if the diagram is locked
+	EditLockHolderID string
 }
 
 func (s *ServiceImpl) PatchDiagram(ctx context.Context, params *PatchDiagramParams) (*model.Diagram, error) {
@@ -226,7 +334,31 @@ func (s *ServiceImpl) PatchDiagram(ctx context.Context, params *PatchDiagramPara
 			return fmt.Errorf("get diagram by id: %w", err)
 		}
 
-		var optionalObjectStorageKey utils.Optional[string]
+		err = s.checkExamAccess(ctx, subject.UserID, params.ID)
+		if err != nil {
+			return err
+		}
+
+		examSession, err := s.getDiagramExamSession(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if examSession != nil && !examSession.IsOpen(time.Now()) {
+			return xerrors.WrapForbidden(ErrExamFinished)
+		}
+
+		editLock, err := s.getActiveEditLock(ctx, params.ID)
+		if err != nil {
+			return fmt.Errorf("get active edit lock: %w", err)
+		}
+		if editLock != nil && !editLock.IsHeldBy(subject.UserID, params.EditLockHolderID) {
+			return xerrors.WrapConflict(ErrDiagramLocked)
+		}
+
+		var (
+			optionalObjectStorageKey utils.Optional[string]
+			lintFindings             *int64
+		)
 		if params.Content.Valid {
 			objectStorageKey, err := utils.GenerateID(objectStorageKeyLength)
 			if err != nil {
@@ -238,6 +370,7 @@ func (s *ServiceImpl) PatchDiagram(ctx context.Context, params *PatchDiagramPara
 			}
 
 			optionalObjectStorageKey = utils.NewOptional(objectStorageKey)
+			lintFindings = countLintFindings(params.Content.Value.Value)
 		}
 
 		diagramModel, err = s.Storage.Diagram().PatchDiagram(ctx, &storage.PatchDiagramParams{
@@ -251,7 +384,7 @@ func (s *ServiceImpl) PatchDiagram(ctx context.Context, params *PatchDiagramPara
 			return fmt.Errorf("patch diagram: %w", err)
 		}
 
-		return nil
+		return s.logActivity(ctx, subject.UserID, params.ID, nil, model.DiagramActivityActionEdit, lintFindings)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't patch diagram: %w", err)
@@ -297,12 +430,26 @@ func (s *ServiceImpl) DeleteDiagram(ctx context.Context, params *DeleteDiagramPa
 			return fmt.Errorf("get diagram by id: %w", err)
 		}
 
+		err = s.checkExamAccess(ctx, subject.UserID, params.ID)
+		if err != nil {
+			return err
+		}
+
+		// The diagram is needed to submit the exam when it ends
+		examSession, err := s.getDiagramExamSession(ctx, params.ID)
+		if err != nil {
+			return err
+		}
+		if examSession != nil && examSession.ClosedAt == nil {
+			return xerrors.WrapForbidden(ErrExamInProgress)
+		}
+
 		diagramModel, err = s.Storage.Diagram().DeleteDiagram(ctx, params.ID)
 		if err != nil {
 			return fmt.Errorf("delete diagram: %w", err)
 		}
 
-		return nil
+		return s.logActivity(ctx, subject.UserID, params.ID, nil, model.DiagramActivityActionDelete, nil)
 	})
 	if err != nil {
 		return nil, fmt.Errorf("can't delete diagram: %w", err)
@@ -311,10 +458,591 @@ func (s *ServiceImpl) DeleteDiagram(ctx context.Context, params *DeleteDiagramPa
 	return diagramModel, nil
 }
 
-func NewService(logger *slog.Logger, storage storage.Storage, s3Client s3client.Client) *ServiceImpl {
+type PublishDiagramParams struct {
+	ID model.DiagramID
+}
+
+// PublishDiagram makes the diagram readable without authentication, public slug is kept between publications
+func (s *ServiceImpl) PublishDiagram(ctx context.Context, params *PublishDiagramParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "publish diagram", slog.Any("params", params))
+
+	diagramModel, err := s.patchOwnDiagram(ctx, params.ID, func(diagramModel *model.Diagram) (*storage.PatchDiagramParams, error) {
+		patchParams := &storage.PatchDiagramParams{
+			ID:          diagramModel.ID,
+			PublishedAt: utils.NewOptional(ptr.To(time.Now())),
+		}
+
+		if diagramModel.PublicSlug == nil {
+			publicSlug, err := utils.GenerateID(publicSlugLength)
+			if err != nil {
+				return nil, fmt.Errorf("generate id (public slug): %w", err)
+			}
+			patchParams.PublicSlug = utils.NewOptional(&publicSlug)
+		}
+
+		return patchParams, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't publish diagram: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+type UnpublishDiagramParams struct {
+	ID model.DiagramID
+}
+
+func (s *ServiceImpl) UnpublishDiagram(ctx context.Context, params *UnpublishDiagramParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "unpublish diagram", slog.Any("params", params))
+
+	diagramModel, err := s.patchOwnDiagram(ctx, params.ID, func(diagramModel *model.Diagram) (*storage.PatchDiagramParams, error) {
+		return &storage.PatchDiagramParams{
+			ID:          diagramModel.ID,
+			PublishedAt: utils.NewOptional[*time.Time](nil),
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't unpublish diagram: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+type GetPublicDiagramParams struct {
+	PublicSlug string
+}
+
+// GetPublicDiagram doesn't require authentication and returns only published diagrams
+func (s *ServiceImpl) GetPublicDiagram(ctx context.Context, params *GetPublicDiagramParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "get public diagram", slog.Any("params", params))
+
+	diagramList, err := s.Storage.Diagram().GetAllDiagrams(ctx, &storage.RowPolicyBackground{}, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyPublicSlug,
+			Value:     params.PublicSlug,
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyPublishedAt,
+			Value:     nil,
+			Operation: model.FilterOperationNotEqual,
+		},
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get all diagrams by public slug: %w", err)
+	}
+	if len(diagramList.Diagrams) == 0 {
+		return nil, xerrors.WrapNotFound(ErrDiagramNotFound)
+	}
+
+	diagramModel := diagramList.Diagrams[0]
+
+	content, err := s.S3Client.GetContent(ctx, diagramModel.ObjectStorageKey)
+	if err != nil {
+		if errors.Is(err, s3client.ErrContentNotFound) {
+			return nil, xerrors.WrapNotFound(ErrDiagramContentNotFound)
+		}
+		return nil, fmt.Errorf("get content: %w", err)
+	}
+
+	diagramModel.Content = utils.NewSecret(&content)
+
+	return diagramModel, nil
+}
+
+// patchOwnDiagram locks the diagram owned by the subject and patches it with params built by makeParams
+func (s *ServiceImpl) patchOwnDiagram(
+	ctx context.Context,
+	id model.DiagramID,
+	makeParams func(diagramModel *model.Diagram) (*storage.PatchDiagramParams, error),
+) (*model.Diagram, error) {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	allowedUserTypes := []model.UserType{
+		model.UserTypeAdmin,
+		model.UserTypeTeacher,
+		model.UserTypeStudent,
+	}
+
+	if !slices.Contains(allowedUserTypes, subject.UserType) {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	var diagramModel *model.Diagram
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		rowPolicy, err := storage.RowPolicyFromContext(ctx)
+		if err != nil {
+			return fmt.Errorf("row policy from context: %w", err)
+		}
+
+		diagramModel, err = s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, id, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrDiagramNotFound)
+			}
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		err = s.checkExamAccess(ctx, subject.UserID, id)
+		if err != nil {
+			return err
+		}
+
+		examSession, err := s.getDiagramExamSession(ctx, id)
+		if err != nil {
+			return err
+		}
+		if examSession != nil && examSession.IsOpen(time.Now()) {
+			return xerrors.WrapForbidden(ErrExamInProgress)
+		}
+
+		patchParams, err := makeParams(diagramModel)
+		if err != nil {
+			return err
+		}
+
+		diagramModel, err = s.Storage.Diagram().PatchDiagram(ctx, patchParams)
+		if err != nil {
+			return fmt.Errorf("patch diagram: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diagramModel, nil
+}
+
+// GetOpenExamSession returns the exam session the user is taking now or nil
+func GetOpenExamSession(ctx context.Context, st storage.Storage, userID model.UserID) (*model.ExamSession, error) {
+	examSessions, err := st.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyClosedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+		{
+			Key:       model.TermKeyEndsAt,
+			Value:     time.Now(),
+			Operation: model.FilterOperationMore,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all exam sessions: %w", err)
+	}
+	if len(examSessions) == 0 {
+		return nil, nil
+	}
+
+	return examSessions[0], nil
+}
+
+// getDiagramExamSession returns the exam session the diagram was created for or nil
+func (s *ServiceImpl) getDiagramExamSession(ctx context.Context, diagramID model.DiagramID) (*model.ExamSession, error) {
+	examSessions, err := s.Storage.ExamSession().GetAllExamSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyDiagramID,
+			Value:     diagramID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all exam sessions: %w", err)
+	}
+	if len(examSessions) == 0 {
+		return nil, nil
+	}
+
+	return examSessions[0], nil
+}
+
+// checkExamAccess forbids access to diagrams other than the exam diagram while the user takes an exam
+func (s *ServiceImpl) checkExamAccess(ctx context.Context, userID model.UserID, diagramID model.DiagramID) error {
+	examSession, err := GetOpenExamSession(ctx, s.Storage, userID)
+	if err != nil {
+		return err
+	}
+	if examSession != nil && examSession.DiagramID != diagramID {
+		return xerrors.WrapForbidden(ErrExamInProgress)
+	}
+
+	return nil
+}
+
+// logActivity writes the action to the activity log used by course analytics
+func (s *ServiceImpl) logActivity(
+	ctx context.Context,
+	userID model.UserID,
+	diagramID model.DiagramID,
+	assignmentID *model.AssignmentID,
+	action model.DiagramActivityAction,
+	lintFindings *int64,
+) error {
+	activityID, err := utils.GenerateID(activityIDLength)
+	if err != nil {
+		return fmt.Errorf("generate id (activity): %w", err)
+	}
+
+	_, err = s.Storage.DiagramActivity().CreateDiagramActivity(ctx, &storage.CreateDiagramActivityParams{
+		ID:           model.DiagramActivityID(activityID),
+		UserID:       userID,
+		DiagramID:    diagramID,
+		AssignmentID: assignmentID,
+		Action:       action,
+		LintFindings: lintFindings,
+	})
+	if err != nil {
+		return fmt.Errorf("create diagram activity: %w", err)
+	}
+
+	return nil
+}
+
+// countLintFindings returns nil if the content is not a valid schema
+func countLintFindings(content string) *int64 {
+	s, err := schema.Parse(content)
+	if err != nil {
+		return nil
+	}
+
+	return ptr.To(int64(len(lint.Lint(s))))
+}
+
+type AcquireEditLockParams struct {
+	DiagramID model.DiagramID
+	HolderID  string
+}
+
+// AcquireEditLock grants the subject exclusive write access to the diagram for EditLockDuration.
+// Acquiring the lock which is already held by the same holder renews it.
+func (s *ServiceImpl) AcquireEditLock(ctx context.Context, params *AcquireEditLockParams) (*model.DiagramEditLock, error) {
+	ctxlog.Info(ctx, s.Logger, "acquire edit lock", slog.Any("params", params))
+
+	var editLock *model.DiagramEditLock
+	err := s.doWithDiagramEditLock(ctx, params.DiagramID, func(ctx context.Context, subject *auth.Subject, activeLock *model.DiagramEditLock) error {
+		var err error
+		if activeLock != nil {
+			if !activeLock.IsHeldBy(subject.UserID, params.HolderID) {
+				return xerrors.WrapConflict(ErrDiagramLocked)
+			}
+
+			editLock, err = s.Storage.DiagramEditLock().PatchDiagramEditLock(ctx, &storage.PatchDiagramEditLockParams{
+				DiagramID: params.DiagramID,
+				Duration:  s.EditLockDuration,
+			})
+			if err != nil {
+				return fmt.Errorf("patch diagram edit lock: %w", err)
+			}
+
+			return nil
+		}
+
+		// Expired lock could be still stored until background job removes it
+		_, err = s.Storage.DiagramEditLock().DeleteDiagramEditLock(ctx, params.DiagramID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("delete diagram edit lock: %w", err)
+		}
+
+		editLock, err = s.Storage.DiagramEditLock().CreateDiagramEditLock(ctx, &storage.CreateDiagramEditLockParams{
+			DiagramID: params.DiagramID,
+			UserID:    subject.UserID,
+			HolderID:  params.HolderID,
+			Duration:  s.EditLockDuration,
+		})
+		if err != nil {
+			return fmt.Errorf("create diagram edit lock: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't acquire edit lock: %w", err)
+	}
+
+	return editLock, nil
+}
+
+type RenewEditLockParams struct {
+	DiagramID model.DiagramID
+	HolderID  string
+}
+
+func (s *ServiceImpl) RenewEditLock(ctx context.Context, params *RenewEditLockParams) (*model.DiagramEditLock, error) {
+	ctxlog.Info(ctx, s.Logger, "renew edit lock", slog.Any("params", params))
+
+	var editLock *model.DiagramEditLock
+	err := s.doWithDiagramEditLock(ctx, params.DiagramID, func(ctx context.Context, subject *auth.Subject, activeLock *model.DiagramEditLock) error {
+		if activeLock == nil || !activeLock.IsHeldBy(subject.UserID, params.HolderID) {
+			return xerrors.WrapConflict(ErrEditLockNotHeld)
+		}
+
+		var err error
+		editLock, err = s.Storage.DiagramEditLock().PatchDiagramEditLock(ctx, &storage.PatchDiagramEditLockParams{
+			DiagramID: params.DiagramID,
+			Duration:  s.EditLockDuration,
+		})
+		if err != nil {
+			return fmt.Errorf("patch diagram edit lock: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't renew edit lock: %w", err)
+	}
+
+	return editLock, nil
+}
+
+type ReleaseEditLockParams struct {
+	DiagramID model.DiagramID
+	HolderID  string
+}
+
+func (s *ServiceImpl) ReleaseEditLock(ctx context.Context, params *ReleaseEditLockParams) (*model.DiagramEditLock, error) {
+	ctxlog.Info(ctx, s.Logger, "release edit lock", slog.Any("params", params))
+
+	var editLock *model.DiagramEditLock
+	err := s.doWithDiagramEditLock(ctx, params.DiagramID, func(ctx context.Context, subject *auth.Subject, activeLock *model.DiagramEditLock) error {
+		if activeLock == nil || !activeLock.IsHeldBy(subject.UserID, params.HolderID) {
+			return xerrors.WrapConflict(ErrEditLockNotHeld)
+		}
+
+		var err error
+		editLock, err = s.Storage.DiagramEditLock().DeleteDiagramEditLock(ctx, params.DiagramID)
+		if err != nil {
+			return fmt.Errorf("delete diagram edit lock: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't release edit lock: %w", err)
+	}
+
+	return editLock, nil
+}
+
+// doWithDiagramEditLock locks the diagram writable by the subject and calls f with its active edit lock if any
+func (s *ServiceImpl) doWithDiagramEditLock(
+	ctx context.Context,
+	diagramID model.DiagramID,
+	f func(ctx context.Context, subject *auth.Subject, activeLock *model.DiagramEditLock) error,
+) error {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+
+	allowedUserTypes := []model.UserType{
+		model.UserTypeAdmin,
+		model.UserTypeTeacher,
+		model.UserTypeStudent,
+	}
+
+	if !slices.Contains(allowedUserTypes, subject.UserType) {
+		return xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	return s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		rowPolicy, err := storage.RowPolicyFromContext(ctx)
+		if err != nil {
+			return fmt.Errorf("row policy from context: %w", err)
+		}
+
+		_, err = s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, diagramID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrDiagramNotFound)
+			}
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		activeLock, err := s.getActiveEditLock(ctx, diagramID)
+		if err != nil {
+			return fmt.Errorf("get active edit lock: %w", err)
+		}
+
+		return f(ctx, subject, activeLock)
+	})
+}
+
+// getActiveEditLock returns nil if the diagram has no lock or it is expired
+func (s *ServiceImpl) getActiveEditLock(ctx context.Context, diagramID model.DiagramID) (*model.DiagramEditLock, error) {
+	editLock, err := s.Storage.DiagramEditLock().GetDiagramEditLock(ctx, diagramID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get diagram edit lock: %w", err)
+	}
+
+	if !editLock.IsActive(time.Now()) {
+		return nil, nil
+	}
+
+	return editLock, nil
+}
+
+type SetDiagramTagsParams struct {
+	ID   model.DiagramID
+	Tags []string
+}
+
+// SetDiagramTags replaces all tags of the diagram with the given ones
+func (s *ServiceImpl) SetDiagramTags(ctx context.Context, params *SetDiagramTagsParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "set diagram tags", slog.Any("params", params))
+
+	tags, err := normalizeTags(params.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("normalize tags: %w", err)
+	}
+
+	diagramModel, err := s.updateDiagramTags(ctx, params.ID, func(ctx context.Context) error {
+		err := s.Storage.DiagramTag().DeleteDiagramTags(ctx, params.ID, nil)
+		if err != nil {
+			return fmt.Errorf("delete diagram tags: %w", err)
+		}
+
+		err = s.Storage.DiagramTag().CreateDiagramTags(ctx, params.ID, tags)
+		if err != nil {
+			return fmt.Errorf("create diagram tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't set diagram tags: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+type RemoveDiagramTagsParams struct {
+	ID   model.DiagramID
+	Tags []string
+}
+
+func (s *ServiceImpl) RemoveDiagramTags(ctx context.Context, params *RemoveDiagramTagsParams) (*model.Diagram, error) {
+	ctxlog.Info(ctx, s.Logger, "remove diagram tags", slog.Any("params", params))
+
+	tags, err := normalizeTags(params.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("normalize tags: %w", err)
+	}
+	if len(tags) == 0 {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidTag)
+	}
+
+	diagramModel, err := s.updateDiagramTags(ctx, params.ID, func(ctx context.Context) error {
+		err := s.Storage.DiagramTag().DeleteDiagramTags(ctx, params.ID, tags)
+		if err != nil {
+			return fmt.Errorf("delete diagram tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't remove diagram tags: %w", err)
+	}
+
+	return diagramModel, nil
+}
+
+// updateDiagramTags locks the diagram of the subject, applies update and returns the diagram with actual tags
+func (s *ServiceImpl) updateDiagramTags(ctx context.Context, id model.DiagramID, update func(ctx context.Context) error) (*model.Diagram, error) {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	allowedUserTypes := []model.UserType{
+		model.UserTypeAdmin,
+		model.UserTypeTeacher,
+		model.UserTypeStudent,
+	}
+
+	if !slices.Contains(allowedUserTypes, subject.UserType) {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	var diagramModel *model.Diagram
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		rowPolicy, err := storage.RowPolicyFromContext(ctx)
+		if err != nil {
+			return fmt.Errorf("row policy from context: %w", err)
+		}
+
+		_, err = s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, id, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrDiagramNotFound)
+			}
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		err = s.checkExamAccess(ctx, subject.UserID, id)
+		if err != nil {
+			return err
+		}
+
+		err = update(ctx)
+		if err != nil {
+			return err
+		}
+
+		diagramModel, err = s.Storage.Diagram().GetDiagramByID(ctx, rowPolicy, id)
+		if err != nil {
+			return fmt.Errorf("get diagram by id: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diagramModel, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = normalizeTag(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, xerrors.WrapInvalidArgument(fmt.Errorf("%w: %q", ErrInvalidTag, tag))
+		}
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+
+	if len(result) > maxTagsCount {
+		return nil, xerrors.WrapInvalidArgument(ErrTooManyTags)
+	}
+
+	return result, nil
+}
+
+func NewService(logger *slog.Logger, storage storage.Storage, s3Client s3client.Client, editLockDuration time.Duration) *ServiceImpl {
 	return &ServiceImpl{
-		Logger:   logger.With("name", "service/diagram"),
-		Storage:  storage,
-		S3Client: s3Client,
+		Logger:           logger.With("name", "service/diagram"),
+		Storage:          storage,
+		S3Client:         s3Client,
+		EditLockDuration: editLockDuration,
 	}
 }
@@ -0,0 +1,579 @@
+package diagram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
+	"github.com/IvLaptev/chartdb-back/internal/tests"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiagramServiceSuite struct {
+	suite.Suite
+
+	DiagramService *ServiceImpl
+	storage        storage.Storage
+	logger         *slog.Logger
+}
+
+func TestSuite(t *testing.T) {
+	suite.Run(t, new(DiagramServiceSuite))
+}
+
+func (s *DiagramServiceSuite) SetupSuite() {
+	var err error
+	s.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	s.storage, err = postgres.NewStorage(*tests.NewPostgresTestConfig(), s.logger)
+	assert.NoError(s.T(), err)
+}
+
+func (s *DiagramServiceSuite) SetupTest() {
+	s.storage.Erase(context.Background())
+	s.DiagramService = NewService(s.logger, s.storage, nil, 2*time.Minute)
+}
+
+// createUser returns the context with the created user as the subject
+func (s *DiagramServiceSuite) createUser(ctx context.Context, userID model.UserID, userType model.UserType) context.Context {
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:          userID,
+		Login:       userID.String() + "@edu.mirea.ru",
+		Type:        userType,
+		ConfirmedAt: ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: userModel.Type,
+	})
+}
+
+func (s *DiagramServiceSuite) createDiagram(ctx context.Context, diagramID model.DiagramID, userID model.UserID) {
+	code, err := utils.GenerateID(codeLength)
+	s.Require().NoError(err)
+
+	_, err = s.storage.Diagram().CreateDiagram(ctx, &storage.CreateDiagramParams{
+		ID:               diagramID,
+		ClientDiagramID:  diagramID.String(),
+		Code:             code,
+		UserID:           userID,
+		ObjectStorageKey: diagramID.String(),
+		Name:             diagramID.String(),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *DiagramServiceSuite) TestEditLock_Acquire() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	editLock, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserID("student"), editLock.UserID)
+	s.Require().Equal("tab1", editLock.HolderID)
+	s.Require().True(editLock.ExpiresAt.After(time.Now().Add(time.Minute)))
+
+	// The holder can edit the diagram, other editors of the same user can't
+	_, err = s.DiagramService.PatchDiagram(ctx, &PatchDiagramParams{
+		ID:               "diagram001",
+		Name:             utils.NewOptional("tab1"),
+		EditLockHolderID: "tab1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.DiagramService.PatchDiagram(ctx, &PatchDiagramParams{
+		ID:               "diagram001",
+		Name:             utils.NewOptional("tab2"),
+		EditLockHolderID: "tab2",
+	})
+	s.Require().ErrorIs(err, ErrDiagramLocked)
+
+	_, err = s.DiagramService.PatchDiagram(ctx, &PatchDiagramParams{
+		ID:   "diagram001",
+		Name: utils.NewOptional("no holder"),
+	})
+	s.Require().ErrorIs(err, ErrDiagramLocked)
+}
+
+func (s *DiagramServiceSuite) TestEditLock_Conflict() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	_, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab2",
+	})
+	s.Require().ErrorIs(err, ErrDiagramLocked)
+
+	_, err = s.DiagramService.RenewEditLock(ctx, &RenewEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab2",
+	})
+	s.Require().ErrorIs(err, ErrEditLockNotHeld)
+
+	_, err = s.DiagramService.ReleaseEditLock(ctx, &ReleaseEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab2",
+	})
+	s.Require().ErrorIs(err, ErrEditLockNotHeld)
+
+	// Other users can't even see the diagram
+	otherCtx := s.createUser(context.Background(), "other", model.UserTypeStudent)
+	_, err = s.DiagramService.AcquireEditLock(otherCtx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().ErrorIs(err, ErrDiagramNotFound)
+}
+
+func (s *DiagramServiceSuite) TestEditLock_Renew() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	acquiredLock, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+
+	renewedLock, err := s.DiagramService.RenewEditLock(ctx, &RenewEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("tab1", renewedLock.HolderID)
+	s.Require().Equal(acquiredLock.CreatedAt, renewedLock.CreatedAt)
+	s.Require().True(renewedLock.ExpiresAt.After(acquiredLock.ExpiresAt))
+
+	// Acquiring the held lock renews it too
+	reacquiredLock, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(acquiredLock.CreatedAt, reacquiredLock.CreatedAt)
+	s.Require().True(reacquiredLock.ExpiresAt.After(renewedLock.ExpiresAt))
+}
+
+func (s *DiagramServiceSuite) TestEditLock_Release() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	_, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.DiagramService.ReleaseEditLock(ctx, &ReleaseEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.DiagramEditLock().GetDiagramEditLock(ctx, "diagram001")
+	s.Require().ErrorIs(err, storage.ErrNotFound)
+
+	_, err = s.DiagramService.ReleaseEditLock(ctx, &ReleaseEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().ErrorIs(err, ErrEditLockNotHeld)
+
+	editLock, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab2",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("tab2", editLock.HolderID)
+}
+
+func (s *DiagramServiceSuite) TestEditLock_Expired() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	_, err := s.storage.DiagramEditLock().CreateDiagramEditLock(ctx, &storage.CreateDiagramEditLockParams{
+		DiagramID: "diagram001",
+		UserID:    "student",
+		HolderID:  "tab1",
+		Duration:  -time.Second,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.DiagramService.RenewEditLock(ctx, &RenewEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab1",
+	})
+	s.Require().ErrorIs(err, ErrEditLockNotHeld)
+
+	_, err = s.DiagramService.PatchDiagram(ctx, &PatchDiagramParams{
+		ID:   "diagram001",
+		Name: utils.NewOptional("no holder"),
+	})
+	s.Require().NoError(err)
+
+	editLock, err := s.DiagramService.AcquireEditLock(ctx, &AcquireEditLockParams{
+		DiagramID: "diagram001",
+		HolderID:  "tab2",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("tab2", editLock.HolderID)
+	s.Require().True(editLock.IsActive(time.Now()))
+}
+
+func (s *DiagramServiceSuite) enrollStudent(ctx context.Context, courseID model.CourseID, teacherID, studentID model.UserID) {
+	_, err := s.storage.Course().CreateCourse(ctx, &storage.CreateCourseParams{
+		ID:        courseID,
+		Name:      courseID.String(),
+		JoinCode:  courseID.String(),
+		CreatedBy: teacherID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.CourseTeacher().CreateCourseTeacher(ctx, &storage.CreateCourseTeacherParams{
+		CourseID: courseID,
+		UserID:   teacherID,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.CourseEnrollment().CreateCourseEnrollment(ctx, &storage.CreateCourseEnrollmentParams{
+		CourseID: courseID,
+		UserID:   studentID,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *DiagramServiceSuite) listUserDiagrams(ctx context.Context, userID model.UserID) []model.DiagramID {
+	diagramList, err := s.DiagramService.ListDiagrams(ctx, &ListDiagramsParams{
+		Filter: []*model.FilterTerm{
+			{
+				Key:       model.TermKeyUserID,
+				Value:     userID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	diagramIDs := make([]model.DiagramID, 0, len(diagramList.Diagrams))
+	for _, diagram := range diagramList.Diagrams {
+		diagramIDs = append(diagramIDs, diagram.ID)
+	}
+
+	return diagramIDs
+}
+
+func (s *DiagramServiceSuite) TestListDiagrams_TeacherVisibility() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createUser(context.Background(), "other", model.UserTypeStudent)
+	s.createUser(context.Background(), "former", model.UserTypeStudent)
+	s.createDiagram(teacherCtx, "teacher001", "teacher")
+	s.createDiagram(teacherCtx, "student001", "student")
+	s.createDiagram(teacherCtx, "other00001", "other")
+	s.createDiagram(teacherCtx, "former0001", "former")
+	s.enrollStudent(teacherCtx, "course001", "teacher", "student")
+	s.enrollStudent(teacherCtx, "course002", "teacher", "former")
+
+	_, err := s.storage.Course().DeleteCourse(teacherCtx, "course002")
+	s.Require().NoError(err)
+
+	// Teachers see diagrams of students enrolled in their courses
+	s.Require().Equal([]model.DiagramID{"student001"}, s.listUserDiagrams(teacherCtx, "student"))
+	s.Require().Empty(s.listUserDiagrams(teacherCtx, "other"))
+	s.Require().Empty(s.listUserDiagrams(teacherCtx, "former"))
+
+	// Own diagrams are listed by default
+	diagramList, err := s.DiagramService.ListDiagrams(teacherCtx, &ListDiagramsParams{})
+	s.Require().NoError(err)
+	s.Require().Len(diagramList.Diagrams, 1)
+	s.Require().Equal(model.DiagramID("teacher001"), diagramList.Diagrams[0].ID)
+
+	// Students see only their own diagrams, even of their teachers
+	s.Require().Equal([]model.DiagramID{"student001"}, s.listUserDiagrams(studentCtx, "student"))
+	s.Require().Empty(s.listUserDiagrams(studentCtx, "teacher"))
+	s.Require().Empty(s.listUserDiagrams(studentCtx, "other"))
+
+	// Writes are allowed only to owners
+	_, err = s.DiagramService.PatchDiagram(teacherCtx, &PatchDiagramParams{
+		ID:   "student001",
+		Name: utils.NewOptional("renamed"),
+	})
+	s.Require().ErrorIs(err, ErrDiagramNotFound)
+}
+
+// submitDiagram submits the diagram of the student to the new assignment of the course
+func (s *DiagramServiceSuite) submitDiagram(ctx context.Context, courseID model.CourseID, assignmentID model.AssignmentID, diagramID model.DiagramID, studentID model.UserID) {
+	_, err := s.storage.Assignment().CreateAssignment(ctx, &storage.CreateAssignmentParams{
+		ID:        assignmentID,
+		CourseID:  courseID,
+		Title:     assignmentID.String(),
+		CreatedBy: "teacher",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.Submission().CreateSubmission(ctx, &storage.CreateSubmissionParams{
+		ID:               model.SubmissionID(assignmentID.String()),
+		AssignmentID:     assignmentID,
+		UserID:           studentID,
+		DiagramID:        diagramID,
+		ObjectStorageKey: diagramID.String(),
+		Name:             diagramID.String(),
+	})
+	s.Require().NoError(err)
+}
+
+func (s *DiagramServiceSuite) TestListDiagrams_TeacherSubmissions() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(teacherCtx, "student001", "student")
+	s.createDiagram(teacherCtx, "student002", "student")
+
+	// The student has left the course, submitted diagrams stay visible to its teachers
+	for _, courseID := range []model.CourseID{"course001", "course002"} {
+		_, err := s.storage.Course().CreateCourse(teacherCtx, &storage.CreateCourseParams{
+			ID:        courseID,
+			Name:      courseID.String(),
+			JoinCode:  courseID.String(),
+			CreatedBy: "teacher",
+		})
+		s.Require().NoError(err)
+
+		_, err = s.storage.CourseTeacher().CreateCourseTeacher(teacherCtx, &storage.CreateCourseTeacherParams{
+			CourseID: courseID,
+			UserID:   "teacher",
+		})
+		s.Require().NoError(err)
+	}
+	s.submitDiagram(teacherCtx, "course001", "assign0001", "student001", "student")
+	s.submitDiagram(teacherCtx, "course002", "assign0002", "student002", "student")
+	s.Require().ElementsMatch([]model.DiagramID{"student001", "student002"}, s.listUserDiagrams(teacherCtx, "student"))
+
+	// Submissions to deleted assignments and courses are not visible
+	_, err := s.storage.Assignment().DeleteAssignment(teacherCtx, "assign0001")
+	s.Require().NoError(err)
+	s.Require().Equal([]model.DiagramID{"student002"}, s.listUserDiagrams(teacherCtx, "student"))
+
+	_, err = s.storage.Course().DeleteCourse(teacherCtx, "course002")
+	s.Require().NoError(err)
+	s.Require().Empty(s.listUserDiagrams(teacherCtx, "student"))
+}
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" Backend ", "backend", "Auth", "БД"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backend", "auth", "бд"}, tags)
+
+	tags, err = normalizeTags(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = normalizeTags([]string{"backend", " "})
+	assert.ErrorIs(t, err, ErrInvalidTag)
+
+	_, err = normalizeTags([]string{strings.Repeat("т", maxTagLength+1)})
+	assert.ErrorIs(t, err, ErrInvalidTag)
+
+	_, err = normalizeTags([]string{strings.Repeat("т", maxTagLength)})
+	assert.NoError(t, err)
+
+	tooMany := make([]string, 0, maxTagsCount+1)
+	for i := range maxTagsCount + 1 {
+		tooMany = append(tooMany, fmt.Sprintf("tag%d", i))
+	}
+	_, err = normalizeTags(tooMany)
+	assert.ErrorIs(t, err, ErrTooManyTags)
+
+	// Duplicates don't count
+	_, err = normalizeTags(append(tooMany[:maxTagsCount], "TAG0"))
+	assert.NoError(t, err)
+}
+
+func (s *DiagramServiceSuite) TestSetDiagramTags() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+
+	diagram, err := s.DiagramService.SetDiagramTags(ctx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{" Library ", "auth", "LIBRARY"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"auth", "library"}, diagram.Tags)
+
+	// Tags are replaced
+	diagram, err = s.DiagramService.SetDiagramTags(ctx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{"billing", "auth"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"auth", "billing"}, diagram.Tags)
+
+	diagram, err = s.DiagramService.RemoveDiagramTags(ctx, &RemoveDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{" AUTH ", "unknown"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"billing"}, diagram.Tags)
+
+	_, err = s.DiagramService.RemoveDiagramTags(ctx, &RemoveDiagramTagsParams{ID: "diagram001"})
+	s.Require().ErrorIs(err, ErrInvalidTag)
+
+	_, err = s.DiagramService.SetDiagramTags(ctx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{""},
+	})
+	s.Require().ErrorIs(err, ErrInvalidTag)
+
+	// Invalid tags don't change the diagram
+	diagram, err = s.DiagramService.SetDiagramTags(ctx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{"billing"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"billing"}, diagram.Tags)
+
+	otherCtx := s.createUser(context.Background(), "other", model.UserTypeStudent)
+	_, err = s.DiagramService.SetDiagramTags(otherCtx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{"stolen"},
+	})
+	s.Require().ErrorIs(err, ErrDiagramNotFound)
+}
+
+func (s *DiagramServiceSuite) TestSetDiagramTags_Teacher() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(teacherCtx, "teacher001", "teacher")
+	s.createDiagram(studentCtx, "student001", "student")
+	s.enrollStudent(teacherCtx, "course001", "teacher", "student")
+
+	diagram, err := s.DiagramService.SetDiagramTags(teacherCtx, &SetDiagramTagsParams{
+		ID:   "teacher001",
+		Tags: []string{"Example"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"example"}, diagram.Tags)
+
+	// Teachers can read diagrams of students but tags are changed by the owner only
+	_, err = s.DiagramService.SetDiagramTags(teacherCtx, &SetDiagramTagsParams{
+		ID:   "student001",
+		Tags: []string{"checked"},
+	})
+	s.Require().ErrorIs(err, ErrDiagramNotFound)
+
+	_, err = s.DiagramService.RemoveDiagramTags(teacherCtx, &RemoveDiagramTagsParams{
+		ID:   "teacher001",
+		Tags: []string{"example"},
+	})
+	s.Require().NoError(err)
+}
+
+func (s *DiagramServiceSuite) TestSetDiagramTags_ExamInProgress() {
+	teacherCtx := s.createUser(context.Background(), "teacher", model.UserTypeTeacher)
+	studentCtx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(studentCtx, "diagram001", "student")
+	s.createDiagram(studentCtx, "exam000001", "student")
+	s.enrollStudent(teacherCtx, "course001", "teacher", "student")
+
+	assignment, err := s.storage.Assignment().CreateAssignment(teacherCtx, &storage.CreateAssignmentParams{
+		ID:               "assign0001",
+		CourseID:         "course001",
+		Title:            "Exam",
+		StarterDiagramID: ptr.To(model.DiagramID("diagram001")),
+		ExamStartsAt:     ptr.To(time.Now().Add(-time.Minute)),
+		ExamEndsAt:       ptr.To(time.Now().Add(time.Hour)),
+		CreatedBy:        "teacher",
+	})
+	s.Require().NoError(err)
+
+	_, err = s.storage.ExamSession().CreateExamSession(teacherCtx, &storage.CreateExamSessionParams{
+		ID:           "session001",
+		AssignmentID: assignment.ID,
+		UserID:       "student",
+		DiagramID:    "exam000001",
+		EndsAt:       *assignment.ExamEndsAt,
+	})
+	s.Require().NoError(err)
+
+	// Other diagrams are not changed during the exam
+	_, err = s.DiagramService.SetDiagramTags(studentCtx, &SetDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{"prepared"},
+	})
+	s.Require().ErrorIs(err, ErrExamInProgress)
+
+	_, err = s.DiagramService.RemoveDiagramTags(studentCtx, &RemoveDiagramTagsParams{
+		ID:   "diagram001",
+		Tags: []string{"prepared"},
+	})
+	s.Require().ErrorIs(err, ErrExamInProgress)
+
+	diagram, err := s.DiagramService.SetDiagramTags(studentCtx, &SetDiagramTagsParams{
+		ID:   "exam000001",
+		Tags: []string{"exam"},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{"exam"}, diagram.Tags)
+}
+
+func (s *DiagramServiceSuite) TestListDiagrams_TagFilter() {
+	ctx := s.createUser(context.Background(), "student", model.UserTypeStudent)
+	s.createDiagram(ctx, "diagram001", "student")
+	s.createDiagram(ctx, "diagram002", "student")
+	s.createDiagram(ctx, "diagram003", "student")
+
+	for id, tags := range map[model.DiagramID][]string{
+		"diagram001": {"backend", "auth"},
+		"diagram002": {"backend"},
+		"diagram003": {"frontend"},
+	} {
+		_, err := s.DiagramService.SetDiagramTags(ctx, &SetDiagramTagsParams{ID: id, Tags: tags})
+		s.Require().NoError(err)
+	}
+
+	listTagged := func(tags ...string) []model.DiagramID {
+		filter := make([]*model.FilterTerm, 0, len(tags))
+		for _, tag := range tags {
+			filter = append(filter, &model.FilterTerm{
+				Key:       model.TermKeyTag,
+				Value:     tag,
+				Operation: model.FilterOperationExact,
+			})
+		}
+
+		diagramList, err := s.DiagramService.ListDiagrams(ctx, &ListDiagramsParams{Filter: filter})
+		s.Require().NoError(err)
+
+		diagramIDs := make([]model.DiagramID, 0, len(diagramList.Diagrams))
+		for _, diagram := range diagramList.Diagrams {
+			diagramIDs = append(diagramIDs, diagram.ID)
+		}
+		return diagramIDs
+	}
+
+	s.Require().ElementsMatch([]model.DiagramID{"diagram001", "diagram002"}, listTagged(" Backend "))
+	s.Require().ElementsMatch([]model.DiagramID{"diagram001"}, listTagged("backend", "AUTH"))
+	s.Require().ElementsMatch([]model.DiagramID{"diagram003"}, listTagged("frontend"))
+	s.Require().Empty(listTagged("unknown"))
+}
@@ -0,0 +1,210 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const auditEntryIDLength int64 = 20
+
+type ChangePasswordParams struct {
+	CurrentPassword utils.Secret[string]
+	NewPassword     utils.Secret[*string]
+}
+
+// ChangePassword replaces the password of the subject, other sessions of the user are revoked
+func (s *ServiceImpl) ChangePassword(ctx context.Context, params *ChangePasswordParams) error {
+	ctxlog.Info(ctx, s.Logger, "change password", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+	if subject.UserType == model.UserTypeGuest {
+		return xerrors.WrapForbidden(ErrForbidden)
+	}
+	if params.NewPassword.Value == nil {
+		return xerrors.WrapInvalidArgument(ErrPasswordRequired)
+	}
+
+	passwordHash, err := s.hashPassword(params.NewPassword.Value)
+	if err != nil {
+		return err
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		users, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyID,
+				Value:     subject.UserID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		}, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get all users: %w", err)
+		}
+		if len(users) == 0 {
+			return xerrors.WrapNotFound(ErrUserNotFound)
+		}
+		if users[0].PasswordHash.Value == nil {
+			return xerrors.WrapInvalidArgument(ErrWrongPassword)
+		}
+
+		ok, _, err := s.PasswordHasher.Verify(params.CurrentPassword.Value, *users[0].PasswordHash.Value)
+		if err != nil {
+			return fmt.Errorf("verify password: %w", err)
+		}
+		if !ok {
+			return xerrors.WrapInvalidArgument(ErrWrongPassword)
+		}
+
+		_, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+			ID:           subject.UserID,
+			PasswordHash: utils.NewOptional(passwordHash),
+		})
+		if err != nil {
+			return fmt.Errorf("patch user: %w", err)
+		}
+
+		err = s.logAudit(ctx, subject.UserID, model.UserAuditActionPasswordChanged, "")
+		if err != nil {
+			return err
+		}
+
+		return s.revokeUserSessions(ctx, subject.UserID, subject.SessionID)
+	})
+	if err != nil {
+		return fmt.Errorf("can't change password: %w", err)
+	}
+
+	return nil
+}
+
+type ChangeEmailParams struct {
+	Login string
+}
+
+// ChangeEmail sends the confirmation code to the new email, the login of the subject
+// is switched only on confirmation
+func (s *ServiceImpl) ChangeEmail(ctx context.Context, params *ChangeEmailParams) error {
+	ctxlog.Info(ctx, s.Logger, "change email", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+	// Guests get the email by the upgrade
+	if subject.UserType == model.UserTypeGuest {
+		return xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	err = s.Policy.ValidateLogin(params.Login)
+	if err != nil {
+		return err
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userModel, err := s.Storage.User().GetUserByID(ctx, subject.UserID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("get user by id: %w", err)
+		}
+		if userModel.Login == params.Login {
+			return xerrors.WrapInvalidArgument(ErrSameLogin)
+		}
+
+		err = s.checkLoginAvailable(ctx, params.Login)
+		if err != nil {
+			return err
+		}
+
+		// Only the latest requested email can be confirmed
+		err = s.Storage.UserConfirmation().DeleteUserConfirmations(ctx, userModel.ID, model.UserConfirmationPurposeEmailChange)
+		if err != nil {
+			return fmt.Errorf("delete user confirmations: %w", err)
+		}
+
+		err = s.sendConfirmation(ctx, params.Login, &storage.CreateUserConfirmationParams{
+			UserID:  userModel.ID,
+			Purpose: model.UserConfirmationPurposeEmailChange,
+			Login:   &params.Login,
+		})
+		if err != nil {
+			return err
+		}
+
+		return s.logAudit(ctx, userModel.ID, model.UserAuditActionEmailChangeRequested, params.Login)
+	})
+	if err != nil {
+		return fmt.Errorf("can't change email: %w", err)
+	}
+
+	return nil
+}
+
+// confirmEmailChange switches the login of the user to the confirmed email
+func (s *ServiceImpl) confirmEmailChange(ctx context.Context, confirmation *model.UserConfirmation, user *model.User) (*model.User, error) {
+	var userModel *model.User
+	err := s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// The confirmation is consumed together with the other pending changes
+		err := s.Storage.UserConfirmation().DeleteUserConfirmations(ctx, user.ID, model.UserConfirmationPurposeEmailChange)
+		if err != nil {
+			return fmt.Errorf("delete user confirmations: %w", err)
+		}
+
+		// The email could be registered after the change was requested
+		err = s.checkLoginAvailable(ctx, *confirmation.Login)
+		if err != nil {
+			return err
+		}
+
+		userModel, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+			ID:    user.ID,
+			Login: utils.NewOptional(*confirmation.Login),
+		})
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("patch user: %w", err)
+		}
+
+		return s.logAudit(ctx, user.ID, model.UserAuditActionEmailChanged, user.Login+" -> "+userModel.Login)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't confirm email change: %w", err)
+	}
+
+	return userModel, nil
+}
+
+// logAudit writes the change of the user credentials to the audit log
+func (s *ServiceImpl) logAudit(ctx context.Context, userID model.UserID, action model.UserAuditAction, details string) error {
+	auditEntryID, err := utils.GenerateID(auditEntryIDLength)
+	if err != nil {
+		return fmt.Errorf("generate id (audit entry): %w", err)
+	}
+
+	_, err = s.Storage.UserAuditEntry().CreateUserAuditEntry(ctx, &storage.CreateUserAuditEntryParams{
+		ID:      model.UserAuditEntryID(auditEntryID),
+		UserID:  userID,
+		Action:  action,
+		Details: details,
+	})
+	if err != nil {
+		return fmt.Errorf("create user audit entry: %w", err)
+	}
+
+	return nil
+}
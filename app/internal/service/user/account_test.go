@@ -0,0 +1,149 @@
+package user
+
+import (
+	"context"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func (s *UserServiceSuite) TestChangePassword_WrongPassword() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, _ := s.loginUser(ctx, userModel.Login, testPassword)
+
+	err := s.UserService.ChangePassword(sessionCtx, &ChangePasswordParams{
+		CurrentPassword: utils.NewSecret("wrong"),
+		NewPassword:     utils.NewSecret(ptr.To("N3wPassw0rd!")),
+	})
+	s.Require().ErrorIs(err, ErrWrongPassword)
+
+	s.loginUser(ctx, userModel.Login, testPassword)
+	s.Require().Empty(s.auditActions(ctx, userModel.ID))
+}
+
+func (s *UserServiceSuite) TestChangePassword_RevokesOtherSessions() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, token := s.loginUser(ctx, userModel.Login, testPassword)
+	_, otherToken := s.loginUser(ctx, userModel.Login, testPassword)
+
+	err := s.UserService.ChangePassword(sessionCtx, &ChangePasswordParams{
+		CurrentPassword: utils.NewSecret(testPassword),
+		NewPassword:     utils.NewSecret(ptr.To("N3wPassw0rd!")),
+	})
+	s.Require().NoError(err)
+
+	// The session changing the password stays active
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+	s.Require().NoError(err)
+
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+otherToken.Value)
+	s.Require().ErrorIs(err, ErrSessionRevoked)
+
+	_, err = s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    userModel.Login,
+		Password: utils.NewSecret(testPassword),
+	})
+	s.Require().ErrorIs(err, ErrUserNotFound)
+	s.loginUser(ctx, userModel.Login, "N3wPassw0rd!")
+
+	s.Require().Equal([]model.UserAuditAction{model.UserAuditActionPasswordChanged}, s.auditActions(ctx, userModel.ID))
+}
+
+func (s *UserServiceSuite) TestChangeEmail_Confirmation() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, _ := s.loginUser(ctx, userModel.Login, testPassword)
+
+	var confirmationID string
+	s.emailsender.EXPECT().SendEmailChangeEmail("new@edu.mirea.ru", gomock.Any()).DoAndReturn(func(_ string, id string) error {
+		confirmationID = id
+		return nil
+	})
+
+	err := s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "new@edu.mirea.ru"})
+	s.Require().NoError(err)
+
+	// The login is kept until the new email is confirmed
+	userModel, err = s.storage.User().GetUserByID(ctx, userModel.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("student@mirea.ru", userModel.Login)
+	s.Require().Equal([]model.UserAuditAction{model.UserAuditActionEmailChangeRequested}, s.auditActions(ctx, userModel.ID))
+
+	userModel, err = s.UserService.ConfirmUser(ctx, &ConfirmUserParams{
+		UserConfirmationID: model.UserConfirmationID(confirmationID),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("new@edu.mirea.ru", userModel.Login)
+
+	s.loginUser(ctx, "new@edu.mirea.ru", testPassword)
+	s.Require().ElementsMatch([]model.UserAuditAction{
+		model.UserAuditActionEmailChangeRequested,
+		model.UserAuditActionEmailChanged,
+	}, s.auditActions(ctx, userModel.ID))
+}
+
+func (s *UserServiceSuite) TestChangeEmail_RepeatedRequest() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, _ := s.loginUser(ctx, userModel.Login, testPassword)
+
+	confirmationIDs := make(map[string]string)
+	s.emailsender.EXPECT().SendEmailChangeEmail(gomock.Any(), gomock.Any()).DoAndReturn(func(login string, id string) error {
+		confirmationIDs[login] = id
+		return nil
+	}).Times(2)
+
+	err := s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "first@edu.mirea.ru"})
+	s.Require().NoError(err)
+	err = s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "second@edu.mirea.ru"})
+	s.Require().NoError(err)
+
+	// The earlier request is dropped by the new one
+	_, err = s.UserService.ConfirmUser(ctx, &ConfirmUserParams{
+		UserConfirmationID: model.UserConfirmationID(confirmationIDs["first@edu.mirea.ru"]),
+	})
+	s.Require().ErrorIs(err, ErrConfirmationCodeNotFound)
+
+	userModel, err = s.UserService.ConfirmUser(ctx, &ConfirmUserParams{
+		UserConfirmationID: model.UserConfirmationID(confirmationIDs["second@edu.mirea.ru"]),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("second@edu.mirea.ru", userModel.Login)
+
+	// The confirmation is consumed
+	_, err = s.UserService.ConfirmUser(ctx, &ConfirmUserParams{
+		UserConfirmationID: model.UserConfirmationID(confirmationIDs["second@edu.mirea.ru"]),
+	})
+	s.Require().ErrorIs(err, ErrConfirmationCodeNotFound)
+}
+
+func (s *UserServiceSuite) TestChangeEmail_Invalid() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	s.createPasswordUser(ctx, "other", "other@mirea.ru", model.UserTypeStudent)
+	sessionCtx, _ := s.loginUser(ctx, userModel.Login, testPassword)
+
+	// Only emails allowed to register can be used
+	err := s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "student@gmail.com"})
+	s.Require().ErrorIs(err, ErrInvalidLogin)
+
+	err = s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "student@mirea.ru"})
+	s.Require().ErrorIs(err, ErrSameLogin)
+
+	err = s.UserService.ChangeEmail(sessionCtx, &ChangeEmailParams{Login: "other@mirea.ru"})
+	s.Require().ErrorIs(err, ErrUserAlreadyExists)
+
+	guestCtx := auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: model.UserTypeGuest,
+	})
+	err = s.UserService.ChangeEmail(guestCtx, &ChangeEmailParams{Login: "new@mirea.ru"})
+	s.Require().ErrorIs(err, ErrForbidden)
+
+	s.Require().Empty(s.auditActions(ctx, userModel.ID))
+}
@@ -3,7 +3,6 @@ package user
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -17,31 +16,51 @@ import (
 	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
 	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
 	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/jwt"
+	"github.com/IvLaptev/chartdb-back/pkg/password"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
-	"github.com/go-playground/validator/v10"
 )
 
 const (
 	userIDLength             int64 = 20
 	userConfirmationIDLength int64 = 40
 
-	tokenExpirationTime = time.Hour * 24
+	tokenIDLength       int64 = 20
+	tokenExpirationTime       = time.Minute * 15
 )
 
 var (
 	ErrInvalidUserID     = errors.New("invalid user id")
 	ErrInvalidLogin      = errors.New("invalid login")
+	ErrGuestsDisabled    = errors.New("guest accounts are disabled")
 	ErrUserNotFound      = errors.New("user not found")
 	ErrUserAlreadyExists = errors.New("user already exists")
 
 	ErrConfirmationCodeExpired  = errors.New("confirmation code expired")
 	ErrConfirmationCodeNotFound = errors.New("confirmation code not found")
+	ErrPasswordRequired         = errors.New("password required")
+	ErrNotGuest                 = errors.New("only guest accounts can be upgraded")
 
-	ErrInvalidToken = errors.New("invalid token")
-	ErrTokenExpired = errors.New("token expired")
+	ErrResetCodeNotFound = errors.New("password reset code not found")
+	ErrResetCodeExpired  = errors.New("password reset code expired")
+	ErrResetCodeUsed     = errors.New("password reset code already used")
+	ErrWrongPassword     = errors.New("wrong password")
+	ErrSameLogin         = errors.New("new email is the same as the current one")
+
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrTokenExpired    = errors.New("token expired")
+	ErrSessionRevoked  = errors.New("session revoked")
+	ErrSessionNotFound = errors.New("session not found")
 
 	ErrForbidden = errors.New("forbidden")
+
+	ErrInvalidRole         = errors.New("only teacher or admin role above the current one can be requested")
+	ErrTextTooLong         = errors.New("text is too long")
+	ErrRoleRequestExists   = errors.New("role request is already pending")
+	ErrRoleRequestNotFound = errors.New("role request not found")
+	ErrRoleRequestDecided  = errors.New("role request is already decided")
+	ErrAdminAlreadyExists  = errors.New("admin already exists")
 )
 
 type Service interface {
@@ -49,8 +68,32 @@ type Service interface {
 	CreateUser(ctx context.Context, params *CreateUserParams) (*model.User, error)
 
 	LoginUser(ctx context.Context, params *LoginUserParams) (*model.UserToken, error)
+	RefreshToken(ctx context.Context, params *RefreshTokenParams) (*model.UserToken, error)
+	Logout(ctx context.Context) error
+	ListSessions(ctx context.Context) ([]*model.Session, error)
+	RevokeSession(ctx context.Context, params *RevokeSessionParams) error
+	RequestPasswordReset(ctx context.Context, params *RequestPasswordResetParams) error
+	ResetPassword(ctx context.Context, params *ResetPasswordParams) error
+	ChangePassword(ctx context.Context, params *ChangePasswordParams) error
+	ChangeEmail(ctx context.Context, params *ChangeEmailParams) error
+	StartOIDCLogin(ctx context.Context) (*StartOIDCLoginResult, error)
+	FinishOIDCLogin(ctx context.Context, params *FinishOIDCLoginParams) (*model.UserToken, error)
+	CreatePersonalAccessToken(ctx context.Context, params *CreatePersonalAccessTokenParams) (*model.PersonalAccessToken, error)
+	ListPersonalAccessTokens(ctx context.Context) ([]*model.PersonalAccessToken, error)
+	RevokePersonalAccessToken(ctx context.Context, params *RevokePersonalAccessTokenParams) error
+	EnrollTOTP(ctx context.Context) (*model.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, params *ConfirmTOTPParams) ([]string, error)
+	DisableTOTP(ctx context.Context, params *DisableTOTPParams) error
+	RegenerateRecoveryCodes(ctx context.Context, params *RegenerateRecoveryCodesParams) ([]string, error)
+	VerifyLoginChallenge(ctx context.Context, params *VerifyLoginChallengeParams) (*model.UserToken, error)
 	ConfirmUser(ctx context.Context, params *ConfirmUserParams) (*model.User, error)
+	UpgradeGuest(ctx context.Context, params *UpgradeGuestParams) (*model.User, error)
 	Authenticate(ctx context.Context, token string) (context.Context, error)
+
+	RequestRole(ctx context.Context, params *RequestRoleParams) (*model.RoleRequest, error)
+	ListRoleRequests(ctx context.Context, params *ListRoleRequestsParams) ([]*model.RoleRequest, error)
+	DecideRoleRequest(ctx context.Context, params *DecideRoleRequestParams) (*model.RoleRequest, error)
+	CreateAdmin(ctx context.Context, params *CreateAdminParams) (*model.User, error)
 }
 
 type ServiceImpl struct {
@@ -59,8 +102,15 @@ type ServiceImpl struct {
 	UserConfirmationTime time.Duration
 	RegistrationTimeout  time.Duration
 	EmailSender          emailsender.EmailSender
-
-	tokenSecret []byte
+	PasswordHasher       *password.Hasher
+	Policy               *Policy
+	// Nil if the login through the OpenID provider is disabled
+	OIDC *OIDC
+	// Nil if only local passwords are checked
+	Authenticator Authenticator
+	TwoFactor     *TwoFactor
+
+	tokenSigner *jwt.Signer
 }
 
 type GetUserParams struct {
@@ -94,22 +144,34 @@ func (s *ServiceImpl) GetUser(ctx context.Context, params *GetUserParams) (*mode
 }
 
 type CreateUserParams struct {
-	Login        string `validate:"email,endswith=mirea.ru"`
-	PasswordHash utils.Secret[*string]
+	// Email of the allowed domain for users with a password
+	Login    string
+	Password utils.Secret[*string]
 }
 
+// CreateUser registers the user with the type given to the email domain by the policy,
+// users without a password are guests
 func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams) (*model.User, error) {
 	ctxlog.Info(ctx, s.Logger, "create user", slog.Any("params", params))
 
-	var userType model.UserType
-	if params.PasswordHash.Value == nil {
-		userType = model.UserTypeGuest
-	} else {
-		userType = model.UserTypeStudent
+	userType := model.UserTypeGuest
+	if params.Password.Value != nil {
+		var err error
+		userType, err = s.Policy.UserType(params.Login)
+		if err != nil {
+			return nil, err
+		}
+	} else if !s.Policy.GuestsAllowed() {
+		return nil, xerrors.WrapForbidden(ErrGuestsDisabled)
+	}
+
+	passwordHash, err := s.hashPassword(params.Password.Value)
+	if err != nil {
+		return nil, err
 	}
 
 	var userModel *model.User
-	err := s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
 		userList, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
 			{
 				Key:       model.TermKeyLogin,
@@ -124,7 +186,7 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 		if len(userList) > 0 {
 			user := userList[0]
 
-			if user.Type != userType {
+			if (user.Type == model.UserTypeGuest) != (userType == model.UserTypeGuest) {
 				return xerrors.WrapInvalidArgument(ErrInvalidLogin)
 			}
 
@@ -138,6 +200,21 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 				return xerrors.WrapInvalidArgument(ErrUserAlreadyExists)
 			}
 
+			// Invited user keeps the account with course enrollments and confirms it as usual
+			if user.Invited() {
+				userModel, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+					ID:           user.ID,
+					PasswordHash: utils.NewOptional(passwordHash),
+				})
+				if err != nil {
+					return fmt.Errorf("patch user: %w", err)
+				}
+
+				return s.sendConfirmation(ctx, userModel.Login, &storage.CreateUserConfirmationParams{
+					UserID: userModel.ID,
+				})
+			}
+
 			userConfirmations, err := s.Storage.UserConfirmation().GetAllUserConfirmation(ctx, []*model.FilterTerm{
 				{
 					Key:       model.TermKeyUserID,
@@ -161,14 +238,8 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 		}
 
 		var confirmedAt *time.Time
-		switch userType {
-		case model.UserTypeGuest:
+		if userType == model.UserTypeGuest {
 			confirmedAt = ptr.To(time.Now())
-		case model.UserTypeStudent:
-			err := validator.New().StructPartial(params, "Login")
-			if err != nil {
-				return xerrors.WrapInvalidArgument(ErrInvalidLogin)
-			}
 		}
 
 		userID, err := utils.GenerateID(userIDLength)
@@ -179,7 +250,7 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 		userModel, err = s.Storage.User().CreateUser(ctx, &storage.CreateUserParams{
 			ID:           model.UserID(userID),
 			Login:        params.Login,
-			PasswordHash: params.PasswordHash.Value,
+			PasswordHash: passwordHash,
 			Type:         userType,
 			ConfirmedAt:  confirmedAt,
 		})
@@ -187,25 +258,10 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 			return fmt.Errorf("create user: %w", err)
 		}
 
-		userConfirmationID, err := utils.GenerateID(userConfirmationIDLength)
-		if err != nil {
-			return fmt.Errorf("generate id: %w", err)
-		}
-
-		if userType == model.UserTypeStudent {
-			userConfirmationModel, err := s.Storage.UserConfirmation().CreateUserConfirmation(ctx, &storage.CreateUserConfirmationParams{
-				ID:       model.UserConfirmationID(userConfirmationID),
-				UserID:   model.UserID(userID),
-				Duration: s.UserConfirmationTime,
+		if userType != model.UserTypeGuest {
+			return s.sendConfirmation(ctx, userModel.Login, &storage.CreateUserConfirmationParams{
+				UserID: userModel.ID,
 			})
-			if err != nil {
-				return fmt.Errorf("create user confirmation: %w", err)
-			}
-
-			err = s.EmailSender.SendCreateUserEmail(userModel.Login, userConfirmationModel.ID.String())
-			if err != nil {
-				return fmt.Errorf("send create user email: %w", err)
-			}
 		}
 
 		return nil
@@ -217,25 +273,69 @@ func (s *ServiceImpl) CreateUser(ctx context.Context, params *CreateUserParams)
 	return userModel, nil
 }
 
+// sendConfirmation creates the confirmation code and sends it to the email, ID and duration of the code are set here
+func (s *ServiceImpl) sendConfirmation(ctx context.Context, to string, params *storage.CreateUserConfirmationParams) error {
+	userConfirmationID, err := utils.GenerateID(userConfirmationIDLength)
+	if err != nil {
+		return fmt.Errorf("generate id: %w", err)
+	}
+
+	params.ID = model.UserConfirmationID(userConfirmationID)
+	params.Duration = s.UserConfirmationTime
+	userConfirmationModel, err := s.Storage.UserConfirmation().CreateUserConfirmation(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create user confirmation: %w", err)
+	}
+
+	if userConfirmationModel.EmailChange() {
+		err = s.EmailSender.SendEmailChangeEmail(to, userConfirmationModel.ID.String())
+		if err != nil {
+			return fmt.Errorf("send email change email: %w", err)
+		}
+		return nil
+	}
+
+	err = s.EmailSender.SendCreateUserEmail(to, userConfirmationModel.ID.String())
+	if err != nil {
+		return fmt.Errorf("send create user email: %w", err)
+	}
+
+	return nil
+}
+
 type LoginUserParams struct {
-	Login        string
-	PasswordHash utils.Secret[string]
+	Login    string
+	Password utils.Secret[string]
+	// Describe the client in the session list
+	UserAgent string
+	IPAddress string
 }
 
+// LoginUser checks the password by the directory if it is configured, local passwords are checked
+// if there is no directory or the directory allows the fallback
 func (s *ServiceImpl) LoginUser(ctx context.Context, params *LoginUserParams) (*model.UserToken, error) {
 	ctxlog.Info(ctx, s.Logger, "login user", slog.Any("params", params))
 
+	if s.Authenticator != nil {
+		userToken, err := s.loginDirectoryUser(ctx, params)
+		switch {
+		case err == nil:
+			return userToken, nil
+		case !s.Authenticator.LocalFallback() && errors.Is(err, ErrInvalidCredentials):
+			return nil, xerrors.WrapNotFound(ErrUserNotFound)
+		case !s.Authenticator.LocalFallback():
+			return nil, fmt.Errorf("can't login user: %w", err)
+		case !errors.Is(err, ErrInvalidCredentials):
+			ctxlog.Warn(ctx, s.Logger, "login directory user, fallback to local password", slog.Any("error", err))
+		}
+	}
+
 	userList, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
 		{
 			Key:       model.TermKeyLogin,
 			Value:     params.Login,
 			Operation: model.FilterOperationExact,
 		},
-		{
-			Key:       model.TermKeyPasswordHash,
-			Value:     params.PasswordHash.Value,
-			Operation: model.FilterOperationExact,
-		},
 		{
 			Key:       model.TermKeyConfirmedAt,
 			Value:     nil,
@@ -246,23 +346,99 @@ func (s *ServiceImpl) LoginUser(ctx context.Context, params *LoginUserParams) (*
 		return nil, fmt.Errorf("get all users: %w", err)
 	}
 
-	if len(userList) == 0 {
+	if len(userList) == 0 || userList[0].PasswordHash.Value == nil {
 		return nil, xerrors.WrapNotFound(ErrUserNotFound)
 	}
+	user := userList[0]
 
-	userToken, err := createToken(userList[0], s.tokenSecret)
+	ok, rehash, err := s.PasswordHasher.Verify(params.Password.Value, *user.PasswordHash.Value)
 	if err != nil {
-		return nil, fmt.Errorf("create token: %w", err)
+		return nil, fmt.Errorf("verify password: %w", err)
+	}
+	if !ok {
+		return nil, xerrors.WrapNotFound(ErrUserNotFound)
 	}
 
-	return &model.UserToken{
-		Value:  userToken,
-		UserID: userList[0].ID,
-	}, nil
+	// Legacy hashes and hashes with outdated parameters are replaced, the login succeeds anyway
+	if rehash {
+		err = s.rehashPassword(ctx, user.ID, params.Password.Value)
+		if err != nil {
+			ctxlog.Warn(ctx, s.Logger, "rehash password", slog.String("user_id", user.ID.String()), slog.Any("error", err))
+		}
+	}
+
+	userToken, err := s.completeLogin(ctx, user, params.UserAgent, params.IPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("can't login user: %w", err)
+	}
+
+	return userToken, nil
+}
+
+// loginDirectoryUser starts the session of the user authenticated by the directory, the user is created on the first login
+func (s *ServiceImpl) loginDirectoryUser(ctx context.Context, params *LoginUserParams) (*model.UserToken, error) {
+	directoryUser, err := s.Authenticator.Authenticate(ctx, params.Login, params.Password.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var userModel *model.User
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userModel, err = s.provisionUser(ctx, directoryUser.Email, directoryUser.UserType)
+		if err != nil {
+			return err
+		}
+
+		userModel, err = s.raiseUserType(ctx, userModel, directoryUser.UserType)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("provision user: %w", err)
+	}
+
+	return s.completeLogin(ctx, userModel, params.UserAgent, params.IPAddress)
+}
+
+func (s *ServiceImpl) rehashPassword(ctx context.Context, userID model.UserID, password string) error {
+	passwordHash, err := s.PasswordHasher.Hash(password)
+	if err != nil {
+		return fmt.Errorf("hash: %w", err)
+	}
+
+	_, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+		ID:           userID,
+		PasswordHash: utils.NewOptional(&passwordHash),
+	})
+	if err != nil {
+		return fmt.Errorf("patch user: %w", err)
+	}
+
+	return nil
+}
+
+// hashPassword checks the new password against the policy and hashes it, users without a password get nil
+func (s *ServiceImpl) hashPassword(password *string) (*string, error) {
+	if password == nil {
+		return nil, nil
+	}
+
+	err := s.Policy.ValidatePassword(*password)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := s.PasswordHasher.Hash(*password)
+	if err != nil {
+		return nil, fmt.Errorf("hash password: %w", err)
+	}
+
+	return &passwordHash, nil
 }
 
 type ConfirmUserParams struct {
 	UserConfirmationID model.UserConfirmationID
+	// Required for invited users which don't have a password yet
+	Password utils.Secret[*string]
 }
 
 func (s *ServiceImpl) ConfirmUser(ctx context.Context, params *ConfirmUserParams) (*model.User, error) {
@@ -282,10 +458,37 @@ func (s *ServiceImpl) ConfirmUser(ctx context.Context, params *ConfirmUserParams
 		return nil, xerrors.WrapInvalidArgument(ErrConfirmationCodeExpired)
 	}
 
-	userModel, err := s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+	userModel, err := s.Storage.User().GetUserByID(ctx, userConfirmation.UserID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapNotFound(ErrUserNotFound)
+		}
+		return nil, fmt.Errorf("get user by id: %w", err)
+	}
+
+	if userConfirmation.GuestUpgrade() {
+		return s.confirmGuestUpgrade(ctx, userConfirmation, userModel)
+	}
+	if userConfirmation.EmailChange() {
+		return s.confirmEmailChange(ctx, userConfirmation, userModel)
+	}
+
+	patchParams := &storage.PatchUserParams{
 		ID:          userConfirmation.UserID,
 		ConfirmedAt: utils.NewOptional(&now),
-	})
+	}
+	if userModel.Invited() {
+		if params.Password.Value == nil {
+			return nil, xerrors.WrapInvalidArgument(ErrPasswordRequired)
+		}
+		passwordHash, err := s.hashPassword(params.Password.Value)
+		if err != nil {
+			return nil, err
+		}
+		patchParams.PasswordHash = utils.NewOptional(passwordHash)
+	}
+
+	userModel, err = s.Storage.User().PatchUser(ctx, patchParams)
 	if err != nil {
 		if errors.Is(err, storage.ErrNotFound) {
 			return nil, xerrors.WrapNotFound(ErrUserNotFound)
@@ -300,9 +503,15 @@ func (s *ServiceImpl) Authenticate(ctx context.Context, token string) (context.C
 	ctxlog.Info(ctx, s.Logger, "authenticate user")
 
 	var userModel *model.User
+	var sessionID model.SessionID
+	var personalAccessToken *model.PersonalAccessToken
 	tokenParts := strings.Split(token, " ")
 	switch len(tokenParts) {
 	case 1:
+		if !s.Policy.GuestsAllowed() {
+			return nil, xerrors.WrapUnauthenticated(ErrGuestsDisabled)
+		}
+
 		userLogin, err := base64.StdEncoding.DecodeString(tokenParts[0])
 		if err != nil {
 			return nil, xerrors.WrapUnauthenticated(ErrInvalidUserID)
@@ -330,20 +539,49 @@ func (s *ServiceImpl) Authenticate(ctx context.Context, token string) (context.C
 
 		userModel = userList[0]
 	case 2:
+		if tokenParts[0] == personalAccessTokenScheme {
+			var err error
+			personalAccessToken, err = s.authenticatePersonalAccessToken(ctx, tokenParts[1])
+			if err != nil {
+				return nil, err
+			}
+
+			userModel, err = s.Storage.User().GetUserByID(ctx, personalAccessToken.UserID)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+				}
+				return nil, fmt.Errorf("get user by id: %w", err)
+			}
+			break
+		}
 		if tokenParts[0] != "Bearer" {
 			return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
 		}
 
-		userToken, err := parseToken(tokenParts[1], s.tokenSecret)
+		claims, err := parseToken(tokenParts[1], s.tokenSigner)
 		if err != nil {
 			return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
 		}
 
-		if userToken.ExpiresAt.Before(time.Now()) {
+		now := time.Now()
+		if time.Unix(claims.ExpiresAt, 0).Before(now) {
 			return nil, xerrors.WrapUnauthenticated(ErrTokenExpired)
 		}
 
-		userModel, err = s.Storage.User().GetUserByID(ctx, userToken.User.ID)
+		session, err := s.Storage.Session().GetSessionByID(ctx, claims.SessionID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+			}
+			return nil, fmt.Errorf("get session by id: %w", err)
+		}
+		if !session.Active(now) || session.UserID != claims.Subject {
+			return nil, xerrors.WrapUnauthenticated(ErrSessionRevoked)
+		}
+		sessionID = session.ID
+
+		userModel, err = s.Storage.User().GetUserByID(ctx, claims.Subject)
 		if err != nil {
 			if errors.Is(err, storage.ErrNotFound) {
 				return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
@@ -356,8 +594,10 @@ func (s *ServiceImpl) Authenticate(ctx context.Context, token string) (context.C
 
 	ctxlog.Info(ctx, s.Logger, "authenticated user", slog.String("user_id", userModel.ID.String()), slog.String("user_type", userModel.Type.String()))
 	ctx = auth.SetSubject(ctx, &auth.Subject{
-		UserID:   userModel.ID,
-		UserType: userModel.Type,
+		UserID:              userModel.ID,
+		UserType:            userModel.Type,
+		SessionID:           sessionID,
+		PersonalAccessToken: personalAccessToken,
 	})
 
 	return ctx, nil
@@ -367,55 +607,76 @@ func NewService(
 	logger *slog.Logger,
 	storage storage.Storage,
 	emailSender emailsender.EmailSender,
+	passwordHasher *password.Hasher,
+	policy *Policy,
+	oidc *OIDC,
+	authenticator Authenticator,
+	twoFactor *TwoFactor,
 	userConfirmationTime time.Duration,
 	registrationTimeout time.Duration,
-	tokenSecret []byte,
+	tokenSigner *jwt.Signer,
 ) *ServiceImpl {
 	return &ServiceImpl{
 		Logger:               logger,
 		Storage:              storage,
 		EmailSender:          emailSender,
+		PasswordHasher:       passwordHasher,
+		Policy:               policy,
+		OIDC:                 oidc,
+		Authenticator:        authenticator,
+		TwoFactor:            twoFactor,
 		UserConfirmationTime: userConfirmationTime,
 		RegistrationTimeout:  registrationTimeout,
-		tokenSecret:          tokenSecret,
+		tokenSigner:          tokenSigner,
 	}
 }
 
-type token struct {
-	User      *model.User `json:"user_id"`
-	ExpiresAt time.Time   `json:"expires_at"`
+// tokenClaims are claims of the access token, the user type is informational
+// as the current type is loaded on authentication
+type tokenClaims struct {
+	Subject   model.UserID    `json:"sub"`
+	SessionID model.SessionID `json:"sid"`
+	UserType  model.UserType  `json:"user_type"`
+	ID        string          `json:"jti"`
+	IssuedAt  int64           `json:"iat"`
+	ExpiresAt int64           `json:"exp"`
 }
 
-func createToken(user *model.User, tokenSecret []byte) (string, error) {
-	token := &token{
-		User:      user,
-		ExpiresAt: time.Now().Add(tokenExpirationTime),
-	}
-
-	jsonToken, err := json.Marshal(token)
+// createToken issues a short-lived access token of the session
+func createToken(user *model.User, sessionID model.SessionID, signer *jwt.Signer) (*model.UserToken, error) {
+	tokenID, err := utils.GenerateID(tokenIDLength)
 	if err != nil {
-		return "", fmt.Errorf("marshal: %w", err)
+		return nil, fmt.Errorf("generate id: %w", err)
 	}
 
-	tokenString, err := utils.AES128Encrypt(string(jsonToken), tokenSecret)
+	now := time.Now()
+	expiresAt := now.Add(tokenExpirationTime)
+	tokenString, err := signer.Sign(&tokenClaims{
+		Subject:   user.ID,
+		SessionID: sessionID,
+		UserType:  user.Type,
+		ID:        tokenID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
 	if err != nil {
-		return "", fmt.Errorf("encrypt: %w", err)
+		return nil, fmt.Errorf("sign: %w", err)
 	}
 
-	return tokenString, nil
+	return &model.UserToken{
+		Value:     tokenString,
+		UserID:    user.ID,
+		ExpiresAt: expiresAt,
+		SessionID: sessionID,
+	}, nil
 }
 
-func parseToken(tokenString string, tokenSecret []byte) (*token, error) {
-	jsonToken, err := utils.AES128Decrypt(tokenString, tokenSecret)
-	if err != nil {
-		return nil, fmt.Errorf("decrypt: %w", err)
-	}
-
-	var token token
-	err = json.Unmarshal([]byte(jsonToken), &token)
+func parseToken(tokenString string, signer *jwt.Signer) (*tokenClaims, error) {
+	var claims tokenClaims
+	err := signer.Verify(tokenString, &claims)
 	if err != nil {
-		return nil, fmt.Errorf("unmarshal: %w", err)
+		return nil, fmt.Errorf("verify: %w", err)
 	}
 
-	return &token, nil
+	return &claims, nil
 }
@@ -14,6 +14,8 @@ import (
 	"github.com/IvLaptev/chartdb-back/internal/storage/postgres"
 	"github.com/IvLaptev/chartdb-back/internal/tests"
 	"github.com/IvLaptev/chartdb-back/pkg/emailsender"
+	"github.com/IvLaptev/chartdb-back/pkg/jwt"
+	"github.com/IvLaptev/chartdb-back/pkg/password"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +46,78 @@ func (s *UserServiceSuite) SetupSuite() {
 
 func (s *UserServiceSuite) SetupTest() {
 	s.storage.Erase(context.Background())
-	s.UserService = NewService(s.logger, s.storage, s.emailsender, 30*time.Minute, 1*time.Hour, []byte("secret"))
+	tokenSigner, err := jwt.NewSigner(jwt.Config{
+		ActiveKeyID: "test",
+		Keys: []jwt.Key{
+			{
+				ID:        "test",
+				Algorithm: jwt.AlgorithmHS256,
+				Secret:    "secret",
+			},
+		},
+	})
+	s.Require().NoError(err)
+	policy, err := NewPolicy(PolicyConfig{})
+	s.Require().NoError(err)
+	twoFactor, err := NewTwoFactor(TwoFactorConfig{})
+	s.Require().NoError(err)
+	s.UserService = NewService(s.logger, s.storage, s.emailsender, password.NewHasher(password.HasherConfig{
+		Memory:      1024,
+		Iterations:  1,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}), policy, nil, nil, twoFactor, 30*time.Minute, 1*time.Hour, tokenSigner)
+}
+
+const testPassword = "Passw0rd!"
+
+// createPasswordUser creates the confirmed user with testPassword
+func (s *UserServiceSuite) createPasswordUser(ctx context.Context, userID model.UserID, login string, userType model.UserType) *model.User {
+	passwordHash, err := s.UserService.PasswordHasher.Hash(testPassword)
+	s.Require().NoError(err)
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:           userID,
+		Login:        login,
+		PasswordHash: &passwordHash,
+		Type:         userType,
+		ConfirmedAt:  ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return userModel
+}
+
+// loginUser starts a new session, the returned context has the subject of the session
+func (s *UserServiceSuite) loginUser(ctx context.Context, login string, password string) (context.Context, *model.UserToken) {
+	token, err := s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    login,
+		Password: utils.NewSecret(password),
+	})
+	s.Require().NoError(err)
+
+	sessionCtx, err := s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+	s.Require().NoError(err)
+
+	return sessionCtx, token
+}
+
+func (s *UserServiceSuite) auditActions(ctx context.Context, userID model.UserID) []model.UserAuditAction {
+	entries, err := s.storage.UserAuditEntry().GetAllUserAuditEntries(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+	})
+	s.Require().NoError(err)
+
+	actions := make([]model.UserAuditAction, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, entry.Action)
+	}
+
+	return actions
 }
 
 func (s *UserServiceSuite) TestCreateUser_GuestOk() {
@@ -52,8 +125,8 @@ func (s *UserServiceSuite) TestCreateUser_GuestOk() {
 
 	userLogin := "00И0000"
 	userModel, err := s.UserService.CreateUser(ctx, &CreateUserParams{
-		Login:        userLogin,
-		PasswordHash: utils.NewSecret[*string](nil),
+		Login:    userLogin,
+		Password: utils.NewSecret[*string](nil),
 	})
 	s.Require().NoError(err)
 
@@ -73,8 +146,8 @@ func (s *UserServiceSuite) TestCreateUser_StudentWrongEmail() {
 
 	userLogin := "00И0000"
 	_, err := s.UserService.CreateUser(ctx, &CreateUserParams{
-		Login:        userLogin,
-		PasswordHash: utils.NewSecret(ptr.To("password")),
+		Login:    userLogin,
+		Password: utils.NewSecret(ptr.To("password")),
 	})
 	s.Require().Error(err)
 }
@@ -86,8 +159,8 @@ func (s *UserServiceSuite) TestCreateUser_StudentOk() {
 	s.emailsender.EXPECT().SendCreateUserEmail(userLogin, gomock.Any()).Return(nil)
 
 	userModel, err := s.UserService.CreateUser(ctx, &CreateUserParams{
-		Login:        userLogin,
-		PasswordHash: utils.NewSecret(ptr.To("password")),
+		Login:    userLogin,
+		Password: utils.NewSecret(ptr.To("password")),
 	})
 	s.Require().NoError(err)
 	s.Require().Equal(model.UserTypeStudent, userModel.Type)
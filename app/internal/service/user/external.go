@@ -0,0 +1,145 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+type GroupRuleConfig struct {
+	Group string `yaml:"group"`
+	// Admins are approved by other admins only
+	UserType string `yaml:"user_type"`
+}
+
+// groupMapping gives user types to groups of external identity sources
+type groupMapping struct {
+	groupTypes      map[string]model.UserType
+	defaultUserType model.UserType
+}
+
+func newGroupMapping(groups []GroupRuleConfig, defaultUserType string) (*groupMapping, error) {
+	m := &groupMapping{
+		groupTypes:      map[string]model.UserType{},
+		defaultUserType: model.UserTypeStudent,
+	}
+
+	if defaultUserType != "" {
+		userType, err := externalUserType(defaultUserType)
+		if err != nil {
+			return nil, fmt.Errorf("default user type: %w", err)
+		}
+		m.defaultUserType = userType
+	}
+	for _, rule := range groups {
+		userType, err := externalUserType(rule.UserType)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", rule.Group, err)
+		}
+		m.groupTypes[rule.Group] = userType
+	}
+
+	return m, nil
+}
+
+func externalUserType(s string) (model.UserType, error) {
+	userType, err := model.UserTypeFromString(s)
+	if err != nil {
+		return model.UserTypeUnspecified, err
+	}
+	if !slices.Contains([]model.UserType{model.UserTypeStudent, model.UserTypeTeacher}, userType) {
+		return model.UserTypeUnspecified, fmt.Errorf("only %s and %s are allowed: %s", model.Student, model.Teacher, s)
+	}
+
+	return userType, nil
+}
+
+// userType returns the highest type given to the groups
+func (m *groupMapping) userType(groups []string) model.UserType {
+	userType := m.defaultUserType
+	for _, group := range groups {
+		if groupType, ok := m.groupTypes[group]; ok && groupType > userType {
+			userType = groupType
+		}
+	}
+
+	return userType
+}
+
+// provisionUser returns the user with the email verified by the external identity source, the user is created
+// on the first login. Must be called in a transaction.
+func (s *ServiceImpl) provisionUser(ctx context.Context, email string, userType model.UserType) (*model.User, error) {
+	users, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     email,
+			Operation: model.FilterOperationExact,
+		},
+	}, storage.WithLock())
+	if err != nil {
+		return nil, fmt.Errorf("get all users: %w", err)
+	}
+
+	if len(users) > 0 {
+		userModel := users[0]
+		if userModel.Type == model.UserTypeGuest {
+			return nil, xerrors.WrapInvalidArgument(ErrInvalidLogin)
+		}
+
+		// The source confirmed the email. A password of an unconfirmed registration could be set
+		// by anyone, so it is dropped and can be set again by the password reset.
+		if userModel.ConfirmedAt == nil {
+			userModel, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+				ID:           userModel.ID,
+				PasswordHash: utils.NewOptional[*string](nil),
+				ConfirmedAt:  utils.NewOptional(ptr.To(time.Now())),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("patch user: %w", err)
+			}
+		}
+
+		return userModel, nil
+	}
+
+	userID, err := utils.GenerateID(userIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	userModel, err := s.Storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:          model.UserID(userID),
+		Login:       email,
+		Type:        userType,
+		ConfirmedAt: ptr.To(time.Now()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user: %w", err)
+	}
+
+	return userModel, nil
+}
+
+// raiseUserType gives the user the type of its external groups, types are never lowered, e.g. admins stay admins
+func (s *ServiceImpl) raiseUserType(ctx context.Context, user *model.User, userType model.UserType) (*model.User, error) {
+	if userType <= user.Type {
+		return user, nil
+	}
+
+	user, err := s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+		ID:   user.ID,
+		Type: utils.NewOptional(userType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch user: %w", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,143 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type UpgradeGuestParams struct {
+	Login    string
+	Password utils.Secret[*string]
+}
+
+// UpgradeGuest sends the confirmation code to the institutional email of the guest.
+// The guest keeps the account with all diagrams and gets the type of the email domain on confirmation.
+func (s *ServiceImpl) UpgradeGuest(ctx context.Context, params *UpgradeGuestParams) (*model.User, error) {
+	ctxlog.Info(ctx, s.Logger, "upgrade guest", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+	if subject.UserType != model.UserTypeGuest {
+		return nil, xerrors.WrapForbidden(ErrNotGuest)
+	}
+	if params.Password.Value == nil {
+		return nil, xerrors.WrapInvalidArgument(ErrPasswordRequired)
+	}
+
+	err = s.Policy.ValidateLogin(params.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := s.hashPassword(params.Password.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var userModel *model.User
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userModel, err = s.Storage.User().GetUserByID(ctx, subject.UserID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("get user by id: %w", err)
+		}
+
+		err = s.checkLoginAvailable(ctx, params.Login)
+		if err != nil {
+			return err
+		}
+
+		return s.sendConfirmation(ctx, params.Login, &storage.CreateUserConfirmationParams{
+			UserID:       userModel.ID,
+			Purpose:      model.UserConfirmationPurposeGuestUpgrade,
+			Login:        &params.Login,
+			PasswordHash: passwordHash,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't upgrade guest: %w", err)
+	}
+
+	return userModel, nil
+}
+
+// confirmGuestUpgrade sets the login and the password of the guest and changes its type by the policy,
+// the user id is kept so diagrams stay with the user
+func (s *ServiceImpl) confirmGuestUpgrade(ctx context.Context, confirmation *model.UserConfirmation, user *model.User) (*model.User, error) {
+	if user.Type != model.UserTypeGuest {
+		// Repeated confirmation of the same upgrade
+		if user.Login == *confirmation.Login {
+			return user, nil
+		}
+		return nil, xerrors.WrapInvalidArgument(ErrNotGuest)
+	}
+
+	// The policy could change after the upgrade was requested
+	userType, err := s.Policy.UserType(*confirmation.Login)
+	if err != nil {
+		return nil, err
+	}
+
+	var userModel *model.User
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// The login could be registered after the upgrade was requested
+		err := s.checkLoginAvailable(ctx, *confirmation.Login)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		userModel, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+			ID:           user.ID,
+			Login:        utils.NewOptional(*confirmation.Login),
+			PasswordHash: utils.NewOptional(confirmation.PasswordHash.Value),
+			ConfirmedAt:  utils.NewOptional(&now),
+			Type:         utils.NewOptional(userType),
+		})
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("patch user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't confirm guest upgrade: %w", err)
+	}
+
+	return userModel, nil
+}
+
+func (s *ServiceImpl) checkLoginAvailable(ctx context.Context, login string) error {
+	userList, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     login,
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get all users: %w", err)
+	}
+	if len(userList) > 0 {
+		return xerrors.WrapInvalidArgument(ErrUserAlreadyExists)
+	}
+
+	return nil
+}
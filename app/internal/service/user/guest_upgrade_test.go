@@ -0,0 +1,95 @@
+package user
+
+import (
+	"context"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func (s *UserServiceSuite) TestUpgradeGuest_KeepsDiagrams() {
+	ctx := context.Background()
+
+	guest, err := s.UserService.CreateUser(ctx, &CreateUserParams{
+		Login:    "00И0000",
+		Password: utils.NewSecret[*string](nil),
+	})
+	s.Require().NoError(err)
+	guestCtx := s.subjectContext(ctx, guest)
+
+	_, err = s.storage.Diagram().CreateDiagram(ctx, &storage.CreateDiagramParams{
+		ID:               "diagram001",
+		ClientDiagramID:  "diagram001",
+		Code:             "abcd",
+		UserID:           guest.ID,
+		ObjectStorageKey: "diagram001",
+		Name:             "Library",
+	})
+	s.Require().NoError(err)
+
+	var confirmationID string
+	s.emailsender.EXPECT().SendCreateUserEmail("student@edu.mirea.ru", gomock.Any()).DoAndReturn(func(_ string, id string) error {
+		confirmationID = id
+		return nil
+	})
+
+	_, err = s.UserService.UpgradeGuest(guestCtx, &UpgradeGuestParams{
+		Login:    "student@edu.mirea.ru",
+		Password: utils.NewSecret(ptr.To(testPassword)),
+	})
+	s.Require().NoError(err)
+
+	// The guest stays a guest until the email is confirmed
+	userModel, err := s.storage.User().GetUserByID(ctx, guest.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserTypeGuest, userModel.Type)
+
+	userModel, err = s.UserService.ConfirmUser(ctx, &ConfirmUserParams{
+		UserConfirmationID: model.UserConfirmationID(confirmationID),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(guest.ID, userModel.ID)
+	s.Require().Equal("student@edu.mirea.ru", userModel.Login)
+	s.Require().Equal(model.UserTypeStudent, userModel.Type)
+	s.Require().NotNil(userModel.ConfirmedAt)
+
+	_, token := s.loginUser(ctx, "student@edu.mirea.ru", testPassword)
+	s.Require().Equal(guest.ID, token.UserID)
+
+	diagram, err := s.storage.Diagram().GetDiagramByID(ctx, &storage.RowPolicyUserID{UserID: guest.ID}, "diagram001")
+	s.Require().NoError(err)
+	s.Require().Equal(guest.ID, diagram.UserID)
+}
+
+func (s *UserServiceSuite) TestUpgradeGuest_Invalid() {
+	ctx := context.Background()
+	student := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+
+	guest, err := s.UserService.CreateUser(ctx, &CreateUserParams{
+		Login:    "00И0000",
+		Password: utils.NewSecret[*string](nil),
+	})
+	s.Require().NoError(err)
+	guestCtx := s.subjectContext(ctx, guest)
+
+	_, err = s.UserService.UpgradeGuest(s.subjectContext(ctx, student), &UpgradeGuestParams{
+		Login:    "new@mirea.ru",
+		Password: utils.NewSecret(ptr.To(testPassword)),
+	})
+	s.Require().ErrorIs(err, ErrNotGuest)
+
+	_, err = s.UserService.UpgradeGuest(guestCtx, &UpgradeGuestParams{
+		Login:    "guest@gmail.com",
+		Password: utils.NewSecret(ptr.To(testPassword)),
+	})
+	s.Require().ErrorIs(err, ErrInvalidLogin)
+
+	_, err = s.UserService.UpgradeGuest(guestCtx, &UpgradeGuestParams{
+		Login:    student.Login,
+		Password: utils.NewSecret(ptr.To(testPassword)),
+	})
+	s.Require().ErrorIs(err, ErrUserAlreadyExists)
+}
@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/ldap"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrNoDirectoryEmail   = errors.New("directory entry has no email")
+)
+
+type LDAPConfig struct {
+	Enabled   bool        `yaml:"enabled"`
+	Directory ldap.Config `yaml:"directory"`
+	// Groups are matched by the full DN or by the value of its first RDN, e.g. "teachers" for "cn=teachers,ou=groups,dc=example,dc=com"
+	Groups []GroupRuleConfig `yaml:"groups"`
+	// Type of users without matching groups, STUDENT if empty
+	DefaultUserType string `yaml:"default_user_type"`
+	// Check local passwords if the directory rejects the login or is unavailable, e.g. for the bootstrap admin
+	LocalFallback bool `yaml:"local_fallback"`
+}
+
+// DirectoryUser is the user authenticated by the external directory
+type DirectoryUser struct {
+	Email    string
+	UserType model.UserType
+}
+
+// Authenticator checks passwords against an external user directory before local passwords.
+// Users are provisioned on the first login by the email of the directory.
+type Authenticator interface {
+	// Authenticate returns ErrInvalidCredentials for unknown logins and wrong passwords
+	Authenticate(ctx context.Context, login, password string) (*DirectoryUser, error)
+	// LocalFallback tells if local passwords are checked when the directory doesn't authenticate the user
+	LocalFallback() bool
+}
+
+// LDAPAuthenticator binds to the LDAP or Active Directory server with the password of the user
+type LDAPAuthenticator struct {
+	client        *ldap.Client
+	groups        *groupMapping
+	localFallback bool
+}
+
+func NewLDAPAuthenticator(config LDAPConfig) (*LDAPAuthenticator, error) {
+	groups, err := newGroupMapping(config.Groups, config.DefaultUserType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LDAPAuthenticator{
+		client:        ldap.NewClient(config.Directory),
+		groups:        groups,
+		localFallback: config.LocalFallback,
+	}, nil
+}
+
+func (a *LDAPAuthenticator) Authenticate(ctx context.Context, login, password string) (*DirectoryUser, error) {
+	entry, err := a.client.Authenticate(login, password)
+	if err != nil {
+		if errors.Is(err, ldap.ErrInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("ldap authenticate: %w", err)
+	}
+	if entry.Email == "" {
+		return nil, fmt.Errorf("%s: %w", entry.DN, ErrNoDirectoryEmail)
+	}
+
+	groups := make([]string, 0, 2*len(entry.Groups))
+	for _, group := range entry.Groups {
+		groups = append(groups, group, ldap.GroupName(group))
+	}
+
+	return &DirectoryUser{
+		Email:    entry.Email,
+		UserType: a.groups.userType(groups),
+	}, nil
+}
+
+func (a *LDAPAuthenticator) LocalFallback() bool {
+	return a.localFallback
+}
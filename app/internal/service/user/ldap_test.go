@@ -0,0 +1,141 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ldap"
+	"github.com/IvLaptev/chartdb-back/pkg/ldap/ldaptest"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLDAPAuthenticator(t *testing.T, localFallback bool) *LDAPAuthenticator {
+	server, err := ldaptest.NewServer(
+		&ldaptest.Entry{
+			DN:       "uid=teacher,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"mail":     {"teacher@example.com"},
+				"memberOf": {"cn=teachers,ou=groups,dc=example,dc=com"},
+			},
+		},
+		&ldaptest.Entry{
+			DN:       "uid=student,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"mail":     {"student@example.com"},
+				"memberOf": {"cn=students,ou=groups,dc=example,dc=com"},
+			},
+		},
+		&ldaptest.Entry{
+			DN:       "uid=service,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"uid": {"service"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	authenticator, err := NewLDAPAuthenticator(LDAPConfig{
+		Enabled: true,
+		Directory: ldap.Config{
+			URL:        server.URL(),
+			BaseDN:     "ou=people,dc=example,dc=com",
+			UserFilter: "(|(mail=%s)(uid=%s))",
+		},
+		Groups: []GroupRuleConfig{
+			{Group: "teachers", UserType: model.Teacher},
+		},
+		LocalFallback: localFallback,
+	})
+	require.NoError(t, err)
+
+	return authenticator
+}
+
+func TestLDAPAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	authenticator := newTestLDAPAuthenticator(t, false)
+
+	user, err := authenticator.Authenticate(ctx, "teacher@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, &DirectoryUser{Email: "teacher@example.com", UserType: model.UserTypeTeacher}, user)
+
+	user, err = authenticator.Authenticate(ctx, "student@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserTypeStudent, user.UserType)
+
+	_, err = authenticator.Authenticate(ctx, "student@example.com", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, err = authenticator.Authenticate(ctx, "service", "secret")
+	assert.ErrorIs(t, err, ErrNoDirectoryEmail)
+}
+
+func (s *UserServiceSuite) TestLoginUser_Directory() {
+	ctx := context.Background()
+	s.UserService.Authenticator = newTestLDAPAuthenticator(s.T(), false)
+
+	// Users are created on the first login
+	token, err := s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    "teacher@example.com",
+		Password: utils.NewSecret("secret"),
+	})
+	s.Require().NoError(err)
+
+	userModel, err := s.storage.User().GetUserByID(ctx, token.UserID)
+	s.Require().NoError(err)
+	s.Require().Equal("teacher@example.com", userModel.Login)
+	s.Require().Equal(model.UserTypeTeacher, userModel.Type)
+	s.Require().NotNil(userModel.ConfirmedAt)
+
+	secondToken, err := s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    "teacher@example.com",
+		Password: utils.NewSecret("secret"),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(token.UserID, secondToken.UserID)
+
+	_, err = s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    "teacher@example.com",
+		Password: utils.NewSecret("wrong"),
+	})
+	s.Require().ErrorIs(err, ErrUserNotFound)
+}
+
+func (s *UserServiceSuite) TestLoginUser_DirectoryLocalFallback() {
+	ctx := context.Background()
+
+	passwordHash, err := s.UserService.PasswordHasher.Hash("Passw0rd!")
+	s.Require().NoError(err)
+	admin, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:           "admin",
+		Login:        "admin@example.com",
+		PasswordHash: &passwordHash,
+		Type:         model.UserTypeAdmin,
+		ConfirmedAt:  ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	params := &LoginUserParams{
+		Login:    admin.Login,
+		Password: utils.NewSecret("Passw0rd!"),
+	}
+
+	s.UserService.Authenticator = newTestLDAPAuthenticator(s.T(), false)
+	_, err = s.UserService.LoginUser(ctx, params)
+	s.Require().ErrorIs(err, ErrUserNotFound)
+
+	s.UserService.Authenticator = newTestLDAPAuthenticator(s.T(), true)
+	token, err := s.UserService.LoginUser(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Equal(admin.ID, token.UserID)
+}
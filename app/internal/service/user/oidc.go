@@ -0,0 +1,238 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/oidc"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const (
+	oidcLoginStateIDLength       int64 = 40
+	oidcNonceLength              int64 = 40
+	oidcLoginStateExpirationTime       = time.Minute * 10
+	userIdentityIDLength         int64 = 20
+
+	defaultGroupsClaim = "groups"
+)
+
+var (
+	ErrOIDCDisabled      = errors.New("oidc login is disabled")
+	ErrInvalidOIDCState  = errors.New("invalid or expired oidc login state")
+	ErrEmailNotVerified  = errors.New("email is not verified by the identity provider")
+	ErrOIDCLoginRejected = errors.New("identity provider rejected the login")
+)
+
+type OIDCConfig struct {
+	Enabled  bool        `yaml:"enabled"`
+	Provider oidc.Config `yaml:"provider"`
+	// Claim of the ID token with the list of groups, "groups" if empty
+	GroupsClaim string            `yaml:"groups_claim"`
+	Groups      []GroupRuleConfig `yaml:"groups"`
+	// Type of users without matching groups, STUDENT if empty
+	DefaultUserType string `yaml:"default_user_type"`
+}
+
+// OIDC logs users in through the OpenID provider and maps groups of the provider to user types
+type OIDC struct {
+	provider    *oidc.Provider
+	groupsClaim string
+	groups      *groupMapping
+}
+
+func NewOIDC(ctx context.Context, config OIDCConfig, client *http.Client) (*OIDC, error) {
+	groups, err := newGroupMapping(config.Groups, config.DefaultUserType)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &OIDC{
+		groupsClaim: config.GroupsClaim,
+		groups:      groups,
+	}
+	if o.groupsClaim == "" {
+		o.groupsClaim = defaultGroupsClaim
+	}
+
+	o.provider, err = oidc.NewProvider(ctx, config.Provider, client)
+	if err != nil {
+		return nil, fmt.Errorf("new provider: %w", err)
+	}
+
+	return o, nil
+}
+
+// UserType returns the highest type given to groups of the user
+func (o *OIDC) UserType(claims *oidc.Claims) model.UserType {
+	return o.groups.userType(claims.Strings(o.groupsClaim))
+}
+
+type StartOIDCLoginResult struct {
+	// Page of the provider to redirect the user to
+	AuthorizationURL string
+	State            model.OIDCLoginStateID
+}
+
+// StartOIDCLogin saves the nonce and the PKCE verifier of the login, the state is returned to the redirect URL
+// and passed back to FinishOIDCLogin with the code
+func (s *ServiceImpl) StartOIDCLogin(ctx context.Context) (*StartOIDCLoginResult, error) {
+	ctxlog.Info(ctx, s.Logger, "start oidc login")
+
+	if s.OIDC == nil {
+		return nil, xerrors.WrapForbidden(ErrOIDCDisabled)
+	}
+
+	stateID, err := utils.GenerateID(oidcLoginStateIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+	nonce, err := utils.GenerateID(oidcNonceLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	codeVerifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("new code verifier: %w", err)
+	}
+
+	state, err := s.Storage.OIDCLoginState().CreateOIDCLoginState(ctx, &storage.CreateOIDCLoginStateParams{
+		ID:           model.OIDCLoginStateID(stateID),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Duration:     oidcLoginStateExpirationTime,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't start oidc login: %w", err)
+	}
+
+	return &StartOIDCLoginResult{
+		AuthorizationURL: s.OIDC.provider.AuthCodeURL(state.ID.String(), state.Nonce, state.CodeVerifier.Value),
+		State:            state.ID,
+	}, nil
+}
+
+type FinishOIDCLoginParams struct {
+	State model.OIDCLoginStateID
+	Code  utils.Secret[string]
+	// Describe the client in the session list
+	UserAgent string
+	IPAddress string
+}
+
+// FinishOIDCLogin exchanges the code for the ID token and starts a session of the linked user.
+// Unknown identities are linked to the user with the verified email of the provider account,
+// users are created if there is none. The type of the user is raised to the type of its groups.
+func (s *ServiceImpl) FinishOIDCLogin(ctx context.Context, params *FinishOIDCLoginParams) (*model.UserToken, error) {
+	ctxlog.Info(ctx, s.Logger, "finish oidc login", slog.Any("params", params))
+
+	if s.OIDC == nil {
+		return nil, xerrors.WrapForbidden(ErrOIDCDisabled)
+	}
+
+	// States are single-use, a replayed callback fails here
+	state, err := s.Storage.OIDCLoginState().DeleteOIDCLoginState(ctx, params.State)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapInvalidArgument(ErrInvalidOIDCState)
+		}
+		return nil, fmt.Errorf("delete oidc login state: %w", err)
+	}
+	if state.ExpiresAt.Before(time.Now()) {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidOIDCState)
+	}
+
+	claims, err := s.OIDC.provider.Exchange(ctx, params.Code.Value, state.CodeVerifier.Value, state.Nonce)
+	if err != nil {
+		ctxlog.Warn(ctx, s.Logger, "exchange oidc code", slog.Any("error", err))
+		return nil, xerrors.WrapUnauthenticated(ErrOIDCLoginRejected)
+	}
+
+	var userModel *model.User
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userModel, err = s.oidcUser(ctx, claims)
+		if err != nil {
+			return err
+		}
+
+		userModel, err = s.raiseUserType(ctx, userModel, s.OIDC.UserType(claims))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't finish oidc login: %w", err)
+	}
+
+	userToken, err := s.completeLogin(ctx, userModel, params.UserAgent, params.IPAddress)
+	if err != nil {
+		return nil, fmt.Errorf("can't finish oidc login: %w", err)
+	}
+
+	return userToken, nil
+}
+
+// oidcUser returns the user linked to the provider account, linking or creating it on the first login
+func (s *ServiceImpl) oidcUser(ctx context.Context, claims *oidc.Claims) (*model.User, error) {
+	identities, err := s.Storage.UserIdentity().GetAllUserIdentities(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyIssuer,
+			Value:     claims.Issuer,
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeySubject,
+			Value:     claims.Subject,
+			Operation: model.FilterOperationExact,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all user identities: %w", err)
+	}
+	if len(identities) > 0 {
+		userModel, err := s.Storage.User().GetUserByID(ctx, identities[0].UserID)
+		if err != nil {
+			return nil, fmt.Errorf("get user by id: %w", err)
+		}
+		return userModel, nil
+	}
+
+	// Emails are trusted for linking only if the provider verified them
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, xerrors.WrapForbidden(ErrEmailNotVerified)
+	}
+
+	userModel, err := s.provisionUser(ctx, claims.Email, s.OIDC.UserType(claims))
+	if err != nil {
+		return nil, err
+	}
+
+	identityID, err := utils.GenerateID(userIdentityIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	_, err = s.Storage.UserIdentity().CreateUserIdentity(ctx, &storage.CreateUserIdentityParams{
+		ID:      model.UserIdentityID(identityID),
+		UserID:  userModel.ID,
+		Issuer:  claims.Issuer,
+		Subject: claims.Subject,
+		Email:   claims.Email,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user identity: %w", err)
+	}
+
+	err = s.logAudit(ctx, userModel.ID, model.UserAuditActionIdentityLinked, claims.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return userModel, nil
+}
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/oidc"
+	"github.com/IvLaptev/chartdb-back/pkg/oidc/oidctest"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCClientID = "chartdb"
+
+func newTestOIDC(t *testing.T, config OIDCConfig) (*oidctest.Provider, *OIDC) {
+	fake, err := oidctest.NewProvider(testOIDCClientID)
+	require.NoError(t, err)
+	t.Cleanup(fake.Close)
+
+	config.Enabled = true
+	config.Provider = oidc.Config{
+		Issuer:      fake.Issuer(),
+		ClientID:    testOIDCClientID,
+		RedirectURL: "http://localhost:5173/oidc/callback",
+	}
+	userOIDC, err := NewOIDC(context.Background(), config, nil)
+	require.NoError(t, err)
+
+	return fake, userOIDC
+}
+
+func TestOIDC_UserType(t *testing.T) {
+	_, userOIDC := newTestOIDC(t, OIDCConfig{
+		GroupsClaim: "roles",
+		Groups: []GroupRuleConfig{
+			{Group: "staff", UserType: model.Teacher},
+			{Group: "students", UserType: model.Student},
+		},
+	})
+
+	for _, test := range []struct {
+		groups   any
+		userType model.UserType
+	}{
+		{groups: "staff", userType: model.UserTypeTeacher},
+		{groups: "other", userType: model.UserTypeStudent},
+		{groups: nil, userType: model.UserTypeStudent},
+		{groups: []any{"students", "staff"}, userType: model.UserTypeTeacher},
+		{groups: []any{"students", "visitors"}, userType: model.UserTypeStudent},
+	} {
+		claims := &oidc.Claims{Raw: map[string]any{"roles": test.groups}}
+		assert.Equal(t, test.userType, userOIDC.UserType(claims), test.groups)
+	}
+}
+
+func TestNewOIDC_InvalidUserType(t *testing.T) {
+	fake, err := oidctest.NewProvider(testOIDCClientID)
+	require.NoError(t, err)
+	defer fake.Close()
+
+	for _, config := range []OIDCConfig{
+		{Groups: []GroupRuleConfig{{Group: "admins", UserType: model.Admin}}},
+		{Groups: []GroupRuleConfig{{Group: "guests", UserType: model.Guest}}},
+		{DefaultUserType: "UNKNOWN"},
+	} {
+		config.Provider = oidc.Config{Issuer: fake.Issuer(), ClientID: testOIDCClientID}
+		_, err := NewOIDC(context.Background(), config, nil)
+		assert.Error(t, err)
+	}
+}
+
+// oidcLogin passes the login through the fake provider, claims are added to the ID token
+func (s *UserServiceSuite) oidcLogin(fake *oidctest.Provider, claims map[string]any) (*model.UserToken, error) {
+	ctx := context.Background()
+
+	result, err := s.UserService.StartOIDCLogin(ctx)
+	s.Require().NoError(err)
+
+	code, state, err := fake.Authorize(result.AuthorizationURL, claims)
+	s.Require().NoError(err)
+	s.Require().Equal(result.State.String(), state)
+
+	return s.UserService.FinishOIDCLogin(ctx, &FinishOIDCLoginParams{
+		State: model.OIDCLoginStateID(state),
+		Code:  utils.NewSecret(code),
+	})
+}
+
+func (s *UserServiceSuite) TestFinishOIDCLogin_CreateUser() {
+	ctx := context.Background()
+	fake, userOIDC := newTestOIDC(s.T(), OIDCConfig{
+		Groups: []GroupRuleConfig{{Group: "staff", UserType: model.Teacher}},
+	})
+	s.UserService.OIDC = userOIDC
+
+	claims := map[string]any{
+		"sub":            "user-1",
+		"email":          "teacher@example.com",
+		"email_verified": true,
+		"groups":         []string{"staff"},
+	}
+	token, err := s.oidcLogin(fake, claims)
+	s.Require().NoError(err)
+
+	userModel, err := s.storage.User().GetUserByID(ctx, token.UserID)
+	s.Require().NoError(err)
+	s.Require().Equal("teacher@example.com", userModel.Login)
+	s.Require().Equal(model.UserTypeTeacher, userModel.Type)
+	s.Require().NotNil(userModel.ConfirmedAt)
+	s.Require().Nil(userModel.PasswordHash.Value)
+
+	// The identity is found by the subject even if the email is changed on the provider
+	claims["email"] = "other@example.com"
+	claims["email_verified"] = false
+	secondToken, err := s.oidcLogin(fake, claims)
+	s.Require().NoError(err)
+	s.Require().Equal(token.UserID, secondToken.UserID)
+}
+
+func (s *UserServiceSuite) TestFinishOIDCLogin_LinkUserByEmail() {
+	ctx := context.Background()
+	fake, userOIDC := newTestOIDC(s.T(), OIDCConfig{})
+	s.UserService.OIDC = userOIDC
+
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:           "user",
+		Login:        "test@edu.mirea.ru",
+		PasswordHash: ptr.To("hash"),
+		Type:         model.UserTypeStudent,
+	})
+	s.Require().NoError(err)
+
+	// Unverified emails are not linked
+	_, err = s.oidcLogin(fake, map[string]any{"sub": "user-1", "email": userModel.Login})
+	s.Require().Error(err)
+
+	token, err := s.oidcLogin(fake, map[string]any{"sub": "user-1", "email": userModel.Login, "email_verified": true})
+	s.Require().NoError(err)
+	s.Require().Equal(userModel.ID, token.UserID)
+
+	// The unconfirmed registration is confirmed by the provider and its password is dropped
+	userModel, err = s.storage.User().GetUserByID(ctx, userModel.ID)
+	s.Require().NoError(err)
+	s.Require().NotNil(userModel.ConfirmedAt)
+	s.Require().Nil(userModel.PasswordHash.Value)
+
+	identities, err := s.storage.UserIdentity().GetAllUserIdentities(ctx, nil)
+	s.Require().NoError(err)
+	s.Require().Len(identities, 1)
+	s.Require().Equal(fake.Issuer(), identities[0].Issuer)
+}
+
+func (s *UserServiceSuite) TestFinishOIDCLogin_StateSingleUse() {
+	ctx := context.Background()
+	fake, userOIDC := newTestOIDC(s.T(), OIDCConfig{})
+	s.UserService.OIDC = userOIDC
+
+	result, err := s.UserService.StartOIDCLogin(ctx)
+	s.Require().NoError(err)
+	code, state, err := fake.Authorize(result.AuthorizationURL, map[string]any{
+		"sub":            "user-1",
+		"email":          "test@example.com",
+		"email_verified": true,
+	})
+	s.Require().NoError(err)
+
+	params := &FinishOIDCLoginParams{
+		State: model.OIDCLoginStateID(state),
+		Code:  utils.NewSecret(code),
+	}
+	_, err = s.UserService.FinishOIDCLogin(ctx, params)
+	s.Require().NoError(err)
+
+	_, err = s.UserService.FinishOIDCLogin(ctx, params)
+	s.Require().ErrorIs(err, ErrInvalidOIDCState)
+}
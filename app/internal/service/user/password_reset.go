@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+const (
+	passwordResetIDLength int64 = 40
+
+	passwordResetExpirationTime = time.Hour
+)
+
+type RequestPasswordResetParams struct {
+	Login string
+}
+
+// RequestPasswordReset sends the reset code to the email of the confirmed user.
+// Unknown logins are not reported to the caller to not reveal registered users.
+func (s *ServiceImpl) RequestPasswordReset(ctx context.Context, params *RequestPasswordResetParams) error {
+	ctxlog.Info(ctx, s.Logger, "request password reset", slog.Any("params", params))
+
+	userList, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyLogin,
+			Value:     params.Login,
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyConfirmedAt,
+			Value:     nil,
+			Operation: model.FilterOperationNotEqual,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get all users: %w", err)
+	}
+	if len(userList) == 0 || userList[0].Type == model.UserTypeGuest {
+		return nil
+	}
+	user := userList[0]
+
+	passwordResetID, err := utils.GenerateID(passwordResetIDLength)
+	if err != nil {
+		return fmt.Errorf("generate id: %w", err)
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		passwordReset, err := s.Storage.PasswordReset().CreatePasswordReset(ctx, &storage.CreatePasswordResetParams{
+			ID:       model.PasswordResetID(passwordResetID),
+			UserID:   user.ID,
+			Duration: passwordResetExpirationTime,
+		})
+		if err != nil {
+			return fmt.Errorf("create password reset: %w", err)
+		}
+
+		err = s.EmailSender.SendPasswordResetEmail(user.Login, passwordReset.ID.String())
+		if err != nil {
+			return fmt.Errorf("send password reset email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("can't request password reset: %w", err)
+	}
+
+	return nil
+}
+
+type ResetPasswordParams struct {
+	PasswordResetID utils.Secret[model.PasswordResetID]
+	Password        utils.Secret[*string]
+}
+
+// ResetPassword sets the new password by the emailed code. The code works once,
+// all sessions of the user are revoked so a stolen session stops working.
+func (s *ServiceImpl) ResetPassword(ctx context.Context, params *ResetPasswordParams) error {
+	ctxlog.Info(ctx, s.Logger, "reset password", slog.Any("params", params))
+
+	if params.Password.Value == nil {
+		return xerrors.WrapInvalidArgument(ErrPasswordRequired)
+	}
+
+	passwordHash, err := s.hashPassword(params.Password.Value)
+	if err != nil {
+		return err
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		passwordReset, err := s.Storage.PasswordReset().GetPasswordResetByID(ctx, params.PasswordResetID.Value, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrResetCodeNotFound)
+			}
+			return fmt.Errorf("get password reset by id: %w", err)
+		}
+		if passwordReset.UsedAt != nil {
+			return xerrors.WrapInvalidArgument(ErrResetCodeUsed)
+		}
+		if passwordReset.ExpiresAt.Before(now) {
+			return xerrors.WrapInvalidArgument(ErrResetCodeExpired)
+		}
+
+		_, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+			ID:           passwordReset.UserID,
+			PasswordHash: utils.NewOptional(passwordHash),
+		})
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("patch user: %w", err)
+		}
+
+		_, err = s.Storage.PasswordReset().PatchPasswordReset(ctx, &storage.PatchPasswordResetParams{
+			ID:     passwordReset.ID,
+			UsedAt: now,
+		})
+		if err != nil {
+			return fmt.Errorf("patch password reset: %w", err)
+		}
+
+		err = s.logAudit(ctx, passwordReset.UserID, model.UserAuditActionPasswordReset, "")
+		if err != nil {
+			return err
+		}
+
+		return s.revokeUserSessions(ctx, passwordReset.UserID, "")
+	})
+	if err != nil {
+		return fmt.Errorf("can't reset password: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// requestPasswordReset returns the emailed reset code
+func (s *UserServiceSuite) requestPasswordReset(ctx context.Context, login string) model.PasswordResetID {
+	var passwordResetID string
+	s.emailsender.EXPECT().SendPasswordResetEmail(login, gomock.Any()).DoAndReturn(func(_ string, id string) error {
+		passwordResetID = id
+		return nil
+	})
+
+	err := s.UserService.RequestPasswordReset(ctx, &RequestPasswordResetParams{Login: login})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(passwordResetID)
+
+	return model.PasswordResetID(passwordResetID)
+}
+
+func (s *UserServiceSuite) TestResetPassword_SingleUse() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+
+	passwordResetID := s.requestPasswordReset(ctx, userModel.Login)
+
+	err := s.UserService.ResetPassword(ctx, &ResetPasswordParams{
+		PasswordResetID: utils.NewSecret(passwordResetID),
+		Password:        utils.NewSecret(ptr.To("N3wPassw0rd!")),
+	})
+	s.Require().NoError(err)
+
+	_, err = s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    userModel.Login,
+		Password: utils.NewSecret(testPassword),
+	})
+	s.Require().ErrorIs(err, ErrUserNotFound)
+	s.loginUser(ctx, userModel.Login, "N3wPassw0rd!")
+
+	err = s.UserService.ResetPassword(ctx, &ResetPasswordParams{
+		PasswordResetID: utils.NewSecret(passwordResetID),
+		Password:        utils.NewSecret(ptr.To("An0therPassw0rd!")),
+	})
+	s.Require().ErrorIs(err, ErrResetCodeUsed)
+
+	err = s.UserService.ResetPassword(ctx, &ResetPasswordParams{
+		PasswordResetID: utils.NewSecret[model.PasswordResetID]("unknown"),
+		Password:        utils.NewSecret(ptr.To("An0therPassw0rd!")),
+	})
+	s.Require().ErrorIs(err, ErrResetCodeNotFound)
+
+	s.Require().Equal([]model.UserAuditAction{model.UserAuditActionPasswordReset}, s.auditActions(ctx, userModel.ID))
+}
+
+func (s *UserServiceSuite) TestResetPassword_Expired() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+
+	_, err := s.storage.PasswordReset().CreatePasswordReset(ctx, &storage.CreatePasswordResetParams{
+		ID:       "expired",
+		UserID:   userModel.ID,
+		Duration: -time.Minute,
+	})
+	s.Require().NoError(err)
+
+	err = s.UserService.ResetPassword(ctx, &ResetPasswordParams{
+		PasswordResetID: utils.NewSecret[model.PasswordResetID]("expired"),
+		Password:        utils.NewSecret(ptr.To("N3wPassw0rd!")),
+	})
+	s.Require().ErrorIs(err, ErrResetCodeExpired)
+
+	// The password is kept
+	s.loginUser(ctx, userModel.Login, testPassword)
+}
+
+func (s *UserServiceSuite) TestResetPassword_RevokesSessions() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+
+	_, firstToken := s.loginUser(ctx, userModel.Login, testPassword)
+	_, secondToken := s.loginUser(ctx, userModel.Login, testPassword)
+
+	passwordResetID := s.requestPasswordReset(ctx, userModel.Login)
+	err := s.UserService.ResetPassword(ctx, &ResetPasswordParams{
+		PasswordResetID: utils.NewSecret(passwordResetID),
+		Password:        utils.NewSecret(ptr.To("N3wPassw0rd!")),
+	})
+	s.Require().NoError(err)
+
+	for _, token := range []*model.UserToken{firstToken, secondToken} {
+		_, err = s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+		s.Require().ErrorIs(err, ErrSessionRevoked)
+
+		_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: token.RefreshToken})
+		s.Require().ErrorIs(err, ErrSessionRevoked)
+	}
+}
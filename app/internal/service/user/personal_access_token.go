@@ -0,0 +1,227 @@
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+const (
+	personalAccessTokenIDLength     int64 = 20
+	personalAccessTokenSecretLength int64 = 40
+
+	// Makes leaked tokens recognizable by secret scanners
+	// Authorization: Token <value>
+	personalAccessTokenScheme    = "Token"
+	personalAccessTokenPrefix    = "chartdb_pat_"
+	personalAccessTokenSeparator = "."
+
+	maxPersonalAccessTokenLifetime = time.Hour * 24 * 365
+	maxPersonalAccessTokenName     = 100
+	// Last use is updated at most once in the interval to avoid a write on every request
+	personalAccessTokenUsageInterval = time.Minute
+)
+
+var (
+	ErrPersonalAccessTokenNotFound = errors.New("personal access token not found")
+	ErrInvalidTokenName            = errors.New("token name must be from 1 to 100 characters")
+	ErrInvalidTokenScopes          = errors.New("at least one valid scope is required")
+	ErrInvalidTokenExpiration      = errors.New("token must expire in the future within a year")
+)
+
+type CreatePersonalAccessTokenParams struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// CreatePersonalAccessToken issues a token of the subject for scripts and CI pipelines,
+// the token value is returned once and only its hash is stored
+func (s *ServiceImpl) CreatePersonalAccessToken(ctx context.Context, params *CreatePersonalAccessTokenParams) (*model.PersonalAccessToken, error) {
+	ctxlog.Info(ctx, s.Logger, "create personal access token", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+	// Tokens can't issue other tokens
+	if subject.UserType == model.UserTypeGuest || subject.PersonalAccessToken != nil {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxPersonalAccessTokenName {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidTokenName)
+	}
+
+	var scopes []model.TokenScope
+	for _, value := range params.Scopes {
+		scope, err := model.TokenScopeFromString(value)
+		if err != nil {
+			return nil, xerrors.WrapInvalidArgument(fmt.Errorf("%w: %w", ErrInvalidTokenScopes, err))
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidTokenScopes)
+	}
+
+	now := time.Now()
+	if !params.ExpiresAt.After(now) || params.ExpiresAt.After(now.Add(maxPersonalAccessTokenLifetime)) {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidTokenExpiration)
+	}
+
+	tokenID, err := utils.GenerateID(personalAccessTokenIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+	secret, err := utils.GenerateID(personalAccessTokenSecretLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate secret: %w", err)
+	}
+	token := personalAccessTokenPrefix + tokenID + personalAccessTokenSeparator + secret
+
+	personalAccessToken, err := s.Storage.PersonalAccessToken().CreatePersonalAccessToken(ctx, &storage.CreatePersonalAccessTokenParams{
+		ID:        model.PersonalAccessTokenID(tokenID),
+		UserID:    subject.UserID,
+		Name:      name,
+		TokenHash: hashToken(token),
+		Scopes:    scopes,
+		ExpiresAt: params.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create personal access token: %w", err)
+	}
+	personalAccessToken.Value = utils.NewSecret(token)
+
+	return personalAccessToken, nil
+}
+
+// ListPersonalAccessTokens returns active tokens of the subject, the newest first
+func (s *ServiceImpl) ListPersonalAccessTokens(ctx context.Context) ([]*model.PersonalAccessToken, error) {
+	ctxlog.Info(ctx, s.Logger, "list personal access tokens")
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	tokens, err := s.Storage.PersonalAccessToken().GetAllPersonalAccessTokens(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyRevokedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+		{
+			Key:       model.TermKeyExpiresAt,
+			Value:     time.Now(),
+			Operation: model.FilterOperationMore,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all personal access tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+type RevokePersonalAccessTokenParams struct {
+	ID model.PersonalAccessTokenID
+}
+
+// RevokePersonalAccessToken stops the token of the subject from working, e.g. after a leak
+func (s *ServiceImpl) RevokePersonalAccessToken(ctx context.Context, params *RevokePersonalAccessTokenParams) error {
+	ctxlog.Info(ctx, s.Logger, "revoke personal access token", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.Storage.PersonalAccessToken().GetPersonalAccessTokenByID(ctx, params.ID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrPersonalAccessTokenNotFound)
+			}
+			return fmt.Errorf("get personal access token by id: %w", err)
+		}
+		if token.UserID != subject.UserID {
+			return xerrors.WrapNotFound(ErrPersonalAccessTokenNotFound)
+		}
+		if token.RevokedAt != nil {
+			return nil
+		}
+
+		_, err = s.Storage.PersonalAccessToken().PatchPersonalAccessToken(ctx, &storage.PatchPersonalAccessTokenParams{
+			ID:        token.ID,
+			RevokedAt: utils.NewOptional(ptr.To(time.Now())),
+		})
+		if err != nil {
+			return fmt.Errorf("patch personal access token: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("can't revoke personal access token: %w", err)
+	}
+
+	return nil
+}
+
+// authenticatePersonalAccessToken returns the active token with the value
+func (s *ServiceImpl) authenticatePersonalAccessToken(ctx context.Context, value string) (*model.PersonalAccessToken, error) {
+	tokenID, _, ok := strings.Cut(strings.TrimPrefix(value, personalAccessTokenPrefix), personalAccessTokenSeparator)
+	if !ok || !strings.HasPrefix(value, personalAccessTokenPrefix) {
+		return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+	}
+
+	token, err := s.Storage.PersonalAccessToken().GetPersonalAccessTokenByID(ctx, model.PersonalAccessTokenID(tokenID))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+		}
+		return nil, fmt.Errorf("get personal access token by id: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(value)), []byte(token.TokenHash.Value)) != 1 {
+		return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+	}
+
+	now := time.Now()
+	if !token.Active(now) {
+		return nil, xerrors.WrapUnauthenticated(ErrTokenExpired)
+	}
+
+	if token.LastUsedAt == nil || token.LastUsedAt.Add(personalAccessTokenUsageInterval).Before(now) {
+		_, err = s.Storage.PersonalAccessToken().PatchPersonalAccessToken(ctx, &storage.PatchPersonalAccessTokenParams{
+			ID:         token.ID,
+			LastUsedAt: utils.NewOptional(&now),
+		})
+		if err != nil {
+			ctxlog.Warn(ctx, s.Logger, "update token last use", slog.String("token_id", token.ID.String()), slog.Any("error", err))
+		}
+	}
+
+	return token, nil
+}
@@ -0,0 +1,87 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+func (s *UserServiceSuite) createPersonalAccessTokenUser(ctx context.Context) context.Context {
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:          "student",
+		Login:       "student@edu.mirea.ru",
+		Type:        model.UserTypeStudent,
+		ConfirmedAt: ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: userModel.Type,
+	})
+}
+
+func (s *UserServiceSuite) TestPersonalAccessToken_Authenticate() {
+	ctx := s.createPersonalAccessTokenUser(context.Background())
+
+	token, err := s.UserService.CreatePersonalAccessToken(ctx, &CreatePersonalAccessTokenParams{
+		Name:      "CI",
+		Scopes:    []string{"diagrams:read", "diagrams:read"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]model.TokenScope{model.TokenScopeDiagramsRead}, token.Scopes)
+	s.Require().NotEqual(token.Value.Value, token.TokenHash.Value)
+
+	tokenCtx, err := s.UserService.Authenticate(context.Background(), "Token "+token.Value.Value)
+	s.Require().NoError(err)
+	subject, err := auth.GetSubject(tokenCtx)
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserID("student"), subject.UserID)
+	s.Require().NotNil(subject.PersonalAccessToken)
+	s.Require().True(subject.HasScope(model.TokenScopeDiagramsRead))
+	s.Require().False(subject.HasScope(model.TokenScopeDiagramsWrite))
+
+	// Tokens can't issue other tokens
+	_, err = s.UserService.CreatePersonalAccessToken(tokenCtx, &CreatePersonalAccessTokenParams{
+		Name:      "CI",
+		Scopes:    []string{"diagrams:write"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	s.Require().ErrorIs(err, ErrForbidden)
+
+	_, err = s.UserService.Authenticate(context.Background(), "Token "+token.Value.Value+"x")
+	s.Require().ErrorIs(err, ErrInvalidToken)
+
+	err = s.UserService.RevokePersonalAccessToken(ctx, &RevokePersonalAccessTokenParams{ID: token.ID})
+	s.Require().NoError(err)
+
+	_, err = s.UserService.Authenticate(context.Background(), "Token "+token.Value.Value)
+	s.Require().ErrorIs(err, ErrTokenExpired)
+
+	tokens, err := s.UserService.ListPersonalAccessTokens(ctx)
+	s.Require().NoError(err)
+	s.Require().Empty(tokens)
+}
+
+func (s *UserServiceSuite) TestCreatePersonalAccessToken_Invalid() {
+	ctx := s.createPersonalAccessTokenUser(context.Background())
+
+	_, err := s.UserService.CreatePersonalAccessToken(ctx, &CreatePersonalAccessTokenParams{
+		Name:      "CI",
+		Scopes:    []string{"diagrams:delete"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	s.Require().ErrorIs(err, ErrInvalidTokenScopes)
+
+	_, err = s.UserService.CreatePersonalAccessToken(ctx, &CreatePersonalAccessTokenParams{
+		Name:      "CI",
+		Scopes:    []string{"diagrams:read"},
+		ExpiresAt: time.Now().Add(2 * maxPersonalAccessTokenLifetime),
+	})
+	s.Require().ErrorIs(err, ErrInvalidTokenExpiration)
+}
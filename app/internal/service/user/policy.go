@@ -0,0 +1,186 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	defaultMinPasswordLength = 8
+
+	anyDomain = "*"
+)
+
+// Used if no domains are configured
+var defaultDomainRules = []DomainRuleConfig{
+	{Domain: "mirea.ru"},
+	{Domain: "*.mirea.ru"},
+}
+
+type PolicyConfig struct {
+	// Domains allowed to register with a password, the first matching rule is used
+	Domains        []DomainRuleConfig   `yaml:"domains"`
+	BlockedDomains []string             `yaml:"blocked_domains"`
+	Password       PasswordPolicyConfig `yaml:"password"`
+	DisableGuests  bool                 `yaml:"disable_guests"`
+}
+
+type DomainRuleConfig struct {
+	// "example.com" matches the domain only, "*.example.com" matches its subdomains and "*" matches any domain
+	Domain string `yaml:"domain"`
+	// Type of registered users, STUDENT if empty. Admins are approved by other admins only.
+	UserType string `yaml:"user_type"`
+}
+
+type PasswordPolicyConfig struct {
+	// 8 if zero
+	MinLength        int  `yaml:"min_length"`
+	RequireUppercase bool `yaml:"require_uppercase"`
+	RequireLowercase bool `yaml:"require_lowercase"`
+	RequireDigit     bool `yaml:"require_digit"`
+	RequireSymbol    bool `yaml:"require_symbol"`
+}
+
+type domainRule struct {
+	pattern  string
+	userType model.UserType
+}
+
+// Policy decides who can register and which passwords are accepted
+type Policy struct {
+	domains        []domainRule
+	blockedDomains []string
+	password       PasswordPolicyConfig
+	disableGuests  bool
+}
+
+func NewPolicy(config PolicyConfig) (*Policy, error) {
+	domainRules := config.Domains
+	if len(domainRules) == 0 {
+		domainRules = defaultDomainRules
+	}
+
+	policy := &Policy{
+		password:      config.Password,
+		disableGuests: config.DisableGuests,
+	}
+	if policy.password.MinLength == 0 {
+		policy.password.MinLength = defaultMinPasswordLength
+	}
+
+	for _, rule := range domainRules {
+		userType := model.UserTypeStudent
+		if rule.UserType != "" {
+			var err error
+			userType, err = model.UserTypeFromString(rule.UserType)
+			if err != nil {
+				return nil, fmt.Errorf("domain %s: %w", rule.Domain, err)
+			}
+		}
+		if userType != model.UserTypeStudent && userType != model.UserTypeTeacher {
+			return nil, fmt.Errorf("domain %s: only %s and %s can register", rule.Domain, model.Student, model.Teacher)
+		}
+
+		policy.domains = append(policy.domains, domainRule{
+			pattern:  strings.ToLower(rule.Domain),
+			userType: userType,
+		})
+	}
+
+	for _, domain := range config.BlockedDomains {
+		policy.blockedDomains = append(policy.blockedDomains, strings.ToLower(domain))
+	}
+
+	return policy, nil
+}
+
+// GuestsAllowed reports whether users can work without registration
+func (p *Policy) GuestsAllowed() bool {
+	return !p.disableGuests
+}
+
+// ValidateLogin checks that the login is an email of the allowed domain
+func (p *Policy) ValidateLogin(login string) error {
+	_, err := p.UserType(login)
+	return err
+}
+
+// UserType returns the type of users registered with the login
+func (p *Policy) UserType(login string) (model.UserType, error) {
+	err := validator.New().Var(login, "email")
+	if err != nil {
+		return model.UserTypeUnspecified, xerrors.WrapInvalidArgument(ErrInvalidLogin)
+	}
+
+	domain := strings.ToLower(login[strings.LastIndex(login, "@")+1:])
+	for _, pattern := range p.blockedDomains {
+		if matchDomain(pattern, domain) {
+			return model.UserTypeUnspecified, xerrors.WrapInvalidArgument(ErrInvalidLogin)
+		}
+	}
+
+	for _, rule := range p.domains {
+		if matchDomain(rule.pattern, domain) {
+			return rule.userType, nil
+		}
+	}
+
+	return model.UserTypeUnspecified, xerrors.WrapInvalidArgument(ErrInvalidLogin)
+}
+
+// ValidatePassword checks the password against the complexity rules
+func (p *Policy) ValidatePassword(password string) error {
+	if len(password) < p.password.MinLength {
+		return xerrors.WrapInvalidArgument(fmt.Errorf("password must be at least %d characters long", p.password.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var missing []string
+	if p.password.RequireUppercase && !hasUpper {
+		missing = append(missing, "an uppercase letter")
+	}
+	if p.password.RequireLowercase && !hasLower {
+		missing = append(missing, "a lowercase letter")
+	}
+	if p.password.RequireDigit && !hasDigit {
+		missing = append(missing, "a digit")
+	}
+	if p.password.RequireSymbol && !hasSymbol {
+		missing = append(missing, "a symbol")
+	}
+	if len(missing) > 0 {
+		return xerrors.WrapInvalidArgument(errors.New("password must contain " + strings.Join(missing, ", ")))
+	}
+
+	return nil
+}
+
+func matchDomain(pattern, domain string) bool {
+	if pattern == anyDomain {
+		return true
+	}
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(domain, "."+suffix)
+	}
+
+	return pattern == domain
+}
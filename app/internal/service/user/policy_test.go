@@ -0,0 +1,91 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_DefaultDomains(t *testing.T) {
+	policy, err := NewPolicy(PolicyConfig{})
+	require.NoError(t, err)
+
+	for _, login := range []string{"test@mirea.ru", "test@edu.mirea.ru", "Test@EDU.MIREA.RU"} {
+		userType, err := policy.UserType(login)
+		require.NoError(t, err, login)
+		assert.Equal(t, model.UserTypeStudent, userType, login)
+	}
+
+	for _, login := range []string{"test@notmirea.ru", "test@gmail.com", "mirea.ru", "00И0000"} {
+		assert.Error(t, policy.ValidateLogin(login), login)
+	}
+	assert.True(t, policy.GuestsAllowed())
+}
+
+func TestPolicy_DomainRules(t *testing.T) {
+	policy, err := NewPolicy(PolicyConfig{
+		Domains: []DomainRuleConfig{
+			{Domain: "staff.example.com", UserType: model.Teacher},
+			{Domain: "*.example.com"},
+			{Domain: "*"},
+		},
+		BlockedDomains: []string{"*.spam.org"},
+		DisableGuests:  true,
+	})
+	require.NoError(t, err)
+
+	userType, err := policy.UserType("test@staff.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserTypeTeacher, userType)
+
+	userType, err = policy.UserType("test@edu.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserTypeStudent, userType)
+
+	userType, err = policy.UserType("test@gmail.com")
+	require.NoError(t, err)
+	assert.Equal(t, model.UserTypeStudent, userType)
+
+	assert.Error(t, policy.ValidateLogin("test@mail.spam.org"))
+	assert.False(t, policy.GuestsAllowed())
+}
+
+func TestPolicy_InvalidUserType(t *testing.T) {
+	_, err := NewPolicy(PolicyConfig{
+		Domains: []DomainRuleConfig{{Domain: "example.com", UserType: model.Admin}},
+	})
+	assert.Error(t, err)
+
+	_, err = NewPolicy(PolicyConfig{
+		Domains: []DomainRuleConfig{{Domain: "example.com", UserType: "OWNER"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestPolicy_ValidatePassword(t *testing.T) {
+	policy, err := NewPolicy(PolicyConfig{})
+	require.NoError(t, err)
+
+	assert.Error(t, policy.ValidatePassword("short"))
+	assert.NoError(t, policy.ValidatePassword("password"))
+
+	policy, err = NewPolicy(PolicyConfig{
+		Password: PasswordPolicyConfig{
+			MinLength:        10,
+			RequireUppercase: true,
+			RequireLowercase: true,
+			RequireDigit:     true,
+			RequireSymbol:    true,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, policy.ValidatePassword("Passw0rd!"))
+	assert.Error(t, policy.ValidatePassword("password12!"))
+	assert.Error(t, policy.ValidatePassword("PASSWORD12!"))
+	assert.Error(t, policy.ValidatePassword("Password!!!"))
+	assert.Error(t, policy.ValidatePassword("Password123"))
+	assert.NoError(t, policy.ValidatePassword("Password12!"))
+}
@@ -0,0 +1,322 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	roleRequestIDLength int64 = 20
+
+	maxRoleRequestReasonLen  = 2000
+	maxDecisionCommentLength = 2000
+)
+
+type RequestRoleParams struct {
+	Type   model.UserType
+	Reason string
+}
+
+// RequestRole creates a request of the subject to become a teacher or an admin,
+// only one request of the user can wait for the decision
+func (s *ServiceImpl) RequestRole(ctx context.Context, params *RequestRoleParams) (*model.RoleRequest, error) {
+	ctxlog.Info(ctx, s.Logger, "request role", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+	if subject.UserType == model.UserTypeGuest {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+	if params.Type != model.UserTypeTeacher && params.Type != model.UserTypeAdmin || params.Type <= subject.UserType {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidRole)
+	}
+
+	reason := strings.TrimSpace(params.Reason)
+	if len(reason) > maxRoleRequestReasonLen {
+		return nil, xerrors.WrapInvalidArgument(ErrTextTooLong)
+	}
+
+	var roleRequest *model.RoleRequest
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		// Lock the user to create a single pending request on concurrent calls
+		_, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyID,
+				Value:     subject.UserID.String(),
+				Operation: model.FilterOperationExact,
+			},
+		}, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get all users: %w", err)
+		}
+
+		pending, err := s.Storage.RoleRequest().GetAllRoleRequests(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyUserID,
+				Value:     subject.UserID.String(),
+				Operation: model.FilterOperationExact,
+			},
+			{
+				Key:       model.TermKeyStatus,
+				Value:     model.RoleRequestStatusPending.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all role requests: %w", err)
+		}
+		if len(pending) > 0 {
+			return xerrors.WrapConflict(ErrRoleRequestExists)
+		}
+
+		roleRequestID, err := utils.GenerateID(roleRequestIDLength)
+		if err != nil {
+			return fmt.Errorf("generate id: %w", err)
+		}
+
+		roleRequest, err = s.Storage.RoleRequest().CreateRoleRequest(ctx, &storage.CreateRoleRequestParams{
+			ID:     model.RoleRequestID(roleRequestID),
+			UserID: subject.UserID,
+			Type:   params.Type,
+			Reason: reason,
+		})
+		if err != nil {
+			return fmt.Errorf("create role request: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't request role: %w", err)
+	}
+
+	return roleRequest, nil
+}
+
+type ListRoleRequestsParams struct {
+	// Optional, requests of all statuses are returned if empty
+	Status model.RoleRequestStatus
+}
+
+// ListRoleRequests returns all requests to admins and own requests to other users
+func (s *ServiceImpl) ListRoleRequests(ctx context.Context, params *ListRoleRequestsParams) ([]*model.RoleRequest, error) {
+	ctxlog.Info(ctx, s.Logger, "list role requests", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	var filter []*model.FilterTerm
+	if subject.UserType != model.UserTypeAdmin {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+	if params.Status != "" {
+		filter = append(filter, &model.FilterTerm{
+			Key:       model.TermKeyStatus,
+			Value:     params.Status.String(),
+			Operation: model.FilterOperationExact,
+		})
+	}
+
+	roleRequests, err := s.Storage.RoleRequest().GetAllRoleRequests(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("get all role requests: %w", err)
+	}
+
+	return roleRequests, nil
+}
+
+type DecideRoleRequestParams struct {
+	ID       model.RoleRequestID
+	Approved bool
+	Comment  string
+}
+
+// DecideRoleRequest approves or rejects the pending request, approval changes the user type.
+// The user is notified by email about the decision.
+func (s *ServiceImpl) DecideRoleRequest(ctx context.Context, params *DecideRoleRequestParams) (*model.RoleRequest, error) {
+	ctxlog.Info(ctx, s.Logger, "decide role request", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+	if subject.UserType != model.UserTypeAdmin {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	comment := strings.TrimSpace(params.Comment)
+	if len(comment) > maxDecisionCommentLength {
+		return nil, xerrors.WrapInvalidArgument(ErrTextTooLong)
+	}
+
+	status := model.RoleRequestStatusRejected
+	if params.Approved {
+		status = model.RoleRequestStatusApproved
+	}
+
+	var roleRequest *model.RoleRequest
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.Storage.RoleRequest().GetRoleRequestByID(ctx, params.ID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrRoleRequestNotFound)
+			}
+			return fmt.Errorf("get role request by id: %w", err)
+		}
+		if current.Status != model.RoleRequestStatusPending {
+			return xerrors.WrapConflict(ErrRoleRequestDecided)
+		}
+
+		roleRequest, err = s.Storage.RoleRequest().PatchRoleRequest(ctx, &storage.PatchRoleRequestParams{
+			ID:              params.ID,
+			Status:          utils.NewOptional(status),
+			DecidedBy:       utils.NewOptional(ptr.To(subject.UserID)),
+			DecisionComment: utils.NewOptional(comment),
+		})
+		if err != nil {
+			return fmt.Errorf("patch role request: %w", err)
+		}
+		roleRequest.UserLogin = current.UserLogin
+
+		if params.Approved {
+			user, err := s.Storage.User().GetUserByID(ctx, current.UserID)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					return xerrors.WrapNotFound(ErrUserNotFound)
+				}
+				return fmt.Errorf("get user by id: %w", err)
+			}
+
+			// The user could be promoted by another request meanwhile
+			if user.Type < current.Type {
+				_, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+					ID:   user.ID,
+					Type: utils.NewOptional(current.Type),
+				})
+				if err != nil {
+					return fmt.Errorf("patch user: %w", err)
+				}
+			}
+		}
+
+		err = s.EmailSender.SendRoleRequestEmail(current.UserLogin, current.Type.String(), params.Approved, comment)
+		if err != nil {
+			return fmt.Errorf("send role request email: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't decide role request: %w", err)
+	}
+
+	return roleRequest, nil
+}
+
+type CreateAdminParams struct {
+	Login    string `validate:"email"`
+	Password utils.Secret[string]
+}
+
+// CreateAdmin creates the first admin of the installation, an existing user with the login
+// is promoted and gets the new password. It's called on bootstrap without a subject.
+func (s *ServiceImpl) CreateAdmin(ctx context.Context, params *CreateAdminParams) (*model.User, error) {
+	ctxlog.Info(ctx, s.Logger, "create admin", slog.Any("params", params))
+
+	err := validator.New().Struct(params)
+	if err != nil {
+		return nil, xerrors.WrapInvalidArgument(ErrInvalidLogin)
+	}
+
+	passwordHash, err := s.hashPassword(&params.Password.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var userModel *model.User
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		admins, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyType,
+				Value:     model.UserTypeAdmin.String(),
+				Operation: model.FilterOperationExact,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("get all users: %w", err)
+		}
+		if len(admins) > 0 {
+			return xerrors.WrapConflict(ErrAdminAlreadyExists)
+		}
+
+		userList, err := s.Storage.User().GetAllUsers(ctx, []*model.FilterTerm{
+			{
+				Key:       model.TermKeyLogin,
+				Value:     params.Login,
+				Operation: model.FilterOperationExact,
+			},
+		}, storage.WithLock())
+		if err != nil {
+			return fmt.Errorf("get all users: %w", err)
+		}
+
+		now := time.Now()
+		if len(userList) > 0 {
+			userModel, err = s.Storage.User().PatchUser(ctx, &storage.PatchUserParams{
+				ID:           userList[0].ID,
+				PasswordHash: utils.NewOptional(passwordHash),
+				ConfirmedAt:  utils.NewOptional(&now),
+				Type:         utils.NewOptional(model.UserTypeAdmin),
+			})
+			if err != nil {
+				return fmt.Errorf("patch user: %w", err)
+			}
+
+			return nil
+		}
+
+		userID, err := utils.GenerateID(userIDLength)
+		if err != nil {
+			return fmt.Errorf("generate id: %w", err)
+		}
+
+		userModel, err = s.Storage.User().CreateUser(ctx, &storage.CreateUserParams{
+			ID:           model.UserID(userID),
+			Login:        params.Login,
+			PasswordHash: passwordHash,
+			Type:         model.UserTypeAdmin,
+			ConfirmedAt:  &now,
+		})
+		if err != nil {
+			return fmt.Errorf("create user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't create admin: %w", err)
+	}
+
+	return userModel, nil
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+func (s *UserServiceSuite) subjectContext(ctx context.Context, userModel *model.User) context.Context {
+	return auth.SetSubject(ctx, &auth.Subject{
+		UserID:   userModel.ID,
+		UserType: userModel.Type,
+	})
+}
+
+func (s *UserServiceSuite) TestRequestRole() {
+	ctx := context.Background()
+	student := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	studentCtx := s.subjectContext(ctx, student)
+
+	_, err := s.UserService.RequestRole(studentCtx, &RequestRoleParams{Type: model.UserTypeStudent})
+	s.Require().ErrorIs(err, ErrInvalidRole)
+
+	roleRequest, err := s.UserService.RequestRole(studentCtx, &RequestRoleParams{
+		Type:   model.UserTypeTeacher,
+		Reason: " Teaching databases ",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(student.ID, roleRequest.UserID)
+	s.Require().Equal(model.UserTypeTeacher, roleRequest.Type)
+	s.Require().Equal("Teaching databases", roleRequest.Reason)
+	s.Require().Equal(model.RoleRequestStatusPending, roleRequest.Status)
+
+	// Only one request waits for the decision
+	_, err = s.UserService.RequestRole(studentCtx, &RequestRoleParams{Type: model.UserTypeAdmin})
+	s.Require().ErrorIs(err, ErrRoleRequestExists)
+
+	roleRequests, err := s.UserService.ListRoleRequests(studentCtx, &ListRoleRequestsParams{})
+	s.Require().NoError(err)
+	s.Require().Len(roleRequests, 1)
+
+	guestCtx := auth.SetSubject(ctx, &auth.Subject{
+		UserID:   student.ID,
+		UserType: model.UserTypeGuest,
+	})
+	_, err = s.UserService.RequestRole(guestCtx, &RequestRoleParams{Type: model.UserTypeTeacher})
+	s.Require().ErrorIs(err, ErrForbidden)
+}
+
+func (s *UserServiceSuite) TestDecideRoleRequest_Approve() {
+	ctx := context.Background()
+	student := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	admin := s.createPasswordUser(ctx, "admin", "admin@mirea.ru", model.UserTypeAdmin)
+
+	roleRequest, err := s.UserService.RequestRole(s.subjectContext(ctx, student), &RequestRoleParams{Type: model.UserTypeTeacher})
+	s.Require().NoError(err)
+
+	// Only admins decide
+	_, err = s.UserService.DecideRoleRequest(s.subjectContext(ctx, student), &DecideRoleRequestParams{
+		ID:       roleRequest.ID,
+		Approved: true,
+	})
+	s.Require().ErrorIs(err, ErrForbidden)
+
+	s.emailsender.EXPECT().SendRoleRequestEmail(student.Login, model.UserTypeTeacher.String(), true, "Welcome").Return(nil)
+	roleRequest, err = s.UserService.DecideRoleRequest(s.subjectContext(ctx, admin), &DecideRoleRequestParams{
+		ID:       roleRequest.ID,
+		Approved: true,
+		Comment:  "Welcome",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(model.RoleRequestStatusApproved, roleRequest.Status)
+	s.Require().NotNil(roleRequest.DecidedBy)
+	s.Require().Equal(admin.ID, *roleRequest.DecidedBy)
+
+	student, err = s.storage.User().GetUserByID(ctx, student.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserTypeTeacher, student.Type)
+
+	_, err = s.UserService.DecideRoleRequest(s.subjectContext(ctx, admin), &DecideRoleRequestParams{
+		ID:       roleRequest.ID,
+		Approved: false,
+	})
+	s.Require().ErrorIs(err, ErrRoleRequestDecided)
+}
+
+func (s *UserServiceSuite) TestDecideRoleRequest_Reject() {
+	ctx := context.Background()
+	student := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	admin := s.createPasswordUser(ctx, "admin", "admin@mirea.ru", model.UserTypeAdmin)
+	studentCtx := s.subjectContext(ctx, student)
+
+	roleRequest, err := s.UserService.RequestRole(studentCtx, &RequestRoleParams{Type: model.UserTypeAdmin})
+	s.Require().NoError(err)
+
+	s.emailsender.EXPECT().SendRoleRequestEmail(student.Login, model.UserTypeAdmin.String(), false, "").Return(nil)
+	roleRequest, err = s.UserService.DecideRoleRequest(s.subjectContext(ctx, admin), &DecideRoleRequestParams{
+		ID:       roleRequest.ID,
+		Approved: false,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(model.RoleRequestStatusRejected, roleRequest.Status)
+
+	student, err = s.storage.User().GetUserByID(ctx, student.ID)
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserTypeStudent, student.Type)
+
+	// The rejected user can request again
+	_, err = s.UserService.RequestRole(studentCtx, &RequestRoleParams{Type: model.UserTypeTeacher})
+	s.Require().NoError(err)
+}
+
+func (s *UserServiceSuite) TestCreateAdmin_Bootstrap() {
+	ctx := context.Background()
+	student := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+
+	// The existing user is promoted and gets the new password
+	admin, err := s.UserService.CreateAdmin(ctx, &CreateAdminParams{
+		Login:    student.Login,
+		Password: utils.NewSecret("Adm1nPassw0rd!"),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(student.ID, admin.ID)
+	s.Require().Equal(model.UserTypeAdmin, admin.Type)
+	s.loginUser(ctx, student.Login, "Adm1nPassw0rd!")
+
+	_, err = s.UserService.CreateAdmin(ctx, &CreateAdminParams{
+		Login:    "admin@mirea.ru",
+		Password: utils.NewSecret("Adm1nPassw0rd!"),
+	})
+	s.Require().ErrorIs(err, ErrAdminAlreadyExists)
+
+	_, err = s.UserService.CreateAdmin(ctx, &CreateAdminParams{
+		Login:    "not an email",
+		Password: utils.NewSecret("Adm1nPassw0rd!"),
+	})
+	s.Require().ErrorIs(err, ErrInvalidLogin)
+}
+
+func (s *UserServiceSuite) TestCreateAdmin_NewUser() {
+	ctx := context.Background()
+
+	admin, err := s.UserService.CreateAdmin(ctx, &CreateAdminParams{
+		Login:    "admin@example.com",
+		Password: utils.NewSecret("Adm1nPassw0rd!"),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(model.UserTypeAdmin, admin.Type)
+	s.Require().NotNil(admin.ConfirmedAt)
+
+	_, token := s.loginUser(ctx, "admin@example.com", "Adm1nPassw0rd!")
+	s.Require().Equal(admin.ID, token.UserID)
+}
@@ -0,0 +1,293 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+const (
+	sessionIDLength     int64 = 20
+	refreshSecretLength int64 = 40
+
+	// Every refresh extends the session
+	sessionExpirationTime = time.Hour * 24 * 30
+
+	maxUserAgentLength = 512
+
+	refreshTokenSeparator = "."
+)
+
+// createSession starts a new session of the user and issues its first tokens
+func (s *ServiceImpl) createSession(ctx context.Context, user *model.User, userAgent, ipAddress string) (*model.UserToken, error) {
+	sessionID, err := utils.GenerateID(sessionIDLength)
+	if err != nil {
+		return nil, fmt.Errorf("generate id: %w", err)
+	}
+
+	refreshToken, refreshTokenHash, err := newRefreshToken(model.SessionID(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("new refresh token: %w", err)
+	}
+
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	session, err := s.Storage.Session().CreateSession(ctx, &storage.CreateSessionParams{
+		ID:               model.SessionID(sessionID),
+		UserID:           user.ID,
+		RefreshTokenHash: refreshTokenHash,
+		UserAgent:        userAgent,
+		IPAddress:        ipAddress,
+		ExpiresAt:        time.Now().Add(sessionExpirationTime),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create session: %w", err)
+	}
+
+	userToken, err := createToken(user, session.ID, s.tokenSigner)
+	if err != nil {
+		return nil, fmt.Errorf("create token: %w", err)
+	}
+	userToken.RefreshToken = utils.NewSecret(refreshToken)
+
+	return userToken, nil
+}
+
+type RefreshTokenParams struct {
+	RefreshToken utils.Secret[string]
+}
+
+// RefreshToken issues a new access token of the session and rotates its refresh token,
+// the used refresh token stops working
+func (s *ServiceImpl) RefreshToken(ctx context.Context, params *RefreshTokenParams) (*model.UserToken, error) {
+	ctxlog.Info(ctx, s.Logger, "refresh token", slog.Any("params", params))
+
+	sessionID, _, ok := strings.Cut(params.RefreshToken.Value, refreshTokenSeparator)
+	if !ok {
+		return nil, xerrors.WrapUnauthenticated(ErrInvalidToken)
+	}
+
+	var userToken *model.UserToken
+	err := s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		session, err := s.Storage.Session().GetSessionByID(ctx, model.SessionID(sessionID), storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapUnauthenticated(ErrInvalidToken)
+			}
+			return fmt.Errorf("get session by id: %w", err)
+		}
+		if !session.Active(time.Now()) {
+			return xerrors.WrapUnauthenticated(ErrSessionRevoked)
+		}
+		if subtle.ConstantTimeCompare(
+			[]byte(hashToken(params.RefreshToken.Value)),
+			[]byte(session.RefreshTokenHash.Value),
+		) != 1 {
+			return xerrors.WrapUnauthenticated(ErrInvalidToken)
+		}
+
+		user, err := s.Storage.User().GetUserByID(ctx, session.UserID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapUnauthenticated(ErrInvalidToken)
+			}
+			return fmt.Errorf("get user by id: %w", err)
+		}
+
+		refreshToken, refreshTokenHash, err := newRefreshToken(session.ID)
+		if err != nil {
+			return fmt.Errorf("new refresh token: %w", err)
+		}
+
+		_, err = s.Storage.Session().PatchSession(ctx, &storage.PatchSessionParams{
+			ID:               session.ID,
+			RefreshTokenHash: utils.NewOptional(refreshTokenHash),
+			ExpiresAt:        utils.NewOptional(time.Now().Add(sessionExpirationTime)),
+		})
+		if err != nil {
+			return fmt.Errorf("patch session: %w", err)
+		}
+
+		userToken, err = createToken(user, session.ID, s.tokenSigner)
+		if err != nil {
+			return fmt.Errorf("create token: %w", err)
+		}
+		userToken.RefreshToken = utils.NewSecret(refreshToken)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't refresh token: %w", err)
+	}
+
+	return userToken, nil
+}
+
+// Logout revokes the session of the subject
+func (s *ServiceImpl) Logout(ctx context.Context) error {
+	ctxlog.Info(ctx, s.Logger, "logout")
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+	if subject.SessionID == "" {
+		return xerrors.WrapInvalidArgument(ErrSessionNotFound)
+	}
+
+	err = s.revokeSession(ctx, subject.UserID, subject.SessionID)
+	if err != nil {
+		return fmt.Errorf("can't logout: %w", err)
+	}
+
+	return nil
+}
+
+// ListSessions returns active sessions of the subject, the most recently used first
+func (s *ServiceImpl) ListSessions(ctx context.Context) ([]*model.Session, error) {
+	ctxlog.Info(ctx, s.Logger, "list sessions")
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	sessions, err := s.Storage.Session().GetAllSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     subject.UserID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyRevokedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+		{
+			Key:       model.TermKeyExpiresAt,
+			Value:     time.Now(),
+			Operation: model.FilterOperationMore,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get all sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+type RevokeSessionParams struct {
+	ID model.SessionID
+}
+
+// RevokeSession signs out one of the subject's sessions, e.g. on a lost device
+func (s *ServiceImpl) RevokeSession(ctx context.Context, params *RevokeSessionParams) error {
+	ctxlog.Info(ctx, s.Logger, "revoke session", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+
+	err = s.revokeSession(ctx, subject.UserID, params.ID)
+	if err != nil {
+		return fmt.Errorf("can't revoke session: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) revokeSession(ctx context.Context, userID model.UserID, sessionID model.SessionID) error {
+	return s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		session, err := s.Storage.Session().GetSessionByID(ctx, sessionID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrSessionNotFound)
+			}
+			return fmt.Errorf("get session by id: %w", err)
+		}
+		if session.UserID != userID {
+			return xerrors.WrapNotFound(ErrSessionNotFound)
+		}
+		if session.RevokedAt != nil {
+			return nil
+		}
+
+		_, err = s.Storage.Session().PatchSession(ctx, &storage.PatchSessionParams{
+			ID:        session.ID,
+			RevokedAt: utils.NewOptional(ptr.To(time.Now())),
+		})
+		if err != nil {
+			return fmt.Errorf("patch session: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// revokeUserSessions revokes all active sessions of the user except the given one
+func (s *ServiceImpl) revokeUserSessions(ctx context.Context, userID model.UserID, except model.SessionID) error {
+	sessions, err := s.Storage.Session().GetAllSessions(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyRevokedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("get all sessions: %w", err)
+	}
+
+	now := time.Now()
+	for _, session := range sessions {
+		if session.ID == except {
+			continue
+		}
+
+		_, err = s.Storage.Session().PatchSession(ctx, &storage.PatchSessionParams{
+			ID:        session.ID,
+			RevokedAt: utils.NewOptional(&now),
+		})
+		if err != nil {
+			return fmt.Errorf("patch session: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// newRefreshToken returns the token given to the client and its hash kept in the storage.
+// The token starts with the session ID to find the session without the lookup by hash.
+func newRefreshToken(sessionID model.SessionID) (string, string, error) {
+	secret, err := utils.GenerateID(refreshSecretLength)
+	if err != nil {
+		return "", "", fmt.Errorf("generate id: %w", err)
+	}
+
+	token := sessionID.String() + refreshTokenSeparator + secret
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,90 @@
+package user
+
+import (
+	"context"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+func (s *UserServiceSuite) TestRefreshToken_Rotation() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	_, token := s.loginUser(ctx, userModel.Login, testPassword)
+
+	refreshedToken, err := s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: token.RefreshToken})
+	s.Require().NoError(err)
+	s.Require().Equal(token.SessionID, refreshedToken.SessionID)
+	s.Require().Equal(userModel.ID, refreshedToken.UserID)
+	s.Require().NotEqual(token.RefreshToken.Value, refreshedToken.RefreshToken.Value)
+
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+refreshedToken.Value)
+	s.Require().NoError(err)
+
+	// The used refresh token stops working, the rotated one works once
+	_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: token.RefreshToken})
+	s.Require().ErrorIs(err, ErrInvalidToken)
+
+	_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: refreshedToken.RefreshToken})
+	s.Require().NoError(err)
+
+	_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: refreshedToken.RefreshToken})
+	s.Require().ErrorIs(err, ErrInvalidToken)
+}
+
+func (s *UserServiceSuite) TestLogout() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, token := s.loginUser(ctx, userModel.Login, testPassword)
+	_, otherToken := s.loginUser(ctx, userModel.Login, testPassword)
+
+	err := s.UserService.Logout(sessionCtx)
+	s.Require().NoError(err)
+
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+	s.Require().ErrorIs(err, ErrSessionRevoked)
+
+	_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: token.RefreshToken})
+	s.Require().ErrorIs(err, ErrSessionRevoked)
+
+	// Other sessions stay active
+	otherCtx, err := s.UserService.Authenticate(ctx, "Bearer "+otherToken.Value)
+	s.Require().NoError(err)
+
+	sessions, err := s.UserService.ListSessions(otherCtx)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+	s.Require().Equal(otherToken.SessionID, sessions[0].ID)
+}
+
+func (s *UserServiceSuite) TestRevokeSession() {
+	ctx := context.Background()
+	userModel := s.createPasswordUser(ctx, "student", "student@mirea.ru", model.UserTypeStudent)
+	sessionCtx, token := s.loginUser(ctx, userModel.Login, testPassword)
+	_, lostToken := s.loginUser(ctx, userModel.Login, testPassword)
+
+	sessions, err := s.UserService.ListSessions(sessionCtx)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 2)
+
+	err = s.UserService.RevokeSession(sessionCtx, &RevokeSessionParams{ID: lostToken.SessionID})
+	s.Require().NoError(err)
+
+	// Access tokens of the revoked session are rejected before they expire
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+lostToken.Value)
+	s.Require().ErrorIs(err, ErrSessionRevoked)
+
+	_, err = s.UserService.RefreshToken(ctx, &RefreshTokenParams{RefreshToken: lostToken.RefreshToken})
+	s.Require().ErrorIs(err, ErrSessionRevoked)
+
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+	s.Require().NoError(err)
+
+	// Sessions of other users can't be revoked
+	otherUser := s.createPasswordUser(ctx, "other", "other@mirea.ru", model.UserTypeStudent)
+	otherCtx, _ := s.loginUser(ctx, otherUser.Login, testPassword)
+	err = s.UserService.RevokeSession(otherCtx, &RevokeSessionParams{ID: token.SessionID})
+	s.Require().ErrorIs(err, ErrSessionNotFound)
+
+	_, err = s.UserService.Authenticate(ctx, "Bearer "+token.Value)
+	s.Require().NoError(err)
+}
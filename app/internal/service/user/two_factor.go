@@ -0,0 +1,581 @@
+package user
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/ctxlog"
+	xerrors "github.com/IvLaptev/chartdb-back/pkg/errors"
+	"github.com/IvLaptev/chartdb-back/pkg/totp"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+)
+
+const (
+	defaultTOTPIssuer = "ChartDB"
+	// Codes of adjacent time steps are accepted to tolerate clocks of phones running fast or slow
+	totpSkew = 1
+
+	loginChallengeIDLength int64 = 40
+	loginChallengeTime           = 5 * time.Minute
+	// The challenge stops working after the attempts, the password has to be entered again
+	maxLoginChallengeAttempts = 5
+
+	recoveryCodeCount           = 10
+	recoveryCodeIDLength  int64 = 20
+	recoveryCodeLength    int64 = 10
+	recoveryCodeSeparator       = "-"
+)
+
+var (
+	ErrTOTPAlreadyEnabled    = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled       = errors.New("two-factor authentication is not enrolled")
+	ErrTOTPNotEnabled        = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorRequired     = errors.New("two-factor authentication is required for the user type")
+	ErrInvalidTwoFactorCode  = errors.New("invalid two-factor code")
+	ErrInvalidLoginChallenge = errors.New("invalid or expired login challenge")
+)
+
+type TwoFactorConfig struct {
+	// Name of the service in authenticator apps, "ChartDB" if empty
+	Issuer string `yaml:"issuer"`
+	// Users of the types can't log in without TOTP, they enroll the authenticator app on the next login
+	RequiredUserTypes []string `yaml:"required_user_types"`
+}
+
+// TwoFactor decides which users need the second factor
+type TwoFactor struct {
+	issuer            string
+	requiredUserTypes []model.UserType
+}
+
+func NewTwoFactor(config TwoFactorConfig) (*TwoFactor, error) {
+	twoFactor := &TwoFactor{
+		issuer: config.Issuer,
+	}
+	if twoFactor.issuer == "" {
+		twoFactor.issuer = defaultTOTPIssuer
+	}
+
+	for _, value := range config.RequiredUserTypes {
+		userType, err := model.UserTypeFromString(value)
+		if err != nil {
+			return nil, fmt.Errorf("required user types: %w", err)
+		}
+		// Guests don't have passwords to log in with
+		if userType == model.UserTypeGuest {
+			return nil, fmt.Errorf("required user types: %s can't use two-factor authentication", model.Guest)
+		}
+		twoFactor.requiredUserTypes = append(twoFactor.requiredUserTypes, userType)
+	}
+
+	return twoFactor, nil
+}
+
+// Required reports whether users of the type must log in with the second factor
+func (t *TwoFactor) Required(userType model.UserType) bool {
+	return slices.Contains(t.requiredUserTypes, userType)
+}
+
+// EnrollTOTP generates the TOTP secret of the subject, two-factor authentication is enabled
+// once ConfirmTOTP checks the first code of the authenticator app
+func (s *ServiceImpl) EnrollTOTP(ctx context.Context) (*model.TOTPEnrollment, error) {
+	ctxlog.Info(ctx, s.Logger, "enroll totp")
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+	if subject.UserType == model.UserTypeGuest {
+		return nil, xerrors.WrapForbidden(ErrForbidden)
+	}
+
+	var enrollment *model.TOTPEnrollment
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userModel, err := s.Storage.User().GetUserByID(ctx, subject.UserID)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapNotFound(ErrUserNotFound)
+			}
+			return fmt.Errorf("get user by id: %w", err)
+		}
+
+		enrollment, err = s.enrollTOTP(ctx, userModel)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't enroll totp: %w", err)
+	}
+
+	return enrollment, nil
+}
+
+type ConfirmTOTPParams struct {
+	Code utils.Secret[string]
+}
+
+// ConfirmTOTP enables two-factor authentication of the subject by the first code of the authenticator app
+// and returns recovery codes, they are shown only once
+func (s *ServiceImpl) ConfirmTOTP(ctx context.Context, params *ConfirmTOTPParams) ([]string, error) {
+	ctxlog.Info(ctx, s.Logger, "confirm totp", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	var recoveryCodes []string
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userTOTP, err := s.Storage.UserTOTP().GetUserTOTP(ctx, subject.UserID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapInvalidArgument(ErrTOTPNotEnrolled)
+			}
+			return fmt.Errorf("get user totp: %w", err)
+		}
+		if userTOTP.Enabled() {
+			return xerrors.WrapConflict(ErrTOTPAlreadyEnabled)
+		}
+
+		ok, err := s.verifyTOTP(ctx, userTOTP, params.Code.Value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return xerrors.WrapInvalidArgument(ErrInvalidTwoFactorCode)
+		}
+
+		recoveryCodes, err = s.enableTOTP(ctx, userTOTP)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't confirm totp: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+type DisableTOTPParams struct {
+	// TOTP or recovery code
+	Code utils.Secret[string]
+}
+
+// DisableTOTP removes the authenticator app and recovery codes of the subject,
+// users of types requiring the second factor can't disable it
+func (s *ServiceImpl) DisableTOTP(ctx context.Context, params *DisableTOTPParams) error {
+	ctxlog.Info(ctx, s.Logger, "disable totp", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return fmt.Errorf("get subject: %w", err)
+	}
+	if s.TwoFactor.Required(subject.UserType) {
+		return xerrors.WrapForbidden(ErrTwoFactorRequired)
+	}
+
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userTOTP, err := s.enabledTOTP(ctx, subject.UserID)
+		if err != nil {
+			return err
+		}
+
+		ok, err := s.verifyTOTP(ctx, userTOTP, params.Code.Value)
+		if err == nil && !ok {
+			ok, err = s.useRecoveryCode(ctx, subject.UserID, params.Code.Value)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return xerrors.WrapInvalidArgument(ErrInvalidTwoFactorCode)
+		}
+
+		err = s.Storage.UserRecoveryCode().DeleteUserRecoveryCodes(ctx, subject.UserID)
+		if err != nil {
+			return fmt.Errorf("delete user recovery codes: %w", err)
+		}
+		_, err = s.Storage.UserTOTP().DeleteUserTOTP(ctx, subject.UserID)
+		if err != nil {
+			return fmt.Errorf("delete user totp: %w", err)
+		}
+
+		return s.logAudit(ctx, subject.UserID, model.UserAuditActionTOTPDisabled, "")
+	})
+	if err != nil {
+		return fmt.Errorf("can't disable totp: %w", err)
+	}
+
+	return nil
+}
+
+type RegenerateRecoveryCodesParams struct {
+	Code utils.Secret[string]
+}
+
+// RegenerateRecoveryCodes replaces recovery codes of the subject, the previous codes stop working
+func (s *ServiceImpl) RegenerateRecoveryCodes(ctx context.Context, params *RegenerateRecoveryCodesParams) ([]string, error) {
+	ctxlog.Info(ctx, s.Logger, "regenerate recovery codes", slog.Any("params", params))
+
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	var recoveryCodes []string
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		userTOTP, err := s.enabledTOTP(ctx, subject.UserID)
+		if err != nil {
+			return err
+		}
+
+		ok, err := s.verifyTOTP(ctx, userTOTP, params.Code.Value)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return xerrors.WrapInvalidArgument(ErrInvalidTwoFactorCode)
+		}
+
+		recoveryCodes, err = s.createRecoveryCodes(ctx, subject.UserID)
+		if err != nil {
+			return err
+		}
+
+		return s.logAudit(ctx, subject.UserID, model.UserAuditActionRecoveryCodesRenewed, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't regenerate recovery codes: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+type VerifyLoginChallengeParams struct {
+	ChallengeID model.LoginChallengeID
+	// TOTP code, recovery codes are accepted once the enrollment is complete
+	Code utils.Secret[string]
+}
+
+// VerifyLoginChallenge starts the session of the login waiting for the second factor.
+// The login enrolling the authenticator app enables two-factor authentication and returns recovery codes.
+func (s *ServiceImpl) VerifyLoginChallenge(ctx context.Context, params *VerifyLoginChallengeParams) (*model.UserToken, error) {
+	ctxlog.Info(ctx, s.Logger, "verify login challenge", slog.Any("params", params))
+
+	var userToken *model.UserToken
+	var wrongCode bool
+	err := s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		challenge, err := s.Storage.LoginChallenge().GetLoginChallengeByID(ctx, params.ChallengeID, storage.WithLock())
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapUnauthenticated(ErrInvalidLoginChallenge)
+			}
+			return fmt.Errorf("get login challenge by id: %w", err)
+		}
+		if challenge.ExpiresAt.Before(time.Now()) || challenge.Attempts >= maxLoginChallengeAttempts {
+			return xerrors.WrapUnauthenticated(ErrInvalidLoginChallenge)
+		}
+
+		userModel, err := s.Storage.User().GetUserByID(ctx, challenge.UserID)
+		if err != nil {
+			return fmt.Errorf("get user by id: %w", err)
+		}
+		userTOTP, err := s.Storage.UserTOTP().GetUserTOTP(ctx, challenge.UserID, storage.WithLock())
+		if err != nil {
+			// The authenticator app was removed after the password was checked
+			if errors.Is(err, storage.ErrNotFound) {
+				return xerrors.WrapUnauthenticated(ErrInvalidLoginChallenge)
+			}
+			return fmt.Errorf("get user totp: %w", err)
+		}
+
+		ok, err := s.verifyTOTP(ctx, userTOTP, params.Code.Value)
+		if err == nil && !ok && userTOTP.Enabled() {
+			ok, err = s.useRecoveryCode(ctx, challenge.UserID, params.Code.Value)
+		}
+		if err != nil {
+			return err
+		}
+		// The attempt is saved, so the error is returned after the transaction
+		if !ok {
+			wrongCode = true
+			_, err = s.Storage.LoginChallenge().PatchLoginChallenge(ctx, &storage.PatchLoginChallengeParams{
+				ID:       challenge.ID,
+				Attempts: utils.NewOptional(challenge.Attempts + 1),
+			})
+			if err != nil {
+				return fmt.Errorf("patch login challenge: %w", err)
+			}
+			return nil
+		}
+
+		var recoveryCodes []string
+		if !userTOTP.Enabled() {
+			recoveryCodes, err = s.enableTOTP(ctx, userTOTP)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = s.Storage.LoginChallenge().DeleteLoginChallenge(ctx, challenge.ID)
+		if err != nil {
+			return fmt.Errorf("delete login challenge: %w", err)
+		}
+
+		userToken, err = s.createSession(ctx, userModel, challenge.UserAgent, challenge.IPAddress)
+		if err != nil {
+			return err
+		}
+		userToken.RecoveryCodes = utils.NewSecret(recoveryCodes)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't verify login challenge: %w", err)
+	}
+	if wrongCode {
+		return nil, xerrors.WrapUnauthenticated(ErrInvalidTwoFactorCode)
+	}
+
+	return userToken, nil
+}
+
+// completeLogin starts the session of the user with the checked password. Users with TOTP and users
+// which must enroll it get the challenge instead, the session is started by VerifyLoginChallenge.
+func (s *ServiceImpl) completeLogin(ctx context.Context, user *model.User, userAgent, ipAddress string) (*model.UserToken, error) {
+	userTOTP, err := s.Storage.UserTOTP().GetUserTOTP(ctx, user.ID)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("get user totp: %w", err)
+	}
+	enabled := err == nil && userTOTP.Enabled()
+	if !enabled && !s.TwoFactor.Required(user.Type) {
+		return s.createSession(ctx, user, userAgent, ipAddress)
+	}
+
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	var challenge *model.LoginChallenge
+	err = s.Storage.DoInTransaction(ctx, func(ctx context.Context) error {
+		challengeID, err := utils.GenerateID(loginChallengeIDLength)
+		if err != nil {
+			return fmt.Errorf("generate id: %w", err)
+		}
+
+		var enrollment *model.TOTPEnrollment
+		if !enabled {
+			enrollment, err = s.enrollTOTP(ctx, user)
+			if err != nil {
+				return err
+			}
+		}
+
+		challenge, err = s.Storage.LoginChallenge().CreateLoginChallenge(ctx, &storage.CreateLoginChallengeParams{
+			ID:        model.LoginChallengeID(challengeID),
+			UserID:    user.ID,
+			UserAgent: userAgent,
+			IPAddress: ipAddress,
+			Duration:  loginChallengeTime,
+		})
+		if err != nil {
+			return fmt.Errorf("create login challenge: %w", err)
+		}
+		challenge.Enrollment = enrollment
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.UserToken{
+		UserID:    user.ID,
+		Challenge: challenge,
+	}, nil
+}
+
+// enrollTOTP replaces the pending secret of the user with a new one
+func (s *ServiceImpl) enrollTOTP(ctx context.Context, user *model.User) (*model.TOTPEnrollment, error) {
+	userTOTP, err := s.Storage.UserTOTP().GetUserTOTP(ctx, user.ID, storage.WithLock())
+	switch {
+	case err == nil && userTOTP.Enabled():
+		return nil, xerrors.WrapConflict(ErrTOTPAlreadyEnabled)
+	case err == nil:
+		_, err = s.Storage.UserTOTP().DeleteUserTOTP(ctx, user.ID)
+		if err != nil {
+			return nil, fmt.Errorf("delete user totp: %w", err)
+		}
+	case !errors.Is(err, storage.ErrNotFound):
+		return nil, fmt.Errorf("get user totp: %w", err)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, fmt.Errorf("generate secret: %w", err)
+	}
+
+	_, err = s.Storage.UserTOTP().CreateUserTOTP(ctx, &storage.CreateUserTOTPParams{
+		UserID: user.ID,
+		Secret: secret,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create user totp: %w", err)
+	}
+
+	return &model.TOTPEnrollment{
+		Secret:          utils.NewSecret(secret),
+		ProvisioningURI: utils.NewSecret(totp.ProvisioningURI(s.TwoFactor.issuer, user.Login, secret)),
+	}, nil
+}
+
+func (s *ServiceImpl) enableTOTP(ctx context.Context, userTOTP *model.UserTOTP) ([]string, error) {
+	_, err := s.Storage.UserTOTP().PatchUserTOTP(ctx, &storage.PatchUserTOTPParams{
+		UserID:    userTOTP.UserID,
+		EnabledAt: utils.NewOptional(ptr.To(time.Now())),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("patch user totp: %w", err)
+	}
+
+	recoveryCodes, err := s.createRecoveryCodes(ctx, userTOTP.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.logAudit(ctx, userTOTP.UserID, model.UserAuditActionTOTPEnabled, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s *ServiceImpl) enabledTOTP(ctx context.Context, userID model.UserID) (*model.UserTOTP, error) {
+	userTOTP, err := s.Storage.UserTOTP().GetUserTOTP(ctx, userID, storage.WithLock())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, xerrors.WrapInvalidArgument(ErrTOTPNotEnabled)
+		}
+		return nil, fmt.Errorf("get user totp: %w", err)
+	}
+	if !userTOTP.Enabled() {
+		return nil, xerrors.WrapInvalidArgument(ErrTOTPNotEnabled)
+	}
+
+	return userTOTP, nil
+}
+
+// verifyTOTP checks the code of the authenticator app, each code is accepted only once
+func (s *ServiceImpl) verifyTOTP(ctx context.Context, userTOTP *model.UserTOTP, code string) (bool, error) {
+	step, ok, err := totp.Validate(userTOTP.Secret.Value, code, time.Now(), totpSkew)
+	if err != nil {
+		return false, fmt.Errorf("validate totp: %w", err)
+	}
+	if !ok || step <= userTOTP.LastUsedStep {
+		return false, nil
+	}
+
+	_, err = s.Storage.UserTOTP().PatchUserTOTP(ctx, &storage.PatchUserTOTPParams{
+		UserID:       userTOTP.UserID,
+		LastUsedStep: utils.NewOptional(step),
+	})
+	if err != nil {
+		return false, fmt.Errorf("patch user totp: %w", err)
+	}
+	userTOTP.LastUsedStep = step
+
+	return true, nil
+}
+
+// useRecoveryCode marks the unused recovery code of the user as used
+func (s *ServiceImpl) useRecoveryCode(ctx context.Context, userID model.UserID, code string) (bool, error) {
+	recoveryCodes, err := s.Storage.UserRecoveryCode().GetAllUserRecoveryCodes(ctx, []*model.FilterTerm{
+		{
+			Key:       model.TermKeyUserID,
+			Value:     userID.String(),
+			Operation: model.FilterOperationExact,
+		},
+		{
+			Key:       model.TermKeyUsedAt,
+			Operation: model.FilterOperationIsNil,
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("get all user recovery codes: %w", err)
+	}
+
+	codeHash := hashToken(normalizeRecoveryCode(code))
+	for _, recoveryCode := range recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(codeHash), []byte(recoveryCode.CodeHash.Value)) != 1 {
+			continue
+		}
+
+		_, err = s.Storage.UserRecoveryCode().PatchUserRecoveryCode(ctx, &storage.PatchUserRecoveryCodeParams{
+			ID:     recoveryCode.ID,
+			UsedAt: utils.NewOptional(ptr.To(time.Now())),
+		})
+		if err != nil {
+			return false, fmt.Errorf("patch user recovery code: %w", err)
+		}
+
+		err = s.logAudit(ctx, userID, model.UserAuditActionRecoveryCodeUsed, "")
+		if err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// createRecoveryCodes replaces recovery codes of the user, only hashes of the codes are stored
+func (s *ServiceImpl) createRecoveryCodes(ctx context.Context, userID model.UserID) ([]string, error) {
+	err := s.Storage.UserRecoveryCode().DeleteUserRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("delete user recovery codes: %w", err)
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := utils.GenerateID(recoveryCodeLength)
+		if err != nil {
+			return nil, fmt.Errorf("generate recovery code: %w", err)
+		}
+		recoveryCodeID, err := utils.GenerateID(recoveryCodeIDLength)
+		if err != nil {
+			return nil, fmt.Errorf("generate id: %w", err)
+		}
+
+		_, err = s.Storage.UserRecoveryCode().CreateUserRecoveryCode(ctx, &storage.CreateUserRecoveryCodeParams{
+			ID:       model.UserRecoveryCodeID(recoveryCodeID),
+			UserID:   userID,
+			CodeHash: hashToken(code),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create user recovery code: %w", err)
+		}
+
+		half := len(code) / 2
+		recoveryCodes = append(recoveryCodes, code[:half]+recoveryCodeSeparator+code[half:])
+	}
+
+	return recoveryCodes, nil
+}
+
+// normalizeRecoveryCode accepts codes typed with any case and without the separator
+func normalizeRecoveryCode(code string) string {
+	code = strings.ReplaceAll(code, recoveryCodeSeparator, "")
+	code = strings.ReplaceAll(code, " ", "")
+
+	return strings.ToLower(code)
+}
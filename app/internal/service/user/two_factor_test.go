@@ -0,0 +1,145 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/totp"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	"github.com/IvLaptev/chartdb-back/pkg/utils/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTwoFactor(t *testing.T) {
+	twoFactor, err := NewTwoFactor(TwoFactorConfig{RequiredUserTypes: []string{model.Teacher, model.Admin}})
+	require.NoError(t, err)
+	assert.True(t, twoFactor.Required(model.UserTypeTeacher))
+	assert.True(t, twoFactor.Required(model.UserTypeAdmin))
+	assert.False(t, twoFactor.Required(model.UserTypeStudent))
+	assert.Equal(t, defaultTOTPIssuer, twoFactor.issuer)
+
+	_, err = NewTwoFactor(TwoFactorConfig{RequiredUserTypes: []string{model.Guest}})
+	assert.Error(t, err)
+	_, err = NewTwoFactor(TwoFactorConfig{RequiredUserTypes: []string{"OWNER"}})
+	assert.Error(t, err)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	assert.Equal(t, "abcdefghij", normalizeRecoveryCode("ABCDE-FGHIJ"))
+	assert.Equal(t, "abcdefghij", normalizeRecoveryCode("abcde fghij"))
+}
+
+func (s *UserServiceSuite) createTwoFactorUser(ctx context.Context, userType model.UserType) *model.User {
+	passwordHash, err := s.UserService.PasswordHasher.Hash("Passw0rd!")
+	s.Require().NoError(err)
+	userModel, err := s.storage.User().CreateUser(ctx, &storage.CreateUserParams{
+		ID:           "teacher",
+		Login:        "teacher@mirea.ru",
+		PasswordHash: &passwordHash,
+		Type:         userType,
+		ConfirmedAt:  ptr.To(time.Now()),
+	})
+	s.Require().NoError(err)
+
+	return userModel
+}
+
+func (s *UserServiceSuite) loginTwoFactorUser(ctx context.Context) *model.UserToken {
+	token, err := s.UserService.LoginUser(ctx, &LoginUserParams{
+		Login:    "teacher@mirea.ru",
+		Password: utils.NewSecret("Passw0rd!"),
+	})
+	s.Require().NoError(err)
+
+	return token
+}
+
+func (s *UserServiceSuite) TestLoginUser_TwoFactorEnrollment() {
+	ctx := context.Background()
+	twoFactor, err := NewTwoFactor(TwoFactorConfig{RequiredUserTypes: []string{model.Teacher}})
+	s.Require().NoError(err)
+	s.UserService.TwoFactor = twoFactor
+	s.createTwoFactorUser(ctx, model.UserTypeTeacher)
+
+	// The user has to add the authenticator app before the session is started
+	token := s.loginTwoFactorUser(ctx)
+	s.Require().Empty(token.Value)
+	s.Require().NotNil(token.Challenge)
+	s.Require().NotNil(token.Challenge.Enrollment)
+
+	code, err := totp.Code(token.Challenge.Enrollment.Secret.Value, totp.Step(time.Now()))
+	s.Require().NoError(err)
+	token, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+		ChallengeID: token.Challenge.ID,
+		Code:        utils.NewSecret(code),
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(token.Value)
+	s.Require().Len(token.RecoveryCodes.Value, recoveryCodeCount)
+	recoveryCodes := token.RecoveryCodes.Value
+
+	// Codes can't be reused
+	token = s.loginTwoFactorUser(ctx)
+	s.Require().Nil(token.Challenge.Enrollment)
+	_, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+		ChallengeID: token.Challenge.ID,
+		Code:        utils.NewSecret(code),
+	})
+	s.Require().ErrorIs(err, ErrInvalidTwoFactorCode)
+
+	token, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+		ChallengeID: token.Challenge.ID,
+		Code:        utils.NewSecret(recoveryCodes[0]),
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(token.Value)
+	s.Require().Empty(token.RecoveryCodes.Value)
+
+	token = s.loginTwoFactorUser(ctx)
+	_, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+		ChallengeID: token.Challenge.ID,
+		Code:        utils.NewSecret(recoveryCodes[0]),
+	})
+	s.Require().ErrorIs(err, ErrInvalidTwoFactorCode)
+}
+
+func (s *UserServiceSuite) TestVerifyLoginChallenge_Attempts() {
+	ctx := context.Background()
+	userModel := s.createTwoFactorUser(ctx, model.UserTypeTeacher)
+	subjectCtx := auth.SetSubject(ctx, &auth.Subject{UserID: userModel.ID, UserType: userModel.Type})
+
+	enrollment, err := s.UserService.EnrollTOTP(subjectCtx)
+	s.Require().NoError(err)
+	code, err := totp.Code(enrollment.Secret.Value, totp.Step(time.Now()))
+	s.Require().NoError(err)
+	recoveryCodes, err := s.UserService.ConfirmTOTP(subjectCtx, &ConfirmTOTPParams{Code: utils.NewSecret(code)})
+	s.Require().NoError(err)
+	s.Require().Len(recoveryCodes, recoveryCodeCount)
+
+	token := s.loginTwoFactorUser(ctx)
+	for range maxLoginChallengeAttempts {
+		_, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+			ChallengeID: token.Challenge.ID,
+			Code:        utils.NewSecret("000000"),
+		})
+		s.Require().ErrorIs(err, ErrInvalidTwoFactorCode)
+	}
+
+	_, err = s.UserService.VerifyLoginChallenge(ctx, &VerifyLoginChallengeParams{
+		ChallengeID: token.Challenge.ID,
+		Code:        utils.NewSecret(recoveryCodes[0]),
+	})
+	s.Require().ErrorIs(err, ErrInvalidLoginChallenge)
+
+	err = s.UserService.DisableTOTP(subjectCtx, &DisableTOTPParams{Code: utils.NewSecret(recoveryCodes[1])})
+	s.Require().NoError(err)
+
+	token = s.loginTwoFactorUser(ctx)
+	s.Require().Nil(token.Challenge)
+	s.Require().NotEmpty(token.Value)
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreateAssignmentParams struct {
+	ID                 model.AssignmentID
+	CourseID           model.CourseID
+	Title              string
+	Description        string
+	StarterDiagramID   *model.DiagramID
+	Deadline           *time.Time
+	ReferenceDiagramID *model.DiagramID
+	Rubric             *model.GradingRubric
+	ExamStartsAt       *time.Time
+	ExamEndsAt         *time.Time
+	PeerReview         *model.PeerReviewSettings
+	PeerReviewEndsAt   *time.Time
+	CreatedBy          model.UserID
+}
+
+type PatchAssignmentParams struct {
+	ID model.AssignmentID
+
+	Title              utils.Optional[string]
+	Description        utils.Optional[string]
+	StarterDiagramID   utils.Optional[*model.DiagramID]
+	Deadline           utils.Optional[*time.Time]
+	ReferenceDiagramID utils.Optional[*model.DiagramID]
+	Rubric             utils.Optional[*model.GradingRubric]
+	GradesReleasedAt   utils.Optional[*time.Time]
+	ExamStartsAt       utils.Optional[*time.Time]
+	ExamEndsAt         utils.Optional[*time.Time]
+	PeerReview         utils.Optional[*model.PeerReviewSettings]
+	PeerReviewEndsAt   utils.Optional[*time.Time]
+	// Set when reviewers are assigned, the value can't be reset
+	PeerReviewsAssignedAt utils.Optional[time.Time]
+}
+
+type CreateSubmissionParams struct {
+	ID               model.SubmissionID
+	AssignmentID     model.AssignmentID
+	UserID           model.UserID
+	DiagramID        model.DiagramID
+	ObjectStorageKey string
+	Name             string
+	TablesCount      int64
+}
+
+type PatchSubmissionParams struct {
+	ID model.SubmissionID
+
+	DiagramID        utils.Optional[model.DiagramID]
+	ObjectStorageKey utils.Optional[string]
+	Name             utils.Optional[string]
+	TablesCount      utils.Optional[int64]
+	SubmittedAt      utils.Optional[time.Time]
+	// Sets auto_graded_at to the current time, nil clears it
+	AutoGrading utils.Optional[*model.GradingResult]
+	Grade       utils.Optional[*float64]
+	Feedback    utils.Optional[string]
+	// Sets graded_at to the current time, nil clears it
+	GradedBy utils.Optional[*model.UserID]
+}
+
+type CreatePlagiarismCheckParams struct {
+	AssignmentID model.AssignmentID
+}
+
+type PatchPlagiarismCheckParams struct {
+	AssignmentID model.AssignmentID
+
+	Status      utils.Optional[model.PlagiarismCheckStatus]
+	Error       utils.Optional[string]
+	RequestedAt utils.Optional[time.Time]
+	CheckedAt   utils.Optional[*time.Time]
+}
+
+type CreatePlagiarismPairParams struct {
+	ID                 model.PlagiarismPairID
+	AssignmentID       model.AssignmentID
+	FirstSubmissionID  model.SubmissionID
+	SecondSubmissionID model.SubmissionID
+	Score              float64
+	NamesScore         float64
+	StructureScore     float64
+	LayoutScore        float64
+}
+
+type CreateExamSessionParams struct {
+	ID           model.ExamSessionID
+	AssignmentID model.AssignmentID
+	UserID       model.UserID
+	DiagramID    model.DiagramID
+	EndsAt       time.Time
+}
+
+type PatchExamSessionParams struct {
+	ID model.ExamSessionID
+
+	EndsAt   utils.Optional[time.Time]
+	ClosedAt utils.Optional[*time.Time]
+}
+
+type CreatePeerReviewParams struct {
+	ID           model.PeerReviewID
+	AssignmentID model.AssignmentID
+	SubmissionID model.SubmissionID
+	ReviewerID   model.UserID
+}
+
+type PatchPeerReviewParams struct {
+	ID model.PeerReviewID
+
+	Scores      utils.Optional[[]int64]
+	Comment     utils.Optional[string]
+	SubmittedAt utils.Optional[time.Time]
+	// Sets hidden_at to the current time or resets it with nil
+	HiddenBy utils.Optional[*model.UserID]
+}
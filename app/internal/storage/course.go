@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreateCourseParams struct {
+	ID          model.CourseID
+	Name        string
+	Description string
+	JoinCode    string
+	CreatedBy   model.UserID
+}
+
+type PatchCourseParams struct {
+	ID model.CourseID
+
+	Name        utils.Optional[string]
+	Description utils.Optional[string]
+}
+
+type CreateCourseGroupParams struct {
+	ID       model.CourseGroupID
+	CourseID model.CourseID
+	Code     string
+	JoinCode string
+}
+
+type CreateCourseTeacherParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+}
+
+type CreateCourseEnrollmentParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+	GroupID  *model.CourseGroupID
+}
+
+type PatchCourseEnrollmentParams struct {
+	CourseID model.CourseID
+	UserID   model.UserID
+
+	GroupID utils.Optional[*model.CourseGroupID]
+}
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/IvLaptev/chartdb-back/internal/model"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 )
@@ -21,4 +23,20 @@ type PatchDiagramParams struct {
 	Name             utils.Optional[string]
 	TablesCount      utils.Optional[int64]
 	ObjectStorageKey utils.Optional[string]
+	PublicSlug       utils.Optional[*string]
+	PublishedAt      utils.Optional[*time.Time]
+}
+
+type CreateDiagramActivityParams struct {
+	ID           model.DiagramActivityID
+	UserID       model.UserID
+	DiagramID    model.DiagramID
+	AssignmentID *model.AssignmentID
+	Action       model.DiagramActivityAction
+	LintFindings *int64
+}
+
+type GetDiagramActivityStatsParams struct {
+	UserIDs []model.UserID
+	Since   time.Time
 }
@@ -0,0 +1,19 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+type CreateDiagramEditLockParams struct {
+	DiagramID model.DiagramID
+	UserID    model.UserID
+	HolderID  string
+	Duration  time.Duration
+}
+
+type PatchDiagramEditLockParams struct {
+	DiagramID model.DiagramID
+	Duration  time.Duration
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+type CreateOIDCLoginStateParams struct {
+	ID           model.OIDCLoginStateID
+	Nonce        string
+	CodeVerifier string
+	Duration     time.Duration
+}
+
+type CreateUserIdentityParams struct {
+	ID      model.UserIdentityID
+	UserID  model.UserID
+	Issuer  string
+	Subject string
+	Email   string
+}
@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+)
+
+type CreatePasswordResetParams struct {
+	ID       model.PasswordResetID
+	UserID   model.UserID
+	Duration time.Duration
+}
+
+type PatchPasswordResetParams struct {
+	ID     model.PasswordResetID
+	UsedAt time.Time
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreatePersonalAccessTokenParams struct {
+	ID        model.PersonalAccessTokenID
+	UserID    model.UserID
+	Name      string
+	TokenHash string
+	Scopes    []model.TokenScope
+	ExpiresAt time.Time
+}
+
+type PatchPersonalAccessTokenParams struct {
+	ID model.PersonalAccessTokenID
+
+	LastUsedAt utils.Optional[*time.Time]
+	RevokedAt  utils.Optional[*time.Time]
+}
@@ -1,12 +1,14 @@
 package postgres
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/IvLaptev/chartdb-back/internal/model"
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgtype"
 )
 
 func tableField(table, field string) string {
@@ -26,10 +28,117 @@ type filterableQueryBuilder[QT any] interface {
 	Where(pred interface{}, args ...interface{}) QT
 }
 
+// termExpressions resolves term keys which can't be expressed as a plain column of the filtered table.
+var termExpressions = map[model.TermKey]func(table string, value any) sq.Sqlizer{
+	model.TermKeyTag: func(table string, value any) sq.Sqlizer {
+		return sq.Expr(
+			fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s = ?)",
+				diagramTagTable,
+				tableField(diagramTagTable, fieldDiagramID),
+				tableField(table, fieldID),
+				tableField(diagramTagTable, fieldTag),
+			),
+			value,
+		)
+	},
+	model.TermKeyTeacherID: func(table string, value any) sq.Sqlizer {
+		return sq.Expr(
+			fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s = ?)",
+				courseTeacherTable,
+				tableField(courseTeacherTable, fieldCourseID),
+				tableField(table, fieldID),
+				tableField(courseTeacherTable, fieldUserID),
+			),
+			value,
+		)
+	},
+	model.TermKeyStudentID: func(table string, value any) sq.Sqlizer {
+		return sq.Expr(
+			fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM %s WHERE %s = %s AND %s = ?)",
+				courseEnrollmentTable,
+				tableField(courseEnrollmentTable, fieldCourseID),
+				tableField(table, fieldID),
+				tableField(courseEnrollmentTable, fieldUserID),
+			),
+			value,
+		)
+	},
+	// Submissions the user reviews while the peer review of the assignment is open
+	model.TermKeyAssignedReviewer: func(table string, value any) sq.Sqlizer {
+		return sq.Expr(
+			fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM %s JOIN %s ON %s = %s AND %s IS NULL WHERE %s = %s AND %s = ? AND %s > now())",
+				peerReviewTable,
+				assignmentTable,
+				tableField(assignmentTable, fieldID),
+				tableField(peerReviewTable, fieldAssignmentID),
+				tableField(assignmentTable, fieldDeletedAt),
+				tableField(peerReviewTable, fieldSubmissionID),
+				tableField(table, fieldID),
+				tableField(peerReviewTable, fieldReviewerID),
+				tableField(assignmentTable, fieldPeerReviewEndsAt),
+			),
+			value,
+		)
+	},
+	// Rows owned by the teacher, by students enrolled in courses the teacher teaches
+	// or diagrams submitted to assignments of these courses
+	model.TermKeyVisibleToTeacher: func(table string, value any) sq.Sqlizer {
+		return sq.Or{
+			sq.Eq{tableField(table, fieldUserID): value},
+			sq.Expr(
+				fmt.Sprintf(
+					"EXISTS (SELECT 1 FROM %s JOIN %s ON %s = %s JOIN %s ON %s = %s AND %s IS NULL WHERE %s = %s AND %s = ?)",
+					courseEnrollmentTable,
+					courseTeacherTable,
+					tableField(courseTeacherTable, fieldCourseID),
+					tableField(courseEnrollmentTable, fieldCourseID),
+					courseTable,
+					tableField(courseTable, fieldID),
+					tableField(courseEnrollmentTable, fieldCourseID),
+					tableField(courseTable, fieldDeletedAt),
+					tableField(courseEnrollmentTable, fieldUserID),
+					tableField(table, fieldUserID),
+					tableField(courseTeacherTable, fieldUserID),
+				),
+				value,
+			),
+			sq.Expr(
+				fmt.Sprintf(
+					"EXISTS (SELECT 1 FROM %s JOIN %s ON %s = %s AND %s IS NULL JOIN %s ON %s = %s JOIN %s ON %s = %s AND %s IS NULL WHERE %s = %s AND %s = ?)",
+					submissionTable,
+					assignmentTable,
+					tableField(assignmentTable, fieldID),
+					tableField(submissionTable, fieldAssignmentID),
+					tableField(assignmentTable, fieldDeletedAt),
+					courseTeacherTable,
+					tableField(courseTeacherTable, fieldCourseID),
+					tableField(assignmentTable, fieldCourseID),
+					courseTable,
+					tableField(courseTable, fieldID),
+					tableField(assignmentTable, fieldCourseID),
+					tableField(courseTable, fieldDeletedAt),
+					tableField(submissionTable, fieldDiagramID),
+					tableField(table, fieldID),
+					tableField(courseTeacherTable, fieldUserID),
+				),
+				value,
+			),
+		}
+	},
+}
+
 func filterQuery[T filterableQueryBuilder[T]](query T, table string, filter []*model.FilterTerm) (T, error) {
 	for _, term := range filter {
 		switch term.Operation {
 		case model.FilterOperationExact:
+			if expression, ok := termExpressions[term.Key]; ok {
+				query = query.Where(expression(table, term.Value))
+				continue
+			}
 			termField, err := resolveTermField(term.Key)
 			if err != nil {
 				return query, err
@@ -122,6 +231,46 @@ func resolveTermField(key model.TermKey) (string, error) {
 		return fieldConfirmedAt, nil
 	case model.TermKeyObjectStorageKey:
 		return fieldObjectStorageKey, nil
+	case model.TermKeyPublicSlug:
+		return fieldPublicSlug, nil
+	case model.TermKeyPublishedAt:
+		return fieldPublishedAt, nil
+	case model.TermKeyCourseID:
+		return fieldCourseID, nil
+	case model.TermKeyGroupID:
+		return fieldGroupID, nil
+	case model.TermKeyJoinCode:
+		return fieldJoinCode, nil
+	case model.TermKeyAssignmentID:
+		return fieldAssignmentID, nil
+	case model.TermKeyDiagramID:
+		return fieldDiagramID, nil
+	case model.TermKeyStatus:
+		return fieldStatus, nil
+	case model.TermKeyEndsAt:
+		return fieldEndsAt, nil
+	case model.TermKeyClosedAt:
+		return fieldClosedAt, nil
+	case model.TermKeySubmissionID:
+		return fieldSubmissionID, nil
+	case model.TermKeyReviewerID:
+		return fieldReviewerID, nil
+	case model.TermKeyDeadline:
+		return fieldDeadline, nil
+	case model.TermKeyPeerReviewEndsAt:
+		return fieldPeerReviewEndsAt, nil
+	case model.TermKeyPeerReviewsAssignedAt:
+		return fieldPeerReviewsAssignedAt, nil
+	case model.TermKeyExpiresAt:
+		return fieldExpiresAt, nil
+	case model.TermKeyRevokedAt:
+		return fieldRevokedAt, nil
+	case model.TermKeyIssuer:
+		return fieldIssuer, nil
+	case model.TermKeySubject:
+		return fieldSubject, nil
+	case model.TermKeyUsedAt:
+		return fieldUsedAt, nil
 	default:
 		return "", fmt.Errorf("unsupported termKey type: %d", key)
 	}
@@ -137,3 +286,66 @@ func patchQueryOptional[T any](
 	}
 	return query
 }
+
+// patchQueryJSON sets jsonb column to marshalled value, nil value sets NULL
+func patchQueryJSON[T any](
+	query sq.UpdateBuilder,
+	fieldName string,
+	optValue utils.Optional[*T],
+) (sq.UpdateBuilder, error) {
+	if !optValue.Valid {
+		return query, nil
+	}
+
+	value, err := jsonValue(optValue.Value)
+	if err != nil {
+		return query, fmt.Errorf("json value of %s: %w", fieldName, err)
+	}
+
+	return query.Set(fieldName, value), nil
+}
+
+// jsonValue returns value suitable for jsonb column, it is passed as a string since simple protocol encodes bytes as bytea
+func jsonValue[T any](value *T) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func jsonToModel[T any](data []byte) (*T, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return &value, nil
+}
+
+func textArrayToStrings(array pgtype.TextArray) []string {
+	result := make([]string, 0, len(array.Elements))
+	for _, element := range array.Elements {
+		if element.Status == pgtype.Present {
+			result = append(result, element.String)
+		}
+	}
+	return result
+}
+
+func tableFields(table string, fields []string) []string {
+	result := make([]string, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, tableField(table, field))
+	}
+	return result
+}
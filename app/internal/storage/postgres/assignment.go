@@ -0,0 +1,227 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const assignmentTable = "assignments"
+
+var (
+	assignmentFields = []string{fieldID, fieldCourseID, fieldTitle, fieldDescription, fieldStarterDiagramID,
+		fieldDeadline, fieldReferenceDiagramID, fieldRubric, fieldGradesReleasedAt, fieldExamStartsAt, fieldExamEndsAt,
+		fieldPeerReview, fieldPeerReviewEndsAt, fieldPeerReviewsAssignedAt, fieldCreatedBy, fieldCreatedAt, fieldUpdatedAt, fieldDeletedAt}
+
+	returningAssignment = returning + strings.Join(assignmentFields, separator)
+)
+
+type assignmentEntity struct {
+	ID                    model.AssignmentID `db:"id"`
+	CourseID              model.CourseID     `db:"course_id"`
+	Title                 string             `db:"title"`
+	Description           string             `db:"description"`
+	StarterDiagramID      *model.DiagramID   `db:"starter_diagram_id"`
+	Deadline              *time.Time         `db:"deadline"`
+	ReferenceDiagramID    *model.DiagramID   `db:"reference_diagram_id"`
+	Rubric                []byte             `db:"rubric"`
+	GradesReleasedAt      *time.Time         `db:"grades_released_at"`
+	ExamStartsAt          *time.Time         `db:"exam_starts_at"`
+	ExamEndsAt            *time.Time         `db:"exam_ends_at"`
+	PeerReview            []byte             `db:"peer_review"`
+	PeerReviewEndsAt      *time.Time         `db:"peer_review_ends_at"`
+	PeerReviewsAssignedAt *time.Time         `db:"peer_reviews_assigned_at"`
+	CreatedBy             model.UserID       `db:"created_by"`
+	CreatedAt             time.Time          `db:"created_at"`
+	UpdatedAt             time.Time          `db:"updated_at"`
+	DeletedAt             *time.Time         `db:"deleted_at"`
+}
+
+func (s *Storage) GetAssignmentByID(ctx context.Context, id model.AssignmentID, opts ...storage.RequestOption) (*model.Assignment, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(assignmentFields...).
+		From(assignmentTable).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, assignmentTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity assignmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return assignmentEntityToModel(&entity)
+}
+
+func (s *Storage) GetAllAssignments(ctx context.Context, filter []*model.FilterTerm) ([]*model.Assignment, error) {
+	query := sq.Select(assignmentFields...).
+		From(assignmentTable).
+		Where(sq.Eq{fieldDeletedAt: nil}).
+		OrderBy(fieldCreatedAt + " " + desc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, assignmentTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*assignmentEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.Assignment, 0, len(entities))
+	for _, entity := range entities {
+		assignment, err := assignmentEntityToModel(entity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, assignment)
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateAssignment(ctx context.Context, params *storage.CreateAssignmentParams) (*model.Assignment, error) {
+	now := time.Now()
+
+	rubric, err := jsonValue(params.Rubric)
+	if err != nil {
+		return nil, fmt.Errorf("rubric: %w", err)
+	}
+
+	peerReview, err := jsonValue(params.PeerReview)
+	if err != nil {
+		return nil, fmt.Errorf("peer review: %w", err)
+	}
+
+	sql, args := sq.Insert(assignmentTable).
+		Columns(assignmentFields...).
+		Values(
+			params.ID.String(),
+			params.CourseID.String(),
+			params.Title,
+			params.Description,
+			params.StarterDiagramID,
+			params.Deadline,
+			params.ReferenceDiagramID,
+			rubric,
+			nil,
+			params.ExamStartsAt,
+			params.ExamEndsAt,
+			peerReview,
+			params.PeerReviewEndsAt,
+			nil,
+			params.CreatedBy.String(),
+			now,
+			now,
+			nil,
+		).
+		Suffix(returningAssignment).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity assignmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return assignmentEntityToModel(&entity)
+}
+
+func (s *Storage) PatchAssignment(ctx context.Context, params *storage.PatchAssignmentParams) (*model.Assignment, error) {
+	query := sq.Update(assignmentTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: params.ID.String()}).
+		Suffix(returningAssignment).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldTitle, params.Title)
+	query = patchQueryOptional(query, fieldDescription, params.Description)
+	query = patchQueryOptional(query, fieldStarterDiagramID, params.StarterDiagramID)
+	query = patchQueryOptional(query, fieldDeadline, params.Deadline)
+	query = patchQueryOptional(query, fieldReferenceDiagramID, params.ReferenceDiagramID)
+	query = patchQueryOptional(query, fieldGradesReleasedAt, params.GradesReleasedAt)
+	query = patchQueryOptional(query, fieldExamStartsAt, params.ExamStartsAt)
+	query = patchQueryOptional(query, fieldExamEndsAt, params.ExamEndsAt)
+	query = patchQueryOptional(query, fieldPeerReviewEndsAt, params.PeerReviewEndsAt)
+	query = patchQueryOptional(query, fieldPeerReviewsAssignedAt, params.PeerReviewsAssignedAt)
+	query, err := patchQueryJSON(query, fieldRubric, params.Rubric)
+	if err != nil {
+		return nil, err
+	}
+	query, err = patchQueryJSON(query, fieldPeerReview, params.PeerReview)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := query.MustSql()
+
+	var entity assignmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return assignmentEntityToModel(&entity)
+}
+
+func (s *Storage) DeleteAssignment(ctx context.Context, id model.AssignmentID) (*model.Assignment, error) {
+	sql, args := sq.Update(assignmentTable).
+		Set(fieldDeletedAt, time.Now()).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		Suffix(returningAssignment).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity assignmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return assignmentEntityToModel(&entity)
+}
+
+func assignmentEntityToModel(entity *assignmentEntity) (*model.Assignment, error) {
+	rubric, err := jsonToModel[model.GradingRubric](entity.Rubric)
+	if err != nil {
+		return nil, fmt.Errorf("assignment %s rubric: %w", entity.ID, err)
+	}
+
+	peerReview, err := jsonToModel[model.PeerReviewSettings](entity.PeerReview)
+	if err != nil {
+		return nil, fmt.Errorf("assignment %s peer review: %w", entity.ID, err)
+	}
+
+	return &model.Assignment{
+		ID:                    entity.ID,
+		CourseID:              entity.CourseID,
+		Title:                 entity.Title,
+		Description:           entity.Description,
+		StarterDiagramID:      entity.StarterDiagramID,
+		Deadline:              entity.Deadline,
+		ReferenceDiagramID:    entity.ReferenceDiagramID,
+		Rubric:                rubric,
+		GradesReleasedAt:      entity.GradesReleasedAt,
+		ExamStartsAt:          entity.ExamStartsAt,
+		ExamEndsAt:            entity.ExamEndsAt,
+		PeerReview:            peerReview,
+		PeerReviewEndsAt:      entity.PeerReviewEndsAt,
+		PeerReviewsAssignedAt: entity.PeerReviewsAssignedAt,
+		CreatedBy:             entity.CreatedBy,
+		CreatedAt:             entity.CreatedAt,
+		UpdatedAt:             entity.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const courseTable = "courses"
+
+var (
+	courseFields = []string{fieldID, fieldName, fieldDescription, fieldJoinCode, fieldCreatedBy,
+		fieldCreatedAt, fieldUpdatedAt, fieldDeletedAt}
+
+	returningCourse = returning + strings.Join(courseFields, separator)
+)
+
+type courseEntity struct {
+	ID          model.CourseID `db:"id"`
+	Name        string         `db:"name"`
+	Description string         `db:"description"`
+	JoinCode    string         `db:"join_code"`
+	CreatedBy   model.UserID   `db:"created_by"`
+	CreatedAt   time.Time      `db:"created_at"`
+	UpdatedAt   time.Time      `db:"updated_at"`
+	DeletedAt   *time.Time     `db:"deleted_at"`
+}
+
+func (s *Storage) GetCourseByID(ctx context.Context, id model.CourseID, opts ...storage.RequestOption) (*model.Course, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(courseFields...).
+		From(courseTable).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, courseTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity courseEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllCourses(ctx context.Context, filter []*model.FilterTerm) ([]*model.Course, error) {
+	query := sq.Select(courseFields...).
+		From(courseTable).
+		Where(sq.Eq{fieldDeletedAt: nil}).
+		OrderBy(fieldCreatedAt + " " + desc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, courseTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*courseEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.Course, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, courseEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateCourse(ctx context.Context, params *storage.CreateCourseParams) (*model.Course, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(courseTable).
+		Columns(courseFields...).
+		Values(
+			params.ID.String(),
+			params.Name,
+			params.Description,
+			params.JoinCode,
+			params.CreatedBy.String(),
+			now,
+			now,
+			nil,
+		).
+		Suffix(returningCourse).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchCourse(ctx context.Context, params *storage.PatchCourseParams) (*model.Course, error) {
+	query := sq.Update(courseTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: params.ID.String()}).
+		Suffix(returningCourse).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldName, params.Name)
+	query = patchQueryOptional(query, fieldDescription, params.Description)
+
+	sql, args := query.MustSql()
+
+	var entity courseEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteCourse(ctx context.Context, id model.CourseID) (*model.Course, error) {
+	sql, args := sq.Update(courseTable).
+		Set(fieldDeletedAt, time.Now()).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		Suffix(returningCourse).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEntityToModel(&entity), nil
+}
+
+func courseEntityToModel(entity *courseEntity) *model.Course {
+	return &model.Course{
+		ID:          entity.ID,
+		Name:        entity.Name,
+		Description: entity.Description,
+		JoinCode:    entity.JoinCode,
+		CreatedBy:   entity.CreatedBy,
+		CreatedAt:   entity.CreatedAt,
+		UpdatedAt:   entity.UpdatedAt,
+	}
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const courseEnrollmentTable = "course_enrollments"
+
+var (
+	courseEnrollmentFields = []string{fieldCourseID, fieldUserID, fieldGroupID, fieldCreatedAt, fieldUpdatedAt}
+
+	returningCourseEnrollment = returning + strings.Join(courseEnrollmentFields, separator)
+)
+
+type courseEnrollmentEntity struct {
+	CourseID  model.CourseID       `db:"course_id"`
+	UserID    model.UserID         `db:"user_id"`
+	UserLogin string               `db:"user_login"`
+	GroupID   *model.CourseGroupID `db:"group_id"`
+	CreatedAt time.Time            `db:"created_at"`
+	UpdatedAt time.Time            `db:"updated_at"`
+}
+
+func (s *Storage) GetAllCourseEnrollments(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseEnrollment, error) {
+	query := sq.Select(tableFields(courseEnrollmentTable, courseEnrollmentFields)...).
+		Column(tableField(userTable, fieldLogin) + " AS user_login").
+		From(courseEnrollmentTable).
+		Join(fmt.Sprintf("%s ON %s = %s", userTable, tableField(userTable, fieldID), tableField(courseEnrollmentTable, fieldUserID))).
+		Where(sq.Eq{tableField(userTable, fieldDeletedAt): nil}).
+		OrderBy(tableField(userTable, fieldLogin) + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, courseEnrollmentTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*courseEnrollmentEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.CourseEnrollment, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, courseEnrollmentEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateCourseEnrollment(ctx context.Context, params *storage.CreateCourseEnrollmentParams) (*model.CourseEnrollment, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(courseEnrollmentTable).
+		Columns(courseEnrollmentFields...).
+		Values(
+			params.CourseID.String(),
+			params.UserID.String(),
+			params.GroupID,
+			now,
+			now,
+		).
+		Suffix(returningCourseEnrollment).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseEnrollmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEnrollmentEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchCourseEnrollment(ctx context.Context, params *storage.PatchCourseEnrollmentParams) (*model.CourseEnrollment, error) {
+	query := sq.Update(courseEnrollmentTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldCourseID: params.CourseID.String(), fieldUserID: params.UserID.String()}).
+		Suffix(returningCourseEnrollment).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldGroupID, params.GroupID)
+
+	sql, args := query.MustSql()
+
+	var entity courseEnrollmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEnrollmentEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteCourseEnrollment(ctx context.Context, courseID model.CourseID, userID model.UserID) (*model.CourseEnrollment, error) {
+	sql, args := sq.Delete(courseEnrollmentTable).
+		Where(sq.Eq{fieldCourseID: courseID.String(), fieldUserID: userID.String()}).
+		Suffix(returningCourseEnrollment).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseEnrollmentEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseEnrollmentEntityToModel(&entity), nil
+}
+
+func courseEnrollmentEntityToModel(entity *courseEnrollmentEntity) *model.CourseEnrollment {
+	return &model.CourseEnrollment{
+		CourseID:  entity.CourseID,
+		UserID:    entity.UserID,
+		UserLogin: entity.UserLogin,
+		GroupID:   entity.GroupID,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
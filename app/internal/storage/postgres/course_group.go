@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const courseGroupTable = "course_groups"
+
+var (
+	courseGroupFields = []string{fieldID, fieldCourseID, fieldCode, fieldJoinCode,
+		fieldCreatedAt, fieldUpdatedAt, fieldDeletedAt}
+
+	returningCourseGroup = returning + strings.Join(courseGroupFields, separator)
+)
+
+type courseGroupEntity struct {
+	ID        model.CourseGroupID `db:"id"`
+	CourseID  model.CourseID      `db:"course_id"`
+	Code      string              `db:"code"`
+	JoinCode  string              `db:"join_code"`
+	CreatedAt time.Time           `db:"created_at"`
+	UpdatedAt time.Time           `db:"updated_at"`
+	DeletedAt *time.Time          `db:"deleted_at"`
+}
+
+func (s *Storage) GetCourseGroupByID(ctx context.Context, id model.CourseGroupID) (*model.CourseGroup, error) {
+	sql, args := sq.Select(courseGroupFields...).
+		From(courseGroupTable).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseGroupEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseGroupEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllCourseGroups(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseGroup, error) {
+	query := sq.Select(courseGroupFields...).
+		From(courseGroupTable).
+		Where(sq.Eq{fieldDeletedAt: nil}).
+		OrderBy(fieldCode + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, courseGroupTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*courseGroupEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.CourseGroup, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, courseGroupEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateCourseGroup(ctx context.Context, params *storage.CreateCourseGroupParams) (*model.CourseGroup, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(courseGroupTable).
+		Columns(courseGroupFields...).
+		Values(
+			params.ID.String(),
+			params.CourseID.String(),
+			params.Code,
+			params.JoinCode,
+			now,
+			now,
+			nil,
+		).
+		Suffix(returningCourseGroup).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseGroupEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseGroupEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteCourseGroup(ctx context.Context, id model.CourseGroupID) (*model.CourseGroup, error) {
+	sql, args := sq.Update(courseGroupTable).
+		Set(fieldDeletedAt, time.Now()).
+		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
+		Suffix(returningCourseGroup).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseGroupEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseGroupEntityToModel(&entity), nil
+}
+
+func courseGroupEntityToModel(entity *courseGroupEntity) *model.CourseGroup {
+	return &model.CourseGroup{
+		ID:        entity.ID,
+		CourseID:  entity.CourseID,
+		Code:      entity.Code,
+		JoinCode:  entity.JoinCode,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
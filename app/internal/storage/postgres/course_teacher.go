@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const courseTeacherTable = "course_teachers"
+
+var (
+	courseTeacherFields = []string{fieldCourseID, fieldUserID, fieldCreatedAt}
+
+	returningCourseTeacher = returning + strings.Join(courseTeacherFields, separator)
+)
+
+type courseTeacherEntity struct {
+	CourseID  model.CourseID `db:"course_id"`
+	UserID    model.UserID   `db:"user_id"`
+	UserLogin string         `db:"user_login"`
+	CreatedAt time.Time      `db:"created_at"`
+}
+
+func (s *Storage) GetAllCourseTeachers(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseTeacher, error) {
+	query := sq.Select(tableFields(courseTeacherTable, courseTeacherFields)...).
+		Column(tableField(userTable, fieldLogin) + " AS user_login").
+		From(courseTeacherTable).
+		Join(fmt.Sprintf("%s ON %s = %s", userTable, tableField(userTable, fieldID), tableField(courseTeacherTable, fieldUserID))).
+		OrderBy(tableField(courseTeacherTable, fieldCreatedAt) + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, courseTeacherTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*courseTeacherEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.CourseTeacher, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, courseTeacherEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateCourseTeacher(ctx context.Context, params *storage.CreateCourseTeacherParams) (*model.CourseTeacher, error) {
+	sql, args := sq.Insert(courseTeacherTable).
+		Columns(courseTeacherFields...).
+		Values(
+			params.CourseID.String(),
+			params.UserID.String(),
+			time.Now(),
+		).
+		Suffix(returningCourseTeacher).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseTeacherEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseTeacherEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteCourseTeacher(ctx context.Context, courseID model.CourseID, userID model.UserID) (*model.CourseTeacher, error) {
+	sql, args := sq.Delete(courseTeacherTable).
+		Where(sq.Eq{fieldCourseID: courseID.String(), fieldUserID: userID.String()}).
+		Suffix(returningCourseTeacher).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity courseTeacherEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return courseTeacherEntityToModel(&entity), nil
+}
+
+func courseTeacherEntityToModel(entity *courseTeacherEntity) *model.CourseTeacher {
+	return &model.CourseTeacher{
+		CourseID:  entity.CourseID,
+		UserID:    entity.UserID,
+		UserLogin: entity.UserLogin,
+		CreatedAt: entity.CreatedAt,
+	}
+}
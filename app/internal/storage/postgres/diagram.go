@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/aws/smithy-go/ptr"
+	"github.com/jackc/pgtype"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -18,29 +20,45 @@ const diagramTable = "diagrams"
 
 var (
 	diagramFields = []string{fieldID, fieldUserID, fieldClientDiagramID, fieldCode,
-		fieldObjectStorageKey, fieldName, fieldTablesCount, fieldCreatedAt,
-		fieldUpdatedAt, fieldDeletedAt}
-
-	returningDiagram = returning + strings.Join(diagramFields, separator)
+		fieldObjectStorageKey, fieldName, fieldTablesCount, fieldPublicSlug,
+		fieldPublishedAt, fieldCreatedAt, fieldUpdatedAt, fieldDeletedAt}
+
+	// diagramTagsColumn aggregates diagram tags into a sorted array
+	diagramTagsColumn = fmt.Sprintf(
+		"COALESCE((SELECT array_agg(%s ORDER BY %s) FROM %s WHERE %s = %s), '{}') AS %s",
+		tableField(diagramTagTable, fieldTag),
+		tableField(diagramTagTable, fieldTag),
+		diagramTagTable,
+		tableField(diagramTagTable, fieldDiagramID),
+		tableField(diagramTable, fieldID),
+		fieldTags,
+	)
+
+	diagramColumns = append(slices.Clone(diagramFields), diagramTagsColumn)
+
+	returningDiagram = returning + strings.Join(diagramColumns, separator)
 )
 
 type diagramEntity struct {
-	ID               model.DiagramID `db:"id"`
-	UserID           model.UserID    `db:"user_id"`
-	ClientDiagramID  string          `db:"client_diagram_id"`
-	Code             string          `db:"code"`
-	ObjectStorageKey string          `db:"object_storage_key"`
-	Name             string          `db:"name"`
-	TablesCount      int64           `db:"tables_count"`
-	CreatedAt        time.Time       `db:"created_at"`
-	UpdatedAt        time.Time       `db:"updated_at"`
-	DeletedAt        *time.Time      `db:"deleted_at"`
+	ID               model.DiagramID  `db:"id"`
+	UserID           model.UserID     `db:"user_id"`
+	ClientDiagramID  string           `db:"client_diagram_id"`
+	Code             string           `db:"code"`
+	ObjectStorageKey string           `db:"object_storage_key"`
+	Name             string           `db:"name"`
+	TablesCount      int64            `db:"tables_count"`
+	Tags             pgtype.TextArray `db:"tags"`
+	PublicSlug       *string          `db:"public_slug"`
+	PublishedAt      *time.Time       `db:"published_at"`
+	CreatedAt        time.Time        `db:"created_at"`
+	UpdatedAt        time.Time        `db:"updated_at"`
+	DeletedAt        *time.Time       `db:"deleted_at"`
 }
 
 func (s *Storage) GetDiagramByID(ctx context.Context, rowPolicy storage.RowPolicy, id model.DiagramID, opts ...storage.RequestOption) (*model.Diagram, error) {
 	options := storage.NewOptions(opts)
 
-	query := sq.Select(diagramFields...).
+	query := sq.Select(diagramColumns...).
 		From(diagramTable).
 		Where(sq.Eq{fieldDeletedAt: nil, fieldID: id.String()}).
 		PlaceholderFormat(sq.Dollar)
@@ -66,7 +84,7 @@ func (s *Storage) GetDiagramByID(ctx context.Context, rowPolicy storage.RowPolic
 }
 
 func (s *Storage) GetAllDiagrams(ctx context.Context, rowPolicy storage.RowPolicy, filter []*model.FilterTerm, page *model.CurrentPage) (*model.DiagramList, error) {
-	query := sq.Select(diagramFields...).
+	query := sq.Select(diagramColumns...).
 		Where(sq.Eq{fieldDeletedAt: nil}).
 		From(diagramTable).
 		PlaceholderFormat(sq.Dollar)
@@ -106,6 +124,8 @@ func (s *Storage) CreateDiagram(ctx context.Context, params *storage.CreateDiagr
 			params.ObjectStorageKey,
 			params.Name,
 			params.TablesCount,
+			nil,
+			nil,
 
 			now,
 			now,
@@ -138,6 +158,8 @@ func (s *Storage) PatchDiagram(ctx context.Context, params *storage.PatchDiagram
 	query = patchQueryOptional(query, fieldName, params.Name)
 	query = patchQueryOptional(query, fieldTablesCount, params.TablesCount)
 	query = patchQueryOptional(query, fieldObjectStorageKey, params.ObjectStorageKey)
+	query = patchQueryOptional(query, fieldPublicSlug, params.PublicSlug)
+	query = patchQueryOptional(query, fieldPublishedAt, params.PublishedAt)
 
 	sql, args := query.MustSql()
 
@@ -178,6 +200,9 @@ func diagramEntityToModel(entity *diagramEntity) *model.Diagram {
 		ObjectStorageKey: entity.ObjectStorageKey,
 		Name:             entity.Name,
 		TablesCount:      entity.TablesCount,
+		Tags:             textArrayToStrings(entity.Tags),
+		PublicSlug:       entity.PublicSlug,
+		PublishedAt:      entity.PublishedAt,
 		CreatedAt:        entity.CreatedAt,
 		UpdatedAt:        entity.UpdatedAt,
 		Content:          utils.NewSecret[*string](nil),
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const diagramActivityTable = "diagram_activities"
+
+var (
+	diagramActivityFields = []string{fieldID, fieldUserID, fieldDiagramID, fieldAssignmentID, fieldAction, fieldLintFindings,
+		fieldCreatedAt}
+
+	returningDiagramActivity = returning + strings.Join(diagramActivityFields, separator)
+)
+
+type diagramActivityEntity struct {
+	ID           model.DiagramActivityID     `db:"id"`
+	UserID       model.UserID                `db:"user_id"`
+	DiagramID    model.DiagramID             `db:"diagram_id"`
+	AssignmentID *model.AssignmentID         `db:"assignment_id"`
+	Action       model.DiagramActivityAction `db:"action"`
+	LintFindings *int64                      `db:"lint_findings"`
+	CreatedAt    time.Time                   `db:"created_at"`
+}
+
+type diagramActivityStatsEntity struct {
+	UserID              model.UserID `db:"user_id"`
+	DiagramsCreated     int64        `db:"diagrams_created"`
+	Edits               int64        `db:"edits"`
+	LastActivityAt      *time.Time   `db:"last_activity_at"`
+	AverageLintFindings *float64     `db:"average_lint_findings"`
+}
+
+type dailyEditsEntity struct {
+	Day   time.Time `db:"day"`
+	Edits int64     `db:"edits"`
+}
+
+func (s *Storage) GetAllDiagramActivities(ctx context.Context, filter []*model.FilterTerm) ([]*model.DiagramActivity, error) {
+	query := sq.Select(diagramActivityFields...).
+		From(diagramActivityTable).
+		OrderBy(fieldCreatedAt + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, diagramActivityTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*diagramActivityEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.DiagramActivity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, diagramActivityEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) GetDiagramActivityStats(
+	ctx context.Context,
+	params *storage.GetDiagramActivityStatsParams,
+) ([]*model.DiagramActivityStats, error) {
+	sql, args := sq.Select(fieldUserID).
+		Column(
+			"count(*) filter (where "+fieldAction+" = ? and "+fieldCreatedAt+" >= ?) as diagrams_created",
+			model.DiagramActivityActionCreate.String(), params.Since,
+		).
+		Column(
+			"count(*) filter (where "+fieldAction+" = ? and "+fieldCreatedAt+" >= ?) as edits",
+			model.DiagramActivityActionEdit.String(), params.Since,
+		).
+		Column("max("+fieldCreatedAt+") as last_activity_at").
+		Column(
+			"avg("+fieldLintFindings+") filter (where "+fieldCreatedAt+" >= ?)::double precision as average_lint_findings",
+			params.Since,
+		).
+		From(diagramActivityTable).
+		Where(sq.Eq{fieldUserID: userIDsToStrings(params.UserIDs)}).
+		GroupBy(fieldUserID).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entities []*diagramActivityStatsEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.DiagramActivityStats, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, &model.DiagramActivityStats{
+			UserID:              entity.UserID,
+			DiagramsCreated:     entity.DiagramsCreated,
+			Edits:               entity.Edits,
+			LastActivityAt:      entity.LastActivityAt,
+			AverageLintFindings: entity.AverageLintFindings,
+		})
+	}
+	return result, nil
+}
+
+func (s *Storage) GetDailyEdits(ctx context.Context, params *storage.GetDiagramActivityStatsParams) ([]*model.DailyEdits, error) {
+	day := "date_trunc('day', " + fieldCreatedAt + ")"
+
+	sql, args := sq.Select(day+" as day", "count(*) as edits").
+		From(diagramActivityTable).
+		Where(sq.Eq{
+			fieldUserID: userIDsToStrings(params.UserIDs),
+			fieldAction: model.DiagramActivityActionEdit.String(),
+		}).
+		Where(sq.GtOrEq{fieldCreatedAt: params.Since}).
+		GroupBy(day).
+		OrderBy(day + " " + asc).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entities []*dailyEditsEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.DailyEdits, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, &model.DailyEdits{
+			Day:   entity.Day,
+			Edits: entity.Edits,
+		})
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateDiagramActivity(ctx context.Context, params *storage.CreateDiagramActivityParams) (*model.DiagramActivity, error) {
+	sql, args := sq.Insert(diagramActivityTable).
+		Columns(diagramActivityFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.DiagramID.String(),
+			params.AssignmentID,
+			params.Action.String(),
+			params.LintFindings,
+			time.Now(),
+		).
+		Suffix(returningDiagramActivity).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity diagramActivityEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return diagramActivityEntityToModel(&entity), nil
+}
+
+func userIDsToStrings(ids []model.UserID) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, id.String())
+	}
+	return result
+}
+
+func diagramActivityEntityToModel(entity *diagramActivityEntity) *model.DiagramActivity {
+	return &model.DiagramActivity{
+		ID:           entity.ID,
+		UserID:       entity.UserID,
+		DiagramID:    entity.DiagramID,
+		AssignmentID: entity.AssignmentID,
+		Action:       entity.Action,
+		LintFindings: entity.LintFindings,
+		CreatedAt:    entity.CreatedAt,
+	}
+}
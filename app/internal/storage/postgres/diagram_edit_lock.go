@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const diagramEditLockTable = "diagram_edit_locks"
+
+var (
+	diagramEditLockFields = []string{fieldDiagramID, fieldUserID, fieldHolderID, fieldCreatedAt, fieldExpiresAt}
+
+	returningDiagramEditLock = returning + strings.Join(diagramEditLockFields, separator)
+)
+
+type diagramEditLockEntity struct {
+	DiagramID model.DiagramID `db:"diagram_id"`
+	UserID    model.UserID    `db:"user_id"`
+	HolderID  string          `db:"holder_id"`
+	CreatedAt time.Time       `db:"created_at"`
+	ExpiresAt time.Time       `db:"expires_at"`
+}
+
+func (s *Storage) GetDiagramEditLock(ctx context.Context, diagramID model.DiagramID) (*model.DiagramEditLock, error) {
+	sql, args := sq.Select(diagramEditLockFields...).
+		From(diagramEditLockTable).
+		Where(sq.Eq{fieldDiagramID: diagramID.String()}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity diagramEditLockEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return diagramEditLockEntityToModel(&entity), nil
+}
+
+func (s *Storage) CreateDiagramEditLock(ctx context.Context, params *storage.CreateDiagramEditLockParams) (*model.DiagramEditLock, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(diagramEditLockTable).
+		Columns(diagramEditLockFields...).
+		Values(
+			params.DiagramID.String(),
+			params.UserID.String(),
+			params.HolderID,
+			now,
+			now.Add(params.Duration),
+		).
+		Suffix(returningDiagramEditLock).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity diagramEditLockEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return diagramEditLockEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchDiagramEditLock(ctx context.Context, params *storage.PatchDiagramEditLockParams) (*model.DiagramEditLock, error) {
+	sql, args := sq.Update(diagramEditLockTable).
+		Set(fieldExpiresAt, time.Now().Add(params.Duration)).
+		Where(sq.Eq{fieldDiagramID: params.DiagramID.String()}).
+		Suffix(returningDiagramEditLock).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity diagramEditLockEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return diagramEditLockEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteDiagramEditLock(ctx context.Context, diagramID model.DiagramID) (*model.DiagramEditLock, error) {
+	sql, args := sq.Delete(diagramEditLockTable).
+		Where(sq.Eq{fieldDiagramID: diagramID.String()}).
+		Suffix(returningDiagramEditLock).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity diagramEditLockEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return diagramEditLockEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteExpiredDiagramEditLocks(ctx context.Context, now time.Time) (int64, error) {
+	sql, args := sq.Delete(diagramEditLockTable).
+		Where(sq.LtOrEq{fieldExpiresAt: now}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	result, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, formatError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+func diagramEditLockEntityToModel(entity *diagramEditLockEntity) *model.DiagramEditLock {
+	return &model.DiagramEditLock{
+		DiagramID: entity.DiagramID,
+		UserID:    entity.UserID,
+		HolderID:  entity.HolderID,
+		CreatedAt: entity.CreatedAt,
+		ExpiresAt: entity.ExpiresAt,
+	}
+}
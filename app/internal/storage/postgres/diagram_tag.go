@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	sq "github.com/Masterminds/squirrel"
+)
+
+const diagramTagTable = "diagram_tags"
+
+var (
+	diagramTagFields = []string{fieldDiagramID, fieldTag, fieldCreatedAt}
+)
+
+func (s *Storage) CreateDiagramTags(ctx context.Context, diagramID model.DiagramID, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	query := sq.Insert(diagramTagTable).
+		Columns(diagramTagFields...).
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(sq.Dollar)
+
+	for _, tag := range tags {
+		query = query.Values(diagramID.String(), tag, now)
+	}
+
+	sql, args := query.MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeleteDiagramTags(ctx context.Context, diagramID model.DiagramID, tags []string) error {
+	query := sq.Delete(diagramTagTable).
+		Where(sq.Eq{fieldDiagramID: diagramID.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if len(tags) > 0 {
+		query = query.Where(sq.Eq{fieldTag: tags})
+	}
+
+	sql, args := query.MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
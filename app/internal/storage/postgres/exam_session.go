@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const examSessionTable = "exam_sessions"
+
+var (
+	examSessionFields = []string{fieldID, fieldAssignmentID, fieldUserID, fieldDiagramID, fieldEndsAt, fieldClosedAt,
+		fieldCreatedAt, fieldUpdatedAt}
+
+	returningExamSession = returning + strings.Join(examSessionFields, separator)
+)
+
+type examSessionEntity struct {
+	ID           model.ExamSessionID `db:"id"`
+	AssignmentID model.AssignmentID  `db:"assignment_id"`
+	UserID       model.UserID        `db:"user_id"`
+	DiagramID    model.DiagramID     `db:"diagram_id"`
+	EndsAt       time.Time           `db:"ends_at"`
+	ClosedAt     *time.Time          `db:"closed_at"`
+	CreatedAt    time.Time           `db:"created_at"`
+	UpdatedAt    time.Time           `db:"updated_at"`
+}
+
+func (s *Storage) GetAllExamSessions(ctx context.Context, filter []*model.FilterTerm) ([]*model.ExamSession, error) {
+	query := sq.Select(examSessionFields...).
+		From(examSessionTable).
+		OrderBy(fieldEndsAt + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, examSessionTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*examSessionEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.ExamSession, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, examSessionEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateExamSession(ctx context.Context, params *storage.CreateExamSessionParams) (*model.ExamSession, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(examSessionTable).
+		Columns(examSessionFields...).
+		Values(
+			params.ID.String(),
+			params.AssignmentID.String(),
+			params.UserID.String(),
+			params.DiagramID.String(),
+			params.EndsAt,
+			nil,
+			now,
+			now,
+		).
+		Suffix(returningExamSession).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity examSessionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return examSessionEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchExamSession(ctx context.Context, params *storage.PatchExamSessionParams) (*model.ExamSession, error) {
+	query := sq.Update(examSessionTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningExamSession).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldEndsAt, params.EndsAt)
+	query = patchQueryOptional(query, fieldClosedAt, params.ClosedAt)
+
+	sql, args := query.MustSql()
+
+	var entity examSessionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return examSessionEntityToModel(&entity), nil
+}
+
+func examSessionEntityToModel(entity *examSessionEntity) *model.ExamSession {
+	return &model.ExamSession{
+		ID:           entity.ID,
+		AssignmentID: entity.AssignmentID,
+		UserID:       entity.UserID,
+		DiagramID:    entity.DiagramID,
+		EndsAt:       entity.EndsAt,
+		ClosedAt:     entity.ClosedAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
@@ -8,6 +8,63 @@ const (
 	fieldObjectStorageKey = "object_storage_key"
 	fieldName             = "name"
 	fieldTablesCount      = "tables_count"
+	fieldDiagramID        = "diagram_id"
+	fieldTag              = "tag"
+	fieldTags             = "tags"
+	fieldPublicSlug       = "public_slug"
+	fieldPublishedAt      = "published_at"
+
+	fieldCourseID    = "course_id"
+	fieldGroupID     = "group_id"
+	fieldJoinCode    = "join_code"
+	fieldDescription = "description"
+	fieldCreatedBy   = "created_by"
+
+	fieldAssignmentID     = "assignment_id"
+	fieldTitle            = "title"
+	fieldStarterDiagramID = "starter_diagram_id"
+	fieldDeadline         = "deadline"
+	fieldSubmittedAt      = "submitted_at"
+
+	fieldReferenceDiagramID = "reference_diagram_id"
+	fieldRubric             = "rubric"
+	fieldAutoGrading        = "auto_grading"
+	fieldAutoGradedAt       = "auto_graded_at"
+
+	fieldStatus             = "status"
+	fieldError              = "error"
+	fieldRequestedAt        = "requested_at"
+	fieldCheckedAt          = "checked_at"
+	fieldFirstSubmissionID  = "first_submission_id"
+	fieldSecondSubmissionID = "second_submission_id"
+	fieldScore              = "score"
+	fieldNamesScore         = "names_score"
+	fieldStructureScore     = "structure_score"
+	fieldLayoutScore        = "layout_score"
+
+	fieldGrade            = "grade"
+	fieldFeedback         = "feedback"
+	fieldGradedBy         = "graded_by"
+	fieldGradedAt         = "graded_at"
+	fieldGradesReleasedAt = "grades_released_at"
+
+	fieldExamStartsAt = "exam_starts_at"
+	fieldExamEndsAt   = "exam_ends_at"
+	fieldEndsAt       = "ends_at"
+	fieldClosedAt     = "closed_at"
+
+	fieldPeerReview            = "peer_review"
+	fieldPeerReviewEndsAt      = "peer_review_ends_at"
+	fieldPeerReviewsAssignedAt = "peer_reviews_assigned_at"
+	fieldSubmissionID          = "submission_id"
+	fieldReviewerID            = "reviewer_id"
+	fieldScores                = "scores"
+	fieldComment               = "comment"
+	fieldHiddenBy              = "hidden_by"
+	fieldHiddenAt              = "hidden_at"
+
+	fieldAction       = "action"
+	fieldLintFindings = "lint_findings"
 
 	fieldLogin        = "login"
 	fieldPasswordHash = "password_hash"
@@ -15,6 +72,40 @@ const (
 	fieldConfirmedAt  = "confirmed_at"
 	fieldExpiresAt    = "expires_at"
 
+	fieldHolderID = "holder_id"
+
+	fieldReason          = "reason"
+	fieldDecidedBy       = "decided_by"
+	fieldDecisionComment = "decision_comment"
+	fieldDecidedAt       = "decided_at"
+
+	fieldRefreshTokenHash = "refresh_token_hash"
+	fieldUserAgent        = "user_agent"
+	fieldIPAddress        = "ip_address"
+	fieldRefreshedAt      = "refreshed_at"
+	fieldRevokedAt        = "revoked_at"
+
+	fieldUsedAt = "used_at"
+
+	fieldPurpose = "purpose"
+	fieldDetails = "details"
+
+	fieldNonce        = "nonce"
+	fieldCodeVerifier = "code_verifier"
+	fieldIssuer       = "issuer"
+	fieldSubject      = "subject"
+	fieldEmail        = "email"
+
+	fieldTokenHash  = "token_hash"
+	fieldScopes     = "scopes"
+	fieldLastUsedAt = "last_used_at"
+
+	fieldSecret       = "secret"
+	fieldEnabledAt    = "enabled_at"
+	fieldLastUsedStep = "last_used_step"
+	fieldCodeHash     = "code_hash"
+	fieldAttempts     = "attempts"
+
 	fieldCreatedAt = "created_at"
 	fieldUpdatedAt = "updated_at"
 	fieldDeletedAt = "deleted_at"
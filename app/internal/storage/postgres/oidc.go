@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	oidcLoginStateTable = "oidc_login_states"
+	userIdentityTable   = "user_identities"
+)
+
+var (
+	oidcLoginStateFields = []string{fieldID, fieldNonce, fieldCodeVerifier, fieldCreatedAt, fieldExpiresAt}
+	userIdentityFields   = []string{fieldID, fieldUserID, fieldIssuer, fieldSubject, fieldEmail, fieldCreatedAt}
+
+	returningOIDCLoginState = returning + strings.Join(oidcLoginStateFields, separator)
+	returningUserIdentity   = returning + strings.Join(userIdentityFields, separator)
+)
+
+type oidcLoginStateEntity struct {
+	ID           model.OIDCLoginStateID `db:"id"`
+	Nonce        string                 `db:"nonce"`
+	CodeVerifier string                 `db:"code_verifier"`
+	CreatedAt    time.Time              `db:"created_at"`
+	ExpiresAt    time.Time              `db:"expires_at"`
+}
+
+type userIdentityEntity struct {
+	ID        model.UserIdentityID `db:"id"`
+	UserID    model.UserID         `db:"user_id"`
+	Issuer    string               `db:"issuer"`
+	Subject   string               `db:"subject"`
+	Email     string               `db:"email"`
+	CreatedAt time.Time            `db:"created_at"`
+}
+
+func (s *Storage) CreateOIDCLoginState(ctx context.Context, params *storage.CreateOIDCLoginStateParams) (*model.OIDCLoginState, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(oidcLoginStateTable).
+		Columns(oidcLoginStateFields...).
+		Values(
+			params.ID.String(),
+			params.Nonce,
+			params.CodeVerifier,
+			now,
+			now.Add(params.Duration),
+		).
+		Suffix(returningOIDCLoginState).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity oidcLoginStateEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return oidcLoginStateEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteOIDCLoginState(ctx context.Context, id model.OIDCLoginStateID) (*model.OIDCLoginState, error) {
+	sql, args := sq.Delete(oidcLoginStateTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		Suffix(returningOIDCLoginState).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity oidcLoginStateEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return oidcLoginStateEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteExpiredOIDCLoginStates(ctx context.Context, now time.Time) (int64, error) {
+	sql, args := sq.Delete(oidcLoginStateTable).
+		Where(sq.LtOrEq{fieldExpiresAt: now}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	result, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, formatError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *Storage) GetAllUserIdentities(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserIdentity, error) {
+	query := sq.Select(userIdentityFields...).
+		From(userIdentityTable).
+		OrderBy(fieldCreatedAt).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, userIdentityTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*userIdentityEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.UserIdentity, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, userIdentityEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateUserIdentity(ctx context.Context, params *storage.CreateUserIdentityParams) (*model.UserIdentity, error) {
+	sql, args := sq.Insert(userIdentityTable).
+		Columns(userIdentityFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.Issuer,
+			params.Subject,
+			params.Email,
+			time.Now(),
+		).
+		Suffix(returningUserIdentity).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity userIdentityEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userIdentityEntityToModel(&entity), nil
+}
+
+func oidcLoginStateEntityToModel(entity *oidcLoginStateEntity) *model.OIDCLoginState {
+	return &model.OIDCLoginState{
+		ID:           entity.ID,
+		Nonce:        entity.Nonce,
+		CodeVerifier: utils.NewSecret(entity.CodeVerifier),
+		CreatedAt:    entity.CreatedAt,
+		ExpiresAt:    entity.ExpiresAt,
+	}
+}
+
+func userIdentityEntityToModel(entity *userIdentityEntity) *model.UserIdentity {
+	return &model.UserIdentity{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Issuer:    entity.Issuer,
+		Subject:   entity.Subject,
+		Email:     entity.Email,
+		CreatedAt: entity.CreatedAt,
+	}
+}
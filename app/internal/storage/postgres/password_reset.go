@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const passwordResetTable = "password_resets"
+
+var (
+	passwordResetFields = []string{fieldID, fieldUserID, fieldCreatedAt, fieldExpiresAt, fieldUsedAt}
+
+	returningPasswordReset = returning + strings.Join(passwordResetFields, separator)
+)
+
+type passwordResetEntity struct {
+	ID        model.PasswordResetID `db:"id"`
+	UserID    model.UserID          `db:"user_id"`
+	CreatedAt time.Time             `db:"created_at"`
+	ExpiresAt time.Time             `db:"expires_at"`
+	UsedAt    *time.Time            `db:"used_at"`
+}
+
+func (s *Storage) GetPasswordResetByID(ctx context.Context, id model.PasswordResetID, opts ...storage.RequestOption) (*model.PasswordReset, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(passwordResetFields...).
+		From(passwordResetTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, passwordResetTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity passwordResetEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return passwordResetEntityToModel(&entity), nil
+}
+
+func (s *Storage) CreatePasswordReset(ctx context.Context, params *storage.CreatePasswordResetParams) (*model.PasswordReset, error) {
+	now := time.Now()
+	query := sq.Insert(passwordResetTable).
+		Columns(passwordResetFields...).
+		Values(
+			params.ID,
+			params.UserID,
+			now,
+			now.Add(params.Duration),
+			nil,
+		).
+		Suffix(returningPasswordReset).
+		PlaceholderFormat(sq.Dollar)
+
+	sql, args := query.MustSql()
+
+	var entity passwordResetEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return passwordResetEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchPasswordReset(ctx context.Context, params *storage.PatchPasswordResetParams) (*model.PasswordReset, error) {
+	query := sq.Update(passwordResetTable).
+		Set(fieldUsedAt, params.UsedAt).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningPasswordReset).
+		PlaceholderFormat(sq.Dollar)
+
+	sql, args := query.MustSql()
+
+	var entity passwordResetEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return passwordResetEntityToModel(&entity), nil
+}
+
+func passwordResetEntityToModel(entity *passwordResetEntity) *model.PasswordReset {
+	return &model.PasswordReset{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		CreatedAt: entity.CreatedAt,
+		ExpiresAt: entity.ExpiresAt,
+		UsedAt:    entity.UsedAt,
+	}
+}
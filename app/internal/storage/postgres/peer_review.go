@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const peerReviewTable = "peer_reviews"
+
+var (
+	peerReviewFields = []string{fieldID, fieldAssignmentID, fieldSubmissionID, fieldReviewerID, fieldScores, fieldComment,
+		fieldSubmittedAt, fieldHiddenBy, fieldHiddenAt, fieldCreatedAt, fieldUpdatedAt}
+
+	returningPeerReview = returning + strings.Join(peerReviewFields, separator)
+)
+
+type peerReviewEntity struct {
+	ID           model.PeerReviewID `db:"id"`
+	AssignmentID model.AssignmentID `db:"assignment_id"`
+	SubmissionID model.SubmissionID `db:"submission_id"`
+	ReviewerID   model.UserID       `db:"reviewer_id"`
+	Scores       []byte             `db:"scores"`
+	Comment      string             `db:"comment"`
+	SubmittedAt  *time.Time         `db:"submitted_at"`
+	HiddenBy     *model.UserID      `db:"hidden_by"`
+	HiddenAt     *time.Time         `db:"hidden_at"`
+	CreatedAt    time.Time          `db:"created_at"`
+	UpdatedAt    time.Time          `db:"updated_at"`
+}
+
+func (s *Storage) GetPeerReviewByID(ctx context.Context, id model.PeerReviewID, opts ...storage.RequestOption) (*model.PeerReview, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(peerReviewFields...).
+		From(peerReviewTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, peerReviewTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity peerReviewEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return peerReviewEntityToModel(&entity)
+}
+
+func (s *Storage) GetAllPeerReviews(ctx context.Context, filter []*model.FilterTerm) ([]*model.PeerReview, error) {
+	query := sq.Select(peerReviewFields...).
+		From(peerReviewTable).
+		OrderBy(fieldCreatedAt+" "+asc, fieldID+" "+asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, peerReviewTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*peerReviewEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.PeerReview, 0, len(entities))
+	for _, entity := range entities {
+		peerReview, err := peerReviewEntityToModel(entity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, peerReview)
+	}
+	return result, nil
+}
+
+func (s *Storage) CreatePeerReview(ctx context.Context, params *storage.CreatePeerReviewParams) (*model.PeerReview, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(peerReviewTable).
+		Columns(peerReviewFields...).
+		Values(
+			params.ID.String(),
+			params.AssignmentID.String(),
+			params.SubmissionID.String(),
+			params.ReviewerID.String(),
+			nil,
+			"",
+			nil,
+			nil,
+			nil,
+			now,
+			now,
+		).
+		Suffix(returningPeerReview).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity peerReviewEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return peerReviewEntityToModel(&entity)
+}
+
+func (s *Storage) PatchPeerReview(ctx context.Context, params *storage.PatchPeerReviewParams) (*model.PeerReview, error) {
+	query := sq.Update(peerReviewTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningPeerReview).
+		PlaceholderFormat(sq.Dollar)
+
+	if params.Scores.Valid {
+		scores, err := jsonValue(&params.Scores.Value)
+		if err != nil {
+			return nil, fmt.Errorf("scores: %w", err)
+		}
+		query = query.Set(fieldScores, scores)
+	}
+	query = patchQueryOptional(query, fieldComment, params.Comment)
+	query = patchQueryOptional(query, fieldSubmittedAt, params.SubmittedAt)
+	query = patchQueryOptional(query, fieldHiddenBy, params.HiddenBy)
+	if params.HiddenBy.Valid {
+		var hiddenAt *time.Time
+		if params.HiddenBy.Value != nil {
+			now := time.Now()
+			hiddenAt = &now
+		}
+		query = query.Set(fieldHiddenAt, hiddenAt)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity peerReviewEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return peerReviewEntityToModel(&entity)
+}
+
+func peerReviewEntityToModel(entity *peerReviewEntity) (*model.PeerReview, error) {
+	scores, err := jsonToModel[[]int64](entity.Scores)
+	if err != nil {
+		return nil, fmt.Errorf("peer review %s scores: %w", entity.ID, err)
+	}
+
+	peerReview := &model.PeerReview{
+		ID:           entity.ID,
+		AssignmentID: entity.AssignmentID,
+		SubmissionID: entity.SubmissionID,
+		ReviewerID:   entity.ReviewerID,
+		Comment:      entity.Comment,
+		SubmittedAt:  entity.SubmittedAt,
+		HiddenBy:     entity.HiddenBy,
+		HiddenAt:     entity.HiddenAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+	if scores != nil {
+		peerReview.Scores = *scores
+	}
+
+	return peerReview, nil
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	personalAccessTokenTable = "personal_access_tokens"
+
+	// Scopes are stored as a space-separated list like OAuth scopes
+	scopeSeparator = " "
+)
+
+var (
+	personalAccessTokenFields = []string{fieldID, fieldUserID, fieldName, fieldTokenHash, fieldScopes,
+		fieldCreatedAt, fieldExpiresAt, fieldLastUsedAt, fieldRevokedAt}
+
+	returningPersonalAccessToken = returning + strings.Join(personalAccessTokenFields, separator)
+)
+
+type personalAccessTokenEntity struct {
+	ID         model.PersonalAccessTokenID `db:"id"`
+	UserID     model.UserID                `db:"user_id"`
+	Name       string                      `db:"name"`
+	TokenHash  string                      `db:"token_hash"`
+	Scopes     string                      `db:"scopes"`
+	CreatedAt  time.Time                   `db:"created_at"`
+	ExpiresAt  time.Time                   `db:"expires_at"`
+	LastUsedAt *time.Time                  `db:"last_used_at"`
+	RevokedAt  *time.Time                  `db:"revoked_at"`
+}
+
+func (s *Storage) GetPersonalAccessTokenByID(ctx context.Context, id model.PersonalAccessTokenID, opts ...storage.RequestOption) (*model.PersonalAccessToken, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(personalAccessTokenFields...).
+		From(personalAccessTokenTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, personalAccessTokenTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity personalAccessTokenEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return personalAccessTokenEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllPersonalAccessTokens(ctx context.Context, filter []*model.FilterTerm) ([]*model.PersonalAccessToken, error) {
+	query := sq.Select(personalAccessTokenFields...).
+		From(personalAccessTokenTable).
+		OrderBy(fieldCreatedAt + " " + desc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, personalAccessTokenTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*personalAccessTokenEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.PersonalAccessToken, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, personalAccessTokenEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreatePersonalAccessToken(ctx context.Context, params *storage.CreatePersonalAccessTokenParams) (*model.PersonalAccessToken, error) {
+	scopes := make([]string, 0, len(params.Scopes))
+	for _, scope := range params.Scopes {
+		scopes = append(scopes, scope.String())
+	}
+
+	sql, args := sq.Insert(personalAccessTokenTable).
+		Columns(personalAccessTokenFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.Name,
+			params.TokenHash,
+			strings.Join(scopes, scopeSeparator),
+			time.Now(),
+			params.ExpiresAt,
+			nil,
+			nil,
+		).
+		Suffix(returningPersonalAccessToken).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity personalAccessTokenEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return personalAccessTokenEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchPersonalAccessToken(ctx context.Context, params *storage.PatchPersonalAccessTokenParams) (*model.PersonalAccessToken, error) {
+	query := sq.Update(personalAccessTokenTable).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningPersonalAccessToken).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldLastUsedAt, params.LastUsedAt)
+	query = patchQueryOptional(query, fieldRevokedAt, params.RevokedAt)
+
+	sql, args := query.MustSql()
+
+	var entity personalAccessTokenEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return personalAccessTokenEntityToModel(&entity), nil
+}
+
+func personalAccessTokenEntityToModel(entity *personalAccessTokenEntity) *model.PersonalAccessToken {
+	var scopes []model.TokenScope
+	for _, scope := range strings.Fields(entity.Scopes) {
+		scopes = append(scopes, model.TokenScope(scope))
+	}
+
+	return &model.PersonalAccessToken{
+		ID:         entity.ID,
+		UserID:     entity.UserID,
+		Name:       entity.Name,
+		TokenHash:  utils.NewSecret(entity.TokenHash),
+		Scopes:     scopes,
+		CreatedAt:  entity.CreatedAt,
+		ExpiresAt:  entity.ExpiresAt,
+		LastUsedAt: entity.LastUsedAt,
+		RevokedAt:  entity.RevokedAt,
+	}
+}
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const plagiarismCheckTable = "plagiarism_checks"
+
+var (
+	plagiarismCheckFields = []string{fieldAssignmentID, fieldStatus, fieldError, fieldRequestedAt, fieldCheckedAt,
+		fieldCreatedAt, fieldUpdatedAt}
+
+	returningPlagiarismCheck = returning + strings.Join(plagiarismCheckFields, separator)
+)
+
+type plagiarismCheckEntity struct {
+	AssignmentID model.AssignmentID          `db:"assignment_id"`
+	Status       model.PlagiarismCheckStatus `db:"status"`
+	Error        string                      `db:"error"`
+	RequestedAt  time.Time                   `db:"requested_at"`
+	CheckedAt    *time.Time                  `db:"checked_at"`
+	CreatedAt    time.Time                   `db:"created_at"`
+	UpdatedAt    time.Time                   `db:"updated_at"`
+}
+
+func (s *Storage) GetPlagiarismCheck(ctx context.Context, assignmentID model.AssignmentID, opts ...storage.RequestOption) (*model.PlagiarismCheck, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(plagiarismCheckFields...).
+		From(plagiarismCheckTable).
+		Where(sq.Eq{fieldAssignmentID: assignmentID.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, plagiarismCheckTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity plagiarismCheckEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return plagiarismCheckEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllPlagiarismChecks(ctx context.Context, filter []*model.FilterTerm) ([]*model.PlagiarismCheck, error) {
+	query := sq.Select(plagiarismCheckFields...).
+		From(plagiarismCheckTable).
+		OrderBy(fieldRequestedAt + " " + asc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, plagiarismCheckTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*plagiarismCheckEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.PlagiarismCheck, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, plagiarismCheckEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreatePlagiarismCheck(ctx context.Context, params *storage.CreatePlagiarismCheckParams) (*model.PlagiarismCheck, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(plagiarismCheckTable).
+		Columns(plagiarismCheckFields...).
+		Values(
+			params.AssignmentID.String(),
+			model.PlagiarismCheckStatusPending.String(),
+			"",
+			now,
+			nil,
+			now,
+			now,
+		).
+		Suffix(returningPlagiarismCheck).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity plagiarismCheckEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return plagiarismCheckEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchPlagiarismCheck(ctx context.Context, params *storage.PatchPlagiarismCheckParams) (*model.PlagiarismCheck, error) {
+	query := sq.Update(plagiarismCheckTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldAssignmentID: params.AssignmentID.String()}).
+		Suffix(returningPlagiarismCheck).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldStatus, params.Status)
+	query = patchQueryOptional(query, fieldError, params.Error)
+	query = patchQueryOptional(query, fieldRequestedAt, params.RequestedAt)
+	query = patchQueryOptional(query, fieldCheckedAt, params.CheckedAt)
+
+	sql, args := query.MustSql()
+
+	var entity plagiarismCheckEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return plagiarismCheckEntityToModel(&entity), nil
+}
+
+func (s *Storage) RequestOutdatedPlagiarismChecks(ctx context.Context, now time.Time) (int64, error) {
+	outdated := sq.Select().
+		Column(tableField(assignmentTable, fieldID)).
+		Column("?", model.PlagiarismCheckStatusPending.String()).
+		Column("?", "").
+		Column("?", now).
+		Column("NULL").
+		Column("?", now).
+		Column("?", now).
+		From(assignmentTable).
+		LeftJoin(fmt.Sprintf("%s ON %s = %s",
+			plagiarismCheckTable,
+			tableField(plagiarismCheckTable, fieldAssignmentID),
+			tableField(assignmentTable, fieldID),
+		)).
+		Where(sq.Eq{tableField(assignmentTable, fieldDeletedAt): nil}).
+		Where(sq.Or{
+			sq.Eq{tableField(plagiarismCheckTable, fieldStatus): nil},
+			sq.NotEq{tableField(plagiarismCheckTable, fieldStatus): model.PlagiarismCheckStatusPending.String()},
+		}).
+		Where(fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM %s WHERE %s = %s AND (%s IS NULL OR %s > %s))",
+			submissionTable,
+			tableField(submissionTable, fieldAssignmentID),
+			tableField(assignmentTable, fieldID),
+			tableField(plagiarismCheckTable, fieldCheckedAt),
+			tableField(submissionTable, fieldSubmittedAt),
+			tableField(plagiarismCheckTable, fieldCheckedAt),
+		))
+
+	sql, args := sq.Insert(plagiarismCheckTable).
+		Columns(plagiarismCheckFields...).
+		Select(outdated).
+		Suffix(fmt.Sprintf(
+			"ON CONFLICT (%s) DO UPDATE SET %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s, %s = EXCLUDED.%s",
+			fieldAssignmentID,
+			fieldStatus, fieldStatus,
+			fieldError, fieldError,
+			fieldRequestedAt, fieldRequestedAt,
+			fieldUpdatedAt, fieldUpdatedAt,
+		)).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	result, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, formatError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+func plagiarismCheckEntityToModel(entity *plagiarismCheckEntity) *model.PlagiarismCheck {
+	return &model.PlagiarismCheck{
+		AssignmentID: entity.AssignmentID,
+		Status:       entity.Status,
+		Error:        entity.Error,
+		RequestedAt:  entity.RequestedAt,
+		CheckedAt:    entity.CheckedAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
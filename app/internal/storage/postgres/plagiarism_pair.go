@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	plagiarismPairTable = "plagiarism_pairs"
+
+	firstSubmissionAlias  = "first_submissions"
+	secondSubmissionAlias = "second_submissions"
+	firstUserAlias        = "first_users"
+	secondUserAlias       = "second_users"
+)
+
+var (
+	plagiarismPairFields = []string{fieldID, fieldAssignmentID, fieldFirstSubmissionID, fieldSecondSubmissionID,
+		fieldScore, fieldNamesScore, fieldStructureScore, fieldLayoutScore, fieldCreatedAt}
+)
+
+type plagiarismPairEntity struct {
+	ID                 model.PlagiarismPairID `db:"id"`
+	AssignmentID       model.AssignmentID     `db:"assignment_id"`
+	FirstSubmissionID  model.SubmissionID     `db:"first_submission_id"`
+	FirstUserID        model.UserID           `db:"first_user_id"`
+	FirstUserLogin     string                 `db:"first_user_login"`
+	SecondSubmissionID model.SubmissionID     `db:"second_submission_id"`
+	SecondUserID       model.UserID           `db:"second_user_id"`
+	SecondUserLogin    string                 `db:"second_user_login"`
+	Score              float64                `db:"score"`
+	NamesScore         float64                `db:"names_score"`
+	StructureScore     float64                `db:"structure_score"`
+	LayoutScore        float64                `db:"layout_score"`
+	CreatedAt          time.Time              `db:"created_at"`
+}
+
+// selectPlagiarismPairs joins authors of both submissions
+func selectPlagiarismPairs() sq.SelectBuilder {
+	return sq.Select(tableFields(plagiarismPairTable, plagiarismPairFields)...).
+		Column(tableField(firstSubmissionAlias, fieldUserID) + " AS first_user_id").
+		Column(tableField(firstUserAlias, fieldLogin) + " AS first_user_login").
+		Column(tableField(secondSubmissionAlias, fieldUserID) + " AS second_user_id").
+		Column(tableField(secondUserAlias, fieldLogin) + " AS second_user_login").
+		From(plagiarismPairTable).
+		Join(fmt.Sprintf("%s AS %s ON %s = %s", submissionTable, firstSubmissionAlias,
+			tableField(firstSubmissionAlias, fieldID), tableField(plagiarismPairTable, fieldFirstSubmissionID))).
+		Join(fmt.Sprintf("%s AS %s ON %s = %s", userTable, firstUserAlias,
+			tableField(firstUserAlias, fieldID), tableField(firstSubmissionAlias, fieldUserID))).
+		Join(fmt.Sprintf("%s AS %s ON %s = %s", submissionTable, secondSubmissionAlias,
+			tableField(secondSubmissionAlias, fieldID), tableField(plagiarismPairTable, fieldSecondSubmissionID))).
+		Join(fmt.Sprintf("%s AS %s ON %s = %s", userTable, secondUserAlias,
+			tableField(secondUserAlias, fieldID), tableField(secondSubmissionAlias, fieldUserID))).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *Storage) GetAllPlagiarismPairs(ctx context.Context, filter []*model.FilterTerm) ([]*model.PlagiarismPair, error) {
+	query := selectPlagiarismPairs().
+		OrderBy(tableField(plagiarismPairTable, fieldScore) + " " + desc)
+
+	query, err := filterQuery(query, plagiarismPairTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*plagiarismPairEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.PlagiarismPair, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, plagiarismPairEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreatePlagiarismPairs(ctx context.Context, params []*storage.CreatePlagiarismPairParams) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	query := sq.Insert(plagiarismPairTable).
+		Columns(plagiarismPairFields...).
+		PlaceholderFormat(sq.Dollar)
+
+	for _, pair := range params {
+		query = query.Values(
+			pair.ID.String(),
+			pair.AssignmentID.String(),
+			pair.FirstSubmissionID.String(),
+			pair.SecondSubmissionID.String(),
+			pair.Score,
+			pair.NamesScore,
+			pair.StructureScore,
+			pair.LayoutScore,
+			now,
+		)
+	}
+
+	sql, args := query.MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
+
+func (s *Storage) DeletePlagiarismPairs(ctx context.Context, assignmentID model.AssignmentID) error {
+	sql, args := sq.Delete(plagiarismPairTable).
+		Where(sq.Eq{fieldAssignmentID: assignmentID.String()}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
+
+func plagiarismPairEntityToModel(entity *plagiarismPairEntity) *model.PlagiarismPair {
+	return &model.PlagiarismPair{
+		ID:                 entity.ID,
+		AssignmentID:       entity.AssignmentID,
+		FirstSubmissionID:  entity.FirstSubmissionID,
+		FirstUserID:        entity.FirstUserID,
+		FirstUserLogin:     entity.FirstUserLogin,
+		SecondSubmissionID: entity.SecondSubmissionID,
+		SecondUserID:       entity.SecondUserID,
+		SecondUserLogin:    entity.SecondUserLogin,
+		Score:              entity.Score,
+		NamesScore:         entity.NamesScore,
+		StructureScore:     entity.StructureScore,
+		LayoutScore:        entity.LayoutScore,
+		CreatedAt:          entity.CreatedAt,
+	}
+}
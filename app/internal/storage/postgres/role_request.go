@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const roleRequestTable = "role_requests"
+
+var (
+	roleRequestFields = []string{fieldID, fieldUserID, fieldType, fieldReason, fieldStatus, fieldDecidedBy,
+		fieldDecisionComment, fieldDecidedAt, fieldCreatedAt, fieldUpdatedAt}
+
+	roleRequestUserLoginColumn = tableField(userTable, fieldLogin) + " AS user_login"
+
+	returningRoleRequest = returning + strings.Join(roleRequestFields, separator)
+)
+
+type roleRequestEntity struct {
+	ID              model.RoleRequestID `db:"id"`
+	UserID          model.UserID        `db:"user_id"`
+	UserLogin       string              `db:"user_login"`
+	Type            string              `db:"type"`
+	Reason          string              `db:"reason"`
+	Status          string              `db:"status"`
+	DecidedBy       *model.UserID       `db:"decided_by"`
+	DecisionComment string              `db:"decision_comment"`
+	DecidedAt       *time.Time          `db:"decided_at"`
+	CreatedAt       time.Time           `db:"created_at"`
+	UpdatedAt       time.Time           `db:"updated_at"`
+}
+
+func selectRoleRequests() sq.SelectBuilder {
+	return sq.Select(tableFields(roleRequestTable, roleRequestFields)...).
+		Column(roleRequestUserLoginColumn).
+		From(roleRequestTable).
+		Join(fmt.Sprintf("%s ON %s = %s", userTable, tableField(userTable, fieldID), tableField(roleRequestTable, fieldUserID))).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *Storage) GetRoleRequestByID(ctx context.Context, id model.RoleRequestID, opts ...storage.RequestOption) (*model.RoleRequest, error) {
+	options := storage.NewOptions(opts)
+
+	query := selectRoleRequests().
+		Where(sq.Eq{tableField(roleRequestTable, fieldID): id.String()})
+
+	if options.UseLock {
+		query = useLock(query, roleRequestTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity roleRequestEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return roleRequestEntityToModel(&entity)
+}
+
+func (s *Storage) GetAllRoleRequests(ctx context.Context, filter []*model.FilterTerm) ([]*model.RoleRequest, error) {
+	query := selectRoleRequests().
+		OrderBy(tableField(roleRequestTable, fieldCreatedAt) + " " + desc)
+
+	query, err := filterQuery(query, roleRequestTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*roleRequestEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.RoleRequest, 0, len(entities))
+	for _, entity := range entities {
+		roleRequest, err := roleRequestEntityToModel(entity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, roleRequest)
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateRoleRequest(ctx context.Context, params *storage.CreateRoleRequestParams) (*model.RoleRequest, error) {
+	now := time.Now()
+
+	query := sq.Insert(roleRequestTable).
+		Columns(roleRequestFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.Type.String(),
+			params.Reason,
+			model.RoleRequestStatusPending.String(),
+			nil,
+			"",
+			nil,
+			now,
+			now,
+		).
+		Suffix(returningRoleRequest).
+		PlaceholderFormat(sq.Dollar)
+
+	sql, args := query.MustSql()
+
+	var entity roleRequestEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return roleRequestEntityToModel(&entity)
+}
+
+func (s *Storage) PatchRoleRequest(ctx context.Context, params *storage.PatchRoleRequestParams) (*model.RoleRequest, error) {
+	now := time.Now()
+
+	query := sq.Update(roleRequestTable).
+		Set(fieldUpdatedAt, now).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningRoleRequest).
+		PlaceholderFormat(sq.Dollar)
+
+	if params.Status.Valid {
+		query = query.Set(fieldStatus, params.Status.Value.String())
+		query = query.Set(fieldDecidedAt, now)
+	}
+	query = patchQueryOptional(query, fieldDecidedBy, params.DecidedBy)
+	query = patchQueryOptional(query, fieldDecisionComment, params.DecisionComment)
+
+	sql, args := query.MustSql()
+
+	var entity roleRequestEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return roleRequestEntityToModel(&entity)
+}
+
+func roleRequestEntityToModel(entity *roleRequestEntity) (*model.RoleRequest, error) {
+	userType, err := model.UserTypeFromString(entity.Type)
+	if err != nil {
+		return nil, fmt.Errorf("role request %s: %w", entity.ID, err)
+	}
+
+	return &model.RoleRequest{
+		ID:              entity.ID,
+		UserID:          entity.UserID,
+		UserLogin:       entity.UserLogin,
+		Type:            userType,
+		Reason:          entity.Reason,
+		Status:          model.RoleRequestStatus(entity.Status),
+		DecidedBy:       entity.DecidedBy,
+		DecisionComment: entity.DecisionComment,
+		DecidedAt:       entity.DecidedAt,
+		CreatedAt:       entity.CreatedAt,
+		UpdatedAt:       entity.UpdatedAt,
+	}, nil
+}
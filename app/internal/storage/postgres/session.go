@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const sessionTable = "sessions"
+
+var (
+	sessionFields = []string{fieldID, fieldUserID, fieldRefreshTokenHash, fieldUserAgent, fieldIPAddress,
+		fieldCreatedAt, fieldRefreshedAt, fieldExpiresAt, fieldRevokedAt}
+
+	returningSession = returning + strings.Join(sessionFields, separator)
+)
+
+type sessionEntity struct {
+	ID               model.SessionID `db:"id"`
+	UserID           model.UserID    `db:"user_id"`
+	RefreshTokenHash string          `db:"refresh_token_hash"`
+	UserAgent        string          `db:"user_agent"`
+	IPAddress        string          `db:"ip_address"`
+	CreatedAt        time.Time       `db:"created_at"`
+	RefreshedAt      time.Time       `db:"refreshed_at"`
+	ExpiresAt        time.Time       `db:"expires_at"`
+	RevokedAt        *time.Time      `db:"revoked_at"`
+}
+
+func (s *Storage) GetSessionByID(ctx context.Context, id model.SessionID, opts ...storage.RequestOption) (*model.Session, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(sessionFields...).
+		From(sessionTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, sessionTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity sessionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return sessionEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllSessions(ctx context.Context, filter []*model.FilterTerm) ([]*model.Session, error) {
+	query := sq.Select(sessionFields...).
+		From(sessionTable).
+		OrderBy(fieldRefreshedAt + " " + desc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, sessionTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*sessionEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.Session, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, sessionEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateSession(ctx context.Context, params *storage.CreateSessionParams) (*model.Session, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(sessionTable).
+		Columns(sessionFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.RefreshTokenHash,
+			params.UserAgent,
+			params.IPAddress,
+			now,
+			now,
+			params.ExpiresAt,
+			nil,
+		).
+		Suffix(returningSession).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity sessionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return sessionEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchSession(ctx context.Context, params *storage.PatchSessionParams) (*model.Session, error) {
+	query := sq.Update(sessionTable).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningSession).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldRefreshTokenHash, params.RefreshTokenHash)
+	if params.RefreshTokenHash.Valid {
+		query = query.Set(fieldRefreshedAt, time.Now())
+	}
+	query = patchQueryOptional(query, fieldExpiresAt, params.ExpiresAt)
+	query = patchQueryOptional(query, fieldRevokedAt, params.RevokedAt)
+
+	sql, args := query.MustSql()
+
+	var entity sessionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return sessionEntityToModel(&entity), nil
+}
+
+func sessionEntityToModel(entity *sessionEntity) *model.Session {
+	return &model.Session{
+		ID:               entity.ID,
+		UserID:           entity.UserID,
+		RefreshTokenHash: utils.NewSecret(entity.RefreshTokenHash),
+		UserAgent:        entity.UserAgent,
+		IPAddress:        entity.IPAddress,
+		CreatedAt:        entity.CreatedAt,
+		RefreshedAt:      entity.RefreshedAt,
+		ExpiresAt:        entity.ExpiresAt,
+		RevokedAt:        entity.RevokedAt,
+	}
+}
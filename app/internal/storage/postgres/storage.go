@@ -97,6 +97,14 @@ func (s *Storage) Diagram() storage.DiagramRepository {
 	return s
 }
 
+func (s *Storage) DiagramTag() storage.DiagramTagRepository {
+	return s
+}
+
+func (s *Storage) DiagramEditLock() storage.DiagramEditLockRepository {
+	return s
+}
+
 func (s *Storage) User() storage.UserRepository {
 	return s
 }
@@ -105,6 +113,90 @@ func (s *Storage) UserConfirmation() storage.UserConfirmationRepository {
 	return s
 }
 
+func (s *Storage) Course() storage.CourseRepository {
+	return s
+}
+
+func (s *Storage) CourseGroup() storage.CourseGroupRepository {
+	return s
+}
+
+func (s *Storage) CourseTeacher() storage.CourseTeacherRepository {
+	return s
+}
+
+func (s *Storage) CourseEnrollment() storage.CourseEnrollmentRepository {
+	return s
+}
+
+func (s *Storage) Assignment() storage.AssignmentRepository {
+	return s
+}
+
+func (s *Storage) Submission() storage.SubmissionRepository {
+	return s
+}
+
+func (s *Storage) PlagiarismCheck() storage.PlagiarismCheckRepository {
+	return s
+}
+
+func (s *Storage) PlagiarismPair() storage.PlagiarismPairRepository {
+	return s
+}
+
+func (s *Storage) ExamSession() storage.ExamSessionRepository {
+	return s
+}
+
+func (s *Storage) DiagramActivity() storage.DiagramActivityRepository {
+	return s
+}
+
+func (s *Storage) PeerReview() storage.PeerReviewRepository {
+	return s
+}
+
+func (s *Storage) RoleRequest() storage.RoleRequestRepository {
+	return s
+}
+
+func (s *Storage) Session() storage.SessionRepository {
+	return s
+}
+
+func (s *Storage) PasswordReset() storage.PasswordResetRepository {
+	return s
+}
+
+func (s *Storage) UserAuditEntry() storage.UserAuditEntryRepository {
+	return s
+}
+
+func (s *Storage) OIDCLoginState() storage.OIDCLoginStateRepository {
+	return s
+}
+
+func (s *Storage) UserIdentity() storage.UserIdentityRepository {
+	return s
+}
+
+func (s *Storage) PersonalAccessToken() storage.PersonalAccessTokenRepository {
+	return s
+}
+
+func (s *Storage) UserTOTP() storage.UserTOTPRepository {
+	return s
+}
+
+func (s *Storage) UserRecoveryCode() storage.UserRecoveryCodeRepository {
+	return s
+}
+
+func (s *Storage) LoginChallenge() storage.LoginChallengeRepository {
+	return s
+}
+
 func handleTx(tx *sqlx.Tx, err error) error {
 	if err != nil {
 		return tx.Rollback()
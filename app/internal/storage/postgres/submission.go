@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const submissionTable = "submissions"
+
+var (
+	submissionFields = []string{fieldID, fieldAssignmentID, fieldUserID, fieldDiagramID, fieldObjectStorageKey,
+		fieldName, fieldTablesCount, fieldAutoGrading, fieldAutoGradedAt, fieldGrade, fieldFeedback, fieldGradedBy, fieldGradedAt,
+		fieldSubmittedAt, fieldCreatedAt, fieldUpdatedAt}
+
+	submissionUserLoginColumn = tableField(userTable, fieldLogin) + " AS user_login"
+
+	returningSubmission = returning + strings.Join(submissionFields, separator)
+)
+
+type submissionEntity struct {
+	ID               model.SubmissionID `db:"id"`
+	AssignmentID     model.AssignmentID `db:"assignment_id"`
+	UserID           model.UserID       `db:"user_id"`
+	UserLogin        string             `db:"user_login"`
+	DiagramID        model.DiagramID    `db:"diagram_id"`
+	ObjectStorageKey string             `db:"object_storage_key"`
+	Name             string             `db:"name"`
+	TablesCount      int64              `db:"tables_count"`
+	AutoGrading      []byte             `db:"auto_grading"`
+	AutoGradedAt     *time.Time         `db:"auto_graded_at"`
+	Grade            *float64           `db:"grade"`
+	Feedback         string             `db:"feedback"`
+	GradedBy         *model.UserID      `db:"graded_by"`
+	GradedAt         *time.Time         `db:"graded_at"`
+	SubmittedAt      time.Time          `db:"submitted_at"`
+	CreatedAt        time.Time          `db:"created_at"`
+	UpdatedAt        time.Time          `db:"updated_at"`
+}
+
+func selectSubmissions() sq.SelectBuilder {
+	return sq.Select(tableFields(submissionTable, submissionFields)...).
+		Column(submissionUserLoginColumn).
+		From(submissionTable).
+		Join(fmt.Sprintf("%s ON %s = %s", userTable, tableField(userTable, fieldID), tableField(submissionTable, fieldUserID))).
+		PlaceholderFormat(sq.Dollar)
+}
+
+func (s *Storage) GetSubmissionByID(ctx context.Context, id model.SubmissionID, opts ...storage.RequestOption) (*model.Submission, error) {
+	options := storage.NewOptions(opts)
+
+	query := selectSubmissions().
+		Where(sq.Eq{tableField(submissionTable, fieldID): id.String()})
+
+	if options.UseLock {
+		query = useLock(query, submissionTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity submissionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return submissionEntityToModel(&entity)
+}
+
+func (s *Storage) GetAllSubmissions(ctx context.Context, filter []*model.FilterTerm) ([]*model.Submission, error) {
+	query := selectSubmissions().
+		OrderBy(tableField(userTable, fieldLogin) + " " + asc)
+
+	query, err := filterQuery(query, submissionTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*submissionEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.Submission, 0, len(entities))
+	for _, entity := range entities {
+		submission, err := submissionEntityToModel(entity)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, submission)
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateSubmission(ctx context.Context, params *storage.CreateSubmissionParams) (*model.Submission, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(submissionTable).
+		Columns(submissionFields...).
+		Values(
+			params.ID.String(),
+			params.AssignmentID.String(),
+			params.UserID.String(),
+			params.DiagramID.String(),
+			params.ObjectStorageKey,
+			params.Name,
+			params.TablesCount,
+			nil,
+			nil,
+			nil,
+			"",
+			nil,
+			nil,
+			now,
+			now,
+			now,
+		).
+		Suffix(returningSubmission).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity submissionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return submissionEntityToModel(&entity)
+}
+
+func (s *Storage) PatchSubmission(ctx context.Context, params *storage.PatchSubmissionParams) (*model.Submission, error) {
+	query := sq.Update(submissionTable).
+		Set(fieldUpdatedAt, time.Now()).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningSubmission).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldDiagramID, params.DiagramID)
+	query = patchQueryOptional(query, fieldObjectStorageKey, params.ObjectStorageKey)
+	query = patchQueryOptional(query, fieldName, params.Name)
+	query = patchQueryOptional(query, fieldTablesCount, params.TablesCount)
+	query = patchQueryOptional(query, fieldSubmittedAt, params.SubmittedAt)
+	if params.AutoGrading.Valid {
+		query = query.Set(fieldAutoGradedAt, gradedAt(params.AutoGrading.Value != nil))
+	}
+	query = patchQueryOptional(query, fieldGrade, params.Grade)
+	query = patchQueryOptional(query, fieldFeedback, params.Feedback)
+	query = patchQueryOptional(query, fieldGradedBy, params.GradedBy)
+	if params.GradedBy.Valid {
+		query = query.Set(fieldGradedAt, gradedAt(params.GradedBy.Value != nil))
+	}
+	query, err := patchQueryJSON(query, fieldAutoGrading, params.AutoGrading)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := query.MustSql()
+
+	var entity submissionEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return submissionEntityToModel(&entity)
+}
+
+func submissionEntityToModel(entity *submissionEntity) (*model.Submission, error) {
+	autoGrading, err := jsonToModel[model.GradingResult](entity.AutoGrading)
+	if err != nil {
+		return nil, fmt.Errorf("submission %s auto grading: %w", entity.ID, err)
+	}
+
+	return &model.Submission{
+		ID:               entity.ID,
+		AssignmentID:     entity.AssignmentID,
+		UserID:           entity.UserID,
+		UserLogin:        entity.UserLogin,
+		DiagramID:        entity.DiagramID,
+		ObjectStorageKey: entity.ObjectStorageKey,
+		Name:             entity.Name,
+		TablesCount:      entity.TablesCount,
+		AutoGrading:      autoGrading,
+		AutoGradedAt:     entity.AutoGradedAt,
+		Grade:            entity.Grade,
+		Feedback:         entity.Feedback,
+		GradedBy:         entity.GradedBy,
+		GradedAt:         entity.GradedAt,
+		SubmittedAt:      entity.SubmittedAt,
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}, nil
+}
+
+// gradedAt returns the time of the grading, cleared grades have no time
+func gradedAt(graded bool) *time.Time {
+	if !graded {
+		return nil
+	}
+	now := time.Now()
+	return &now
+}
@@ -6,8 +6,31 @@ import (
 
 var Tables []string = []string{
 	"diagrams",
+	"diagram_tags",
+	"diagram_edit_locks",
 	"users",
 	"user_confirmations",
+	"courses",
+	"course_groups",
+	"course_teachers",
+	"course_enrollments",
+	"assignments",
+	"submissions",
+	"plagiarism_checks",
+	"plagiarism_pairs",
+	"exam_sessions",
+	"diagram_activities",
+	"peer_reviews",
+	"role_requests",
+	"sessions",
+	"password_resets",
+	"user_audit_entries",
+	"oidc_login_states",
+	"user_identities",
+	"personal_access_tokens",
+	"user_totp",
+	"user_recovery_codes",
+	"login_challenges",
 }
 
 func (s *Storage) Erase(ctx context.Context) {
@@ -0,0 +1,341 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	userTOTPTable         = "user_totp"
+	userRecoveryCodeTable = "user_recovery_codes"
+	loginChallengeTable   = "login_challenges"
+)
+
+var (
+	userTOTPFields         = []string{fieldUserID, fieldSecret, fieldEnabledAt, fieldLastUsedStep, fieldCreatedAt}
+	userRecoveryCodeFields = []string{fieldID, fieldUserID, fieldCodeHash, fieldCreatedAt, fieldUsedAt}
+	loginChallengeFields   = []string{fieldID, fieldUserID, fieldUserAgent, fieldIPAddress, fieldAttempts,
+		fieldCreatedAt, fieldExpiresAt}
+
+	returningUserTOTP         = returning + strings.Join(userTOTPFields, separator)
+	returningUserRecoveryCode = returning + strings.Join(userRecoveryCodeFields, separator)
+	returningLoginChallenge   = returning + strings.Join(loginChallengeFields, separator)
+)
+
+type userTOTPEntity struct {
+	UserID       model.UserID `db:"user_id"`
+	Secret       string       `db:"secret"`
+	EnabledAt    *time.Time   `db:"enabled_at"`
+	LastUsedStep int64        `db:"last_used_step"`
+	CreatedAt    time.Time    `db:"created_at"`
+}
+
+type userRecoveryCodeEntity struct {
+	ID        model.UserRecoveryCodeID `db:"id"`
+	UserID    model.UserID             `db:"user_id"`
+	CodeHash  string                   `db:"code_hash"`
+	CreatedAt time.Time                `db:"created_at"`
+	UsedAt    *time.Time               `db:"used_at"`
+}
+
+type loginChallengeEntity struct {
+	ID        model.LoginChallengeID `db:"id"`
+	UserID    model.UserID           `db:"user_id"`
+	UserAgent string                 `db:"user_agent"`
+	IPAddress string                 `db:"ip_address"`
+	Attempts  int64                  `db:"attempts"`
+	CreatedAt time.Time              `db:"created_at"`
+	ExpiresAt time.Time              `db:"expires_at"`
+}
+
+func (s *Storage) GetUserTOTP(ctx context.Context, userID model.UserID, opts ...storage.RequestOption) (*model.UserTOTP, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(userTOTPFields...).
+		From(userTOTPTable).
+		Where(sq.Eq{fieldUserID: userID.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, userTOTPTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity userTOTPEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userTOTPEntityToModel(&entity), nil
+}
+
+func (s *Storage) CreateUserTOTP(ctx context.Context, params *storage.CreateUserTOTPParams) (*model.UserTOTP, error) {
+	sql, args := sq.Insert(userTOTPTable).
+		Columns(userTOTPFields...).
+		Values(
+			params.UserID.String(),
+			params.Secret,
+			nil,
+			0,
+			time.Now(),
+		).
+		Suffix(returningUserTOTP).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity userTOTPEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userTOTPEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchUserTOTP(ctx context.Context, params *storage.PatchUserTOTPParams) (*model.UserTOTP, error) {
+	query := sq.Update(userTOTPTable).
+		Where(sq.Eq{fieldUserID: params.UserID.String()}).
+		Suffix(returningUserTOTP).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldEnabledAt, params.EnabledAt)
+	query = patchQueryOptional(query, fieldLastUsedStep, params.LastUsedStep)
+
+	sql, args := query.MustSql()
+
+	var entity userTOTPEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userTOTPEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteUserTOTP(ctx context.Context, userID model.UserID) (*model.UserTOTP, error) {
+	sql, args := sq.Delete(userTOTPTable).
+		Where(sq.Eq{fieldUserID: userID.String()}).
+		Suffix(returningUserTOTP).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity userTOTPEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userTOTPEntityToModel(&entity), nil
+}
+
+func (s *Storage) GetAllUserRecoveryCodes(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserRecoveryCode, error) {
+	query := sq.Select(userRecoveryCodeFields...).
+		From(userRecoveryCodeTable).
+		OrderBy(fieldCreatedAt).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, userRecoveryCodeTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*userRecoveryCodeEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.UserRecoveryCode, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, userRecoveryCodeEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateUserRecoveryCode(ctx context.Context, params *storage.CreateUserRecoveryCodeParams) (*model.UserRecoveryCode, error) {
+	sql, args := sq.Insert(userRecoveryCodeTable).
+		Columns(userRecoveryCodeFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.CodeHash,
+			time.Now(),
+			nil,
+		).
+		Suffix(returningUserRecoveryCode).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity userRecoveryCodeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userRecoveryCodeEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchUserRecoveryCode(ctx context.Context, params *storage.PatchUserRecoveryCodeParams) (*model.UserRecoveryCode, error) {
+	query := sq.Update(userRecoveryCodeTable).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningUserRecoveryCode).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldUsedAt, params.UsedAt)
+
+	sql, args := query.MustSql()
+
+	var entity userRecoveryCodeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userRecoveryCodeEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteUserRecoveryCodes(ctx context.Context, userID model.UserID) error {
+	sql, args := sq.Delete(userRecoveryCodeTable).
+		Where(sq.Eq{fieldUserID: userID.String()}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
+
+func (s *Storage) GetLoginChallengeByID(ctx context.Context, id model.LoginChallengeID, opts ...storage.RequestOption) (*model.LoginChallenge, error) {
+	options := storage.NewOptions(opts)
+
+	query := sq.Select(loginChallengeFields...).
+		From(loginChallengeTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		PlaceholderFormat(sq.Dollar)
+
+	if options.UseLock {
+		query = useLock(query, loginChallengeTable)
+	}
+
+	sql, args := query.MustSql()
+
+	var entity loginChallengeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return loginChallengeEntityToModel(&entity), nil
+}
+
+func (s *Storage) CreateLoginChallenge(ctx context.Context, params *storage.CreateLoginChallengeParams) (*model.LoginChallenge, error) {
+	now := time.Now()
+
+	sql, args := sq.Insert(loginChallengeTable).
+		Columns(loginChallengeFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.UserAgent,
+			params.IPAddress,
+			0,
+			now,
+			now.Add(params.Duration),
+		).
+		Suffix(returningLoginChallenge).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity loginChallengeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return loginChallengeEntityToModel(&entity), nil
+}
+
+func (s *Storage) PatchLoginChallenge(ctx context.Context, params *storage.PatchLoginChallengeParams) (*model.LoginChallenge, error) {
+	query := sq.Update(loginChallengeTable).
+		Where(sq.Eq{fieldID: params.ID.String()}).
+		Suffix(returningLoginChallenge).
+		PlaceholderFormat(sq.Dollar)
+
+	query = patchQueryOptional(query, fieldAttempts, params.Attempts)
+
+	sql, args := query.MustSql()
+
+	var entity loginChallengeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return loginChallengeEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteLoginChallenge(ctx context.Context, id model.LoginChallengeID) (*model.LoginChallenge, error) {
+	sql, args := sq.Delete(loginChallengeTable).
+		Where(sq.Eq{fieldID: id.String()}).
+		Suffix(returningLoginChallenge).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity loginChallengeEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return loginChallengeEntityToModel(&entity), nil
+}
+
+func (s *Storage) DeleteExpiredLoginChallenges(ctx context.Context, now time.Time) (int64, error) {
+	sql, args := sq.Delete(loginChallengeTable).
+		Where(sq.LtOrEq{fieldExpiresAt: now}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	result, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, formatError(err)
+	}
+
+	return result.RowsAffected()
+}
+
+func userTOTPEntityToModel(entity *userTOTPEntity) *model.UserTOTP {
+	return &model.UserTOTP{
+		UserID:       entity.UserID,
+		Secret:       utils.NewSecret(entity.Secret),
+		EnabledAt:    entity.EnabledAt,
+		LastUsedStep: entity.LastUsedStep,
+		CreatedAt:    entity.CreatedAt,
+	}
+}
+
+func userRecoveryCodeEntityToModel(entity *userRecoveryCodeEntity) *model.UserRecoveryCode {
+	return &model.UserRecoveryCode{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		CodeHash:  utils.NewSecret(entity.CodeHash),
+		CreatedAt: entity.CreatedAt,
+		UsedAt:    entity.UsedAt,
+	}
+}
+
+func loginChallengeEntityToModel(entity *loginChallengeEntity) *model.LoginChallenge {
+	return &model.LoginChallenge{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		UserAgent: entity.UserAgent,
+		IPAddress: entity.IPAddress,
+		Attempts:  entity.Attempts,
+		CreatedAt: entity.CreatedAt,
+		ExpiresAt: entity.ExpiresAt,
+	}
+}
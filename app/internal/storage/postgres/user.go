@@ -86,7 +86,7 @@ func (s *Storage) CreateUser(ctx context.Context, params *storage.CreateUserPara
 			params.Login,
 			params.PasswordHash,
 			params.Type.String(),
-			nil,
+			params.ConfirmedAt,
 			now,
 			now,
 			nil,
@@ -113,7 +113,12 @@ func (s *Storage) PatchUser(ctx context.Context, params *storage.PatchUserParams
 		Suffix(returningUser).
 		PlaceholderFormat(sq.Dollar)
 
+	query = patchQueryOptional(query, fieldLogin, params.Login)
+	query = patchQueryOptional(query, fieldPasswordHash, params.PasswordHash)
 	query = patchQueryOptional(query, fieldConfirmedAt, params.ConfirmedAt)
+	if params.Type.Valid {
+		query = query.Set(fieldType, params.Type.Value.String())
+	}
 
 	sql, args := query.MustSql()
 
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/internal/storage"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+const userAuditEntryTable = "user_audit_entries"
+
+var (
+	userAuditEntryFields = []string{fieldID, fieldUserID, fieldAction, fieldDetails, fieldCreatedAt}
+
+	returningUserAuditEntry = returning + strings.Join(userAuditEntryFields, separator)
+)
+
+type userAuditEntryEntity struct {
+	ID        model.UserAuditEntryID `db:"id"`
+	UserID    model.UserID           `db:"user_id"`
+	Action    model.UserAuditAction  `db:"action"`
+	Details   string                 `db:"details"`
+	CreatedAt time.Time              `db:"created_at"`
+}
+
+func (s *Storage) GetAllUserAuditEntries(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserAuditEntry, error) {
+	query := sq.Select(userAuditEntryFields...).
+		From(userAuditEntryTable).
+		OrderBy(fieldCreatedAt + " " + desc).
+		PlaceholderFormat(sq.Dollar)
+
+	query, err := filterQuery(query, userAuditEntryTable, filter)
+	if err != nil {
+		return nil, fmt.Errorf("filter query: %w", err)
+	}
+
+	sql, args := query.MustSql()
+
+	var entities []*userAuditEntryEntity
+	if err := sqlx.SelectContext(ctx, s.DB(ctx), &entities, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	result := make([]*model.UserAuditEntry, 0, len(entities))
+	for _, entity := range entities {
+		result = append(result, userAuditEntryEntityToModel(entity))
+	}
+	return result, nil
+}
+
+func (s *Storage) CreateUserAuditEntry(ctx context.Context, params *storage.CreateUserAuditEntryParams) (*model.UserAuditEntry, error) {
+	sql, args := sq.Insert(userAuditEntryTable).
+		Columns(userAuditEntryFields...).
+		Values(
+			params.ID.String(),
+			params.UserID.String(),
+			params.Action.String(),
+			params.Details,
+			time.Now(),
+		).
+		Suffix(returningUserAuditEntry).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	var entity userAuditEntryEntity
+	if err := sqlx.GetContext(ctx, s.DB(ctx), &entity, sql, args...); err != nil {
+		return nil, formatError(err)
+	}
+
+	return userAuditEntryEntityToModel(&entity), nil
+}
+
+func userAuditEntryEntityToModel(entity *userAuditEntryEntity) *model.UserAuditEntry {
+	return &model.UserAuditEntry{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		Action:    entity.Action,
+		Details:   entity.Details,
+		CreatedAt: entity.CreatedAt,
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/IvLaptev/chartdb-back/internal/model"
 	"github.com/IvLaptev/chartdb-back/internal/storage"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
 )
@@ -14,16 +15,19 @@ import (
 const userConfirmationTable = "user_confirmations"
 
 var (
-	userConfirmationFields = []string{fieldID, fieldUserID, fieldCreatedAt, fieldExpiresAt}
+	userConfirmationFields = []string{fieldID, fieldUserID, fieldPurpose, fieldLogin, fieldPasswordHash, fieldCreatedAt, fieldExpiresAt}
 
 	returningUserConfirmation = returning + strings.Join(userConfirmationFields, separator)
 )
 
 type userConfirmationEntity struct {
-	ID        model.UserConfirmationID `db:"id"`
-	UserID    model.UserID             `db:"user_id"`
-	CreatedAt time.Time                `db:"created_at"`
-	ExpiresAt time.Time                `db:"expires_at"`
+	ID           model.UserConfirmationID `db:"id"`
+	UserID       model.UserID             `db:"user_id"`
+	Purpose      string                   `db:"purpose"`
+	Login        *string                  `db:"login"`
+	PasswordHash *string                  `db:"password_hash"`
+	CreatedAt    time.Time                `db:"created_at"`
+	ExpiresAt    time.Time                `db:"expires_at"`
 }
 
 func (s *Storage) GetUserConfirmationByID(ctx context.Context, id model.UserConfirmationID) (*model.UserConfirmation, error) {
@@ -64,11 +68,19 @@ func (s *Storage) GetAllUserConfirmation(ctx context.Context, filter []*model.Fi
 
 func (s *Storage) CreateUserConfirmation(ctx context.Context, params *storage.CreateUserConfirmationParams) (*model.UserConfirmation, error) {
 	now := time.Now()
+	purpose := params.Purpose
+	if purpose == "" {
+		purpose = model.UserConfirmationPurposeRegistration
+	}
+
 	query := sq.Insert(userConfirmationTable).
 		Columns(userConfirmationFields...).
 		Values(
 			params.ID,
 			params.UserID,
+			purpose.String(),
+			params.Login,
+			params.PasswordHash,
 			now,
 			now.Add(params.Duration),
 		).
@@ -84,12 +96,30 @@ func (s *Storage) CreateUserConfirmation(ctx context.Context, params *storage.Cr
 	return userConfirmationEntityToModel(&entity), nil
 }
 
+func (s *Storage) DeleteUserConfirmations(ctx context.Context, userID model.UserID, purpose model.UserConfirmationPurpose) error {
+	sql, args := sq.Delete(userConfirmationTable).
+		Where(sq.Eq{fieldUserID: userID.String()}).
+		Where(sq.Eq{fieldPurpose: purpose.String()}).
+		PlaceholderFormat(sq.Dollar).
+		MustSql()
+
+	_, err := s.DB(ctx).ExecContext(ctx, sql, args...)
+	if err != nil {
+		return formatError(err)
+	}
+
+	return nil
+}
+
 func userConfirmationEntityToModel(entity *userConfirmationEntity) *model.UserConfirmation {
 	return &model.UserConfirmation{
-		ID:        entity.ID,
-		UserID:    entity.UserID,
-		CreatedAt: entity.CreatedAt,
-		ExpiresAt: entity.ExpiresAt,
+		ID:           entity.ID,
+		UserID:       entity.UserID,
+		Purpose:      model.UserConfirmationPurpose(entity.Purpose),
+		Login:        entity.Login,
+		PasswordHash: utils.NewSecret(entity.PasswordHash),
+		CreatedAt:    entity.CreatedAt,
+		ExpiresAt:    entity.ExpiresAt,
 	}
 }
 
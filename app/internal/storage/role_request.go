@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreateRoleRequestParams struct {
+	ID     model.RoleRequestID
+	UserID model.UserID
+	Type   model.UserType
+	Reason string
+}
+
+type PatchRoleRequestParams struct {
+	ID model.RoleRequestID
+
+	// Decision time is set together with the status
+	Status          utils.Optional[model.RoleRequestStatus]
+	DecidedBy       utils.Optional[*model.UserID]
+	DecisionComment utils.Optional[string]
+}
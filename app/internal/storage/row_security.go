@@ -26,6 +26,37 @@ func (s *RowPolicyUserID) GetFilter() []*model.FilterTerm {
 	}
 }
 
+// RowPolicyTeacher allows access to rows of the teacher and of students enrolled in the teacher's courses
+type RowPolicyTeacher struct {
+	UserID model.UserID
+}
+
+func (s *RowPolicyTeacher) GetFilter() []*model.FilterTerm {
+	return []*model.FilterTerm{
+		{
+			Key:       model.TermKeyVisibleToTeacher,
+			Value:     s.UserID,
+			Operation: model.FilterOperationExact,
+		},
+	}
+}
+
+// RowPolicyPeerReviewer allows temporary access to submissions assigned to the user for peer review,
+// access ends with the review period of the assignment
+type RowPolicyPeerReviewer struct {
+	UserID model.UserID
+}
+
+func (s *RowPolicyPeerReviewer) GetFilter() []*model.FilterTerm {
+	return []*model.FilterTerm{
+		{
+			Key:       model.TermKeyAssignedReviewer,
+			Value:     s.UserID,
+			Operation: model.FilterOperationExact,
+		},
+	}
+}
+
 type RowPolicyBackground struct{}
 
 func (s *RowPolicyBackground) GetFilter() []*model.FilterTerm {
@@ -42,3 +73,25 @@ func RowPolicyFromContext(ctx context.Context) (RowPolicy, error) {
 		UserID: subject.UserID,
 	}, nil
 }
+
+// ReadRowPolicyFromContext returns row policy for read-only access: admins see all rows,
+// teachers see rows of their students and others see only their own rows
+func ReadRowPolicyFromContext(ctx context.Context) (RowPolicy, error) {
+	subject, err := auth.GetSubject(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get subject: %w", err)
+	}
+
+	switch subject.UserType {
+	case model.UserTypeAdmin:
+		return &RowPolicyBackground{}, nil
+	case model.UserTypeTeacher:
+		return &RowPolicyTeacher{
+			UserID: subject.UserID,
+		}, nil
+	default:
+		return &RowPolicyUserID{
+			UserID: subject.UserID,
+		}, nil
+	}
+}
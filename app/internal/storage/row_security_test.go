@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/internal/auth"
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRowPolicyFromContext(t *testing.T) {
+	cases := []struct {
+		userType model.UserType
+		expected RowPolicy
+	}{
+		{userType: model.UserTypeAdmin, expected: &RowPolicyBackground{}},
+		{userType: model.UserTypeTeacher, expected: &RowPolicyTeacher{UserID: "user"}},
+		{userType: model.UserTypeStudent, expected: &RowPolicyUserID{UserID: "user"}},
+		{userType: model.UserTypeGuest, expected: &RowPolicyUserID{UserID: "user"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.userType.String(), func(t *testing.T) {
+			ctx := auth.SetSubject(context.Background(), &auth.Subject{
+				UserID:   "user",
+				UserType: c.userType,
+			})
+
+			rowPolicy, err := ReadRowPolicyFromContext(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, rowPolicy)
+
+			// Writes are limited to own rows for everyone
+			rowPolicy, err = RowPolicyFromContext(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, &RowPolicyUserID{UserID: "user"}, rowPolicy)
+		})
+	}
+
+	_, err := ReadRowPolicyFromContext(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreateSessionParams struct {
+	ID               model.SessionID
+	UserID           model.UserID
+	RefreshTokenHash string
+	UserAgent        string
+	IPAddress        string
+	ExpiresAt        time.Time
+}
+
+type PatchSessionParams struct {
+	ID model.SessionID
+
+	// Refresh time is set together with the hash
+	RefreshTokenHash utils.Optional[string]
+	ExpiresAt        utils.Optional[time.Time]
+	RevokedAt        utils.Optional[*time.Time]
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/IvLaptev/chartdb-back/internal/model"
 )
@@ -11,8 +12,31 @@ type Storage interface {
 	Erase(ctx context.Context)
 
 	Diagram() DiagramRepository
+	DiagramTag() DiagramTagRepository
+	DiagramEditLock() DiagramEditLockRepository
 	User() UserRepository
 	UserConfirmation() UserConfirmationRepository
+	Course() CourseRepository
+	CourseGroup() CourseGroupRepository
+	CourseTeacher() CourseTeacherRepository
+	CourseEnrollment() CourseEnrollmentRepository
+	Assignment() AssignmentRepository
+	Submission() SubmissionRepository
+	PlagiarismCheck() PlagiarismCheckRepository
+	PlagiarismPair() PlagiarismPairRepository
+	ExamSession() ExamSessionRepository
+	DiagramActivity() DiagramActivityRepository
+	PeerReview() PeerReviewRepository
+	RoleRequest() RoleRequestRepository
+	Session() SessionRepository
+	PasswordReset() PasswordResetRepository
+	UserAuditEntry() UserAuditEntryRepository
+	OIDCLoginState() OIDCLoginStateRepository
+	UserIdentity() UserIdentityRepository
+	PersonalAccessToken() PersonalAccessTokenRepository
+	UserTOTP() UserTOTPRepository
+	UserRecoveryCode() UserRecoveryCodeRepository
+	LoginChallenge() LoginChallengeRepository
 }
 
 type DiagramRepository interface {
@@ -25,6 +49,22 @@ type DiagramRepository interface {
 	DeleteDiagram(ctx context.Context, id model.DiagramID) (*model.Diagram, error)
 }
 
+type DiagramTagRepository interface {
+	CreateDiagramTags(ctx context.Context, diagramID model.DiagramID, tags []string) error
+	// Deletes all diagram tags if tags is empty
+	DeleteDiagramTags(ctx context.Context, diagramID model.DiagramID, tags []string) error
+}
+
+type DiagramEditLockRepository interface {
+	GetDiagramEditLock(ctx context.Context, diagramID model.DiagramID) (*model.DiagramEditLock, error)
+
+	CreateDiagramEditLock(ctx context.Context, params *CreateDiagramEditLockParams) (*model.DiagramEditLock, error)
+	PatchDiagramEditLock(ctx context.Context, params *PatchDiagramEditLockParams) (*model.DiagramEditLock, error)
+	DeleteDiagramEditLock(ctx context.Context, diagramID model.DiagramID) (*model.DiagramEditLock, error)
+	// Returns count of deleted locks
+	DeleteExpiredDiagramEditLocks(ctx context.Context, now time.Time) (int64, error)
+}
+
 type UserRepository interface {
 	GetUserByID(ctx context.Context, id model.UserID) (*model.User, error)
 	// Supported options: [WithLock]
@@ -40,4 +80,182 @@ type UserConfirmationRepository interface {
 	GetAllUserConfirmation(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserConfirmation, error)
 
 	CreateUserConfirmation(ctx context.Context, params *CreateUserConfirmationParams) (*model.UserConfirmation, error)
+	DeleteUserConfirmations(ctx context.Context, userID model.UserID, purpose model.UserConfirmationPurpose) error
+}
+
+type CourseRepository interface {
+	// Supported options: [WithLock]
+	GetCourseByID(ctx context.Context, id model.CourseID, opts ...RequestOption) (*model.Course, error)
+	GetAllCourses(ctx context.Context, filter []*model.FilterTerm) ([]*model.Course, error)
+
+	CreateCourse(ctx context.Context, params *CreateCourseParams) (*model.Course, error)
+	PatchCourse(ctx context.Context, params *PatchCourseParams) (*model.Course, error)
+	DeleteCourse(ctx context.Context, id model.CourseID) (*model.Course, error)
+}
+
+type CourseGroupRepository interface {
+	GetCourseGroupByID(ctx context.Context, id model.CourseGroupID) (*model.CourseGroup, error)
+	GetAllCourseGroups(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseGroup, error)
+
+	CreateCourseGroup(ctx context.Context, params *CreateCourseGroupParams) (*model.CourseGroup, error)
+	DeleteCourseGroup(ctx context.Context, id model.CourseGroupID) (*model.CourseGroup, error)
+}
+
+type CourseTeacherRepository interface {
+	GetAllCourseTeachers(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseTeacher, error)
+
+	CreateCourseTeacher(ctx context.Context, params *CreateCourseTeacherParams) (*model.CourseTeacher, error)
+	DeleteCourseTeacher(ctx context.Context, courseID model.CourseID, userID model.UserID) (*model.CourseTeacher, error)
+}
+
+type CourseEnrollmentRepository interface {
+	GetAllCourseEnrollments(ctx context.Context, filter []*model.FilterTerm) ([]*model.CourseEnrollment, error)
+
+	CreateCourseEnrollment(ctx context.Context, params *CreateCourseEnrollmentParams) (*model.CourseEnrollment, error)
+	PatchCourseEnrollment(ctx context.Context, params *PatchCourseEnrollmentParams) (*model.CourseEnrollment, error)
+	DeleteCourseEnrollment(ctx context.Context, courseID model.CourseID, userID model.UserID) (*model.CourseEnrollment, error)
+}
+
+type AssignmentRepository interface {
+	// Supported options: [WithLock]
+	GetAssignmentByID(ctx context.Context, id model.AssignmentID, opts ...RequestOption) (*model.Assignment, error)
+	GetAllAssignments(ctx context.Context, filter []*model.FilterTerm) ([]*model.Assignment, error)
+
+	CreateAssignment(ctx context.Context, params *CreateAssignmentParams) (*model.Assignment, error)
+	PatchAssignment(ctx context.Context, params *PatchAssignmentParams) (*model.Assignment, error)
+	DeleteAssignment(ctx context.Context, id model.AssignmentID) (*model.Assignment, error)
+}
+
+type SubmissionRepository interface {
+	// Supported options: [WithLock]
+	GetSubmissionByID(ctx context.Context, id model.SubmissionID, opts ...RequestOption) (*model.Submission, error)
+	GetAllSubmissions(ctx context.Context, filter []*model.FilterTerm) ([]*model.Submission, error)
+
+	CreateSubmission(ctx context.Context, params *CreateSubmissionParams) (*model.Submission, error)
+	PatchSubmission(ctx context.Context, params *PatchSubmissionParams) (*model.Submission, error)
+}
+
+type PlagiarismCheckRepository interface {
+	// Supported options: [WithLock]
+	GetPlagiarismCheck(ctx context.Context, assignmentID model.AssignmentID, opts ...RequestOption) (*model.PlagiarismCheck, error)
+	GetAllPlagiarismChecks(ctx context.Context, filter []*model.FilterTerm) ([]*model.PlagiarismCheck, error)
+
+	// Creates a pending check
+	CreatePlagiarismCheck(ctx context.Context, params *CreatePlagiarismCheckParams) (*model.PlagiarismCheck, error)
+	PatchPlagiarismCheck(ctx context.Context, params *PatchPlagiarismCheckParams) (*model.PlagiarismCheck, error)
+	// Makes checks of assignments with submissions made after the last check pending, returns count of requested checks
+	RequestOutdatedPlagiarismChecks(ctx context.Context, now time.Time) (int64, error)
+}
+
+type PlagiarismPairRepository interface {
+	GetAllPlagiarismPairs(ctx context.Context, filter []*model.FilterTerm) ([]*model.PlagiarismPair, error)
+
+	CreatePlagiarismPairs(ctx context.Context, params []*CreatePlagiarismPairParams) error
+	DeletePlagiarismPairs(ctx context.Context, assignmentID model.AssignmentID) error
+}
+
+type ExamSessionRepository interface {
+	GetAllExamSessions(ctx context.Context, filter []*model.FilterTerm) ([]*model.ExamSession, error)
+
+	CreateExamSession(ctx context.Context, params *CreateExamSessionParams) (*model.ExamSession, error)
+	PatchExamSession(ctx context.Context, params *PatchExamSessionParams) (*model.ExamSession, error)
+}
+
+type DiagramActivityRepository interface {
+	GetAllDiagramActivities(ctx context.Context, filter []*model.FilterTerm) ([]*model.DiagramActivity, error)
+	GetDiagramActivityStats(ctx context.Context, params *GetDiagramActivityStatsParams) ([]*model.DiagramActivityStats, error)
+	// Edits of all requested users are summed up by day
+	GetDailyEdits(ctx context.Context, params *GetDiagramActivityStatsParams) ([]*model.DailyEdits, error)
+
+	CreateDiagramActivity(ctx context.Context, params *CreateDiagramActivityParams) (*model.DiagramActivity, error)
+}
+
+type PeerReviewRepository interface {
+	// Supported options: [WithLock]
+	GetPeerReviewByID(ctx context.Context, id model.PeerReviewID, opts ...RequestOption) (*model.PeerReview, error)
+	GetAllPeerReviews(ctx context.Context, filter []*model.FilterTerm) ([]*model.PeerReview, error)
+
+	CreatePeerReview(ctx context.Context, params *CreatePeerReviewParams) (*model.PeerReview, error)
+	PatchPeerReview(ctx context.Context, params *PatchPeerReviewParams) (*model.PeerReview, error)
+}
+
+type RoleRequestRepository interface {
+	// Supported options: [WithLock]
+	GetRoleRequestByID(ctx context.Context, id model.RoleRequestID, opts ...RequestOption) (*model.RoleRequest, error)
+	GetAllRoleRequests(ctx context.Context, filter []*model.FilterTerm) ([]*model.RoleRequest, error)
+
+	CreateRoleRequest(ctx context.Context, params *CreateRoleRequestParams) (*model.RoleRequest, error)
+	PatchRoleRequest(ctx context.Context, params *PatchRoleRequestParams) (*model.RoleRequest, error)
+}
+
+type SessionRepository interface {
+	// Supported options: [WithLock]
+	GetSessionByID(ctx context.Context, id model.SessionID, opts ...RequestOption) (*model.Session, error)
+	GetAllSessions(ctx context.Context, filter []*model.FilterTerm) ([]*model.Session, error)
+
+	CreateSession(ctx context.Context, params *CreateSessionParams) (*model.Session, error)
+	PatchSession(ctx context.Context, params *PatchSessionParams) (*model.Session, error)
+}
+
+type PasswordResetRepository interface {
+	// Supported options: [WithLock]
+	GetPasswordResetByID(ctx context.Context, id model.PasswordResetID, opts ...RequestOption) (*model.PasswordReset, error)
+
+	CreatePasswordReset(ctx context.Context, params *CreatePasswordResetParams) (*model.PasswordReset, error)
+	PatchPasswordReset(ctx context.Context, params *PatchPasswordResetParams) (*model.PasswordReset, error)
+}
+
+type UserAuditEntryRepository interface {
+	GetAllUserAuditEntries(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserAuditEntry, error)
+
+	CreateUserAuditEntry(ctx context.Context, params *CreateUserAuditEntryParams) (*model.UserAuditEntry, error)
+}
+
+type OIDCLoginStateRepository interface {
+	CreateOIDCLoginState(ctx context.Context, params *CreateOIDCLoginStateParams) (*model.OIDCLoginState, error)
+	// The state is single-use, it is returned once and deleted
+	DeleteOIDCLoginState(ctx context.Context, id model.OIDCLoginStateID) (*model.OIDCLoginState, error)
+	DeleteExpiredOIDCLoginStates(ctx context.Context, now time.Time) (int64, error)
+}
+
+type UserIdentityRepository interface {
+	GetAllUserIdentities(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserIdentity, error)
+
+	CreateUserIdentity(ctx context.Context, params *CreateUserIdentityParams) (*model.UserIdentity, error)
+}
+
+type PersonalAccessTokenRepository interface {
+	// Supported options: [WithLock]
+	GetPersonalAccessTokenByID(ctx context.Context, id model.PersonalAccessTokenID, opts ...RequestOption) (*model.PersonalAccessToken, error)
+	GetAllPersonalAccessTokens(ctx context.Context, filter []*model.FilterTerm) ([]*model.PersonalAccessToken, error)
+
+	CreatePersonalAccessToken(ctx context.Context, params *CreatePersonalAccessTokenParams) (*model.PersonalAccessToken, error)
+	PatchPersonalAccessToken(ctx context.Context, params *PatchPersonalAccessTokenParams) (*model.PersonalAccessToken, error)
+}
+
+type UserTOTPRepository interface {
+	// Supported options: [WithLock]
+	GetUserTOTP(ctx context.Context, userID model.UserID, opts ...RequestOption) (*model.UserTOTP, error)
+
+	CreateUserTOTP(ctx context.Context, params *CreateUserTOTPParams) (*model.UserTOTP, error)
+	PatchUserTOTP(ctx context.Context, params *PatchUserTOTPParams) (*model.UserTOTP, error)
+	DeleteUserTOTP(ctx context.Context, userID model.UserID) (*model.UserTOTP, error)
+}
+
+type UserRecoveryCodeRepository interface {
+	GetAllUserRecoveryCodes(ctx context.Context, filter []*model.FilterTerm) ([]*model.UserRecoveryCode, error)
+
+	CreateUserRecoveryCode(ctx context.Context, params *CreateUserRecoveryCodeParams) (*model.UserRecoveryCode, error)
+	PatchUserRecoveryCode(ctx context.Context, params *PatchUserRecoveryCodeParams) (*model.UserRecoveryCode, error)
+	DeleteUserRecoveryCodes(ctx context.Context, userID model.UserID) error
+}
+
+type LoginChallengeRepository interface {
+	// Supported options: [WithLock]
+	GetLoginChallengeByID(ctx context.Context, id model.LoginChallengeID, opts ...RequestOption) (*model.LoginChallenge, error)
+
+	CreateLoginChallenge(ctx context.Context, params *CreateLoginChallengeParams) (*model.LoginChallenge, error)
+	PatchLoginChallenge(ctx context.Context, params *PatchLoginChallengeParams) (*model.LoginChallenge, error)
+	DeleteLoginChallenge(ctx context.Context, id model.LoginChallengeID) (*model.LoginChallenge, error)
+	DeleteExpiredLoginChallenges(ctx context.Context, now time.Time) (int64, error)
 }
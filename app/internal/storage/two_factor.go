@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/internal/model"
+	"github.com/IvLaptev/chartdb-back/pkg/utils"
+)
+
+type CreateUserTOTPParams struct {
+	UserID model.UserID
+	Secret string
+}
+
+type PatchUserTOTPParams struct {
+	UserID model.UserID
+
+	EnabledAt    utils.Optional[*time.Time]
+	LastUsedStep utils.Optional[int64]
+}
+
+type CreateUserRecoveryCodeParams struct {
+	ID       model.UserRecoveryCodeID
+	UserID   model.UserID
+	CodeHash string
+}
+
+type PatchUserRecoveryCodeParams struct {
+	ID model.UserRecoveryCodeID
+
+	UsedAt utils.Optional[*time.Time]
+}
+
+type CreateLoginChallengeParams struct {
+	ID        model.LoginChallengeID
+	UserID    model.UserID
+	UserAgent string
+	IPAddress string
+	Duration  time.Duration
+}
+
+type PatchLoginChallengeParams struct {
+	ID model.LoginChallengeID
+
+	Attempts utils.Optional[int64]
+}
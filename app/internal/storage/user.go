@@ -16,6 +16,9 @@ type CreateUserParams struct {
 }
 
 type PatchUserParams struct {
-	ID          model.UserID
-	ConfirmedAt utils.Optional[*time.Time]
+	ID           model.UserID
+	Login        utils.Optional[string]
+	PasswordHash utils.Optional[*string]
+	ConfirmedAt  utils.Optional[*time.Time]
+	Type         utils.Optional[model.UserType]
 }
@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/IvLaptev/chartdb-back/internal/model"
+
+type CreateUserAuditEntryParams struct {
+	ID      model.UserAuditEntryID
+	UserID  model.UserID
+	Action  model.UserAuditAction
+	Details string
+}
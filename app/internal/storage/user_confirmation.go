@@ -10,4 +10,9 @@ type CreateUserConfirmationParams struct {
 	ID       model.UserConfirmationID
 	UserID   model.UserID
 	Duration time.Duration
+	// Registration is used if empty
+	Purpose model.UserConfirmationPurpose
+	// Set for guest upgrades and email changes
+	Login        *string
+	PasswordHash *string
 }
@@ -0,0 +1,10 @@
+create table diagram_tags (
+    diagram_id varchar(10) not null,
+    tag text not null,
+    created_at timestamp with time zone not null,
+    primary key (diagram_id, tag)
+);
+
+alter table diagram_tags add constraint fk_diagram_tags_diagram_id foreign key (diagram_id) references diagrams (id);
+
+create index idx_diagram_tags_tag on diagram_tags (tag);
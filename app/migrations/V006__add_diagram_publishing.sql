@@ -0,0 +1,4 @@
+alter table diagrams add column public_slug text, add column published_at timestamp with time zone;
+
+create unique index idx_unique_public_slug
+	on diagrams (public_slug) where (public_slug is not null);
@@ -0,0 +1,12 @@
+create table diagram_edit_locks (
+    diagram_id varchar(10) primary key,
+    user_id text not null,
+    holder_id text not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null
+);
+
+alter table diagram_edit_locks add constraint fk_diagram_edit_locks_diagram_id foreign key (diagram_id) references diagrams (id);
+alter table diagram_edit_locks add constraint fk_diagram_edit_locks_user_id foreign key (user_id) references users (id);
+
+create index idx_diagram_edit_locks_expires_at on diagram_edit_locks (expires_at);
@@ -0,0 +1,59 @@
+create table courses (
+    id text primary key,
+    name text not null,
+    description text not null,
+    join_code text not null,
+    created_by text not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    deleted_at timestamp with time zone
+);
+
+alter table courses add constraint fk_courses_created_by foreign key (created_by) references users (id);
+
+create unique index idx_unique_course_join_code
+    on courses (join_code) where (deleted_at is null);
+
+create table course_groups (
+    id text primary key,
+    course_id text not null,
+    code text not null,
+    join_code text not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    deleted_at timestamp with time zone
+);
+
+alter table course_groups add constraint fk_course_groups_course_id foreign key (course_id) references courses (id);
+
+create unique index idx_unique_course_group_code
+    on course_groups (course_id, code) where (deleted_at is null);
+create unique index idx_unique_course_group_join_code
+    on course_groups (join_code) where (deleted_at is null);
+
+create table course_teachers (
+    course_id text not null,
+    user_id text not null,
+    created_at timestamp with time zone not null,
+    primary key (course_id, user_id)
+);
+
+alter table course_teachers add constraint fk_course_teachers_course_id foreign key (course_id) references courses (id);
+alter table course_teachers add constraint fk_course_teachers_user_id foreign key (user_id) references users (id);
+
+create index idx_course_teachers_user_id on course_teachers (user_id);
+
+create table course_enrollments (
+    course_id text not null,
+    user_id text not null,
+    group_id text,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    primary key (course_id, user_id)
+);
+
+alter table course_enrollments add constraint fk_course_enrollments_course_id foreign key (course_id) references courses (id);
+alter table course_enrollments add constraint fk_course_enrollments_user_id foreign key (user_id) references users (id);
+alter table course_enrollments add constraint fk_course_enrollments_group_id foreign key (group_id) references course_groups (id);
+
+create index idx_course_enrollments_user_id on course_enrollments (user_id);
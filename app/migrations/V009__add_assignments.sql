@@ -0,0 +1,38 @@
+create table assignments (
+    id text primary key,
+    course_id text not null,
+    title text not null,
+    description text not null,
+    starter_diagram_id varchar(10),
+    deadline timestamp with time zone,
+    created_by text not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null,
+    deleted_at timestamp with time zone
+);
+
+alter table assignments add constraint fk_assignments_course_id foreign key (course_id) references courses (id);
+alter table assignments add constraint fk_assignments_starter_diagram_id foreign key (starter_diagram_id) references diagrams (id);
+alter table assignments add constraint fk_assignments_created_by foreign key (created_by) references users (id);
+
+create index idx_assignments_course_id on assignments (course_id);
+
+create table submissions (
+    id text primary key,
+    assignment_id text not null,
+    user_id text not null,
+    diagram_id varchar(10) not null,
+    object_storage_key text not null,
+    name text not null,
+    tables_count bigint not null,
+    submitted_at timestamp with time zone not null,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+alter table submissions add constraint fk_submissions_assignment_id foreign key (assignment_id) references assignments (id);
+alter table submissions add constraint fk_submissions_user_id foreign key (user_id) references users (id);
+alter table submissions add constraint fk_submissions_diagram_id foreign key (diagram_id) references diagrams (id);
+
+create unique index idx_unique_submission_assignment_user on submissions (assignment_id, user_id);
+create index idx_submissions_diagram_id on submissions (diagram_id);
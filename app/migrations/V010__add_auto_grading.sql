@@ -0,0 +1,5 @@
+alter table assignments add column reference_diagram_id varchar(10), add column rubric jsonb;
+
+alter table assignments add constraint fk_assignments_reference_diagram_id foreign key (reference_diagram_id) references diagrams (id);
+
+alter table submissions add column auto_grading jsonb, add column auto_graded_at timestamp with time zone;
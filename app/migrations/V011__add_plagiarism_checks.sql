@@ -0,0 +1,31 @@
+create table plagiarism_checks (
+    assignment_id text primary key,
+    status text not null,
+    error text not null,
+    requested_at timestamp with time zone not null,
+    checked_at timestamp with time zone,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+alter table plagiarism_checks add constraint fk_plagiarism_checks_assignment_id foreign key (assignment_id) references assignments (id);
+
+create index idx_plagiarism_checks_status on plagiarism_checks (status);
+
+create table plagiarism_pairs (
+    id text primary key,
+    assignment_id text not null,
+    first_submission_id text not null,
+    second_submission_id text not null,
+    score double precision not null,
+    names_score double precision not null,
+    structure_score double precision not null,
+    layout_score double precision not null,
+    created_at timestamp with time zone not null
+);
+
+alter table plagiarism_pairs add constraint fk_plagiarism_pairs_assignment_id foreign key (assignment_id) references assignments (id);
+alter table plagiarism_pairs add constraint fk_plagiarism_pairs_first_submission_id foreign key (first_submission_id) references submissions (id);
+alter table plagiarism_pairs add constraint fk_plagiarism_pairs_second_submission_id foreign key (second_submission_id) references submissions (id);
+
+create index idx_plagiarism_pairs_assignment_id on plagiarism_pairs (assignment_id);
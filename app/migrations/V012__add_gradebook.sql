@@ -0,0 +1,5 @@
+alter table submissions add column grade double precision, add column feedback text not null default '', add column graded_by text, add column graded_at timestamp with time zone;
+
+alter table submissions add constraint fk_submissions_graded_by foreign key (graded_by) references users (id);
+
+alter table assignments add column grades_released_at timestamp with time zone;
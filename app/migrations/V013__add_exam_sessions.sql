@@ -0,0 +1,21 @@
+alter table assignments add column exam_starts_at timestamp with time zone, add column exam_ends_at timestamp with time zone;
+
+create table exam_sessions (
+    id text primary key,
+    assignment_id text not null,
+    user_id text not null,
+    diagram_id varchar(10) not null,
+    ends_at timestamp with time zone not null,
+    closed_at timestamp with time zone,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+alter table exam_sessions add constraint fk_exam_sessions_assignment_id foreign key (assignment_id) references assignments (id);
+alter table exam_sessions add constraint fk_exam_sessions_user_id foreign key (user_id) references users (id);
+alter table exam_sessions add constraint fk_exam_sessions_diagram_id foreign key (diagram_id) references diagrams (id);
+
+create unique index idx_unique_exam_session_assignment_user on exam_sessions (assignment_id, user_id);
+create index idx_exam_sessions_user_id on exam_sessions (user_id);
+create index idx_exam_sessions_diagram_id on exam_sessions (diagram_id);
+create index idx_exam_sessions_ends_at on exam_sessions (ends_at) where closed_at is null;
@@ -0,0 +1,14 @@
+create table diagram_activities (
+    id text primary key,
+    user_id text not null,
+    diagram_id varchar(10) not null,
+    assignment_id text,
+    action text not null,
+    lint_findings bigint,
+    created_at timestamp with time zone not null
+);
+
+alter table diagram_activities add constraint fk_diagram_activities_user_id foreign key (user_id) references users (id);
+
+create index idx_diagram_activities_user_id_created_at on diagram_activities (user_id, created_at);
+create index idx_diagram_activities_assignment_id on diagram_activities (assignment_id) where assignment_id is not null;
@@ -0,0 +1,28 @@
+alter table assignments
+    add column peer_review jsonb,
+    add column peer_review_ends_at timestamp with time zone,
+    add column peer_reviews_assigned_at timestamp with time zone;
+
+create table peer_reviews (
+    id text primary key,
+    assignment_id text not null,
+    submission_id text not null,
+    reviewer_id text not null,
+    scores jsonb,
+    comment text not null,
+    submitted_at timestamp with time zone,
+    hidden_by text,
+    hidden_at timestamp with time zone,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+alter table peer_reviews add constraint fk_peer_reviews_assignment_id foreign key (assignment_id) references assignments (id);
+alter table peer_reviews add constraint fk_peer_reviews_submission_id foreign key (submission_id) references submissions (id);
+alter table peer_reviews add constraint fk_peer_reviews_reviewer_id foreign key (reviewer_id) references users (id);
+alter table peer_reviews add constraint fk_peer_reviews_hidden_by foreign key (hidden_by) references users (id);
+
+create unique index idx_unique_peer_review_submission_reviewer on peer_reviews (submission_id, reviewer_id);
+create index idx_peer_reviews_assignment_id on peer_reviews (assignment_id);
+create index idx_peer_reviews_reviewer_id on peer_reviews (reviewer_id);
+create index idx_assignments_peer_review_ends_at on assignments (peer_review_ends_at) where peer_reviews_assigned_at is null;
@@ -0,0 +1,18 @@
+create table role_requests (
+    id text primary key,
+    user_id text not null,
+    type user_type not null,
+    reason text not null,
+    status text not null,
+    decided_by text,
+    decision_comment text not null,
+    decided_at timestamp with time zone,
+    created_at timestamp with time zone not null,
+    updated_at timestamp with time zone not null
+);
+
+alter table role_requests add constraint fk_role_requests_user_id foreign key (user_id) references users (id);
+alter table role_requests add constraint fk_role_requests_decided_by foreign key (decided_by) references users (id);
+
+create unique index idx_unique_role_request_pending_user_id on role_requests (user_id) where status = 'pending';
+create index idx_role_requests_status on role_requests (status);
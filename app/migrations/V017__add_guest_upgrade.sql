@@ -0,0 +1,3 @@
+alter table user_confirmations
+    add column login text,
+    add column password_hash text;
@@ -0,0 +1,15 @@
+create table sessions (
+    id text primary key,
+    user_id text not null,
+    refresh_token_hash text not null,
+    user_agent text not null,
+    ip_address text not null,
+    created_at timestamp with time zone not null,
+    refreshed_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null,
+    revoked_at timestamp with time zone
+);
+
+alter table sessions add constraint fk_sessions_user_id foreign key (user_id) references users (id);
+
+create index idx_sessions_user_id on sessions (user_id) where revoked_at is null;
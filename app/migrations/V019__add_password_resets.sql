@@ -0,0 +1,9 @@
+create table password_resets (
+    id text primary key,
+    user_id text not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null,
+    used_at timestamp with time zone
+);
+
+alter table password_resets add constraint fk_password_resets_user_id foreign key (user_id) references users (id);
@@ -0,0 +1,15 @@
+alter table user_confirmations add column purpose text not null default 'registration';
+
+update user_confirmations set purpose = 'guest_upgrade' where login is not null;
+
+create table user_audit_entries (
+    id text primary key,
+    user_id text not null,
+    action text not null,
+    details text not null,
+    created_at timestamp with time zone not null
+);
+
+alter table user_audit_entries add constraint fk_user_audit_entries_user_id foreign key (user_id) references users (id);
+
+create index idx_user_audit_entries_user_id_created_at on user_audit_entries (user_id, created_at);
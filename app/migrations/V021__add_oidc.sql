@@ -0,0 +1,23 @@
+create table oidc_login_states (
+    id text primary key,
+    nonce text not null,
+    code_verifier text not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null
+);
+
+create index idx_oidc_login_states_expires_at on oidc_login_states (expires_at);
+
+create table user_identities (
+    id text primary key,
+    user_id text not null,
+    issuer text not null,
+    subject text not null,
+    email text not null,
+    created_at timestamp with time zone not null
+);
+
+alter table user_identities add constraint fk_user_identities_user_id foreign key (user_id) references users (id);
+
+create unique index idx_user_identities_issuer_subject on user_identities (issuer, subject);
+create index idx_user_identities_user_id on user_identities (user_id);
@@ -0,0 +1,15 @@
+create table personal_access_tokens (
+    id text primary key,
+    user_id text not null,
+    name text not null,
+    token_hash text not null,
+    scopes text not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone
+);
+
+alter table personal_access_tokens add constraint fk_personal_access_tokens_user_id foreign key (user_id) references users (id);
+
+create index idx_personal_access_tokens_user_id on personal_access_tokens (user_id) where revoked_at is null;
@@ -0,0 +1,35 @@
+create table user_totp (
+    user_id text primary key,
+    secret text not null,
+    enabled_at timestamp with time zone,
+    last_used_step bigint not null,
+    created_at timestamp with time zone not null
+);
+
+alter table user_totp add constraint fk_user_totp_user_id foreign key (user_id) references users (id);
+
+create table user_recovery_codes (
+    id text primary key,
+    user_id text not null,
+    code_hash text not null,
+    created_at timestamp with time zone not null,
+    used_at timestamp with time zone
+);
+
+alter table user_recovery_codes add constraint fk_user_recovery_codes_user_id foreign key (user_id) references users (id);
+
+create index idx_user_recovery_codes_user_id on user_recovery_codes (user_id) where used_at is null;
+
+create table login_challenges (
+    id text primary key,
+    user_id text not null,
+    user_agent text not null,
+    ip_address text not null,
+    attempts integer not null,
+    created_at timestamp with time zone not null,
+    expires_at timestamp with time zone not null
+);
+
+alter table login_challenges add constraint fk_login_challenges_user_id foreign key (user_id) references users (id);
+
+create index idx_login_challenges_expires_at on login_challenges (expires_at);
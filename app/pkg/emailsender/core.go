@@ -2,4 +2,8 @@ package emailsender
 
 type EmailSender interface {
 	SendCreateUserEmail(to string, token string) error
+	SendInvitationEmail(to string, courseName string, token string) error
+	SendRoleRequestEmail(to string, role string, approved bool, comment string) error
+	SendPasswordResetEmail(to string, token string) error
+	SendEmailChangeEmail(to string, token string) error
 }
@@ -52,3 +52,59 @@ func (mr *MockEmailSenderMockRecorder) SendCreateUserEmail(to, token any) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCreateUserEmail", reflect.TypeOf((*MockEmailSender)(nil).SendCreateUserEmail), to, token)
 }
+
+// SendEmailChangeEmail mocks base method.
+func (m *MockEmailSender) SendEmailChangeEmail(to, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendEmailChangeEmail", to, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendEmailChangeEmail indicates an expected call of SendEmailChangeEmail.
+func (mr *MockEmailSenderMockRecorder) SendEmailChangeEmail(to, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendEmailChangeEmail", reflect.TypeOf((*MockEmailSender)(nil).SendEmailChangeEmail), to, token)
+}
+
+// SendInvitationEmail mocks base method.
+func (m *MockEmailSender) SendInvitationEmail(to, courseName, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInvitationEmail", to, courseName, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInvitationEmail indicates an expected call of SendInvitationEmail.
+func (mr *MockEmailSenderMockRecorder) SendInvitationEmail(to, courseName, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInvitationEmail", reflect.TypeOf((*MockEmailSender)(nil).SendInvitationEmail), to, courseName, token)
+}
+
+// SendPasswordResetEmail mocks base method.
+func (m *MockEmailSender) SendPasswordResetEmail(to, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPasswordResetEmail", to, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPasswordResetEmail indicates an expected call of SendPasswordResetEmail.
+func (mr *MockEmailSenderMockRecorder) SendPasswordResetEmail(to, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPasswordResetEmail", reflect.TypeOf((*MockEmailSender)(nil).SendPasswordResetEmail), to, token)
+}
+
+// SendRoleRequestEmail mocks base method.
+func (m *MockEmailSender) SendRoleRequestEmail(to, role string, approved bool, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendRoleRequestEmail", to, role, approved, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendRoleRequestEmail indicates an expected call of SendRoleRequestEmail.
+func (mr *MockEmailSenderMockRecorder) SendRoleRequestEmail(to, role, approved, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRoleRequestEmail", reflect.TypeOf((*MockEmailSender)(nil).SendRoleRequestEmail), to, role, approved, comment)
+}
@@ -9,9 +9,18 @@ import (
 )
 
 const (
-	createUserTemplate = "create_user_template.html"
+	createUserTemplate    = "create_user_template.html"
+	invitationTemplate    = "invitation_template.html"
+	roleRequestTemplate   = "role_request_template.html"
+	passwordResetTemplate = "password_reset_template.html"
+	emailChangeTemplate   = "email_change_template.html"
 )
 
+var roleNames = map[string]string{
+	"TEACHER": "преподаватель",
+	"ADMIN":   "администратор",
+}
+
 type CustomSender struct {
 	dialer          *gomail.Dialer
 	sender          string
@@ -58,11 +67,106 @@ func (s *CustomSender) SendCreateUserEmail(to string, token string) error {
 	return nil
 }
 
+func (s *CustomSender) SendInvitationEmail(to string, courseName string, token string) error {
+	err := s.sendMessage(to, "Приглашение в ChartDB", func(msg *gomail.Message) error {
+		msg.AddAlternativeWriter("text/html", func(w io.Writer) error {
+			return s.templates.ExecuteTemplate(w, invitationTemplate, struct {
+				ServiceEndpoint string
+				CourseName      string
+				Token           string
+			}{
+				ServiceEndpoint: s.serviceEndpoint,
+				CourseName:      courseName,
+				Token:           token,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CustomSender) SendRoleRequestEmail(to string, role string, approved bool, comment string) error {
+	roleName, ok := roleNames[role]
+	if !ok {
+		roleName = role
+	}
+
+	err := s.sendMessage(to, "Запрос роли в ChartDB", func(msg *gomail.Message) error {
+		msg.AddAlternativeWriter("text/html", func(w io.Writer) error {
+			return s.templates.ExecuteTemplate(w, roleRequestTemplate, struct {
+				ServiceEndpoint string
+				Role            string
+				Approved        bool
+				Comment         string
+			}{
+				ServiceEndpoint: s.serviceEndpoint,
+				Role:            roleName,
+				Approved:        approved,
+				Comment:         comment,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CustomSender) SendPasswordResetEmail(to string, token string) error {
+	err := s.sendMessage(to, "Восстановление пароля", func(msg *gomail.Message) error {
+		msg.AddAlternativeWriter("text/html", func(w io.Writer) error {
+			return s.templates.ExecuteTemplate(w, passwordResetTemplate, struct {
+				ServiceEndpoint string
+				Token           string
+			}{
+				ServiceEndpoint: s.serviceEndpoint,
+				Token:           token,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CustomSender) SendEmailChangeEmail(to string, token string) error {
+	err := s.sendMessage(to, "Подтверждение смены почты", func(msg *gomail.Message) error {
+		msg.AddAlternativeWriter("text/html", func(w io.Writer) error {
+			return s.templates.ExecuteTemplate(w, emailChangeTemplate, struct {
+				ServiceEndpoint string
+				Token           string
+			}{
+				ServiceEndpoint: s.serviceEndpoint,
+				Token:           token,
+			})
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
 func NewCustomSender(config *CustomEmailSenderConfig) (*CustomSender, error) {
 	dialer := gomail.NewDialer(config.Host, config.Port, config.Username, config.Password)
 
 	templates, err := template.ParseFiles(
-		config.TemplatePath + "/" + createUserTemplate,
+		config.TemplatePath+"/"+createUserTemplate,
+		config.TemplatePath+"/"+invitationTemplate,
+		config.TemplatePath+"/"+roleRequestTemplate,
+		config.TemplatePath+"/"+passwordResetTemplate,
+		config.TemplatePath+"/"+emailChangeTemplate,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("parse files: %w", err)
@@ -6,6 +6,22 @@ func (*MockSender) SendCreateUserEmail(to string, token string) error {
 	return nil
 }
 
+func (*MockSender) SendInvitationEmail(to string, courseName string, token string) error {
+	return nil
+}
+
+func (*MockSender) SendRoleRequestEmail(to string, role string, approved bool, comment string) error {
+	return nil
+}
+
+func (*MockSender) SendPasswordResetEmail(to string, token string) error {
+	return nil
+}
+
+func (*MockSender) SendEmailChangeEmail(to string, token string) error {
+	return nil
+}
+
 func NewMockSender() *MockSender {
 	return &MockSender{}
 }
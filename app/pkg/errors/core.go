@@ -8,6 +8,7 @@ const (
 	ErrorStatusUnauthenticated
 	ErrorStatusInvalidArgument
 	ErrorStatusForbidden
+	ErrorStatusConflict
 )
 
 const (
@@ -15,6 +16,7 @@ const (
 	msgUnauthenticated = "unauthenticated"
 	msgInvalidArgument = "invalid argument"
 	msgForbidden       = "forbidden"
+	msgConflict        = "conflict"
 
 	msgInternalServerError = "internal server error"
 )
@@ -61,6 +63,10 @@ func WrapForbidden(err error) *Error {
 	return WrapError(err, ErrorStatusForbidden, msgForbidden)
 }
 
+func WrapConflict(err error) *Error {
+	return WrapError(err, ErrorStatusConflict, msgConflict)
+}
+
 func WrapInvalidArgument(err error) *Error {
 	return WrapError(err, ErrorStatusInvalidArgument, msgInvalidArgument)
 }
@@ -59,6 +59,12 @@ func HTTPErrorHandler(w http.ResponseWriter, err error) error {
 			Code:    http.StatusForbidden,
 			Details: resultErr.Error(),
 		}
+	case ErrorStatusConflict:
+		jsonErr = jsonError{
+			Message: resultErr.message,
+			Code:    http.StatusConflict,
+			Details: resultErr.Error(),
+		}
 	default:
 		jsonErr = jsonError{
 			Message: msgInternalServerError,
@@ -0,0 +1,208 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Algorithm string
+
+const (
+	AlgorithmEdDSA Algorithm = "EdDSA"
+	AlgorithmHS256 Algorithm = "HS256"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrUnknownKey       = errors.New("unknown key")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Key is a signing key, secret is the raw HMAC secret for HS256 and the base64 encoded
+// Ed25519 seed for EdDSA
+type Key struct {
+	ID        string    `yaml:"id"`
+	Algorithm Algorithm `yaml:"algorithm"`
+	Secret    string    `yaml:"secret"`
+}
+
+// Config lists all keys accepted for verification, new tokens are signed with the active key.
+// Keys are rotated by adding a new key, making it active and removing the old key
+// once tokens signed with it expire.
+type Config struct {
+	ActiveKeyID string `yaml:"active_key_id"`
+	Keys        []Key  `yaml:"keys"`
+}
+
+type header struct {
+	Algorithm Algorithm `json:"alg"`
+	Type      string    `json:"typ"`
+	KeyID     string    `json:"kid"`
+}
+
+type signingKey struct {
+	id         string
+	algorithm  Algorithm
+	secret     []byte
+	privateKey ed25519.PrivateKey
+}
+
+func (k *signingKey) sign(data []byte) []byte {
+	switch k.algorithm {
+	case AlgorithmEdDSA:
+		return ed25519.Sign(k.privateKey, data)
+	default:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(data)
+		return mac.Sum(nil)
+	}
+}
+
+func (k *signingKey) verify(data []byte, signature []byte) bool {
+	switch k.algorithm {
+	case AlgorithmEdDSA:
+		return ed25519.Verify(k.privateKey.Public().(ed25519.PublicKey), data, signature)
+	default:
+		return hmac.Equal(k.sign(data), signature)
+	}
+}
+
+type Signer struct {
+	activeKey *signingKey
+	keys      map[string]*signingKey
+}
+
+func NewSigner(config Config) (*Signer, error) {
+	signer := &Signer{
+		keys: make(map[string]*signingKey, len(config.Keys)),
+	}
+
+	for _, key := range config.Keys {
+		if key.ID == "" {
+			return nil, errors.New("key id is required")
+		}
+		if _, ok := signer.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key %s", key.ID)
+		}
+
+		signingKey := &signingKey{
+			id:        key.ID,
+			algorithm: key.Algorithm,
+		}
+		switch key.Algorithm {
+		case AlgorithmEdDSA:
+			seed, err := base64.StdEncoding.DecodeString(key.Secret)
+			if err != nil {
+				return nil, fmt.Errorf("decode key %s: %w", key.ID, err)
+			}
+			if len(seed) != ed25519.SeedSize {
+				return nil, fmt.Errorf("key %s: seed must be %d bytes long", key.ID, ed25519.SeedSize)
+			}
+			signingKey.privateKey = ed25519.NewKeyFromSeed(seed)
+		case AlgorithmHS256:
+			if len(key.Secret) == 0 {
+				return nil, fmt.Errorf("key %s: secret is required", key.ID)
+			}
+			signingKey.secret = []byte(key.Secret)
+		default:
+			return nil, fmt.Errorf("key %s: unsupported algorithm %q", key.ID, key.Algorithm)
+		}
+
+		signer.keys[key.ID] = signingKey
+	}
+
+	activeKey, ok := signer.keys[config.ActiveKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q: %w", config.ActiveKeyID, ErrUnknownKey)
+	}
+	signer.activeKey = activeKey
+
+	return signer, nil
+}
+
+// Sign encodes claims and signs the token with the active key
+func (s *Signer) Sign(claims any) (string, error) {
+	encodedHeader, err := encodeSegment(&header{
+		Algorithm: s.activeKey.algorithm,
+		Type:      "JWT",
+		KeyID:     s.activeKey.id,
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode header: %w", err)
+	}
+
+	encodedClaims, err := encodeSegment(claims)
+	if err != nil {
+		return "", fmt.Errorf("encode claims: %w", err)
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	signature := s.activeKey.sign([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the signature with the key from the token header and decodes claims,
+// validation of claims is left to the caller
+func (s *Signer) Verify(token string, claims any) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+
+	var tokenHeader header
+	if err := decodeSegment(parts[0], &tokenHeader); err != nil {
+		return fmt.Errorf("decode header: %w", err)
+	}
+
+	key, ok := s.keys[tokenHeader.KeyID]
+	if !ok {
+		return ErrUnknownKey
+	}
+	// The algorithm is bound to the key to prevent algorithm substitution
+	if tokenHeader.Algorithm != key.algorithm {
+		return ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidToken
+	}
+	if !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return ErrInvalidSignature
+	}
+
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return fmt.Errorf("decode claims: %w", err)
+	}
+
+	return nil
+}
+
+func encodeSegment(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, value any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
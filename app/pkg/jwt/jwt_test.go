@@ -0,0 +1,76 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClaims struct {
+	Subject string `json:"sub"`
+}
+
+var (
+	hmacKey = Key{
+		ID:        "hmac",
+		Algorithm: AlgorithmHS256,
+		Secret:    "secret",
+	}
+	edKey = Key{
+		ID:        "ed",
+		Algorithm: AlgorithmEdDSA,
+		Secret:    base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+	}
+)
+
+func TestSignAndVerify(t *testing.T) {
+	for _, key := range []Key{hmacKey, edKey} {
+		signer, err := NewSigner(Config{ActiveKeyID: key.ID, Keys: []Key{key}})
+		require.NoError(t, err)
+
+		token, err := signer.Sign(&testClaims{Subject: "user"})
+		require.NoError(t, err)
+
+		var claims testClaims
+		require.NoError(t, signer.Verify(token, &claims))
+		assert.Equal(t, "user", claims.Subject)
+
+		parts := strings.Split(token, ".")
+		tampered, err := encodeSegment(&testClaims{Subject: "admin"})
+		require.NoError(t, err)
+		assert.ErrorIs(t, signer.Verify(parts[0]+"."+tampered+"."+parts[2], &claims), ErrInvalidSignature)
+	}
+}
+
+func TestVerify_Rotation(t *testing.T) {
+	oldSigner, err := NewSigner(Config{ActiveKeyID: hmacKey.ID, Keys: []Key{hmacKey}})
+	require.NoError(t, err)
+	oldToken, err := oldSigner.Sign(&testClaims{Subject: "user"})
+	require.NoError(t, err)
+
+	signer, err := NewSigner(Config{ActiveKeyID: edKey.ID, Keys: []Key{edKey, hmacKey}})
+	require.NoError(t, err)
+
+	var claims testClaims
+	require.NoError(t, signer.Verify(oldToken, &claims))
+
+	newToken, err := signer.Sign(&testClaims{Subject: "user"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, oldSigner.Verify(newToken, &claims), ErrUnknownKey)
+}
+
+func TestVerify_AlgorithmMismatch(t *testing.T) {
+	signer, err := NewSigner(Config{ActiveKeyID: edKey.ID, Keys: []Key{edKey}})
+	require.NoError(t, err)
+
+	// HMAC token signed with a key id of the Ed25519 key
+	forged, err := (&Signer{activeKey: &signingKey{id: edKey.ID, algorithm: AlgorithmHS256, secret: []byte("secret")}}).
+		Sign(&testClaims{Subject: "admin"})
+	require.NoError(t, err)
+
+	var claims testClaims
+	assert.ErrorIs(t, signer.Verify(forged, &claims), ErrInvalidToken)
+}
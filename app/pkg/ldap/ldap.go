@@ -0,0 +1,168 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	defaultUserFilter     = "(mail=%s)"
+	defaultEmailAttribute = "mail"
+	defaultGroupAttribute = "memberOf"
+	defaultTimeout        = 5 * time.Second
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrAmbiguousUser      = errors.New("login matches several directory entries")
+)
+
+type Config struct {
+	// ldap://host:389 or ldaps://host:636
+	URL                string `yaml:"url"`
+	StartTLS           bool   `yaml:"start_tls"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+	// Service account searching for users, anonymous search if empty
+	BindDN       string `yaml:"bind_dn"`
+	BindPassword string `yaml:"bind_password" env:"LDAP_BIND_PASSWORD"`
+	BaseDN       string `yaml:"base_dn"`
+	// %s is replaced by the escaped login, "(mail=%s)" if empty.
+	// Active Directory usually uses "(&(objectClass=user)(sAMAccountName=%s))".
+	UserFilter string `yaml:"user_filter"`
+	// "mail" if empty
+	EmailAttribute string `yaml:"email_attribute"`
+	// Attribute with DNs or names of the user groups, "memberOf" if empty
+	GroupAttribute string        `yaml:"group_attribute"`
+	Timeout        time.Duration `yaml:"timeout"`
+}
+
+// Entry is the directory user
+type Entry struct {
+	DN     string
+	Email  string
+	Groups []string
+}
+
+// Client authenticates users by binding to the directory with their DN and password
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) *Client {
+	if config.UserFilter == "" {
+		config.UserFilter = defaultUserFilter
+	}
+	if config.EmailAttribute == "" {
+		config.EmailAttribute = defaultEmailAttribute
+	}
+	if config.GroupAttribute == "" {
+		config.GroupAttribute = defaultGroupAttribute
+	}
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	return &Client{
+		config: config,
+	}
+}
+
+// Authenticate finds the user by the login and checks the password by the bind as the user.
+// ErrInvalidCredentials is returned for unknown logins and wrong passwords.
+func (c *Client) Authenticate(login, password string) (*Entry, error) {
+	// Most servers treat the bind with an empty password as an anonymous bind which always succeeds
+	if login == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if c.config.BindDN != "" {
+		err = conn.Bind(c.config.BindDN, c.config.BindPassword)
+		if err != nil {
+			return nil, fmt.Errorf("bind service account: %w", err)
+		}
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		c.config.BaseDN,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		2,
+		int(c.config.Timeout.Seconds()),
+		false,
+		strings.ReplaceAll(c.config.UserFilter, "%s", goldap.EscapeFilter(login)),
+		[]string{c.config.EmailAttribute, c.config.GroupAttribute},
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("search user: %w", err)
+	}
+	switch {
+	case result == nil || len(result.Entries) == 0:
+		return nil, ErrInvalidCredentials
+	case len(result.Entries) > 1:
+		return nil, ErrAmbiguousUser
+	}
+	entry := result.Entries[0]
+
+	err = conn.Bind(entry.DN, password)
+	if err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("bind user: %w", err)
+	}
+
+	return &Entry{
+		DN:     entry.DN,
+		Email:  entry.GetAttributeValue(c.config.EmailAttribute),
+		Groups: entry.GetAttributeValues(c.config.GroupAttribute),
+	}, nil
+}
+
+func (c *Client) dial() (*goldap.Conn, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.config.InsecureSkipVerify,
+	}
+
+	conn, err := goldap.DialURL(c.config.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: c.config.Timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("dial: %w", err)
+	}
+	conn.SetTimeout(c.config.Timeout)
+
+	if c.config.StartTLS {
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("start tls: %w", err)
+		}
+	}
+
+	return conn, nil
+}
+
+// GroupName returns the first RDN value of the group DN, e.g. "teachers" for "cn=teachers,ou=groups,dc=example,dc=com".
+// Values which are not DNs are returned as is.
+func GroupName(group string) string {
+	dn, err := goldap.ParseDN(group)
+	if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+		return group
+	}
+
+	return dn.RDNs[0].Attributes[0].Value
+}
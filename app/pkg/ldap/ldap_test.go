@@ -0,0 +1,103 @@
+package ldap_test
+
+import (
+	"testing"
+
+	"github.com/IvLaptev/chartdb-back/pkg/ldap"
+	"github.com/IvLaptev/chartdb-back/pkg/ldap/ldaptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBaseDN      = "ou=people,dc=example,dc=com"
+	testServiceDN   = "cn=chartdb,ou=services,dc=example,dc=com"
+	testServicePass = "service"
+)
+
+func newTestServer(t *testing.T) *ldaptest.Server {
+	server, err := ldaptest.NewServer(
+		&ldaptest.Entry{DN: testServiceDN, Password: testServicePass},
+		&ldaptest.Entry{
+			DN:       "uid=teacher,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"uid":      {"teacher"},
+				"mail":     {"teacher@example.com"},
+				"memberOf": {"cn=teachers,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"},
+			},
+		},
+		&ldaptest.Entry{
+			DN:       "uid=student,ou=people,dc=example,dc=com",
+			Password: "secret",
+			Attributes: map[string][]string{
+				"uid":  {"student"},
+				"mail": {"student@example.com"},
+			},
+		},
+	)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newTestServer(t)
+	client := ldap.NewClient(ldap.Config{
+		URL:          server.URL(),
+		BindDN:       testServiceDN,
+		BindPassword: testServicePass,
+		BaseDN:       testBaseDN,
+	})
+
+	entry, err := client.Authenticate("teacher@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "uid=teacher,ou=people,dc=example,dc=com", entry.DN)
+	assert.Equal(t, "teacher@example.com", entry.Email)
+	assert.Equal(t, []string{"cn=teachers,ou=groups,dc=example,dc=com", "cn=staff,ou=groups,dc=example,dc=com"}, entry.Groups)
+	assert.Equal(t, []string{testServiceDN, entry.DN}, server.Binds())
+
+	for login, password := range map[string]string{
+		"teacher@example.com": "wrong",
+		"unknown@example.com": "secret",
+		"*":                   "secret",
+		"student@example.com": "",
+	} {
+		_, err := client.Authenticate(login, password)
+		assert.ErrorIs(t, err, ldap.ErrInvalidCredentials, login)
+	}
+}
+
+func TestAuthenticate_UserFilter(t *testing.T) {
+	server := newTestServer(t)
+	client := ldap.NewClient(ldap.Config{
+		URL:        server.URL(),
+		BaseDN:     testBaseDN,
+		UserFilter: "(&(uid=%s)(mail=*))",
+	})
+
+	entry, err := client.Authenticate("student", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "student@example.com", entry.Email)
+	assert.Empty(t, entry.Groups)
+}
+
+func TestAuthenticate_ServiceAccount(t *testing.T) {
+	server := newTestServer(t)
+	client := ldap.NewClient(ldap.Config{
+		URL:          server.URL(),
+		BindDN:       testServiceDN,
+		BindPassword: "wrong",
+		BaseDN:       testBaseDN,
+	})
+
+	_, err := client.Authenticate("teacher@example.com", "secret")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ldap.ErrInvalidCredentials)
+}
+
+func TestGroupName(t *testing.T) {
+	assert.Equal(t, "teachers", ldap.GroupName("cn=teachers,ou=groups,dc=example,dc=com"))
+	assert.Equal(t, "teachers", ldap.GroupName("teachers"))
+}
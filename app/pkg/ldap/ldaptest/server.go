@@ -0,0 +1,290 @@
+// Package ldaptest provides an in-process LDAP server for tests
+package ldaptest
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+)
+
+// Protocol operations and result codes of RFC 4511 used by the server
+const (
+	opBindRequest       ber.Tag = 0
+	opBindResponse      ber.Tag = 1
+	opUnbindRequest     ber.Tag = 2
+	opSearchRequest     ber.Tag = 3
+	opSearchResultEntry ber.Tag = 4
+	opSearchResultDone  ber.Tag = 5
+	opExtendedRequest   ber.Tag = 23
+	opExtendedResponse  ber.Tag = 24
+
+	filterAnd           ber.Tag = 0
+	filterOr            ber.Tag = 1
+	filterNot           ber.Tag = 2
+	filterEqualityMatch ber.Tag = 3
+	filterPresent       ber.Tag = 7
+
+	resultSuccess            = 0
+	resultProtocolError      = 2
+	resultInvalidCredentials = 49
+)
+
+// Entry is the directory object, entries with a password can bind
+type Entry struct {
+	DN         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// Server supports simple binds and subtree searches by equality, presence, and, or and not filters
+type Server struct {
+	listener net.Listener
+	entries  []*Entry
+
+	mu    sync.Mutex
+	binds []string
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+func NewServer(entries ...*Entry) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+
+	s := &Server{
+		listener: listener,
+		entries:  entries,
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.serve()
+	}()
+
+	return s, nil
+}
+
+func (s *Server) URL() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Binds returns DNs of successful binds in order
+func (s *Server) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.binds...)
+}
+
+func (s *Server) Close() {
+	s.listener.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.conns, conn)
+				s.mu.Unlock()
+				conn.Close()
+			}()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil {
+			return
+		}
+		if len(packet.Children) < 2 {
+			return
+		}
+		messageID, ok := packet.Children[0].Value.(int64)
+		if !ok {
+			return
+		}
+
+		request := packet.Children[1]
+		switch request.Tag {
+		case opBindRequest:
+			err = s.bind(conn, messageID, request)
+		case opSearchRequest:
+			err = s.search(conn, messageID, request)
+		case opUnbindRequest:
+			return
+		case opExtendedRequest:
+			// StartTLS and other extended operations aren't supported
+			err = writeResult(conn, messageID, opExtendedResponse, resultProtocolError, "unsupported operation")
+		default:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (s *Server) bind(conn net.Conn, messageID int64, request *ber.Packet) error {
+	if len(request.Children) < 3 {
+		return errors.New("invalid bind request")
+	}
+	dn := request.Children[1].Data.String()
+	password := request.Children[2].Data.String()
+
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.DN, dn) && entry.Password != "" && entry.Password == password {
+			s.mu.Lock()
+			s.binds = append(s.binds, entry.DN)
+			s.mu.Unlock()
+
+			return writeResult(conn, messageID, opBindResponse, resultSuccess, "")
+		}
+	}
+
+	return writeResult(conn, messageID, opBindResponse, resultInvalidCredentials, "invalid credentials")
+}
+
+func (s *Server) search(conn net.Conn, messageID int64, request *ber.Packet) error {
+	if len(request.Children) < 8 {
+		return errors.New("invalid search request")
+	}
+	baseDN := strings.ToLower(request.Children[0].Data.String())
+	filter := request.Children[6]
+
+	var attributes []string
+	for _, attribute := range request.Children[7].Children {
+		attributes = append(attributes, attribute.Data.String())
+	}
+
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.DN), baseDN) || !matchFilter(filter, entry) {
+			continue
+		}
+
+		err := writeResponse(conn, messageID, entryPacket(entry, attributes))
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeResult(conn, messageID, opSearchResultDone, resultSuccess, "")
+}
+
+func matchFilter(filter *ber.Packet, entry *Entry) bool {
+	switch filter.Tag {
+	case filterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(child, entry) {
+				return false
+			}
+		}
+		return true
+	case filterOr:
+		for _, child := range filter.Children {
+			if matchFilter(child, entry) {
+				return true
+			}
+		}
+		return false
+	case filterNot:
+		return len(filter.Children) == 1 && !matchFilter(filter.Children[0], entry)
+	case filterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return false
+		}
+		for _, value := range attributeValues(entry, filter.Children[0].Data.String()) {
+			if strings.EqualFold(value, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+		return false
+	case filterPresent:
+		return len(attributeValues(entry, filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+func attributeValues(entry *Entry, name string) []string {
+	for attribute, values := range entry.Attributes {
+		if strings.EqualFold(attribute, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+func entryPacket(entry *Entry, attributes []string) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, opSearchResultEntry, nil, "Search Result Entry")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+
+	attributeList := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for _, name := range attributes {
+		values := attributeValues(entry, name)
+		if len(values) == 0 {
+			continue
+		}
+
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		valueSet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			valueSet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(valueSet)
+		attributeList.AppendChild(attribute)
+	}
+	response.AppendChild(attributeList)
+
+	return response
+}
+
+func writeResult(conn net.Conn, messageID int64, op ber.Tag, code int64, message string) error {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, op, nil, "Result")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, code, "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+
+	return writeResponse(conn, messageID, response)
+}
+
+func writeResponse(conn net.Conn, messageID int64, response *ber.Packet) error {
+	envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	envelope.AppendChild(response)
+
+	_, err := conn.Write(envelope.Bytes())
+	return err
+}
@@ -0,0 +1,132 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/oidc"
+	"github.com/IvLaptev/chartdb-back/pkg/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID    = "chartdb"
+	testRedirectURL = "http://localhost:5173/oidc/callback"
+)
+
+func newTestProvider(t *testing.T) (*oidctest.Provider, *oidc.Provider) {
+	fake, err := oidctest.NewProvider(testClientID)
+	require.NoError(t, err)
+	t.Cleanup(fake.Close)
+
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Issuer:      fake.Issuer(),
+		ClientID:    testClientID,
+		RedirectURL: testRedirectURL,
+	}, nil)
+	require.NoError(t, err)
+
+	return fake, provider
+}
+
+func TestExchange(t *testing.T) {
+	ctx := context.Background()
+	fake, provider := newTestProvider(t)
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	authURL := provider.AuthCodeURL("state", "nonce", verifier)
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, testRedirectURL, u.Query().Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+	assert.NotContains(t, authURL, verifier)
+
+	code, state, err := fake.Authorize(authURL, map[string]any{
+		"sub":            "user-1",
+		"email":          "test@example.com",
+		"email_verified": true,
+		"groups":         []string{"teachers", "staff"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "state", state)
+
+	claims, err := provider.Exchange(ctx, code, verifier, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Subject)
+	assert.Equal(t, "test@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, []string{"teachers", "staff"}, claims.Strings("groups"))
+
+	// Codes are single-use
+	_, err = provider.Exchange(ctx, code, verifier, "nonce")
+	assert.Error(t, err)
+}
+
+func TestExchange_WrongVerifier(t *testing.T) {
+	fake, provider := newTestProvider(t)
+
+	verifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+	otherVerifier, err := oidc.NewCodeVerifier()
+	require.NoError(t, err)
+
+	code, _, err := fake.Authorize(provider.AuthCodeURL("state", "nonce", verifier), map[string]any{"sub": "user-1"})
+	require.NoError(t, err)
+
+	_, err = provider.Exchange(context.Background(), code, otherVerifier, "nonce")
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	ctx := context.Background()
+	fake, provider := newTestProvider(t)
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":   fake.Issuer(),
+			"aud":   []string{"other", testClientID},
+			"sub":   "user-1",
+			"nonce": "nonce",
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+		}
+	}
+
+	token, err := fake.Sign(validClaims())
+	require.NoError(t, err)
+	_, err = provider.Verify(ctx, token, "nonce")
+	require.NoError(t, err)
+
+	_, err = provider.Verify(ctx, token, "other nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+
+	for name, value := range map[string]any{
+		"iss": "https://other.example.com",
+		"aud": "other",
+		"exp": now.Add(-time.Hour).Unix(),
+		"sub": "",
+	} {
+		claims := validClaims()
+		claims[name] = value
+		token, err := fake.Sign(claims)
+		require.NoError(t, err)
+
+		_, err = provider.Verify(ctx, token, "nonce")
+		assert.ErrorIs(t, err, oidc.ErrInvalidToken, name)
+	}
+
+	// Tampered payload
+	otherToken, err := fake.Sign(map[string]any{"sub": "user-2"})
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+	otherParts := strings.Split(otherToken, ".")
+	_, err = provider.Verify(ctx, parts[0]+"."+otherParts[1]+"."+parts[2], "nonce")
+	assert.ErrorIs(t, err, oidc.ErrInvalidSignature)
+}
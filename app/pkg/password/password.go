@@ -0,0 +1,99 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+var (
+	ErrUnknownHashFormat = errors.New("unknown password hash format")
+)
+
+// HasherConfig holds Argon2id parameters, memory is in KiB. Changing the parameters rehashes
+// passwords of users on their next login.
+type HasherConfig struct {
+	Memory      uint32 `yaml:"memory" env-default:"19456"`
+	Iterations  uint32 `yaml:"iterations" env-default:"2"`
+	Parallelism uint8  `yaml:"parallelism" env-default:"1"`
+	SaltLength  uint32 `yaml:"salt_length" env-default:"16"`
+	KeyLength   uint32 `yaml:"key_length" env-default:"32"`
+}
+
+type Hasher struct {
+	config HasherConfig
+}
+
+func NewHasher(config HasherConfig) *Hasher {
+	return &Hasher{
+		config: config,
+	}
+}
+
+// Hash returns the Argon2id hash in PHC string format with a random salt
+func (h *Hasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.config.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("read salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.config.Iterations, h.config.Memory, h.config.Parallelism, h.config.KeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		h.config.Memory, h.config.Iterations, h.config.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify compares the password with the hash in constant time. Legacy unsalted SHA-1 hashes and
+// hashes made with other parameters are reported to be rehashed.
+func (h *Hasher) Verify(password string, hash string) (ok bool, rehash bool, err error) {
+	if !strings.HasPrefix(hash, argon2idPrefix) {
+		if len(hash) != hex.EncodedLen(sha1.Size) {
+			return false, false, ErrUnknownHashFormat
+		}
+
+		sum := sha1.Sum([]byte(password))
+		ok = subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(hash)) == 1
+		return ok, true, nil
+	}
+
+	var version int
+	var config HasherConfig
+	var encodedSalt, encodedKey string
+	parts := strings.Split(strings.TrimPrefix(hash, argon2idPrefix), "$")
+	if len(parts) != 4 {
+		return false, false, ErrUnknownHashFormat
+	}
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, ErrUnknownHashFormat
+	}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &config.Memory, &config.Iterations, &config.Parallelism); err != nil {
+		return false, false, ErrUnknownHashFormat
+	}
+	encodedSalt, encodedKey = parts[2], parts[3]
+
+	salt, err := base64.RawStdEncoding.DecodeString(encodedSalt)
+	if err != nil {
+		return false, false, fmt.Errorf("decode salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return false, false, fmt.Errorf("decode key: %w", err)
+	}
+	config.SaltLength = uint32(len(salt))
+	config.KeyLength = uint32(len(key))
+
+	otherKey := argon2.IDKey([]byte(password), salt, config.Iterations, config.Memory, config.Parallelism, config.KeyLength)
+	ok = subtle.ConstantTimeCompare(key, otherKey) == 1
+
+	return ok, config != h.config, nil
+}
@@ -0,0 +1,65 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = HasherConfig{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestHashAndVerify(t *testing.T) {
+	hasher := NewHasher(testConfig)
+
+	hash, err := hasher.Hash("password")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	otherHash, err := hasher.Hash("password")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, otherHash, "salt must be random")
+
+	ok, rehash, err := hasher.Verify("password", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, rehash)
+
+	ok, _, err = hasher.Verify("wrong password", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerify_ChangedConfig(t *testing.T) {
+	hash, err := NewHasher(testConfig).Hash("password")
+	require.NoError(t, err)
+
+	config := testConfig
+	config.Iterations = 2
+	ok, rehash, err := NewHasher(config).Verify("password", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+}
+
+func TestVerify_LegacySHA1(t *testing.T) {
+	hasher := NewHasher(testConfig)
+
+	ok, rehash, err := hasher.Verify("Hello, world!", "943a702d06f34599aee1f8da8ef9f7296031d699")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, rehash)
+
+	ok, _, err = hasher.Verify("password", "943a702d06f34599aee1f8da8ef9f7296031d699")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = hasher.Verify("password", "plain")
+	assert.ErrorIs(t, err, ErrUnknownHashFormat)
+}
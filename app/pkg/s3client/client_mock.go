@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/s3client/client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=pkg/s3client/client.go -destination=pkg/s3client/client_mock.go -package=s3client
+//
+
+// Package s3client is a generated GoMock package.
+package s3client
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockClient is a mock of Client interface.
+type MockClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockClientMockRecorder
+	isgomock struct{}
+}
+
+// MockClientMockRecorder is the mock recorder for MockClient.
+type MockClientMockRecorder struct {
+	mock *MockClient
+}
+
+// NewMockClient creates a new mock instance.
+func NewMockClient(ctrl *gomock.Controller) *MockClient {
+	mock := &MockClient{ctrl: ctrl}
+	mock.recorder = &MockClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockClient) EXPECT() *MockClientMockRecorder {
+	return m.recorder
+}
+
+// BatchDeleteObjects mocks base method.
+func (m *MockClient) BatchDeleteObjects(ctx context.Context, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteObjects", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchDeleteObjects indicates an expected call of BatchDeleteObjects.
+func (mr *MockClientMockRecorder) BatchDeleteObjects(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteObjects", reflect.TypeOf((*MockClient)(nil).BatchDeleteObjects), ctx, keys)
+}
+
+// GetContent mocks base method.
+func (m *MockClient) GetContent(ctx context.Context, key string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContent", ctx, key)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContent indicates an expected call of GetContent.
+func (mr *MockClientMockRecorder) GetContent(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContent", reflect.TypeOf((*MockClient)(nil).GetContent), ctx, key)
+}
+
+// ListObjects mocks base method.
+func (m *MockClient) ListObjects(ctx context.Context, nextPageToken *string) (*ObjectList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListObjects", ctx, nextPageToken)
+	ret0, _ := ret[0].(*ObjectList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListObjects indicates an expected call of ListObjects.
+func (mr *MockClientMockRecorder) ListObjects(ctx, nextPageToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListObjects", reflect.TypeOf((*MockClient)(nil).ListObjects), ctx, nextPageToken)
+}
+
+// SaveContent mocks base method.
+func (m *MockClient) SaveContent(ctx context.Context, key, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveContent", ctx, key, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveContent indicates an expected call of SaveContent.
+func (mr *MockClientMockRecorder) SaveContent(ctx, key, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveContent", reflect.TypeOf((*MockClient)(nil).SaveContent), ctx, key, content)
+}
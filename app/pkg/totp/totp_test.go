@@ -0,0 +1,68 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/IvLaptev/chartdb-back/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SHA1 test vectors of RFC 6238, truncated to 6 digits
+func TestCode(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	for _, tc := range []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	} {
+		code, err := totp.Code(secret, totp.Step(time.Unix(tc.unix, 0)))
+		require.NoError(t, err)
+		assert.Equal(t, tc.code, code, tc.unix)
+	}
+
+	_, err := totp.Code("not base32!", 1)
+	assert.ErrorIs(t, err, totp.ErrInvalidSecret)
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+
+	now := time.Now()
+	code, err := totp.Code(secret, totp.Step(now.Add(-totp.Period)))
+	require.NoError(t, err)
+
+	step, ok, err := totp.Validate(secret, code, now, 1)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now)-1, step)
+
+	_, ok, err = totp.Validate(secret, code, now.Add(totp.Period), 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = totp.Validate(secret, "12345", now, 1)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri, err := url.Parse(totp.ProvisioningURI("ChartDB", "user@example.com", "SECRET"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/ChartDB:user@example.com", uri.Path)
+	assert.Equal(t, "SECRET", uri.Query().Get("secret"))
+	assert.Equal(t, "ChartDB", uri.Query().Get("issuer"))
+}
@@ -46,6 +46,15 @@ func (o Optional[T]) Get() (T, error) {
 	return o.Value, nil
 }
 
+// ValueOr returns value if its set or fallback otherwise
+func (o Optional[T]) ValueOr(fallback T) T {
+	if !o.Valid {
+		return fallback
+	}
+
+	return o.Value
+}
+
 func OptionalFromPointer[T any](v *T) Optional[T] {
 	if v == nil {
 		return NewEmptyOptional[T]()
@@ -13,6 +13,11 @@ func NewSecret[T any](value T) Secret[T] {
 	return Secret[T]{Value: value}
 }
 
+// String hides the value in text logs, which print structs with fmt
+func (s Secret[T]) String() string {
+	return "***"
+}
+
 // marshal and unmarshal Secret
 func (s Secret[T]) MarshalJSON() ([]byte, error) {
 	return []byte(`"***"`), nil
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecretLogging(t *testing.T) {
+	params := &struct {
+		ID       Secret[string]
+		Password Secret[*string]
+	}{
+		ID:       NewSecret("reset-code"),
+		Password: NewSecret[*string](nil),
+	}
+
+	var text bytes.Buffer
+	slog.New(slog.NewTextHandler(&text, nil)).Info("reset password", slog.Any("params", params))
+	assert.NotContains(t, text.String(), "reset-code")
+	assert.Contains(t, text.String(), "ID:***")
+
+	var json bytes.Buffer
+	slog.New(slog.NewJSONHandler(&json, nil)).Info("reset password", slog.Any("params", params))
+	assert.NotContains(t, json.String(), "reset-code")
+	assert.Contains(t, json.String(), `"ID":"***"`)
+}